@@ -11,6 +11,7 @@ import (
 	"github.com/coeeter/aniways/internal/infra/client/anilist"
 	"github.com/coeeter/aniways/internal/infra/client/hianime"
 	"github.com/coeeter/aniways/internal/infra/client/jikan"
+	"github.com/coeeter/aniways/internal/infra/client/kitsu"
 	"github.com/coeeter/aniways/internal/infra/client/myanimelist"
 	"github.com/coeeter/aniways/internal/infra/client/shikimori"
 	"github.com/coeeter/aniways/internal/infra/database"
@@ -25,11 +26,12 @@ type Deps struct {
 	Log         *slog.Logger
 	Db          *pgxpool.Pool
 	Repo        *repository.Queries
-	Cache       *cache.RedisClient
+	Cache       cache.Cache
 	Scraper     *hianime.HianimeScraper
 	MAL         *myanimelist.Client
 	Jikan       *jikan.Client
 	Anilist     *anilist.Client
+	Kitsu       *kitsu.Client
 	Shiki       *shikimori.Client
 	Cld         *cloudinary.Cloudinary
 	EmailClient email.EmailClient
@@ -56,20 +58,20 @@ func InitDeps(ctx context.Context, svcName string) (*Deps, error) {
 	deps.Db = db
 
 	cacheLog := rootLogger.With("component", "cache")
-	useCache := env.AppEnv != "development" || env.UseCache
-	redisCache, err := cache.NewRedisClient(ctx, env.AppEnv, env.RedisAddr, env.RedisPassword, cacheLog, useCache)
+	appCache, err := cache.New(ctx, env, cacheLog)
 	if err != nil {
 		deps.Close()
-		return deps, fmt.Errorf("failed to initialize redis cache: %w", err)
+		return deps, fmt.Errorf("failed to initialize cache: %w", err)
 	}
-	deps.Cache = redisCache
+	deps.Cache = appCache
 
 	deps.Repo = repository.New(db)
 	deps.Scraper = hianime.NewHianimeScraper()
 	deps.MAL = myanimelist.NewClient(env.MyAnimeListClientID)
 	deps.Jikan = jikan.NewClient()
 	deps.Anilist = anilist.New()
-	deps.Shiki = shikimori.NewClient(redisCache)
+	deps.Shiki = shikimori.NewClient(appCache)
+	deps.Kitsu = kitsu.NewClient(kitsu.BaseURL, appCache)
 	deps.EmailClient = email.NewClient(env.ResendAPIKey, env.ResendFromEmail)
 
 	cld, err := cloudinary.NewFromParams(env.CloudinaryName, env.CloudinaryAPIKey, env.CloudinaryAPISecret)
@@ -84,7 +86,7 @@ func InitDeps(ctx context.Context, svcName string) (*Deps, error) {
 		env.MyAnimeListClientSecret,
 		fmt.Sprintf("%s/auth/oauth/myanimelist/callback", env.ApiURL),
 		deps.Repo,
-		redisCache,
+		appCache,
 	)
 
 	anilistOauthProvider := oauth.NewAnilistProvider(
@@ -94,9 +96,27 @@ func InitDeps(ctx context.Context, svcName string) (*Deps, error) {
 		deps.Repo,
 	)
 
+	kitsuOauthProvider := oauth.NewKitsuProvider(
+		env.KitsuClientID,
+		env.KitsuClientSecret,
+		oauth.KitsuTokenURL,
+		deps.Repo,
+	)
+
 	deps.Providers = map[string]oauth.Provider{
 		malOauthProvider.Name():     malOauthProvider,
 		anilistOauthProvider.Name(): anilistOauthProvider,
+		kitsuOauthProvider.Name():   kitsuOauthProvider,
+	}
+
+	if env.ShikimoriClientID != "" {
+		shikimoriOauthProvider := oauth.NewShikimoriProvider(
+			env.ShikimoriClientID,
+			env.ShikimoriClientSecret,
+			fmt.Sprintf("%s/auth/oauth/shikimori/callback", env.ApiURL),
+			deps.Repo,
+		)
+		deps.Providers[shikimoriOauthProvider.Name()] = shikimoriOauthProvider
 	}
 
 	return deps, nil
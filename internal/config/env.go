@@ -8,16 +8,23 @@ import (
 type Env struct {
 	AppEnv                  string `envconfig:"APP_ENV" default:"development"`
 	AppPort                 string `envconfig:"APP_PORT" default:"8080"`
+	WorkerControlPort       string `envconfig:"WORKER_CONTROL_PORT" default:"8090"`
 	AllowedOrigins          string `envconfig:"ALLOWED_ORIGINS" default:"http://localhost:3000"`
 	FrontendURL             string `envconfig:"FRONTEND_URL" default:"http://localhost:3000"`
 	ApiURL                  string `envconfig:"API_URL" default:"http://localhost:8080"`
 	DatabaseURL             string `envconfig:"DATABASE_URL" required:"true"`
-	RedisAddr               string `envconfig:"REDIS_ADDR" required:"true"`
-	RedisPassword           string `envconfig:"REDIS_PASSWORD" required:"true"`
+	CacheBackend            string `envconfig:"CACHE_BACKEND" default:"redis"`
+	CacheMemoryMaxBytes     int64  `envconfig:"CACHE_MEMORY_MAX_BYTES" default:"67108864"`
+	RedisAddr               string `envconfig:"REDIS_ADDR"`
+	RedisPassword           string `envconfig:"REDIS_PASSWORD"`
 	MyAnimeListClientID     string `envconfig:"MYANIMELIST_CLIENT_ID" required:"true"`
 	MyAnimeListClientSecret string `envconfig:"MYANIMELIST_CLIENT_SECRET" required:"true"`
 	AnilistClientID         string `envconfig:"ANILIST_CLIENT_ID" required:"true"`
 	AnilistClientSecret     string `envconfig:"ANILIST_CLIENT_SECRET" required:"true"`
+	KitsuClientID           string `envconfig:"KITSU_CLIENT_ID" default:"dd031b32d2f56c990b1425efe6c42ad847e7fe3ab46bf1299f05ecd856bdb7dd"`
+	KitsuClientSecret       string `envconfig:"KITSU_CLIENT_SECRET" default:"54d7307928f63414defd96399fc31ba847961ceaecef3a5fd93144e960c0e151"`
+	ShikimoriClientID       string `envconfig:"SHIKIMORI_CLIENT_ID"`
+	ShikimoriClientSecret   string `envconfig:"SHIKIMORI_CLIENT_SECRET"`
 	CloudinaryName          string `envconfig:"CLOUDINARY_NAME" required:"true"`
 	CloudinaryAPIKey        string `envconfig:"CLOUDINARY_API_KEY" required:"true"`
 	CloudinaryAPISecret     string `envconfig:"CLOUDINARY_API_SECRET" required:"true"`
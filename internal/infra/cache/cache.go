@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/coeeter/aniways/internal/config"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	BackendRedis  = "redis"
+	BackendMemory = "memory"
+)
+
+// Cache stores JSON encoded values under string keys. Values read back with
+// Get are decoded into dest the same way for every backend.
+type Cache interface {
+	Get(ctx context.Context, key string, dest any) (bool, error)
+	Set(ctx context.Context, key string, value any, expiresIn time.Duration) error
+	Del(ctx context.Context, key string) error
+	// TTL returns how long key has left, or a negative duration when it is
+	// missing or never expires.
+	TTL(ctx context.Context, key string) (time.Duration, error)
+	// Incr adds one to the counter under key and returns the new count. A
+	// counter that did not exist yet expires after expiresIn.
+	Incr(ctx context.Context, key string, expiresIn time.Duration) (int64, error)
+	// Tag records key under each tag for InvalidateTag.
+	Tag(ctx context.Context, key string, tags ...string) error
+	// InvalidateTag deletes every key recorded under tag and returns how many
+	// were deleted.
+	InvalidateTag(ctx context.Context, tag string) (int, error)
+	// DeletePrefix deletes every key starting with prefix.
+	DeletePrefix(ctx context.Context, prefix string) (int, error)
+	Pipeline() Pipeline
+	Close() error
+
+	core() *base
+}
+
+// Pipeline batches writes until Exec. Backends without batching apply them
+// right away.
+type Pipeline interface {
+	Set(ctx context.Context, key string, value any, expiresIn time.Duration) error
+	Exec(ctx context.Context) error
+}
+
+// base is what every backend shares for GetOrFill.
+type base struct {
+	appEnv   string
+	log      *slog.Logger
+	useCache bool // If false, cache will be bypassed even if available
+	fills    *singleflight.Group
+}
+
+func newBase(appEnv string, log *slog.Logger, useCache bool) *base {
+	return &base{appEnv: appEnv, log: log, useCache: useCache, fills: &singleflight.Group{}}
+}
+
+func (b *base) core() *base {
+	return b
+}
+
+// New builds the backend selected by CACHE_BACKEND.
+func New(ctx context.Context, env *config.Env, log *slog.Logger) (Cache, error) {
+	useCache := env.AppEnv != "development" || env.UseCache
+
+	switch env.CacheBackend {
+	case BackendRedis:
+		if env.RedisAddr == "" {
+			return nil, fmt.Errorf("REDIS_ADDR is required for the %s cache backend", BackendRedis)
+		}
+		return NewRedisClient(ctx, env.AppEnv, env.RedisAddr, env.RedisPassword, log, useCache)
+	case BackendMemory:
+		return NewMemoryCache(env.AppEnv, env.CacheMemoryMaxBytes, log, useCache), nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", env.CacheBackend)
+	}
+}
@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// fillTimeout bounds a fetch that runs detached from the request that started
+// it, both for shared cold fills and background refreshes.
+const fillTimeout = 30 * time.Second
+
+type fillOptions struct {
+	staleFor time.Duration
+	tags     []string
+}
+
+type FillOption func(*fillOptions)
+
+// StaleFor turns on stale-while-revalidate: for d after the ttl has passed
+// the old value is served while a single background fetch replaces it.
+func StaleFor(d time.Duration) FillOption {
+	return func(o *fillOptions) {
+		o.staleFor = d
+	}
+}
+
+// staleEntry is how values are stored for stale-while-revalidate keys, the
+// redis expiry covers the stale window so freshness is kept alongside.
+type staleEntry struct {
+	Value      json.RawMessage `json:"v"`
+	FreshUntil int64           `json:"f"`
+}
+
+type refreshAheadKey struct{}
+
+// WithRefreshAhead makes GetOrFill refetch values that go stale within
+// window instead of serving them, used by the cache warming job.
+func WithRefreshAhead(ctx context.Context, window time.Duration) context.Context {
+	return context.WithValue(ctx, refreshAheadKey{}, window)
+}
+
+func refreshAhead(ctx context.Context) (time.Duration, bool) {
+	window, ok := ctx.Value(refreshAheadKey{}).(time.Duration)
+	return window, ok
+}
+
+func GetOrFill[T any](
+	ctx context.Context,
+	c Cache,
+	key string,
+	ttl time.Duration,
+	fetch func(context.Context) (T, error),
+	opts ...FillOption,
+) (val T, err error) {
+	var o fillOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	b := c.core()
+	shouldUseCache := b.appEnv != "development" || b.useCache
+	if !shouldUseCache {
+		b.log.Debug("cache bypassed", "key", key, "useCache", b.useCache, "appEnv", b.appEnv)
+		return fetch(ctx)
+	}
+
+	if o.staleFor > 0 {
+		return getOrFillStale(ctx, c, key, ttl, o.staleFor, o.tags, fetch)
+	}
+
+	var tmp T
+	if ok, err := c.Get(ctx, key, &tmp); err == nil && ok {
+		window, warming := refreshAhead(ctx)
+		if !warming || ttlOf(ctx, c, key) > window {
+			c.core().log.Debug("cache hit", "key", key)
+			return tmp, nil
+		}
+	} else if err != nil {
+		c.core().log.Warn("cache get failed, fetching", "key", key, "err", err)
+	}
+
+	return fill(ctx, c, key, func(ctx context.Context) (T, error) {
+		v, err := fetch(ctx)
+		if err != nil {
+			return v, err
+		}
+		if err := c.Set(ctx, key, v, ttl); err != nil {
+			c.core().log.Warn("cache set failed", "key", key, "err", err)
+			return v, nil
+		}
+		applyTags(ctx, c, key, o.tags)
+		return v, nil
+	})
+}
+
+func getOrFillStale[T any](
+	ctx context.Context,
+	c Cache,
+	key string,
+	ttl, staleFor time.Duration,
+	tags []string,
+	fetch func(context.Context) (T, error),
+) (T, error) {
+	store := func(ctx context.Context) (T, error) {
+		v, err := fetch(ctx)
+		if err != nil {
+			return v, err
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return v, err
+		}
+		entry := staleEntry{Value: raw, FreshUntil: time.Now().Add(ttl).Unix()}
+		if err := c.Set(ctx, key, entry, ttl+staleFor); err != nil {
+			c.core().log.Warn("cache set failed", "key", key, "err", err)
+			return v, nil
+		}
+		applyTags(ctx, c, key, tags)
+		return v, nil
+	}
+
+	var entry staleEntry
+	ok, err := c.Get(ctx, key, &entry)
+	if err != nil {
+		c.core().log.Warn("cache get failed, fetching", "key", key, "err", err)
+	}
+
+	var tmp T
+	// values written before the key switched to stale-while-revalidate have no
+	// freshness and are refetched
+	if err == nil && ok && entry.FreshUntil > 0 {
+		if err := json.Unmarshal(entry.Value, &tmp); err == nil {
+			freshFor := time.Until(time.Unix(entry.FreshUntil, 0))
+			window, warming := refreshAhead(ctx)
+			switch {
+			case warming && freshFor <= window:
+				return fill(ctx, c, key, store)
+			case freshFor > 0:
+				c.core().log.Debug("cache hit", "key", key)
+			default:
+				c.core().log.Debug("cache stale, revalidating", "key", key)
+				go func() {
+					if _, err := fill(context.WithoutCancel(ctx), c, key, store); err != nil {
+						c.core().log.Warn("cache revalidate failed", "key", key, "err", err)
+					}
+				}()
+			}
+			return tmp, nil
+		}
+	}
+
+	return fill(ctx, c, key, store)
+}
+
+// fill runs fetch once per key across concurrent callers. The fetch is
+// detached from the caller so one cancelled request doesn't fail the others
+// waiting on it.
+func fill[T any](
+	ctx context.Context,
+	c Cache,
+	key string,
+	fetch func(context.Context) (T, error),
+) (T, error) {
+	var zero T
+
+	ch := c.core().fills.DoChan(key, func() (any, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fillTimeout)
+		defer cancel()
+		return fetch(fetchCtx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		if res.Shared {
+			c.core().log.Debug("cache fill shared", "key", key)
+		}
+		return res.Val.(T), nil
+	}
+}
+
+func ttlOf(ctx context.Context, c Cache, key string) time.Duration {
+	ttl, err := c.TTL(ctx, key)
+	if err != nil {
+		c.core().log.Warn("cache ttl failed", "key", key, "err", err)
+		return -1
+	}
+	return ttl
+}
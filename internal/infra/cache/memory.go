@@ -0,0 +1,252 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryCache is an in-process LRU bounded by the encoded size of its keys
+// and values. It keeps the API usable without Redis in development and tests,
+// entries are not shared between processes.
+type MemoryCache struct {
+	*base
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	order    *list.List // front is most recently used
+	items    map[string]*list.Element
+	tags     map[string]map[string]struct{}
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // zero when the entry never expires
+	tags      []string
+}
+
+func (e *memoryEntry) size() int64 {
+	return int64(len(e.key) + len(e.value))
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+func NewMemoryCache(appEnv string, maxBytes int64, log *slog.Logger, useCache bool) *MemoryCache {
+	log.Info("using in-memory cache", "maxBytes", maxBytes, "useCache", useCache)
+
+	return &MemoryCache{
+		base:     newBase(appEnv, log, useCache),
+		maxBytes: maxBytes,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+		tags:     make(map[string]map[string]struct{}),
+	}
+}
+
+func (c *MemoryCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+	c.tags = make(map[string]map[string]struct{})
+	c.size = 0
+	return nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value any, expiresIn time.Duration) error {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	entry := &memoryEntry{key: key, value: valueBytes}
+	if expiresIn > 0 {
+		entry.expiresAt = time.Now().Add(expiresIn)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.put(entry)
+	return nil
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string, dest any) (bool, error) {
+	c.mu.Lock()
+	entry, ok := c.lookup(key)
+	var raw []byte
+	if ok {
+		raw = entry.value
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		return false, nil // cache miss
+	}
+	return true, json.Unmarshal(raw, dest)
+}
+
+func (c *MemoryCache) Del(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+	return nil
+}
+
+func (c *MemoryCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(key)
+	if !ok || entry.expiresAt.IsZero() {
+		return -1, nil
+	}
+	return time.Until(entry.expiresAt), nil
+}
+
+func (c *MemoryCache) Incr(ctx context.Context, key string, expiresIn time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var n int64
+	entry := &memoryEntry{key: key}
+	if existing, ok := c.lookup(key); ok {
+		if err := json.Unmarshal(existing.value, &n); err != nil {
+			return 0, err
+		}
+		entry.expiresAt = existing.expiresAt
+	} else if expiresIn > 0 {
+		entry.expiresAt = time.Now().Add(expiresIn)
+	}
+
+	n++
+	entry.value = []byte(strconv.FormatInt(n, 10))
+	c.put(entry)
+	return n, nil
+}
+
+func (c *MemoryCache) Tag(ctx context.Context, key string, tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+	entry := el.Value.(*memoryEntry)
+	for _, tag := range tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.tags[tag] = keys
+		}
+		if _, ok := keys[key]; !ok {
+			keys[key] = struct{}{}
+			entry.tags = append(entry.tags, tag)
+		}
+	}
+	return nil
+}
+
+func (c *MemoryCache) InvalidateTag(ctx context.Context, tag string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := 0
+	for key := range c.tags[tag] {
+		if el, ok := c.items[key]; ok {
+			c.remove(el)
+			deleted++
+		}
+	}
+	delete(c.tags, tag)
+	return deleted, nil
+}
+
+func (c *MemoryCache) DeletePrefix(ctx context.Context, prefix string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := 0
+	for key, el := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(el)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// Pipeline writes straight through, there is no round trip to save.
+func (c *MemoryCache) Pipeline() Pipeline {
+	return memoryPipeline{c}
+}
+
+type memoryPipeline struct {
+	c *MemoryCache
+}
+
+func (p memoryPipeline) Set(ctx context.Context, key string, value any, expiresIn time.Duration) error {
+	return p.c.Set(ctx, key, value, expiresIn)
+}
+
+func (p memoryPipeline) Exec(ctx context.Context) error {
+	return nil
+}
+
+// put stores entry in place of any entry under its key, evicting the least
+// recently used entries past maxBytes. Callers hold c.mu.
+func (c *MemoryCache) put(entry *memoryEntry) {
+	if el, ok := c.items[entry.key]; ok {
+		c.remove(el)
+	}
+	if entry.size() > c.maxBytes {
+		c.log.Debug("value larger than cache, not stored", "key", entry.key, "size", entry.size())
+		return
+	}
+
+	c.items[entry.key] = c.order.PushFront(entry)
+	c.size += entry.size()
+
+	for c.size > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+// lookup returns a live entry and marks it as recently used, expired entries
+// are dropped on the way. Callers hold c.mu.
+func (c *MemoryCache) lookup(key string) (*memoryEntry, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*memoryEntry)
+	if entry.expired(time.Now()) {
+		c.remove(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry, true
+}
+
+func (c *MemoryCache) remove(el *list.Element) {
+	entry := c.order.Remove(el).(*memoryEntry)
+	delete(c.items, entry.key)
+	c.size -= entry.size()
+	for _, tag := range entry.tags {
+		delete(c.tags[tag], entry.key)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
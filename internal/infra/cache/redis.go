@@ -5,16 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
 type RedisClient struct {
-	r        redis.Cmdable
-	appEnv   string
-	log      *slog.Logger
-	useCache bool // If false, cache will be bypassed even if available
+	*base
+	r *redis.Client
 }
 
 func NewRedisClient(
@@ -37,22 +36,15 @@ func NewRedisClient(
 
 	log.Info("connected to redis", "addr", addr, "useCache", useCache)
 
-	return &RedisClient{r: rdb, appEnv: appEnv, log: log, useCache: useCache}, nil
+	return &RedisClient{base: newBase(appEnv, log, useCache), r: rdb}, nil
 }
 
 func (c *RedisClient) Close() error {
-	if client, ok := c.r.(*redis.Client); ok {
-		return client.Close()
-	}
-	return fmt.Errorf("unsupported Redis client type")
+	return c.r.Close()
 }
 
 func (c *RedisClient) Set(ctx context.Context, key string, value any, expiresIn time.Duration) error {
-	valueBytes, err := json.Marshal(value)
-	if err != nil {
-		return err
-	}
-	return c.r.Set(ctx, key, valueBytes, expiresIn).Err()
+	return redisSet(ctx, c.r, key, value, expiresIn)
 }
 
 func (c *RedisClient) Get(ctx context.Context, key string, dest any) (bool, error) {
@@ -72,51 +64,112 @@ func (c *RedisClient) Del(ctx context.Context, key string) error {
 	return c.r.Del(ctx, key).Err()
 }
 
-func (c *RedisClient) Pipeline() *RedisClient {
-	return &RedisClient{r: c.r.Pipeline(), appEnv: c.appEnv, log: c.log, useCache: c.useCache}
+func (c *RedisClient) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return c.r.PTTL(ctx, key).Result()
 }
 
-func (c *RedisClient) Exec(ctx context.Context) ([]redis.Cmder, error) {
-	if pipe, ok := c.r.(redis.Pipeliner); ok {
-		return pipe.Exec(ctx)
+func (c *RedisClient) Incr(ctx context.Context, key string, expiresIn time.Duration) (int64, error) {
+	pipe := c.r.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	if expiresIn > 0 {
+		pipe.ExpireNX(ctx, key, expiresIn)
 	}
-	return nil, fmt.Errorf("unsupported Redis client type for pipeline execution")
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
 }
 
-func GetOrFill[T any](
-	ctx context.Context,
-	rc *RedisClient,
-	key string,
-	ttl time.Duration,
-	fetch func(context.Context) (T, error),
-) (val T, err error) {
-	var zero T
-
-	shouldUseCache := rc.appEnv != "development" || rc.useCache
-
-	var tmp T
-	if shouldUseCache {
-		if ok, err := rc.Get(ctx, key, &tmp); err == nil && ok {
-			rc.log.Debug("cache hit", "key", key)
-			return tmp, nil
-		} else if err != nil {
-			rc.log.Warn("cache get failed, fetching", "key", key, "err", err)
-		}
-	} else {
-		rc.log.Debug("cache bypassed", "key", key, "useCache", rc.useCache, "appEnv", rc.appEnv)
+func (c *RedisClient) Tag(ctx context.Context, key string, tags ...string) error {
+	pipe := c.r.Pipeline()
+	for _, tag := range tags {
+		pipe.SAdd(ctx, tagKey(tag), key)
+		pipe.Expire(ctx, tagKey(tag), tagTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *RedisClient) InvalidateTag(ctx context.Context, tag string) (int, error) {
+	keys, err := c.r.SMembers(ctx, tagKey(tag)).Result()
+	if err != nil {
+		return 0, err
 	}
 
-	tmp, err = fetch(ctx)
+	// keys that expired on their own are still members, only count live ones
+	deleted, err := c.r.Del(ctx, append(keys, tagKey(tag))...).Result()
 	if err != nil {
-		return zero, err
+		return 0, err
+	}
+	return max(int(deleted)-1, 0), nil
+}
+
+func (c *RedisClient) DeletePrefix(ctx context.Context, prefix string) (int, error) {
+	deleted := 0
+	iter := c.r.Scan(ctx, 0, escapeGlob(prefix)+"*", 500).Iterator()
+	batch := make([]string, 0, 500)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := c.r.Unlink(ctx, batch...).Result()
+		if err != nil {
+			return err
+		}
+		deleted += int(n)
+		batch = batch[:0]
+		return nil
 	}
 
-	// Only set cache if we're using it
-	if shouldUseCache {
-		if err = rc.Set(ctx, key, tmp, ttl); err != nil {
-			rc.log.Warn("cache set failed", "key", key, "err", err)
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == cap(batch) {
+			if err := flush(); err != nil {
+				return deleted, err
+			}
 		}
 	}
+	if err := iter.Err(); err != nil {
+		return deleted, err
+	}
+	return deleted, flush()
+}
+
+// escapeGlob makes prefix match literally in a SCAN pattern.
+func escapeGlob(prefix string) string {
+	var b strings.Builder
+	for _, r := range prefix {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (c *RedisClient) Pipeline() Pipeline {
+	return &redisPipeline{p: c.r.Pipeline()}
+}
+
+type redisPipeline struct {
+	p redis.Pipeliner
+}
+
+func (p *redisPipeline) Set(ctx context.Context, key string, value any, expiresIn time.Duration) error {
+	return redisSet(ctx, p.p, key, value, expiresIn)
+}
+
+func (p *redisPipeline) Exec(ctx context.Context) error {
+	_, err := p.p.Exec(ctx)
+	return err
+}
 
-	return tmp, nil
+func redisSet(ctx context.Context, r redis.Cmdable, key string, value any, expiresIn time.Duration) error {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return r.Set(ctx, key, valueBytes, expiresIn).Err()
 }
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// TagListings groups the home page listings that map AniList results onto
+// our catalog, they go stale when new anime are inserted.
+const TagListings = "listings"
+
+// tagTTL outlives every GetOrFill ttl so a tag never forgets a live key.
+const tagTTL = 31 * 24 * time.Hour
+
+// AnimeTag groups every cache entry derived from one anime.
+func AnimeTag(id string) string {
+	return "anime:" + id
+}
+
+// Tags attaches tags to the value GetOrFill stores, so InvalidateTag can drop
+// it together with every other entry sharing a tag.
+func Tags(tags ...string) FillOption {
+	return func(o *fillOptions) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+func tagKey(tag string) string {
+	return "cache_tag:" + tag
+}
+
+func applyTags(ctx context.Context, c Cache, key string, tags []string) {
+	if len(tags) == 0 {
+		return
+	}
+	if err := c.Tag(ctx, key, tags...); err != nil {
+		c.core().log.Warn("cache tag failed", "key", key, "tags", tags, "err", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	operations "github.com/coeeter/aniways/internal/infra/client/anilist/graphql"
@@ -17,6 +18,15 @@ const apiURL = "https://graphql.anilist.co"
 
 var ErrInvalidToken = errors.New("invalid token")
 
+// IsUnauthorized reports whether err means AniList rejected the user's token.
+func IsUnauthorized(err error) bool {
+	if errors.Is(err, ErrInvalidToken) {
+		return true
+	}
+	var httpErr *graphql.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnauthorized
+}
+
 type Client struct {
 	graphqlClient graphql.Client
 }
@@ -142,6 +152,13 @@ type InsertAnimeToListParams struct {
 	MalID           int
 	Status          string
 	WatchedEpisodes int
+	// The fields below are left untouched on AniList when nil. Score is out
+	// of 100 with 0 clearing it, dates are formatted as 2006-01-02.
+	Score       *int
+	Rewatches   *int
+	Notes       *string
+	StartedAt   *string
+	CompletedAt *string
 }
 
 func (c *Client) convertFromRepoStatus(status string) operations.MediaListStatus {
@@ -184,7 +201,18 @@ func (c *Client) InsertAnimeToList(ctx context.Context, params InsertAnimeToList
 
 	status := c.convertFromRepoStatus(params.Status)
 
-	_, err = operations.InsertMediaListEntry(ctx, c.graphqlClient, mediaID, status, params.WatchedEpisodes)
+	_, err = operations.InsertMediaListEntry(
+		ctx,
+		c.graphqlClient,
+		mediaID,
+		status,
+		params.WatchedEpisodes,
+		params.Score,
+		params.Rewatches,
+		params.Notes,
+		fuzzyDate(params.StartedAt),
+		fuzzyDate(params.CompletedAt),
+	)
 	if err != nil {
 		return err
 	}
@@ -192,6 +220,19 @@ func (c *Client) InsertAnimeToList(ctx context.Context, params InsertAnimeToList
 	return nil
 }
 
+// fuzzyDate converts a 2006-01-02 date. Empty dates are left out, so a date
+// cleared locally stays set on AniList.
+func fuzzyDate(date *string) *operations.FuzzyDateInput {
+	if date == nil || *date == "" {
+		return nil
+	}
+	t, err := time.Parse(time.DateOnly, *date)
+	if err != nil {
+		return nil
+	}
+	return &operations.FuzzyDateInput{Year: t.Year(), Month: int(t.Month()), Day: t.Day()}
+}
+
 type UpdateAnimeEntryStatusParams struct {
 	Token  string
 	MalID  int
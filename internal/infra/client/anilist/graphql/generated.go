@@ -31,6 +31,25 @@ func (v *DeleteMediaListEntryResponse) GetDeleteMediaListEntry() DeleteMediaList
 	return v.DeleteMediaListEntry
 }
 
+// Date object that allows for incomplete date values (fuzzy)
+type FuzzyDateInput struct {
+	// Numeric Year (2017)
+	Year int `json:"year"`
+	// Numeric Month (3)
+	Month int `json:"month"`
+	// Numeric Day (24)
+	Day int `json:"day"`
+}
+
+// GetYear returns FuzzyDateInput.Year, and is useful for accessing the field via an interface.
+func (v *FuzzyDateInput) GetYear() int { return v.Year }
+
+// GetMonth returns FuzzyDateInput.Month, and is useful for accessing the field via an interface.
+func (v *FuzzyDateInput) GetMonth() int { return v.Month }
+
+// GetDay returns FuzzyDateInput.Day, and is useful for accessing the field via an interface.
+func (v *FuzzyDateInput) GetDay() int { return v.Day }
+
 // GetAnimeDetailsMedia includes the requested fields of the GraphQL type Media.
 // The GraphQL type's documentation follows.
 //
@@ -575,8 +594,18 @@ type GetUserAnimeListPageMediaList struct {
 	// The amount of episodes/chapters consumed by the user
 	Progress int `json:"progress"`
 	// When the entry data was last updated
-	UpdatedAt int                                `json:"updatedAt"`
-	Media     GetUserAnimeListPageMediaListMedia `json:"media"`
+	UpdatedAt int `json:"updatedAt"`
+	// The score of the entry
+	Score float64 `json:"score"`
+	// The amount of times the user has rewatched/read the media
+	Repeat int `json:"repeat"`
+	// Text notes
+	Notes string `json:"notes"`
+	// When the entry was started by the user
+	StartedAt GetUserAnimeListPageMediaListStartedAtFuzzyDate `json:"startedAt"`
+	// When the entry was completed by the user
+	CompletedAt GetUserAnimeListPageMediaListCompletedAtFuzzyDate `json:"completedAt"`
+	Media       GetUserAnimeListPageMediaListMedia                `json:"media"`
 }
 
 // GetId returns GetUserAnimeListPageMediaList.Id, and is useful for accessing the field via an interface.
@@ -591,9 +620,50 @@ func (v *GetUserAnimeListPageMediaList) GetProgress() int { return v.Progress }
 // GetUpdatedAt returns GetUserAnimeListPageMediaList.UpdatedAt, and is useful for accessing the field via an interface.
 func (v *GetUserAnimeListPageMediaList) GetUpdatedAt() int { return v.UpdatedAt }
 
+// GetScore returns GetUserAnimeListPageMediaList.Score, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaList) GetScore() float64 { return v.Score }
+
+// GetRepeat returns GetUserAnimeListPageMediaList.Repeat, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaList) GetRepeat() int { return v.Repeat }
+
+// GetNotes returns GetUserAnimeListPageMediaList.Notes, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaList) GetNotes() string { return v.Notes }
+
+// GetStartedAt returns GetUserAnimeListPageMediaList.StartedAt, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaList) GetStartedAt() GetUserAnimeListPageMediaListStartedAtFuzzyDate {
+	return v.StartedAt
+}
+
+// GetCompletedAt returns GetUserAnimeListPageMediaList.CompletedAt, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaList) GetCompletedAt() GetUserAnimeListPageMediaListCompletedAtFuzzyDate {
+	return v.CompletedAt
+}
+
 // GetMedia returns GetUserAnimeListPageMediaList.Media, and is useful for accessing the field via an interface.
 func (v *GetUserAnimeListPageMediaList) GetMedia() GetUserAnimeListPageMediaListMedia { return v.Media }
 
+// GetUserAnimeListPageMediaListCompletedAtFuzzyDate includes the requested fields of the GraphQL type FuzzyDate.
+// The GraphQL type's documentation follows.
+//
+// Date object that allows for incomplete date values (fuzzy)
+type GetUserAnimeListPageMediaListCompletedAtFuzzyDate struct {
+	// Numeric Year (2017)
+	Year int `json:"year"`
+	// Numeric Month (3)
+	Month int `json:"month"`
+	// Numeric Day (24)
+	Day int `json:"day"`
+}
+
+// GetYear returns GetUserAnimeListPageMediaListCompletedAtFuzzyDate.Year, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListCompletedAtFuzzyDate) GetYear() int { return v.Year }
+
+// GetMonth returns GetUserAnimeListPageMediaListCompletedAtFuzzyDate.Month, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListCompletedAtFuzzyDate) GetMonth() int { return v.Month }
+
+// GetDay returns GetUserAnimeListPageMediaListCompletedAtFuzzyDate.Day, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListCompletedAtFuzzyDate) GetDay() int { return v.Day }
+
 // GetUserAnimeListPageMediaListMedia includes the requested fields of the GraphQL type Media.
 // The GraphQL type's documentation follows.
 //
@@ -603,6 +673,12 @@ type GetUserAnimeListPageMediaListMedia struct {
 	Id int `json:"id"`
 	// The mal id of the media
 	IdMal int `json:"idMal"`
+	// The official titles of the media in various languages
+	Title GetUserAnimeListPageMediaListMediaTitle `json:"title"`
+	// Alternative titles of the media
+	Synonyms []string `json:"synonyms"`
+	// The season year the media was initially released in
+	SeasonYear int `json:"seasonYear"`
 }
 
 // GetId returns GetUserAnimeListPageMediaListMedia.Id, and is useful for accessing the field via an interface.
@@ -611,6 +687,61 @@ func (v *GetUserAnimeListPageMediaListMedia) GetId() int { return v.Id }
 // GetIdMal returns GetUserAnimeListPageMediaListMedia.IdMal, and is useful for accessing the field via an interface.
 func (v *GetUserAnimeListPageMediaListMedia) GetIdMal() int { return v.IdMal }
 
+// GetTitle returns GetUserAnimeListPageMediaListMedia.Title, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListMedia) GetTitle() GetUserAnimeListPageMediaListMediaTitle {
+	return v.Title
+}
+
+// GetSynonyms returns GetUserAnimeListPageMediaListMedia.Synonyms, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListMedia) GetSynonyms() []string { return v.Synonyms }
+
+// GetSeasonYear returns GetUserAnimeListPageMediaListMedia.SeasonYear, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListMedia) GetSeasonYear() int { return v.SeasonYear }
+
+// GetUserAnimeListPageMediaListMediaTitle includes the requested fields of the GraphQL type MediaTitle.
+// The GraphQL type's documentation follows.
+//
+// The official titles of the media in various languages
+type GetUserAnimeListPageMediaListMediaTitle struct {
+	// The romanization of the native language title
+	Romaji string `json:"romaji"`
+	// The official english title
+	English string `json:"english"`
+	// Official title in it's native language
+	Native string `json:"native"`
+}
+
+// GetRomaji returns GetUserAnimeListPageMediaListMediaTitle.Romaji, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListMediaTitle) GetRomaji() string { return v.Romaji }
+
+// GetEnglish returns GetUserAnimeListPageMediaListMediaTitle.English, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListMediaTitle) GetEnglish() string { return v.English }
+
+// GetNative returns GetUserAnimeListPageMediaListMediaTitle.Native, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListMediaTitle) GetNative() string { return v.Native }
+
+// GetUserAnimeListPageMediaListStartedAtFuzzyDate includes the requested fields of the GraphQL type FuzzyDate.
+// The GraphQL type's documentation follows.
+//
+// Date object that allows for incomplete date values (fuzzy)
+type GetUserAnimeListPageMediaListStartedAtFuzzyDate struct {
+	// Numeric Year (2017)
+	Year int `json:"year"`
+	// Numeric Month (3)
+	Month int `json:"month"`
+	// Numeric Day (24)
+	Day int `json:"day"`
+}
+
+// GetYear returns GetUserAnimeListPageMediaListStartedAtFuzzyDate.Year, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListStartedAtFuzzyDate) GetYear() int { return v.Year }
+
+// GetMonth returns GetUserAnimeListPageMediaListStartedAtFuzzyDate.Month, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListStartedAtFuzzyDate) GetMonth() int { return v.Month }
+
+// GetDay returns GetUserAnimeListPageMediaListStartedAtFuzzyDate.Day, and is useful for accessing the field via an interface.
+func (v *GetUserAnimeListPageMediaListStartedAtFuzzyDate) GetDay() int { return v.Day }
+
 // GetUserAnimeListResponse is returned by GetUserAnimeList on success.
 type GetUserAnimeListResponse struct {
 	Page GetUserAnimeListPage `json:"Page"`
@@ -996,9 +1127,14 @@ func (v *__GetUserEntryIdInput) GetUserId() int { return v.UserId }
 
 // __InsertMediaListEntryInput is used internally by genqlient
 type __InsertMediaListEntryInput struct {
-	MediaId  int             `json:"mediaId"`
-	Status   MediaListStatus `json:"status"`
-	Progress int             `json:"progress"`
+	MediaId     int             `json:"mediaId"`
+	Status      MediaListStatus `json:"status"`
+	Progress    int             `json:"progress"`
+	ScoreRaw    *int            `json:"scoreRaw,omitempty"`
+	Repeat      *int            `json:"repeat,omitempty"`
+	Notes       *string         `json:"notes,omitempty"`
+	StartedAt   *FuzzyDateInput `json:"startedAt,omitempty"`
+	CompletedAt *FuzzyDateInput `json:"completedAt,omitempty"`
 }
 
 // GetMediaId returns __InsertMediaListEntryInput.MediaId, and is useful for accessing the field via an interface.
@@ -1010,6 +1146,21 @@ func (v *__InsertMediaListEntryInput) GetStatus() MediaListStatus { return v.Sta
 // GetProgress returns __InsertMediaListEntryInput.Progress, and is useful for accessing the field via an interface.
 func (v *__InsertMediaListEntryInput) GetProgress() int { return v.Progress }
 
+// GetScoreRaw returns __InsertMediaListEntryInput.ScoreRaw, and is useful for accessing the field via an interface.
+func (v *__InsertMediaListEntryInput) GetScoreRaw() *int { return v.ScoreRaw }
+
+// GetRepeat returns __InsertMediaListEntryInput.Repeat, and is useful for accessing the field via an interface.
+func (v *__InsertMediaListEntryInput) GetRepeat() *int { return v.Repeat }
+
+// GetNotes returns __InsertMediaListEntryInput.Notes, and is useful for accessing the field via an interface.
+func (v *__InsertMediaListEntryInput) GetNotes() *string { return v.Notes }
+
+// GetStartedAt returns __InsertMediaListEntryInput.StartedAt, and is useful for accessing the field via an interface.
+func (v *__InsertMediaListEntryInput) GetStartedAt() *FuzzyDateInput { return v.StartedAt }
+
+// GetCompletedAt returns __InsertMediaListEntryInput.CompletedAt, and is useful for accessing the field via an interface.
+func (v *__InsertMediaListEntryInput) GetCompletedAt() *FuzzyDateInput { return v.CompletedAt }
+
 // __UpdateMediaListProgressInput is used internally by genqlient
 type __UpdateMediaListProgressInput struct {
 	MediaId  int `json:"mediaId"`
@@ -1319,9 +1470,29 @@ query GetUserAnimeList ($page: Int, $perPage: Int, $userId: Int) {
 			status
 			progress
 			updatedAt
+			score(format: POINT_100)
+			repeat
+			notes
+			startedAt {
+				year
+				month
+				day
+			}
+			completedAt {
+				year
+				month
+				day
+			}
 			media {
 				id
 				idMal
+				title {
+					romaji
+					english
+					native
+				}
+				synonyms
+				seasonYear
 			}
 		}
 	}
@@ -1395,8 +1566,8 @@ func GetUserEntryId(
 
 // The mutation executed by InsertMediaListEntry.
 const InsertMediaListEntry_Operation = `
-mutation InsertMediaListEntry ($mediaId: Int, $status: MediaListStatus, $progress: Int) {
-	SaveMediaListEntry(mediaId: $mediaId, status: $status, progress: $progress) {
+mutation InsertMediaListEntry ($mediaId: Int, $status: MediaListStatus, $progress: Int, $scoreRaw: Int, $repeat: Int, $notes: String, $startedAt: FuzzyDateInput, $completedAt: FuzzyDateInput) {
+	SaveMediaListEntry(mediaId: $mediaId, status: $status, progress: $progress, scoreRaw: $scoreRaw, repeat: $repeat, notes: $notes, startedAt: $startedAt, completedAt: $completedAt) {
 		id
 		status
 		progress
@@ -1415,14 +1586,24 @@ func InsertMediaListEntry(
 	mediaId int,
 	status MediaListStatus,
 	progress int,
+	scoreRaw *int,
+	repeat *int,
+	notes *string,
+	startedAt *FuzzyDateInput,
+	completedAt *FuzzyDateInput,
 ) (data_ *InsertMediaListEntryResponse, err_ error) {
 	req_ := &graphql.Request{
 		OpName: "InsertMediaListEntry",
 		Query:  InsertMediaListEntry_Operation,
 		Variables: &__InsertMediaListEntryInput{
-			MediaId:  mediaId,
-			Status:   status,
-			Progress: progress,
+			MediaId:     mediaId,
+			Status:      status,
+			Progress:    progress,
+			ScoreRaw:    scoreRaw,
+			Repeat:      repeat,
+			Notes:       notes,
+			StartedAt:   startedAt,
+			CompletedAt: completedAt,
 		},
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -11,6 +12,10 @@ import (
 	"github.com/PuerkitoBio/goquery"
 )
 
+// ErrNotFound is returned when HiAnime answers with a 404, which usually
+// means the anime was taken down.
+var ErrNotFound = errors.New("hianime: not found")
+
 type HianimeFetcher struct {
 	baseURL string
 	Client  *http.Client
@@ -58,6 +63,9 @@ func (f *HianimeFetcher) GetDocument(
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
@@ -0,0 +1,414 @@
+package kitsu
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/coeeter/aniways/internal/infra/cache"
+)
+
+// ErrNotFound is returned when Kitsu has no anime mapped to the MAL ID.
+var ErrNotFound = errors.New("anime not found on Kitsu")
+
+// ErrUnauthorized is returned when Kitsu rejects the user's access token.
+var ErrUnauthorized = errors.New("unauthorized by Kitsu")
+
+const jsonAPIMediaType = "application/vnd.api+json"
+
+// BaseURL is the Kitsu API the client talks to outside of tests.
+const BaseURL = "https://kitsu.io/api/edge"
+
+type Client struct {
+	baseURL     string
+	httpClient  *http.Client
+	redisClient cache.Cache
+}
+
+func NewClient(baseURL string, redisClient cache.Cache) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				MaxIdleConns:       20,
+				IdleConnTimeout:    30 * time.Second,
+				DisableCompression: true,
+			},
+		},
+		redisClient: redisClient,
+	}
+}
+
+// GetSelfID returns the Kitsu user ID the token belongs to.
+func (c *Client) GetSelfID(ctx context.Context, token string) (string, error) {
+	query := url.Values{}
+	query.Set("filter[self]", "true")
+	query.Set("fields[users]", "name")
+
+	var doc document
+	if err := c.do(ctx, http.MethodGet, "/users", query, token, nil, &doc); err != nil {
+		return "", err
+	}
+
+	var users []resourceID
+	if err := json.Unmarshal(doc.Data, &users); err != nil {
+		return "", fmt.Errorf("failed to decode users: %w", err)
+	}
+	if len(users) == 0 {
+		// kitsu answers anonymous requests with no users instead of a 401
+		return "", ErrUnauthorized
+	}
+	return users[0].ID, nil
+}
+
+type GetLibraryEntriesParams struct {
+	Token        string
+	UserID       string
+	Page         int
+	ItemsPerPage int
+}
+
+// GetLibraryEntries returns a page of the user's anime library with the MAL
+// ID of every entry resolved from Kitsu's mappings.
+func (c *Client) GetLibraryEntries(ctx context.Context, params GetLibraryEntriesParams) (LibraryPage, error) {
+	limit := params.ItemsPerPage
+	if limit == 0 {
+		limit = 20
+	}
+	offset := (params.Page - 1) * limit
+
+	query := url.Values{}
+	query.Set("filter[userId]", params.UserID)
+	query.Set("filter[kind]", "anime")
+	query.Set("include", "anime.mappings")
+	query.Set("fields[libraryEntries]", "status,progress,updatedAt,anime")
+	query.Set("fields[anime]", "canonicalTitle,titles,startDate,mappings")
+	query.Set("fields[mappings]", "externalSite,externalId")
+	query.Set("page[limit]", strconv.Itoa(limit))
+	query.Set("page[offset]", strconv.Itoa(offset))
+
+	var doc document
+	if err := c.do(ctx, http.MethodGet, "/library-entries", query, params.Token, nil, &doc); err != nil {
+		return LibraryPage{}, err
+	}
+
+	var data []resource
+	if err := json.Unmarshal(doc.Data, &data); err != nil {
+		return LibraryPage{}, fmt.Errorf("failed to decode library entries: %w", err)
+	}
+
+	included := make(map[string]resource, len(doc.Included))
+	for _, r := range doc.Included {
+		included[r.Type+":"+r.ID] = r
+	}
+
+	page := LibraryPage{
+		Entries: make([]LibraryEntry, 0, len(data)),
+		HasNext: doc.Links.Next != "",
+	}
+	for _, r := range data {
+		var attrs libraryEntryAttributes
+		if err := json.Unmarshal(r.Attributes, &attrs); err != nil {
+			return LibraryPage{}, fmt.Errorf("failed to decode library entry %s: %w", r.ID, err)
+		}
+
+		entry := LibraryEntry{
+			ID:        r.ID,
+			Status:    KitsuListStatus(attrs.Status),
+			Progress:  attrs.Progress,
+			UpdatedAt: attrs.UpdatedAt,
+		}
+
+		var anime resourceID
+		if rel, ok := r.Relationships["anime"]; ok && json.Unmarshal(rel.Data, &anime) == nil {
+			entry.AnimeID = anime.ID
+			entry.MalID = malIDOf(included, anime)
+			entry.Titles, entry.Year = titlesOf(included, anime)
+		}
+
+		page.Entries = append(page.Entries, entry)
+	}
+
+	return page, nil
+}
+
+func titlesOf(included map[string]resource, anime resourceID) ([]string, int) {
+	a, ok := included["anime:"+anime.ID]
+	if !ok {
+		return nil, 0
+	}
+
+	var attrs animeAttributes
+	if err := json.Unmarshal(a.Attributes, &attrs); err != nil {
+		return nil, 0
+	}
+
+	titles := []string{attrs.CanonicalTitle}
+	for _, t := range attrs.Titles {
+		titles = append(titles, t)
+	}
+
+	year := 0
+	if len(attrs.StartDate) >= 4 {
+		year, _ = strconv.Atoi(attrs.StartDate[:4])
+	}
+	return titles, year
+}
+
+func malIDOf(included map[string]resource, anime resourceID) int {
+	a, ok := included["anime:"+anime.ID]
+	if !ok {
+		return 0
+	}
+
+	var mappings []resourceID
+	if rel, ok := a.Relationships["mappings"]; !ok || json.Unmarshal(rel.Data, &mappings) != nil {
+		return 0
+	}
+
+	for _, m := range mappings {
+		mapping, ok := included["mappings:"+m.ID]
+		if !ok {
+			continue
+		}
+		var attrs mappingAttributes
+		if err := json.Unmarshal(mapping.Attributes, &attrs); err != nil {
+			continue
+		}
+		if attrs.ExternalSite != malAnimeSite {
+			continue
+		}
+		id, err := strconv.Atoi(attrs.ExternalID)
+		if err != nil {
+			continue
+		}
+		return id
+	}
+	return 0
+}
+
+// GetKitsuID maps a MAL anime ID to its Kitsu anime ID.
+func (c *Client) GetKitsuID(ctx context.Context, malID int) (string, error) {
+	key := fmt.Sprintf("kitsu:mal:%d", malID)
+
+	var id string
+	if ok, _ := c.redisClient.Get(ctx, key, &id); ok {
+		return id, nil
+	}
+
+	query := url.Values{}
+	query.Set("filter[externalSite]", malAnimeSite)
+	query.Set("filter[externalId]", strconv.Itoa(malID))
+	query.Set("include", "item")
+	query.Set("fields[mappings]", "item")
+
+	var doc document
+	if err := c.do(ctx, http.MethodGet, "/mappings", query, "", nil, &doc); err != nil {
+		return "", err
+	}
+
+	var mappings []resource
+	if err := json.Unmarshal(doc.Data, &mappings); err != nil {
+		return "", fmt.Errorf("failed to decode mappings: %w", err)
+	}
+	for _, m := range mappings {
+		var item resourceID
+		if rel, ok := m.Relationships["item"]; ok && json.Unmarshal(rel.Data, &item) == nil && item.Type == "anime" {
+			id = item.ID
+			break
+		}
+	}
+	if id == "" {
+		return "", fmt.Errorf("mal ID %d: %w", malID, ErrNotFound)
+	}
+
+	_ = c.redisClient.Set(ctx, key, id, 7*24*time.Hour)
+	return id, nil
+}
+
+type UpdateLibraryEntryParams struct {
+	Token           string
+	AnimeID         string
+	Status          string
+	WatchedEpisodes int
+	// The fields below are left untouched on Kitsu when nil. RatingTwenty
+	// is out of 20 with 0 clearing it, dates are formatted as 2006-01-02.
+	RatingTwenty   *int
+	StartedAt      *string
+	FinishedAt     *string
+	ReconsumeCount *int
+	Notes          *string
+}
+
+// UpdateLibraryEntry creates the user's library entry for the anime or
+// overwrites the existing one.
+func (c *Client) UpdateLibraryEntry(ctx context.Context, params UpdateLibraryEntryParams) error {
+	userID, err := c.GetSelfID(ctx, params.Token)
+	if err != nil {
+		return err
+	}
+
+	entryID, err := c.findEntry(ctx, params.Token, userID, params.AnimeID)
+	if err != nil {
+		return err
+	}
+
+	attributes := map[string]any{
+		"status": KitsuListStatus("").FromRepository(params.Status),
+	}
+	if params.WatchedEpisodes >= 0 {
+		attributes["progress"] = params.WatchedEpisodes
+	}
+	if params.RatingTwenty != nil {
+		if *params.RatingTwenty == 0 {
+			attributes["ratingTwenty"] = nil
+		} else {
+			attributes["ratingTwenty"] = *params.RatingTwenty
+		}
+	}
+	if params.StartedAt != nil {
+		attributes["startedAt"] = *params.StartedAt
+	}
+	if params.FinishedAt != nil {
+		attributes["finishedAt"] = *params.FinishedAt
+	}
+	if params.ReconsumeCount != nil {
+		attributes["reconsumeCount"] = *params.ReconsumeCount
+	}
+	if params.Notes != nil {
+		attributes["notes"] = *params.Notes
+	}
+
+	if entryID != "" {
+		body := map[string]any{
+			"data": map[string]any{
+				"id":         entryID,
+				"type":       "libraryEntries",
+				"attributes": attributes,
+			},
+		}
+		return c.do(ctx, http.MethodPatch, "/library-entries/"+entryID, nil, params.Token, body, nil)
+	}
+
+	body := map[string]any{
+		"data": map[string]any{
+			"type":       "libraryEntries",
+			"attributes": attributes,
+			"relationships": map[string]any{
+				"user":  map[string]any{"data": resourceID{ID: userID, Type: "users"}},
+				"anime": map[string]any{"data": resourceID{ID: params.AnimeID, Type: "anime"}},
+			},
+		},
+	}
+	return c.do(ctx, http.MethodPost, "/library-entries", nil, params.Token, body, nil)
+}
+
+type DeleteLibraryEntryParams struct {
+	Token   string
+	AnimeID string
+}
+
+func (c *Client) DeleteLibraryEntry(ctx context.Context, params DeleteLibraryEntryParams) error {
+	userID, err := c.GetSelfID(ctx, params.Token)
+	if err != nil {
+		return err
+	}
+
+	entryID, err := c.findEntry(ctx, params.Token, userID, params.AnimeID)
+	if err != nil || entryID == "" {
+		return err
+	}
+
+	err = c.do(ctx, http.MethodDelete, "/library-entries/"+entryID, nil, params.Token, nil, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (c *Client) findEntry(ctx context.Context, token, userID, animeID string) (string, error) {
+	query := url.Values{}
+	query.Set("filter[userId]", userID)
+	query.Set("filter[animeId]", animeID)
+	query.Set("fields[libraryEntries]", "status")
+
+	var doc document
+	if err := c.do(ctx, http.MethodGet, "/library-entries", query, token, nil, &doc); err != nil {
+		return "", err
+	}
+
+	var entries []resourceID
+	if err := json.Unmarshal(doc.Data, &entries); err != nil {
+		return "", fmt.Errorf("failed to decode library entries: %w", err)
+	}
+	if len(entries) == 0 {
+		return "", nil
+	}
+	return entries[0].ID, nil
+}
+
+func (c *Client) do(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	token string,
+	body any,
+	out *document,
+) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", jsonAPIMediaType)
+	if body != nil {
+		req.Header.Set("Content-Type", jsonAPIMediaType)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package kitsu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testToken = "token"
+
+// fakeKitsu stands in for the Kitsu API. It answers the self lookup for
+// testToken and records every write it receives.
+type fakeKitsu struct {
+	t       *testing.T
+	entries map[string]string // anime ID to library entry ID
+	pages   []string          // library pages served in order
+	writes  []request
+}
+
+type request struct {
+	Method string
+	Path   string
+	Body   map[string]any
+}
+
+func newFakeKitsu(t *testing.T) (*fakeKitsu, *Client) {
+	f := &fakeKitsu{t: t, entries: map[string]string{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, NewClient(srv.URL, nil)
+}
+
+func (f *fakeKitsu) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+testToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.Header.Get("Accept") != jsonAPIMediaType {
+		f.t.Errorf("Accept = %q, want %q", r.Header.Get("Accept"), jsonAPIMediaType)
+	}
+
+	q := r.URL.Query()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/users":
+		if q.Get("filter[self]") != "true" {
+			f.t.Errorf("filter[self] = %q, want true", q.Get("filter[self]"))
+		}
+		io.WriteString(w, `{"data":[{"id":"42","type":"users"}]}`)
+
+	case r.Method == http.MethodGet && r.URL.Path == "/library-entries" && q.Get("filter[animeId]") != "":
+		id, ok := f.entries[q.Get("filter[animeId]")]
+		if !ok {
+			io.WriteString(w, `{"data":[]}`)
+			return
+		}
+		io.WriteString(w, `{"data":[{"id":"`+id+`","type":"libraryEntries"}]}`)
+
+	case r.Method == http.MethodGet && r.URL.Path == "/library-entries":
+		if q.Get("filter[userId]") != "42" || q.Get("include") != "anime.mappings" {
+			f.t.Errorf("unexpected library query %s", r.URL.RawQuery)
+		}
+		switch q.Get("page[offset]") {
+		case "0":
+			io.WriteString(w, f.pages[0])
+		case "2":
+			io.WriteString(w, f.pages[1])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+
+	case r.Method == http.MethodPost || r.Method == http.MethodPatch || r.Method == http.MethodDelete:
+		if r.Method != http.MethodDelete && r.Header.Get("Content-Type") != jsonAPIMediaType {
+			f.t.Errorf("Content-Type = %q, want %q", r.Header.Get("Content-Type"), jsonAPIMediaType)
+		}
+		req := request{Method: r.Method, Path: r.URL.Path}
+		if r.Method != http.MethodDelete {
+			if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+				f.t.Errorf("decode body: %v", err)
+			}
+		}
+		f.writes = append(f.writes, req)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		io.WriteString(w, `{"data":{"id":"1","type":"libraryEntries"}}`)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGetSelfID(t *testing.T) {
+	_, client := newFakeKitsu(t)
+
+	id, err := client.GetSelfID(context.Background(), testToken)
+	if err != nil {
+		t.Fatalf("GetSelfID: %v", err)
+	}
+	if id != "42" {
+		t.Errorf("id = %q, want 42", id)
+	}
+}
+
+func TestGetLibraryEntries(t *testing.T) {
+	f, client := newFakeKitsu(t)
+	f.pages = []string{
+		`{
+			"data": [
+				{"id": "100", "type": "libraryEntries",
+				 "attributes": {"status": "current", "progress": 3, "updatedAt": "2024-01-02T00:00:00.000Z"},
+				 "relationships": {"anime": {"data": {"id": "7", "type": "anime"}}}},
+				{"id": "101", "type": "libraryEntries",
+				 "attributes": {"status": "planned", "progress": 0},
+				 "relationships": {"anime": {"data": {"id": "8", "type": "anime"}}}}
+			],
+			"included": [
+				{"id": "7", "type": "anime",
+				 "attributes": {"canonicalTitle": "Cowboy Bebop", "titles": {"ja_jp": "カウボーイビバップ"}, "startDate": "1998-04-03"},
+				 "relationships": {"mappings": {"data": [{"id": "m1", "type": "mappings"}, {"id": "m2", "type": "mappings"}]}}},
+				{"id": "m1", "type": "mappings", "attributes": {"externalSite": "anidb", "externalId": "23"}},
+				{"id": "m2", "type": "mappings", "attributes": {"externalSite": "myanimelist/anime", "externalId": "1"}},
+				{"id": "8", "type": "anime",
+				 "attributes": {"canonicalTitle": "Unmapped"},
+				 "relationships": {"mappings": {"data": []}}}
+			],
+			"links": {"next": "https://kitsu.io/api/edge/library-entries?page[offset]=2"}
+		}`,
+		`{
+			"data": [
+				{"id": "102", "type": "libraryEntries",
+				 "attributes": {"status": "completed", "progress": 26},
+				 "relationships": {"anime": {"data": {"id": "9", "type": "anime"}}}}
+			],
+			"included": [],
+			"links": {}
+		}`,
+	}
+
+	first, err := client.GetLibraryEntries(context.Background(), GetLibraryEntriesParams{
+		Token:        testToken,
+		UserID:       "42",
+		Page:         1,
+		ItemsPerPage: 2,
+	})
+	if err != nil {
+		t.Fatalf("GetLibraryEntries page 1: %v", err)
+	}
+	if !first.HasNext {
+		t.Error("page 1 HasNext = false, want true")
+	}
+	if len(first.Entries) != 2 {
+		t.Fatalf("page 1 has %d entries, want 2", len(first.Entries))
+	}
+
+	bebop := first.Entries[0]
+	if bebop.ID != "100" || bebop.AnimeID != "7" || bebop.Status != KitsuListStatusCurrent || bebop.Progress != 3 {
+		t.Errorf("unexpected entry %+v", bebop)
+	}
+	if bebop.MalID != 1 {
+		t.Errorf("MalID = %d, want 1 from the myanimelist mapping", bebop.MalID)
+	}
+	if bebop.Year != 1998 || len(bebop.Titles) != 2 || bebop.Titles[0] != "Cowboy Bebop" {
+		t.Errorf("titles = %v, year = %d", bebop.Titles, bebop.Year)
+	}
+	if first.Entries[1].MalID != 0 {
+		t.Errorf("unmapped MalID = %d, want 0", first.Entries[1].MalID)
+	}
+
+	second, err := client.GetLibraryEntries(context.Background(), GetLibraryEntriesParams{
+		Token:        testToken,
+		UserID:       "42",
+		Page:         2,
+		ItemsPerPage: 2,
+	})
+	if err != nil {
+		t.Fatalf("GetLibraryEntries page 2: %v", err)
+	}
+	if second.HasNext {
+		t.Error("page 2 HasNext = true, want false")
+	}
+	if len(second.Entries) != 1 || second.Entries[0].Status.ToRepository() != "completed" {
+		t.Errorf("unexpected page 2 %+v", second.Entries)
+	}
+	if second.Entries[0].MalID != 0 {
+		t.Errorf("MalID without included anime = %d, want 0", second.Entries[0].MalID)
+	}
+}
+
+func TestUpdateLibraryEntry(t *testing.T) {
+	f, client := newFakeKitsu(t)
+	f.entries["7"] = "100"
+
+	rating, rewatches, started, notes := 0, 2, "2024-01-02", "again"
+	err := client.UpdateLibraryEntry(context.Background(), UpdateLibraryEntryParams{
+		Token:           testToken,
+		AnimeID:         "7",
+		Status:          "paused",
+		WatchedEpisodes: 5,
+		RatingTwenty:    &rating,
+		StartedAt:       &started,
+		ReconsumeCount:  &rewatches,
+		Notes:           &notes,
+	})
+	if err != nil {
+		t.Fatalf("UpdateLibraryEntry existing: %v", err)
+	}
+
+	err = client.UpdateLibraryEntry(context.Background(), UpdateLibraryEntryParams{
+		Token:           testToken,
+		AnimeID:         "8",
+		Status:          "watching",
+		WatchedEpisodes: 1,
+	})
+	if err != nil {
+		t.Fatalf("UpdateLibraryEntry new: %v", err)
+	}
+
+	if len(f.writes) != 2 {
+		t.Fatalf("got %d writes, want 2", len(f.writes))
+	}
+
+	patch := f.writes[0]
+	if patch.Method != http.MethodPatch || patch.Path != "/library-entries/100" {
+		t.Errorf("existing entry written with %s %s", patch.Method, patch.Path)
+	}
+	attrs := patch.Body["data"].(map[string]any)["attributes"].(map[string]any)
+	if attrs["status"] != "on_hold" || attrs["progress"] != float64(5) {
+		t.Errorf("unexpected patch attributes %v", attrs)
+	}
+	if v, ok := attrs["ratingTwenty"]; !ok || v != nil {
+		t.Errorf("ratingTwenty = %v, want null to clear it", v)
+	}
+	if attrs["startedAt"] != started || attrs["reconsumeCount"] != float64(2) || attrs["notes"] != notes {
+		t.Errorf("unexpected patch details %v", attrs)
+	}
+	if _, ok := attrs["finishedAt"]; ok {
+		t.Errorf("nil finishedAt was sent: %v", attrs["finishedAt"])
+	}
+
+	post := f.writes[1]
+	if post.Method != http.MethodPost || post.Path != "/library-entries" {
+		t.Errorf("new entry written with %s %s", post.Method, post.Path)
+	}
+	data := post.Body["data"].(map[string]any)
+	rels := data["relationships"].(map[string]any)
+	user := rels["user"].(map[string]any)["data"].(map[string]any)
+	anime := rels["anime"].(map[string]any)["data"].(map[string]any)
+	if user["id"] != "42" || anime["id"] != "8" {
+		t.Errorf("unexpected relationships %v", rels)
+	}
+	if data["attributes"].(map[string]any)["status"] != "current" {
+		t.Errorf("unexpected post attributes %v", data["attributes"])
+	}
+}
+
+func TestDeleteLibraryEntry(t *testing.T) {
+	f, client := newFakeKitsu(t)
+	f.entries["7"] = "100"
+
+	err := client.DeleteLibraryEntry(context.Background(), DeleteLibraryEntryParams{
+		Token:   testToken,
+		AnimeID: "7",
+	})
+	if err != nil {
+		t.Fatalf("DeleteLibraryEntry: %v", err)
+	}
+	if len(f.writes) != 1 || f.writes[0].Method != http.MethodDelete || f.writes[0].Path != "/library-entries/100" {
+		t.Errorf("unexpected writes %+v", f.writes)
+	}
+
+	// nothing to delete when the anime is not in the library
+	err = client.DeleteLibraryEntry(context.Background(), DeleteLibraryEntryParams{
+		Token:   testToken,
+		AnimeID: "8",
+	})
+	if err != nil {
+		t.Fatalf("DeleteLibraryEntry missing: %v", err)
+	}
+	if len(f.writes) != 1 {
+		t.Errorf("missing entry caused a write: %+v", f.writes[1:])
+	}
+}
+
+func TestErrorStatuses(t *testing.T) {
+	f, client := newFakeKitsu(t)
+
+	_, err := client.GetSelfID(context.Background(), "revoked")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("401 error = %v, want ErrUnauthorized", err)
+	}
+
+	f.pages = []string{`{"data":[]}`}
+	_, err = client.GetLibraryEntries(context.Background(), GetLibraryEntriesParams{
+		Token:        testToken,
+		UserID:       "42",
+		Page:         9,
+		ItemsPerPage: 2,
+	})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("404 error = %v, want ErrNotFound", err)
+	}
+}
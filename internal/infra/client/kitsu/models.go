@@ -0,0 +1,110 @@
+package kitsu
+
+import (
+	"encoding/json"
+
+	"github.com/coeeter/aniways/internal/repository"
+)
+
+type KitsuListStatus string
+
+var (
+	KitsuListStatusCurrent   = KitsuListStatus("current")
+	KitsuListStatusPlanned   = KitsuListStatus("planned")
+	KitsuListStatusCompleted = KitsuListStatus("completed")
+	KitsuListStatusOnHold    = KitsuListStatus("on_hold")
+	KitsuListStatusDropped   = KitsuListStatus("dropped")
+)
+
+func (status KitsuListStatus) ToRepository() string {
+	switch status {
+	case KitsuListStatusCurrent:
+		return string(repository.LibraryStatusWatching)
+	case KitsuListStatusCompleted:
+		return string(repository.LibraryStatusCompleted)
+	case KitsuListStatusOnHold:
+		return string(repository.LibraryStatusPaused)
+	case KitsuListStatusDropped:
+		return string(repository.LibraryStatusDropped)
+	default:
+		return string(repository.LibraryStatusPlanning)
+	}
+}
+
+func (KitsuListStatus) FromRepository(status string) KitsuListStatus {
+	switch status {
+	case string(repository.LibraryStatusWatching):
+		return KitsuListStatusCurrent
+	case string(repository.LibraryStatusCompleted):
+		return KitsuListStatusCompleted
+	case string(repository.LibraryStatusPaused):
+		return KitsuListStatusOnHold
+	case string(repository.LibraryStatusDropped):
+		return KitsuListStatusDropped
+	default:
+		return KitsuListStatusPlanned
+	}
+}
+
+// LibraryEntry is a Kitsu library entry with the MAL ID of its anime, zero
+// when Kitsu has no MAL mapping for it.
+type LibraryEntry struct {
+	ID        string
+	AnimeID   string
+	MalID     int
+	Titles    []string
+	Year      int
+	Status    KitsuListStatus
+	Progress  int
+	UpdatedAt string
+}
+
+type LibraryPage struct {
+	Entries []LibraryEntry
+	HasNext bool
+}
+
+// JSON:API documents, only the fields read by the client are decoded.
+
+type resource struct {
+	ID            string                  `json:"id"`
+	Type          string                  `json:"type"`
+	Attributes    json.RawMessage         `json:"attributes"`
+	Relationships map[string]relationship `json:"relationships"`
+}
+
+type relationship struct {
+	Data json.RawMessage `json:"data"`
+}
+
+type resourceID struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type document struct {
+	Data     json.RawMessage `json:"data"`
+	Included []resource      `json:"included"`
+	Links    struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+type libraryEntryAttributes struct {
+	Status    string `json:"status"`
+	Progress  int    `json:"progress"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+type animeAttributes struct {
+	CanonicalTitle string            `json:"canonicalTitle"`
+	Titles         map[string]string `json:"titles"`
+	StartDate      string            `json:"startDate"`
+}
+
+type mappingAttributes struct {
+	ExternalSite string `json:"externalSite"`
+	ExternalID   string `json:"externalId"`
+}
+
+const malAnimeSite = "myanimelist/anime"
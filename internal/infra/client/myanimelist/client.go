@@ -3,6 +3,7 @@ package myanimelist
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -13,6 +14,12 @@ import (
 	"github.com/PuerkitoBio/goquery"
 )
 
+// ErrNotFound is returned when MAL has no anime with the requested ID.
+var ErrNotFound = errors.New("anime not found on MyAnimeList")
+
+// ErrUnauthorized is returned when MAL rejects the user's access token.
+var ErrUnauthorized = errors.New("unauthorized by MyAnimeList")
+
 type Client struct {
 	baseURL    string
 	clientId   string
@@ -72,6 +79,9 @@ func (c *Client) GetAnimeMetadata(ctx context.Context, malID int) (*MalAnimeMeta
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("mal ID %d: %w", malID, ErrNotFound)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
@@ -150,7 +160,7 @@ func (c *Client) GetAnimeList(ctx context.Context, params GetAnimeListParams) (A
 
 	query.Set("limit", strconv.Itoa(limit))
 	query.Set("offset", strconv.Itoa(offset))
-	query.Set("fields", "list_status")
+	query.Set("fields", "list_status{status,score,num_episodes_watched,updated_at,start_date,finish_date,num_times_rewatched,comments},alternative_titles,start_season")
 
 	u.RawQuery = query.Encode()
 
@@ -166,6 +176,9 @@ func (c *Client) GetAnimeList(ctx context.Context, params GetAnimeListParams) (A
 		return AnimeList{}, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return AnimeList{}, ErrUnauthorized
+	}
 	if resp.StatusCode != http.StatusOK {
 		return AnimeList{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
@@ -183,6 +196,13 @@ type UpdateAnimeListParams struct {
 	AnimeID         int
 	Status          string
 	WatchedEpisodes int
+	// The fields below are left untouched on MAL when nil. Score is out of
+	// 10 with 0 clearing it, dates are formatted as 2006-01-02.
+	Score      *int
+	Rewatches  *int
+	Comments   *string
+	StartDate  *string
+	FinishDate *string
 }
 
 func (c *Client) UpdateAnimeList(ctx context.Context, params UpdateAnimeListParams) error {
@@ -195,6 +215,21 @@ func (c *Client) UpdateAnimeList(ctx context.Context, params UpdateAnimeListPara
 	if params.WatchedEpisodes >= 0 {
 		body.Set("num_watched_episodes", strconv.Itoa(params.WatchedEpisodes))
 	}
+	if params.Score != nil {
+		body.Set("score", strconv.Itoa(*params.Score))
+	}
+	if params.Rewatches != nil {
+		body.Set("num_times_rewatched", strconv.Itoa(*params.Rewatches))
+	}
+	if params.Comments != nil {
+		body.Set("comments", *params.Comments)
+	}
+	if params.StartDate != nil {
+		body.Set("start_date", *params.StartDate)
+	}
+	if params.FinishDate != nil {
+		body.Set("finish_date", *params.FinishDate)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/anime/%d/my_list_status", c.baseURL, params.AnimeID), strings.NewReader(body.Encode()))
 	if err != nil {
@@ -209,6 +244,12 @@ func (c *Client) UpdateAnimeList(ctx context.Context, params UpdateAnimeListPara
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("mal ID %d: %w", params.AnimeID, ErrNotFound)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
@@ -242,6 +283,12 @@ func (c *Client) UpdateAnimeListStatus(ctx context.Context, params UpdateAnimeLi
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("mal ID %d: %w", params.AnimeID, ErrNotFound)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
@@ -267,5 +314,9 @@ func (c *Client) DeleteAnimeList(ctx context.Context, params DeleteAnimeListPara
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+
 	return nil
 }
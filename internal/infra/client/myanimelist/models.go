@@ -201,4 +201,10 @@ type ListStatus struct {
 	Status          string `json:"status"`
 	EpisodesWatched int    `json:"num_episodes_watched"`
 	UpdatedAt       string `json:"updated_at"`
+	// Score is out of 10, 0 when the user has not scored the anime.
+	Score             int    `json:"score"`
+	StartDate         string `json:"start_date"`
+	FinishDate        string `json:"finish_date"`
+	NumTimesRewatched int    `json:"num_times_rewatched"`
+	Comments          string `json:"comments"`
 }
@@ -1,22 +1,47 @@
 package shikimori
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/coeeter/aniways/internal/infra/cache"
 )
 
+var (
+	// ErrNotFound is returned when Shikimori has no record for the request.
+	ErrNotFound = errors.New("not found on Shikimori")
+	// ErrUnauthorized is returned when Shikimori rejects the user's access token.
+	ErrUnauthorized = errors.New("unauthorized by Shikimori")
+	// ErrRateLimited is returned when Shikimori answers 429 despite the limits.
+	ErrRateLimited = errors.New("rate limited by Shikimori")
+)
+
+// UserAgent identifies the app, Shikimori rejects requests without one.
+const UserAgent = "AniWays"
+
+// rateLimits are Shikimori's documented limits. Requests are counted in the
+// shared cache, so the API and the worker stay under them together.
+var rateLimits = []struct {
+	window time.Duration
+	max    int64
+}{
+	{time.Second, 5},
+	{time.Minute, 90},
+}
+
 type Client struct {
 	baseURL     string
 	httpClient  *http.Client
-	redisClient *cache.RedisClient
+	redisClient cache.Cache
 }
 
-func NewClient(redisClient *cache.RedisClient) *Client {
+func NewClient(redisClient cache.Cache) *Client {
 	return &Client{
 		baseURL: "https://shikimori.one/api",
 		httpClient: &http.Client{
@@ -45,34 +70,101 @@ func (c *Client) GetAnimeFranchise(ctx context.Context, malID int) (*FranchiseRe
 		return &fr, nil
 	}
 
-	url := fmt.Sprintf("%s/animes/%d/franchise", c.baseURL, malID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	var franchiseResponse FranchiseResponse
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/animes/%d/franchise", malID), "", nil, &franchiseResponse); err != nil {
+		return nil, err
+	}
+
+	pipeline := c.redisClient.Pipeline()
+	pipeline.Set(ctx, key, franchiseResponse, 7*24*time.Hour)
+	for _, node := range franchiseResponse.Nodes {
+		pipeline.Set(ctx, fmt.Sprintf("shikimori:derived_from:%d", node.ID), malID, 7*24*time.Hour)
+	}
+	pipeline.Exec(ctx)
+
+	return &franchiseResponse, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path, token string, body, out any) error {
+	if err := c.waitForLimits(ctx); err != nil {
+		return err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	var franchiseResponse FranchiseResponse
-	if err := json.NewDecoder(resp.Body).Decode(&franchiseResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
 	}
-
-	pipeline := c.redisClient.Pipeline()
-	pipeline.Set(ctx, key, franchiseResponse, 7*24*time.Hour)
-	for _, node := range franchiseResponse.Nodes {
-		pipeline.Set(ctx, fmt.Sprintf("shikimori:derived_from:%d", node.ID), malID, 7*24*time.Hour)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
-	pipeline.Exec(ctx)
+	return nil
+}
 
-	return &franchiseResponse, nil
+// waitForLimits blocks until the request fits in the current window of every
+// rate limit. Counting fails open, a cache outage must not stop the calls
+// and Shikimori's own 429 still surfaces as ErrRateLimited.
+func (c *Client) waitForLimits(ctx context.Context) error {
+	for {
+		now := time.Now()
+		var wait time.Duration
+		for _, limit := range rateLimits {
+			window := now.Truncate(limit.window)
+			key := fmt.Sprintf("shikimori:ratelimit:%s:%d", limit.window, window.Unix())
+			n, err := c.redisClient.Incr(ctx, key, limit.window+time.Second)
+			if err != nil {
+				return nil
+			}
+			if n > limit.max {
+				wait = window.Add(limit.window).Sub(now)
+				break
+			}
+		}
+		if wait == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
 }
@@ -1,5 +1,7 @@
 package shikimori
 
+import "github.com/coeeter/aniways/internal/repository"
+
 type FranchiseResponse struct {
 	Links     []Link `json:"links"`
 	Nodes     []Node `json:"nodes"`
@@ -17,3 +19,61 @@ type Node struct {
 	ID     int `json:"id"`
 	Weight int `json:"weight"`
 }
+
+type ShikimoriListStatus string
+
+var (
+	ShikimoriListStatusPlanned    = ShikimoriListStatus("planned")
+	ShikimoriListStatusWatching   = ShikimoriListStatus("watching")
+	ShikimoriListStatusRewatching = ShikimoriListStatus("rewatching")
+	ShikimoriListStatusCompleted  = ShikimoriListStatus("completed")
+	ShikimoriListStatusOnHold     = ShikimoriListStatus("on_hold")
+	ShikimoriListStatusDropped    = ShikimoriListStatus("dropped")
+)
+
+func (status ShikimoriListStatus) ToRepository() string {
+	switch status {
+	case ShikimoriListStatusWatching, ShikimoriListStatusRewatching:
+		return string(repository.LibraryStatusWatching)
+	case ShikimoriListStatusCompleted:
+		return string(repository.LibraryStatusCompleted)
+	case ShikimoriListStatusOnHold:
+		return string(repository.LibraryStatusPaused)
+	case ShikimoriListStatusDropped:
+		return string(repository.LibraryStatusDropped)
+	default:
+		return string(repository.LibraryStatusPlanning)
+	}
+}
+
+func (ShikimoriListStatus) FromRepository(status string) ShikimoriListStatus {
+	switch status {
+	case string(repository.LibraryStatusWatching):
+		return ShikimoriListStatusWatching
+	case string(repository.LibraryStatusCompleted):
+		return ShikimoriListStatusCompleted
+	case string(repository.LibraryStatusPaused):
+		return ShikimoriListStatusOnHold
+	case string(repository.LibraryStatusDropped):
+		return ShikimoriListStatusDropped
+	default:
+		return ShikimoriListStatusPlanned
+	}
+}
+
+type WhoAmIResponse struct {
+	ID       int    `json:"id"`
+	Nickname string `json:"nickname"`
+}
+
+// UserRate is an entry of a user's list. Shikimori anime IDs are MAL IDs, so
+// TargetID maps to MAL directly.
+type UserRate struct {
+	ID         int                 `json:"id"`
+	UserID     int                 `json:"user_id"`
+	TargetID   int                 `json:"target_id"`
+	TargetType string              `json:"target_type"`
+	Status     ShikimoriListStatus `json:"status"`
+	Episodes   int                 `json:"episodes"`
+	UpdatedAt  string              `json:"updated_at"`
+}
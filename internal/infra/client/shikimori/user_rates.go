@@ -0,0 +1,147 @@
+package shikimori
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const animeTargetType = "Anime"
+
+// WhoAmI returns the Shikimori user the token belongs to.
+func (c *Client) WhoAmI(ctx context.Context, token string) (WhoAmIResponse, error) {
+	var user WhoAmIResponse
+	if err := c.do(ctx, http.MethodGet, "/users/whoami", token, nil, &user); err != nil {
+		return WhoAmIResponse{}, err
+	}
+	if user.ID == 0 {
+		// whoami answers null for anonymous requests instead of a 401
+		return WhoAmIResponse{}, ErrUnauthorized
+	}
+	return user, nil
+}
+
+type GetUserRatesParams struct {
+	Token        string
+	UserID       int
+	Page         int
+	ItemsPerPage int
+}
+
+// GetUserRates returns a page of the user's anime list.
+func (c *Client) GetUserRates(ctx context.Context, params GetUserRatesParams) ([]UserRate, error) {
+	limit := params.ItemsPerPage
+	if limit == 0 {
+		limit = 100
+	}
+
+	query := url.Values{}
+	query.Set("user_id", strconv.Itoa(params.UserID))
+	query.Set("target_type", animeTargetType)
+	query.Set("page", strconv.Itoa(params.Page))
+	query.Set("limit", strconv.Itoa(limit))
+
+	var rates []UserRate
+	if err := c.do(ctx, http.MethodGet, "/v2/user_rates?"+query.Encode(), params.Token, nil, &rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+type UpdateUserRateParams struct {
+	Token           string
+	MalID           int
+	Status          string
+	WatchedEpisodes int
+	// The fields below are left untouched on Shikimori when nil. Score is
+	// out of 10 with 0 clearing it.
+	Score     *int
+	Rewatches *int
+	Text      *string
+}
+
+// UpdateUserRate creates the user's rate for the anime or overwrites the
+// existing one.
+func (c *Client) UpdateUserRate(ctx context.Context, params UpdateUserRateParams) error {
+	user, err := c.WhoAmI(ctx, params.Token)
+	if err != nil {
+		return err
+	}
+
+	rateID, err := c.findUserRate(ctx, params.Token, user.ID, params.MalID)
+	if err != nil {
+		return err
+	}
+
+	userRate := map[string]any{
+		"status": ShikimoriListStatus("").FromRepository(params.Status),
+	}
+	if params.WatchedEpisodes >= 0 {
+		userRate["episodes"] = params.WatchedEpisodes
+	}
+	if params.Score != nil {
+		userRate["score"] = *params.Score
+	}
+	if params.Rewatches != nil {
+		userRate["rewatches"] = *params.Rewatches
+	}
+	if params.Text != nil {
+		userRate["text"] = *params.Text
+	}
+
+	if rateID != 0 {
+		body := map[string]any{"user_rate": userRate}
+		return c.do(ctx, http.MethodPatch, fmt.Sprintf("/v2/user_rates/%d", rateID), params.Token, body, nil)
+	}
+
+	userRate["user_id"] = user.ID
+	userRate["target_id"] = params.MalID
+	userRate["target_type"] = animeTargetType
+	body := map[string]any{"user_rate": userRate}
+	err = c.do(ctx, http.MethodPost, "/v2/user_rates", params.Token, body, nil)
+	if err == ErrNotFound {
+		return fmt.Errorf("mal ID %d: %w", params.MalID, ErrNotFound)
+	}
+	return err
+}
+
+type DeleteUserRateParams struct {
+	Token string
+	MalID int
+}
+
+func (c *Client) DeleteUserRate(ctx context.Context, params DeleteUserRateParams) error {
+	user, err := c.WhoAmI(ctx, params.Token)
+	if err != nil {
+		return err
+	}
+
+	rateID, err := c.findUserRate(ctx, params.Token, user.ID, params.MalID)
+	if err != nil || rateID == 0 {
+		return err
+	}
+
+	err = c.do(ctx, http.MethodDelete, fmt.Sprintf("/v2/user_rates/%d", rateID), params.Token, nil, nil)
+	if err == ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+func (c *Client) findUserRate(ctx context.Context, token string, userID, malID int) (int, error) {
+	query := url.Values{}
+	query.Set("user_id", strconv.Itoa(userID))
+	query.Set("target_id", strconv.Itoa(malID))
+	query.Set("target_type", animeTargetType)
+
+	var rates []UserRate
+	if err := c.do(ctx, http.MethodGet, "/v2/user_rates?"+query.Encode(), token, nil, &rates); err != nil {
+		return 0, err
+	}
+	if len(rates) == 0 {
+		return 0, nil
+	}
+	return rates[0].ID, nil
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS seed_run_errors;
+DROP TABLE IF EXISTS seed_run_quarantined_pages;
+DROP TABLE IF EXISTS seed_runs;
+DROP TYPE IF EXISTS seed_run_status;
+DROP TYPE IF EXISTS seed_run_phase;
+DROP TYPE IF EXISTS seed_run_kind;
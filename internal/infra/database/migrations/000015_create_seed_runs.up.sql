@@ -0,0 +1,57 @@
+CREATE TYPE seed_run_kind AS ENUM(
+  'full_seed',
+  'recently_updated'
+);
+
+CREATE TYPE seed_run_phase AS ENUM(
+  'az_list',
+  'recently_updated'
+);
+
+CREATE TYPE seed_run_status AS ENUM(
+  'running',
+  'completed',
+  'failed'
+);
+
+CREATE TABLE seed_runs(
+  id varchar(21) PRIMARY KEY DEFAULT generate_nanoid(),
+  kind seed_run_kind NOT NULL,
+  phase seed_run_phase NOT NULL,
+  status seed_run_status NOT NULL DEFAULT 'running',
+  next_page int NOT NULL DEFAULT 1,
+  total_pages int NOT NULL DEFAULT 0,
+  error_message text NULL DEFAULT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  completed_at timestamp NULL DEFAULT NULL
+);
+
+CREATE INDEX idx_seed_runs_kind_status ON seed_runs(kind, status, created_at DESC);
+
+CREATE TRIGGER set_seed_runs_updated_at
+  BEFORE UPDATE ON seed_runs
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at_timestamp();
+
+CREATE TABLE seed_run_quarantined_pages(
+  seed_run_id varchar(21) NOT NULL,
+  phase seed_run_phase NOT NULL,
+  page int NOT NULL,
+  attempts int NOT NULL DEFAULT 1,
+  error_message text NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (seed_run_id, phase, page),
+  FOREIGN KEY (seed_run_id) REFERENCES seed_runs(id) ON DELETE CASCADE
+);
+
+CREATE TABLE seed_run_errors(
+  seed_run_id varchar(21) NOT NULL,
+  hi_anime_id text NOT NULL,
+  phase seed_run_phase NOT NULL,
+  error_message text NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (seed_run_id, hi_anime_id),
+  FOREIGN KEY (seed_run_id) REFERENCES seed_runs(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE animes
+    DROP COLUMN IF EXISTS unavailable_at,
+    DROP COLUMN IF EXISTS missing_checks;
@@ -0,0 +1,3 @@
+ALTER TABLE animes
+    ADD COLUMN missing_checks int NOT NULL DEFAULT 0,
+    ADD COLUMN unavailable_at timestamp NULL DEFAULT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS anime_mapping_override_audit;
+DROP TYPE IF EXISTS mapping_override_action;
+DROP TABLE IF EXISTS anime_mapping_overrides;
@@ -0,0 +1,35 @@
+CREATE TABLE anime_mapping_overrides(
+  hi_anime_id text PRIMARY KEY,
+  mal_id int NULL DEFAULT NULL,
+  anilist_id int NULL DEFAULT NULL,
+  season season NULL DEFAULT NULL,
+  season_year int NULL DEFAULT NULL,
+  ename text NULL DEFAULT NULL,
+  jname text NULL DEFAULT NULL,
+  note text NULL DEFAULT NULL,
+  updated_by text NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER set_anime_mapping_overrides_updated_at
+  BEFORE UPDATE ON anime_mapping_overrides
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at_timestamp();
+
+CREATE TYPE mapping_override_action AS ENUM(
+  'upsert',
+  'delete'
+);
+
+CREATE TABLE anime_mapping_override_audit(
+  id varchar(21) PRIMARY KEY DEFAULT generate_nanoid(),
+  hi_anime_id text NOT NULL,
+  action mapping_override_action NOT NULL,
+  changed_by text NOT NULL,
+  previous jsonb NULL DEFAULT NULL,
+  current jsonb NULL DEFAULT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_anime_mapping_override_audit_hi_anime_id ON anime_mapping_override_audit(hi_anime_id, created_at DESC);
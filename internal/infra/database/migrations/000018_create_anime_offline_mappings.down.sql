@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS anime_mapping_suggestions;
+DROP TYPE IF EXISTS mapping_suggestion_status;
+DROP TABLE IF EXISTS anime_offline_mappings;
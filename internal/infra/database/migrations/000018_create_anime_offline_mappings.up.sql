@@ -0,0 +1,51 @@
+CREATE TABLE anime_offline_mappings(
+  id bigserial PRIMARY KEY,
+  title text NOT NULL,
+  synonyms text[] NOT NULL DEFAULT '{}',
+  media_type text NULL DEFAULT NULL,
+  episodes int NULL DEFAULT NULL,
+  season season NULL DEFAULT NULL,
+  season_year int NULL DEFAULT NULL,
+  mal_id int NULL DEFAULT NULL,
+  anilist_id int NULL DEFAULT NULL,
+  kitsu_id int NULL DEFAULT NULL,
+  anidb_id int NULL DEFAULT NULL
+);
+
+CREATE INDEX idx_anime_offline_mappings_mal_id ON anime_offline_mappings(mal_id);
+
+CREATE INDEX idx_anime_offline_mappings_anilist_id ON anime_offline_mappings(anilist_id);
+
+CREATE INDEX idx_anime_offline_mappings_kitsu_id ON anime_offline_mappings(kitsu_id);
+
+CREATE INDEX idx_anime_offline_mappings_title_trgm ON anime_offline_mappings USING gin(title gin_trgm_ops);
+
+CREATE TYPE mapping_suggestion_status AS ENUM(
+  'pending',
+  'approved',
+  'rejected'
+);
+
+CREATE TABLE anime_mapping_suggestions(
+  id varchar(21) PRIMARY KEY DEFAULT generate_nanoid(),
+  anime_id varchar(21) NOT NULL,
+  mal_id int NULL DEFAULT NULL,
+  anilist_id int NULL DEFAULT NULL,
+  confidence real NOT NULL,
+  method text NOT NULL,
+  matched_title text NOT NULL,
+  status mapping_suggestion_status NOT NULL DEFAULT 'pending',
+  reviewed_by text NULL DEFAULT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  reviewed_at timestamp NULL DEFAULT NULL,
+  UNIQUE (anime_id),
+  FOREIGN KEY (anime_id) REFERENCES animes(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_anime_mapping_suggestions_status ON anime_mapping_suggestions(status, confidence DESC);
+
+CREATE TRIGGER set_anime_mapping_suggestions_updated_at
+  BEFORE UPDATE ON anime_mapping_suggestions
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at_timestamp();
@@ -0,0 +1,53 @@
+DROP TRIGGER IF EXISTS library_import_jobs_enqueue_trigger ON library_import_jobs;
+
+DROP FUNCTION IF EXISTS enqueue_library_import_job;
+
+DROP TRIGGER IF EXISTS sync_insert_update_enqueue ON external_library_sync;
+
+DROP FUNCTION IF EXISTS enqueue_library_sync;
+
+CREATE OR REPLACE FUNCTION notify_library_sync()
+  RETURNS TRIGGER
+  AS $$
+BEGIN
+  PERFORM
+    pg_notify('library_sync', json_build_object('user_id', NEW.user_id, 'anime_id', NEW.anime_id, 'provider', NEW.provider, 'action', NEW.action, 'payload', NEW.payload)::text);
+  RETURN NEW;
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER sync_insert_update_notify
+  AFTER INSERT OR UPDATE ON external_library_sync
+  FOR EACH ROW
+  WHEN(NEW.status = 'pending')
+  EXECUTE FUNCTION notify_library_sync();
+
+CREATE OR REPLACE FUNCTION notify_library_import_job_change()
+  RETURNS TRIGGER
+  AS $$
+DECLARE
+  payload json;
+BEGIN
+  payload = json_build_object('id', NEW.id, 'user_id', NEW.user_id, 'provider', NEW.provider, 'status', NEW.status);
+  PERFORM
+    pg_notify('library_import_jobs', payload::text);
+  RETURN NEW;
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER library_import_jobs_notify_trigger
+  AFTER INSERT ON library_import_jobs
+  FOR EACH ROW
+  EXECUTE FUNCTION notify_library_import_job_change();
+
+DROP TRIGGER IF EXISTS jobs_notify_trigger ON jobs;
+
+DROP FUNCTION IF EXISTS notify_job_queued;
+
+DROP FUNCTION IF EXISTS enqueue_job;
+
+DROP TABLE IF EXISTS jobs;
+
+DROP TYPE IF EXISTS job_status;
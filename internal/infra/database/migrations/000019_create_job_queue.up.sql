@@ -0,0 +1,136 @@
+CREATE TYPE job_status AS ENUM(
+  'queued',
+  'running',
+  'completed',
+  'dead'
+);
+
+CREATE TABLE jobs(
+  id varchar(21) PRIMARY KEY DEFAULT generate_nanoid(),
+  queue varchar(64) NOT NULL,
+  dedupe_key text NULL,
+  payload jsonb NOT NULL DEFAULT '{}',
+  status job_status NOT NULL DEFAULT 'queued',
+  attempts int NOT NULL DEFAULT 0,
+  max_attempts int NOT NULL DEFAULT 10,
+  run_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  locked_by text NULL,
+  locked_until timestamp NULL,
+  last_error text NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  completed_at timestamp NULL
+);
+
+CREATE INDEX idx_jobs_ready ON jobs(queue, run_at)
+WHERE
+  status = 'queued';
+
+CREATE INDEX idx_jobs_lease ON jobs(locked_until)
+WHERE
+  status = 'running';
+
+CREATE INDEX idx_jobs_finished ON jobs(updated_at)
+WHERE
+  status IN ('completed', 'dead');
+
+-- at most one queued job per key, a running one may still have a queued successor
+CREATE UNIQUE INDEX idx_jobs_dedupe ON jobs(queue, dedupe_key)
+WHERE
+  status = 'queued' AND dedupe_key IS NOT NULL;
+
+CREATE TRIGGER set_jobs_updated_at
+  BEFORE UPDATE ON jobs
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at_timestamp();
+
+CREATE OR REPLACE FUNCTION enqueue_job(job_queue varchar, job_dedupe_key text, job_payload jsonb, job_max_attempts int)
+  RETURNS void
+  AS $$
+BEGIN
+  INSERT INTO jobs(queue, dedupe_key, payload, max_attempts)
+    VALUES (job_queue, job_dedupe_key, job_payload, job_max_attempts)
+  ON CONFLICT (queue, dedupe_key)
+  WHERE
+    status = 'queued'
+      AND dedupe_key IS NOT NULL
+      DO UPDATE SET
+        payload = EXCLUDED.payload,
+        run_at = LEAST(jobs.run_at, EXCLUDED.run_at);
+END;
+$$
+LANGUAGE plpgsql;
+
+-- wake-up hint only, workers also poll so a missed notification just delays a job
+CREATE OR REPLACE FUNCTION notify_job_queued()
+  RETURNS TRIGGER
+  AS $$
+BEGIN
+  PERFORM
+    pg_notify('job_queue', NEW.queue);
+  RETURN NEW;
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER jobs_notify_trigger
+  AFTER INSERT ON jobs
+  FOR EACH ROW
+  EXECUTE FUNCTION notify_job_queued();
+
+-- library sync and import enqueue jobs instead of notifying listeners directly
+DROP TRIGGER IF EXISTS sync_insert_update_notify ON external_library_sync;
+
+DROP FUNCTION IF EXISTS notify_library_sync;
+
+DROP TRIGGER IF EXISTS library_import_jobs_notify_trigger ON library_import_jobs;
+
+DROP FUNCTION IF EXISTS notify_library_import_job_change;
+
+CREATE OR REPLACE FUNCTION enqueue_library_sync()
+  RETURNS TRIGGER
+  AS $$
+BEGIN
+  PERFORM
+    enqueue_job('library_sync', NEW.user_id || ':' || NEW.anime_id || ':' || NEW.provider || ':' || NEW.action, json_build_object('user_id', NEW.user_id, 'anime_id', NEW.anime_id, 'provider', NEW.provider, 'action', NEW.action)::jsonb, 8);
+  RETURN NEW;
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER sync_insert_update_enqueue
+  AFTER INSERT OR UPDATE ON external_library_sync
+  FOR EACH ROW
+  WHEN(NEW.status = 'pending')
+  EXECUTE FUNCTION enqueue_library_sync();
+
+CREATE OR REPLACE FUNCTION enqueue_library_import_job()
+  RETURNS TRIGGER
+  AS $$
+BEGIN
+  PERFORM
+    enqueue_job('library_import', NEW.id, json_build_object('id', NEW.id)::jsonb, 3);
+  RETURN NEW;
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER library_import_jobs_enqueue_trigger
+  AFTER INSERT ON library_import_jobs
+  FOR EACH ROW
+  EXECUTE FUNCTION enqueue_library_import_job();
+
+-- pick up work whose notification was lost before the queue existed
+SELECT
+  enqueue_job('library_sync', user_id || ':' || anime_id || ':' || provider || ':' || action, json_build_object('user_id', user_id, 'anime_id', anime_id, 'provider', provider, 'action', action)::jsonb, 8)
+FROM
+  external_library_sync
+WHERE
+  status = 'pending';
+
+SELECT
+  enqueue_job('library_import', id, json_build_object('id', id)::jsonb, 3)
+FROM
+  library_import_jobs
+WHERE
+  status IN ('pending', 'in_progress');
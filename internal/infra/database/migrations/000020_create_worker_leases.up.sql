@@ -0,0 +1,12 @@
+CREATE TABLE worker_leases(
+  name varchar(128) PRIMARY KEY,
+  holder text NOT NULL,
+  expires_at timestamp NOT NULL,
+  acquired_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER set_worker_leases_updated_at
+  BEFORE UPDATE ON worker_leases
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at_timestamp();
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS job_schedules;
+
+DROP TABLE IF EXISTS job_runs;
+
+DROP TYPE IF EXISTS job_run_trigger;
+
+DROP TYPE IF EXISTS job_run_status;
@@ -0,0 +1,39 @@
+CREATE TYPE job_run_status AS ENUM(
+  'running',
+  'succeeded',
+  'failed'
+);
+
+CREATE TYPE job_run_trigger AS ENUM(
+  'schedule',
+  'manual'
+);
+
+CREATE TABLE job_runs(
+  id varchar(21) PRIMARY KEY DEFAULT generate_nanoid(),
+  job_name varchar(128) NOT NULL,
+  trigger job_run_trigger NOT NULL,
+  status job_run_status NOT NULL DEFAULT 'running',
+  holder text NOT NULL,
+  error_count int NOT NULL DEFAULT 0,
+  warn_count int NOT NULL DEFAULT 0,
+  log_summary text NULL,
+  started_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  finished_at timestamp NULL,
+  duration_ms bigint NULL
+);
+
+CREATE INDEX idx_job_runs_job_name_started_at ON job_runs(job_name, started_at DESC);
+
+CREATE TABLE job_schedules(
+  job_name varchar(128) PRIMARY KEY,
+  paused boolean NOT NULL DEFAULT FALSE,
+  updated_by text NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER set_job_schedules_updated_at
+  BEFORE UPDATE ON job_schedules
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at_timestamp();
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_anime_metadata_updated_at;
+
+DROP TABLE IF EXISTS anime_metadata_sweep_failures;
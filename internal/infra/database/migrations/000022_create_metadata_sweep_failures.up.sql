@@ -0,0 +1,15 @@
+CREATE TABLE anime_metadata_sweep_failures(
+  mal_id int PRIMARY KEY,
+  attempts int NOT NULL DEFAULT 1,
+  last_error text NOT NULL,
+  next_attempt_at timestamp NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_anime_metadata_updated_at ON anime_metadata(updated_at);
+
+CREATE TRIGGER set_anime_metadata_sweep_failures_updated_at
+  BEFORE UPDATE ON anime_metadata_sweep_failures
+  FOR EACH ROW
+  EXECUTE FUNCTION set_updated_at_timestamp();
@@ -0,0 +1,14 @@
+-- enum values cannot be dropped, dead rows go back to failed
+UPDATE
+  external_library_sync
+SET
+  status = 'failed'
+WHERE
+  status = 'dead';
+
+DROP INDEX IF EXISTS idx_external_library_sync_next_attempt;
+
+ALTER TABLE external_library_sync
+  DROP COLUMN IF EXISTS next_attempt_at,
+  DROP COLUMN IF EXISTS last_error,
+  DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,10 @@
+ALTER TYPE library_sync_status ADD VALUE IF NOT EXISTS 'dead';
+
+ALTER TABLE external_library_sync
+  ADD COLUMN attempts integer NOT NULL DEFAULT 0,
+  ADD COLUMN last_error text,
+  ADD COLUMN next_attempt_at timestamp;
+
+CREATE INDEX idx_external_library_sync_next_attempt ON external_library_sync(next_attempt_at)
+WHERE
+  status = 'failed';
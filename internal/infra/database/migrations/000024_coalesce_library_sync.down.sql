@@ -0,0 +1,34 @@
+UPDATE
+  jobs
+SET
+  status = 'completed',
+  completed_at = NOW()
+WHERE
+  queue = 'library_sync'
+  AND status = 'queued';
+
+CREATE OR REPLACE FUNCTION enqueue_library_sync()
+  RETURNS TRIGGER
+  AS $$
+BEGIN
+  PERFORM
+    enqueue_job('library_sync', NEW.user_id || ':' || NEW.anime_id || ':' || NEW.provider || ':' || NEW.action, json_build_object('user_id', NEW.user_id, 'anime_id', NEW.anime_id, 'provider', NEW.provider, 'action', NEW.action)::jsonb, 8);
+  RETURN NEW;
+END;
+$$
+LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS enqueue_job_after;
+
+-- one row per provider is still unique with the action in the key
+ALTER TABLE external_library_sync
+  DROP COLUMN IF EXISTS revision,
+  DROP CONSTRAINT external_library_sync_pkey,
+  ADD PRIMARY KEY (user_id, anime_id, provider, action);
+
+SELECT
+  enqueue_job('library_sync', user_id || ':' || anime_id || ':' || provider || ':' || action, json_build_object('user_id', user_id, 'anime_id', anime_id, 'provider', provider, 'action', action)::jsonb, 8)
+FROM
+  external_library_sync
+WHERE
+  status = 'pending';
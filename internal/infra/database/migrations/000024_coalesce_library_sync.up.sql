@@ -0,0 +1,85 @@
+CREATE OR REPLACE FUNCTION enqueue_job_after(job_queue varchar, job_dedupe_key text, job_payload jsonb, job_max_attempts int, delay_seconds int)
+  RETURNS void
+  AS $$
+BEGIN
+  INSERT INTO jobs(queue, dedupe_key, payload, max_attempts, run_at)
+    VALUES (job_queue, job_dedupe_key, job_payload, job_max_attempts, NOW() + delay_seconds * INTERVAL '1 second')
+  ON CONFLICT (queue, dedupe_key)
+  WHERE
+    status = 'queued'
+      AND dedupe_key IS NOT NULL
+      DO UPDATE SET
+        payload = EXCLUDED.payload,
+        run_at = LEAST(jobs.run_at, EXCLUDED.run_at);
+END;
+$$
+LANGUAGE plpgsql;
+
+-- changes within the debounce window share one job, which reads the row when
+-- it runs and so pushes only the latest state
+CREATE OR REPLACE FUNCTION enqueue_library_sync()
+  RETURNS TRIGGER
+  AS $$
+BEGIN
+  PERFORM
+    enqueue_job_after('library_sync', NEW.user_id || ':' || NEW.anime_id || ':' || NEW.provider, json_build_object('user_id', NEW.user_id, 'anime_id', NEW.anime_id, 'provider', NEW.provider)::jsonb, 8, 5);
+  RETURN NEW;
+END;
+$$
+LANGUAGE plpgsql;
+
+-- keep only the newest row of every entry and provider
+DELETE FROM external_library_sync s USING external_library_sync newer
+WHERE newer.user_id = s.user_id
+  AND newer.anime_id = s.anime_id
+  AND newer.provider = s.provider
+  AND (newer.updated_at, newer.action) > (s.updated_at, s.action);
+
+-- rows now hold the desired remote state: the whole entry, or its deletion
+UPDATE
+  external_library_sync s
+SET
+  action = 'add_entry',
+  payload = json_build_object('status', l.status, 'watched_episodes', l.watched_episodes)::jsonb
+FROM
+  library l
+WHERE
+  l.user_id = s.user_id
+  AND l.anime_id = s.anime_id;
+
+UPDATE
+  external_library_sync s
+SET
+  action = 'delete_entry',
+  payload = '{}'
+WHERE
+  NOT EXISTS (
+    SELECT
+      1
+    FROM
+      library l
+    WHERE
+      l.user_id = s.user_id
+      AND l.anime_id = s.anime_id);
+
+ALTER TABLE external_library_sync
+  DROP CONSTRAINT external_library_sync_pkey,
+  ADD PRIMARY KEY (user_id, anime_id, provider),
+  ADD COLUMN revision integer NOT NULL DEFAULT 0;
+
+-- replace the jobs queued under the per-action keys
+UPDATE
+  jobs
+SET
+  status = 'completed',
+  completed_at = NOW()
+WHERE
+  queue = 'library_sync'
+  AND status = 'queued';
+
+SELECT
+  enqueue_job('library_sync', user_id || ':' || anime_id || ':' || provider, json_build_object('user_id', user_id, 'anime_id', anime_id, 'provider', provider)::jsonb, 8)
+FROM
+  external_library_sync
+WHERE
+  status = 'pending';
@@ -0,0 +1,2 @@
+ALTER TABLE oauth_tokens
+  ADD COLUMN needs_reauth BOOLEAN NOT NULL DEFAULT FALSE;
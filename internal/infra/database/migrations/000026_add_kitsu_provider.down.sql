@@ -0,0 +1,9 @@
+-- enum values cannot be dropped, remove everything tied to kitsu instead
+DELETE FROM external_library_sync
+WHERE provider = 'kitsu';
+
+DELETE FROM library_import_jobs
+WHERE provider = 'kitsu';
+
+DELETE FROM oauth_tokens
+WHERE provider = 'kitsu';
@@ -0,0 +1 @@
+ALTER TYPE provider ADD VALUE IF NOT EXISTS 'kitsu';
@@ -0,0 +1,9 @@
+-- enum values cannot be dropped, remove everything tied to shikimori instead
+DELETE FROM external_library_sync
+WHERE provider = 'shikimori';
+
+DELETE FROM library_import_jobs
+WHERE provider = 'shikimori';
+
+DELETE FROM oauth_tokens
+WHERE provider = 'shikimori';
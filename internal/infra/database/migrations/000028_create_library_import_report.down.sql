@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS library_import_report_items;
+
+ALTER TABLE library_import_jobs
+  DROP COLUMN IF EXISTS matched_count;
+
+DROP TYPE IF EXISTS library_import_issue;
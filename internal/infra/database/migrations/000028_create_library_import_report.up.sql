@@ -0,0 +1,22 @@
+CREATE TYPE library_import_issue AS ENUM(
+  'unmatched',
+  'ambiguous'
+);
+
+ALTER TABLE library_import_jobs
+  ADD COLUMN matched_count int NOT NULL DEFAULT 0;
+
+CREATE TABLE library_import_report_items(
+  id bigserial PRIMARY KEY,
+  job_id varchar(21) NOT NULL,
+  issue library_import_issue NOT NULL,
+  external_id text NOT NULL,
+  title text NOT NULL,
+  mal_id int NULL DEFAULT NULL,
+  anilist_id int NULL DEFAULT NULL,
+  candidate_ids text[] NOT NULL DEFAULT '{}',
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (job_id) REFERENCES library_import_jobs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_library_import_report_items_job_id ON library_import_report_items(job_id);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS library_import_changes;
+
+ALTER TABLE library_import_jobs
+  DROP COLUMN IF EXISTS strategy,
+  DROP COLUMN IF EXISTS dry_run;
+
+DROP TYPE IF EXISTS library_import_strategy;
@@ -0,0 +1,26 @@
+CREATE TYPE library_import_strategy AS ENUM(
+  'remote',
+  'local',
+  'newest',
+  'max_progress'
+);
+
+ALTER TABLE library_import_jobs
+  ADD COLUMN strategy library_import_strategy NOT NULL DEFAULT 'remote',
+  ADD COLUMN dry_run boolean NOT NULL DEFAULT FALSE;
+
+-- entries an import changed, or would change when the job is a dry run
+CREATE TABLE library_import_changes(
+  id bigserial PRIMARY KEY,
+  job_id varchar(21) NOT NULL,
+  anime_id varchar(21) NOT NULL,
+  local_status library_status NULL DEFAULT NULL,
+  local_watched_episodes int NULL DEFAULT NULL,
+  status library_status NOT NULL,
+  watched_episodes int NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (job_id) REFERENCES library_import_jobs(id) ON DELETE CASCADE,
+  FOREIGN KEY (anime_id) REFERENCES animes(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_library_import_changes_job_id ON library_import_changes(job_id);
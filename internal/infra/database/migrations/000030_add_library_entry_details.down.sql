@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_library_user_id_score;
+
+ALTER TABLE library
+  DROP COLUMN IF EXISTS score,
+  DROP COLUMN IF EXISTS started_at,
+  DROP COLUMN IF EXISTS completed_at,
+  DROP COLUMN IF EXISTS rewatches,
+  DROP COLUMN IF EXISTS notes;
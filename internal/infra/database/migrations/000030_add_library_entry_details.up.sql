@@ -0,0 +1,9 @@
+-- score is out of 100 like AniList's raw score, MAL receives it rounded to 1-10
+ALTER TABLE library
+  ADD COLUMN score smallint NULL DEFAULT NULL CHECK (score BETWEEN 1 AND 100),
+  ADD COLUMN started_at date NULL DEFAULT NULL,
+  ADD COLUMN completed_at date NULL DEFAULT NULL,
+  ADD COLUMN rewatches integer NOT NULL DEFAULT 0 CHECK (rewatches >= 0),
+  ADD COLUMN notes text NOT NULL DEFAULT '';
+
+CREATE INDEX idx_library_user_id_score ON library(user_id, score);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_library_status_updated_at;
+
+ALTER TABLE settings
+  DROP COLUMN IF EXISTS auto_start_library,
+  DROP COLUMN IF EXISTS auto_complete_library,
+  DROP COLUMN IF EXISTS auto_pause_library,
+  DROP COLUMN IF EXISTS auto_pause_after_days;
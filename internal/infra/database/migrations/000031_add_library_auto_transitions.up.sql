@@ -0,0 +1,8 @@
+-- auto_pause_after_days is only read when auto_pause_library is on
+ALTER TABLE settings
+  ADD COLUMN auto_start_library boolean NOT NULL DEFAULT TRUE,
+  ADD COLUMN auto_complete_library boolean NOT NULL DEFAULT TRUE,
+  ADD COLUMN auto_pause_library boolean NOT NULL DEFAULT FALSE,
+  ADD COLUMN auto_pause_after_days integer NOT NULL DEFAULT 30 CHECK (auto_pause_after_days > 0);
+
+CREATE INDEX idx_library_status_updated_at ON library(status, updated_at);
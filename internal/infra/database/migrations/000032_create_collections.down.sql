@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS collection_items;
+
+DROP TABLE IF EXISTS collections;
+
+DROP TYPE IF EXISTS collection_visibility;
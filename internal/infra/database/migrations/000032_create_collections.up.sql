@@ -0,0 +1,36 @@
+CREATE TYPE collection_visibility AS ENUM(
+  'private',
+  'unlisted',
+  'public'
+);
+
+-- slug is what shared links use, so it stays the same when a collection is
+-- renamed and cannot be guessed for unlisted collections
+CREATE TABLE collections(
+  id varchar(21) PRIMARY KEY DEFAULT generate_nanoid(),
+  user_id varchar(21) NOT NULL,
+  slug varchar(21) NOT NULL UNIQUE DEFAULT generate_nanoid(12),
+  name varchar(100) NOT NULL,
+  description text NOT NULL DEFAULT '',
+  visibility collection_visibility NOT NULL DEFAULT 'private',
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_collections_user_id ON collections(user_id);
+
+CREATE TABLE collection_items(
+  collection_id varchar(21) NOT NULL,
+  anime_id varchar(21) NOT NULL,
+  position integer NOT NULL,
+  note text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (collection_id, anime_id),
+  FOREIGN KEY (collection_id) REFERENCES collections(id) ON DELETE CASCADE,
+  FOREIGN KEY (anime_id) REFERENCES animes(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_collection_items_collection_id_position ON collection_items(collection_id, position);
+
+CREATE INDEX idx_collection_items_anime_id ON collection_items(anime_id);
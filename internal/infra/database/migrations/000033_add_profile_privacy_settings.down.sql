@@ -0,0 +1,6 @@
+ALTER TABLE settings
+  DROP COLUMN IF EXISTS public_profile,
+  DROP COLUMN IF EXISTS profile_show_library,
+  DROP COLUMN IF EXISTS profile_show_stats,
+  DROP COLUMN IF EXISTS profile_show_favorites,
+  DROP COLUMN IF EXISTS profile_show_activity;
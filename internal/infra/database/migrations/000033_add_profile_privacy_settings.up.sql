@@ -0,0 +1,7 @@
+-- profiles are opt-in, the section switches only apply once one is public
+ALTER TABLE settings
+  ADD COLUMN public_profile boolean NOT NULL DEFAULT FALSE,
+  ADD COLUMN profile_show_library boolean NOT NULL DEFAULT TRUE,
+  ADD COLUMN profile_show_stats boolean NOT NULL DEFAULT TRUE,
+  ADD COLUMN profile_show_favorites boolean NOT NULL DEFAULT TRUE,
+  ADD COLUMN profile_show_activity boolean NOT NULL DEFAULT TRUE;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS library_activities;
+
+DROP TYPE IF EXISTS library_activity_type;
+
+DROP TABLE IF EXISTS follows;
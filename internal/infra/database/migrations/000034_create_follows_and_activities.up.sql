@@ -0,0 +1,38 @@
+CREATE TABLE follows(
+  follower_id varchar(21) NOT NULL,
+  followee_id varchar(21) NOT NULL,
+  -- muted follows stay in the lists but are left out of the feed
+  muted boolean NOT NULL DEFAULT FALSE,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (follower_id, followee_id),
+  CHECK (follower_id <> followee_id),
+  FOREIGN KEY (follower_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (followee_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_follows_followee_id ON follows(followee_id, created_at DESC);
+
+CREATE TYPE library_activity_type AS ENUM(
+  'added',
+  'progressed',
+  'completed',
+  'scored'
+);
+
+-- the feed is built on read from the activities of followed users, so
+-- activities are looked up by user and time
+CREATE TABLE library_activities(
+  id varchar(21) PRIMARY KEY DEFAULT generate_nanoid(),
+  user_id varchar(21) NOT NULL,
+  anime_id varchar(21) NOT NULL,
+  type library_activity_type NOT NULL,
+  watched_episodes integer NOT NULL,
+  score smallint,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (anime_id) REFERENCES animes(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_library_activities_user_id_created_at ON library_activities(user_id, created_at DESC);
+
+CREATE INDEX idx_library_activities_anime_id ON library_activities(anime_id);
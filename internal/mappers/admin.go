@@ -0,0 +1,77 @@
+package mappers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/coeeter/aniways/internal/infra/client/hianime"
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+)
+
+// ApplyMappingOverride replaces the scraped fields that an admin has pinned
+// for this HiAnime ID. Unset override fields keep the scraped value.
+func ApplyMappingOverride(info *hianime.ScrapedAnimeInfoDto, o repository.AnimeMappingOverride) {
+	if o.MalID.Valid {
+		info.MalID = int(o.MalID.Int32)
+	}
+	if o.AnilistID.Valid {
+		info.AnilistID = int(o.AnilistID.Int32)
+	}
+	if o.Season.Valid {
+		info.Season = string(o.Season.Season)
+	}
+	if o.SeasonYear.Valid {
+		info.SeasonYear = int(o.SeasonYear.Int32)
+	}
+	if o.Ename.Valid && strings.TrimSpace(o.Ename.String) != "" {
+		info.EName = o.Ename.String
+	}
+	if o.Jname.Valid && strings.TrimSpace(o.Jname.String) != "" {
+		info.JName = o.Jname.String
+	}
+}
+
+func AnimeMappingOverrideFromRepository(o repository.AnimeMappingOverride) models.AnimeMappingOverride {
+	override := models.AnimeMappingOverride{
+		HiAnimeID: o.HiAnimeID,
+		UpdatedBy: o.UpdatedBy,
+		CreatedAt: o.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt: o.UpdatedAt.Time.Format(time.RFC3339),
+	}
+	if o.MalID.Valid {
+		override.MalID = &o.MalID.Int32
+	}
+	if o.AnilistID.Valid {
+		override.AnilistID = &o.AnilistID.Int32
+	}
+	if o.Season.Valid {
+		season := string(o.Season.Season)
+		override.Season = &season
+	}
+	if o.SeasonYear.Valid {
+		override.SeasonYear = &o.SeasonYear.Int32
+	}
+	if o.Ename.Valid {
+		override.EName = &o.Ename.String
+	}
+	if o.Jname.Valid {
+		override.JName = &o.Jname.String
+	}
+	if o.Note.Valid {
+		override.Note = &o.Note.String
+	}
+	return override
+}
+
+func AnimeMappingOverrideAuditFromRepository(a repository.AnimeMappingOverrideAudit) models.AnimeMappingOverrideAuditEntry {
+	return models.AnimeMappingOverrideAuditEntry{
+		ID:        a.ID,
+		HiAnimeID: a.HiAnimeID,
+		Action:    string(a.Action),
+		ChangedBy: a.ChangedBy,
+		Previous:  a.Previous,
+		Current:   a.Current,
+		CreatedAt: a.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
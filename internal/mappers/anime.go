@@ -60,6 +60,10 @@ func AnimeWithLibraryFromCatalog(anime repository.GetAnimeCatalogRow) models.Ani
 		library := models.LibraryInfo{
 			ID:              anime.LibraryID.String,
 			WatchedEpisodes: anime.LibraryWatchedEpisodes.Int32,
+			Score:           LibraryScore(anime.LibraryScore),
+			StartedAt:       LibraryDate(anime.LibraryStartedAt),
+			CompletedAt:     LibraryDate(anime.LibraryCompletedAt),
+			Rewatches:       anime.LibraryRewatches.Int32,
 		}
 
 		if anime.LibraryStatus.Valid {
@@ -0,0 +1,30 @@
+package mappers
+
+import (
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+)
+
+func CollectionFromRepository(c repository.Collection, itemCount int64) models.CollectionResponse {
+	return models.CollectionResponse{
+		ID:          c.ID,
+		UserID:      c.UserID,
+		Slug:        c.Slug,
+		Name:        c.Name,
+		Description: c.Description,
+		Visibility:  models.CollectionVisibility(c.Visibility),
+		ItemCount:   itemCount,
+		CreatedAt:   c.CreatedAt.Time,
+		UpdatedAt:   c.UpdatedAt.Time,
+	}
+}
+
+func CollectionItemFromRepository(i repository.CollectionItem, a repository.Anime) models.CollectionItemResponse {
+	return models.CollectionItemResponse{
+		AnimeID:   i.AnimeID,
+		Position:  i.Position,
+		Note:      i.Note,
+		CreatedAt: i.CreatedAt.Time,
+		Anime:     AnimeFromRepository(a),
+	}
+}
@@ -0,0 +1,26 @@
+package mappers
+
+import (
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+)
+
+func PublicUserFromRepository(u repository.User) models.PublicUserResponse {
+	return models.PublicUserResponse{
+		ID:             u.ID,
+		Username:       u.Username,
+		ProfilePicture: u.ProfilePicture.String,
+	}
+}
+
+func ActivityFromRepository(a repository.LibraryActivity, u repository.User, anime repository.Anime) models.ActivityResponse {
+	return models.ActivityResponse{
+		ID:              a.ID,
+		Type:            models.LibraryActivityType(a.Type),
+		WatchedEpisodes: a.WatchedEpisodes,
+		Score:           LibraryScore(a.Score),
+		CreatedAt:       a.CreatedAt.Time,
+		User:            PublicUserFromRepository(u),
+		Anime:           AnimeFromRepository(anime),
+	}
+}
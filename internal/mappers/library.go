@@ -1,8 +1,11 @@
 package mappers
 
 import (
+	"time"
+
 	"github.com/coeeter/aniways/internal/models"
 	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func LibraryFromRepository(l repository.Library, a repository.Anime) models.LibraryResponse {
@@ -12,13 +15,67 @@ func LibraryFromRepository(l repository.Library, a repository.Anime) models.Libr
 		AnimeID:         l.AnimeID,
 		Status:          models.LibraryStatus(l.Status),
 		WatchedEpisodes: l.WatchedEpisodes,
+		Score:           LibraryScore(l.Score),
+		StartedAt:       LibraryDate(l.StartedAt),
+		CompletedAt:     LibraryDate(l.CompletedAt),
+		Rewatches:       l.Rewatches,
+		Notes:           l.Notes,
 		CreatedAt:       l.CreatedAt.Time,
 		UpdatedAt:       l.UpdatedAt.Time,
 		Anime:           AnimeFromRepository(a),
 	}
 }
 
-func LibraryImportJobFromRepository(j repository.LibraryImportJob) models.LibraryImportJobResponse {
+func LibraryScore(score pgtype.Int2) *int32 {
+	if !score.Valid {
+		return nil
+	}
+	s := int32(score.Int16)
+	return &s
+}
+
+// LibraryDate formats a library date as 2006-01-02.
+func LibraryDate(date pgtype.Date) *string {
+	if !date.Valid {
+		return nil
+	}
+	d := date.Time.Format(time.DateOnly)
+	return &d
+}
+
+func LibraryImportJobFromRepository(
+	j repository.LibraryImportJob,
+	items []repository.LibraryImportReportItem,
+	changes []repository.GetLibraryImportChangesRow,
+) models.LibraryImportJobResponse {
+	report := models.LibraryImportReportResponse{
+		Matched: j.MatchedCount,
+		Items:   make([]models.LibraryImportReportItemResponse, 0, len(items)),
+		Changes: make([]models.LibraryImportChangeResponse, 0, len(changes)),
+	}
+	for _, item := range items {
+		switch item.Issue {
+		case repository.LibraryImportIssueUnmatched:
+			report.Unmatched++
+		case repository.LibraryImportIssueAmbiguous:
+			report.Ambiguous++
+		}
+		report.Items = append(report.Items, LibraryImportReportItemFromRepository(item))
+	}
+	for _, row := range changes {
+		c := row.LibraryImportChange
+		switch {
+		case !c.LocalStatus.Valid:
+			report.Added++
+		case c.LocalStatus.LibraryStatus != c.Status:
+			report.StatusChanged++
+		}
+		if c.LocalWatchedEpisodes.Valid && c.WatchedEpisodes < c.LocalWatchedEpisodes.Int32 {
+			report.ProgressRegressed++
+		}
+		report.Changes = append(report.Changes, LibraryImportChangeFromRepository(c, row.Anime))
+	}
+
 	return models.LibraryImportJobResponse{
 		ID:          j.ID,
 		UserID:      j.UserID,
@@ -26,5 +83,63 @@ func LibraryImportJobFromRepository(j repository.LibraryImportJob) models.Librar
 		CreatedAt:   j.CreatedAt.Time,
 		UpdatedAt:   j.UpdatedAt.Time,
 		CompletedAt: j.CompletedAt.Time,
+		Strategy:    models.LibraryImportStrategy(j.Strategy),
+		DryRun:      j.DryRun,
+		Report:      report,
+	}
+}
+
+func LibraryImportChangeFromRepository(c repository.LibraryImportChange, a repository.Anime) models.LibraryImportChangeResponse {
+	resp := models.LibraryImportChangeResponse{
+		AnimeID:         c.AnimeID,
+		Status:          models.LibraryStatus(c.Status),
+		WatchedEpisodes: c.WatchedEpisodes,
+		Anime:           AnimeFromRepository(a),
+	}
+	if c.LocalStatus.Valid {
+		status := models.LibraryStatus(c.LocalStatus.LibraryStatus)
+		resp.LocalStatus = &status
+	}
+	if c.LocalWatchedEpisodes.Valid {
+		resp.LocalWatchedEpisodes = &c.LocalWatchedEpisodes.Int32
+	}
+	return resp
+}
+
+func LibraryImportReportItemFromRepository(i repository.LibraryImportReportItem) models.LibraryImportReportItemResponse {
+	resp := models.LibraryImportReportItemResponse{
+		Issue:        string(i.Issue),
+		ExternalID:   i.ExternalID,
+		Title:        i.Title,
+		CandidateIDs: i.CandidateIds,
+	}
+	if resp.CandidateIDs == nil {
+		resp.CandidateIDs = []string{}
+	}
+	if i.MalID.Valid {
+		resp.MalID = &i.MalID.Int32
+	}
+	if i.AnilistID.Valid {
+		resp.AnilistID = &i.AnilistID.Int32
+	}
+	return resp
+}
+
+func LibrarySyncFailureFromRepository(s repository.ExternalLibrarySync, a repository.Anime) models.LibrarySyncFailureResponse {
+	resp := models.LibrarySyncFailureResponse{
+		AnimeID:   s.AnimeID,
+		Provider:  string(s.Provider),
+		Action:    string(s.Action),
+		Status:    string(s.Status),
+		Attempts:  s.Attempts,
+		UpdatedAt: s.UpdatedAt.Time,
+		Anime:     AnimeFromRepository(a),
+	}
+	if s.LastError.Valid {
+		resp.LastError = &s.LastError.String
+	}
+	if s.NextAttemptAt.Valid {
+		resp.NextAttemptAt = &s.NextAttemptAt.Time
 	}
+	return resp
 }
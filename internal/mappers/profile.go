@@ -0,0 +1,26 @@
+package mappers
+
+import (
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+)
+
+// PublicLibraryFromRepository maps an entry shown to other users, notes are
+// private to the owner.
+func PublicLibraryFromRepository(l repository.Library, a repository.Anime) models.LibraryResponse {
+	entry := LibraryFromRepository(l, a)
+	entry.Notes = ""
+	return entry
+}
+
+func ProfileStatsFromRepository(s repository.GetProfileStatsRow) *models.ProfileStatsResponse {
+	return &models.ProfileStatsResponse{
+		Watching:        s.Watching,
+		Planning:        s.Planning,
+		Completed:       s.Completed,
+		Dropped:         s.Dropped,
+		Paused:          s.Paused,
+		EpisodesWatched: s.EpisodesWatched,
+		MeanScore:       s.MeanScore,
+	}
+}
@@ -7,23 +7,41 @@ import (
 
 func SettingsFromRepository(r repository.GetSettingsOfUserRow) models.SettingsResponse {
 	return models.SettingsResponse{
-		UserID:            r.Setting.UserID,
-		AutoNextEpisode:   r.Setting.AutoNextEpisode,
-		AutoPlayEpisode:   r.Setting.AutoPlayEpisode,
-		AutoResumeEpisode: r.Setting.AutoResumeEpisode,
-		IncognitoMode:     r.Setting.IncognitoMode,
-		Theme:             ThemesFromRepository(r.Theme),
+		UserID:               r.Setting.UserID,
+		AutoNextEpisode:      r.Setting.AutoNextEpisode,
+		AutoPlayEpisode:      r.Setting.AutoPlayEpisode,
+		AutoResumeEpisode:    r.Setting.AutoResumeEpisode,
+		IncognitoMode:        r.Setting.IncognitoMode,
+		Theme:                ThemesFromRepository(r.Theme),
+		AutoStartLibrary:     r.Setting.AutoStartLibrary,
+		AutoCompleteLibrary:  r.Setting.AutoCompleteLibrary,
+		AutoPauseLibrary:     r.Setting.AutoPauseLibrary,
+		AutoPauseAfterDays:   int(r.Setting.AutoPauseAfterDays),
+		PublicProfile:        r.Setting.PublicProfile,
+		ProfileShowLibrary:   r.Setting.ProfileShowLibrary,
+		ProfileShowStats:     r.Setting.ProfileShowStats,
+		ProfileShowFavorites: r.Setting.ProfileShowFavorites,
+		ProfileShowActivity:  r.Setting.ProfileShowActivity,
 	}
 }
 
 func SettingsFromSaveRepository(r repository.SaveSettingsRow) models.SettingsResponse {
 	return models.SettingsResponse{
-		UserID:            r.UserID,
-		AutoNextEpisode:   r.AutoNextEpisode,
-		AutoPlayEpisode:   r.AutoPlayEpisode,
-		AutoResumeEpisode: r.AutoResumeEpisode,
-		IncognitoMode:     r.IncognitoMode,
-		Theme:             ThemesFromRepository(r.Theme),
+		UserID:               r.UserID,
+		AutoNextEpisode:      r.AutoNextEpisode,
+		AutoPlayEpisode:      r.AutoPlayEpisode,
+		AutoResumeEpisode:    r.AutoResumeEpisode,
+		IncognitoMode:        r.IncognitoMode,
+		Theme:                ThemesFromRepository(r.Theme),
+		AutoStartLibrary:     r.AutoStartLibrary,
+		AutoCompleteLibrary:  r.AutoCompleteLibrary,
+		AutoPauseLibrary:     r.AutoPauseLibrary,
+		AutoPauseAfterDays:   int(r.AutoPauseAfterDays),
+		PublicProfile:        r.PublicProfile,
+		ProfileShowLibrary:   r.ProfileShowLibrary,
+		ProfileShowStats:     r.ProfileShowStats,
+		ProfileShowFavorites: r.ProfileShowFavorites,
+		ProfileShowActivity:  r.ProfileShowActivity,
 	}
 }
 
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"sync"
 	"time"
 )
@@ -124,3 +125,91 @@ func (job *BulkJob) ToResultResponse() BulkJobResult {
 		FailedIDs: failedIDs,
 	}
 }
+
+type SeedRun struct {
+	ID               string  `json:"id"`
+	Kind             string  `json:"kind" example:"full_seed"`
+	Phase            string  `json:"phase" example:"az_list"`
+	Status           string  `json:"status" example:"running"`
+	NextPage         int32   `json:"nextPage" example:"120"`
+	TotalPages       int32   `json:"totalPages" example:"210"`
+	ErroredCount     int64   `json:"erroredCount" example:"12"`
+	QuarantinedCount int64   `json:"quarantinedCount" example:"1"`
+	ErrorMessage     *string `json:"errorMessage"`
+	CreatedAt        string  `json:"createdAt" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt        string  `json:"updatedAt" example:"2023-01-01T00:05:00Z"`
+	CompletedAt      *string `json:"completedAt"`
+}
+
+type AnimeMappingOverrideRequest struct {
+	MalID      *int32  `json:"malId" validate:"omitempty,min=1" example:"5114"`
+	AnilistID  *int32  `json:"anilistId" validate:"omitempty,min=1" example:"5114"`
+	Season     *string `json:"season" validate:"omitempty,oneof=winter spring summer fall unknown" example:"spring"`
+	SeasonYear *int32  `json:"seasonYear" validate:"omitempty,min=1900,max=2100" example:"2009"`
+	EName      *string `json:"ename" example:"Fullmetal Alchemist: Brotherhood"`
+	JName      *string `json:"jname" example:"Hagane no Renkinjutsushi: Fullmetal Alchemist"`
+	Note       *string `json:"note" example:"HiAnime links the 2003 series"`
+}
+
+type AnimeMappingOverride struct {
+	HiAnimeID  string  `json:"hiAnimeId" example:"fullmetal-alchemist-brotherhood-1"`
+	MalID      *int32  `json:"malId" example:"5114"`
+	AnilistID  *int32  `json:"anilistId" example:"5114"`
+	Season     *string `json:"season" example:"spring"`
+	SeasonYear *int32  `json:"seasonYear" example:"2009"`
+	EName      *string `json:"ename"`
+	JName      *string `json:"jname"`
+	Note       *string `json:"note"`
+	UpdatedBy  string  `json:"updatedBy" example:"admin"`
+	CreatedAt  string  `json:"createdAt" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt  string  `json:"updatedAt" example:"2023-01-01T00:05:00Z"`
+}
+
+type AnimeMappingOverrideAuditEntry struct {
+	ID        string          `json:"id"`
+	HiAnimeID string          `json:"hiAnimeId"`
+	Action    string          `json:"action" example:"upsert"`
+	ChangedBy string          `json:"changedBy" example:"admin"`
+	Previous  json.RawMessage `json:"previous"`
+	Current   json.RawMessage `json:"current"`
+	CreatedAt string          `json:"createdAt" example:"2023-01-01T00:00:00Z"`
+}
+
+type AnimeMappingSuggestion struct {
+	ID           string  `json:"id"`
+	AnimeID      string  `json:"animeId"`
+	HiAnimeID    string  `json:"hiAnimeId" example:"fullmetal-alchemist-brotherhood-1"`
+	EName        string  `json:"ename"`
+	JName        string  `json:"jname"`
+	Season       string  `json:"season" example:"spring"`
+	SeasonYear   int32   `json:"seasonYear" example:"2009"`
+	MalID        *int32  `json:"malId" example:"5114"`
+	AnilistID    *int32  `json:"anilistId" example:"5114"`
+	Confidence   float32 `json:"confidence" example:"0.72"`
+	Method       string  `json:"method" example:"title_fuzzy"`
+	MatchedTitle string  `json:"matchedTitle" example:"Fullmetal Alchemist: Brotherhood"`
+	Status       string  `json:"status" example:"pending"`
+	ReviewedBy   *string `json:"reviewedBy"`
+	CreatedAt    string  `json:"createdAt" example:"2023-01-01T00:00:00Z"`
+}
+
+type MetadataCoverage struct {
+	Total           int64   `json:"total" example:"12000"`
+	WithMetadata    int64   `json:"withMetadata" example:"11800"`
+	Missing         int64   `json:"missing" example:"200"`
+	Fresh           int64   `json:"fresh" example:"11000"`
+	Stale           int64   `json:"stale" example:"800"`
+	Airing          int64   `json:"airing" example:"150"`
+	AiringStale     int64   `json:"airingStale" example:"10"`
+	Failing         int64   `json:"failing" example:"25"`
+	OldestUpdatedAt *string `json:"oldestUpdatedAt" example:"2023-01-01T00:00:00Z"`
+}
+
+type CachePurgeRequest struct {
+	Tag    string `json:"tag" validate:"required_without=Prefix,excluded_with=Prefix" example:"anime:abc123"`
+	Prefix string `json:"prefix" validate:"omitempty,min=3" example:"episode_servers:"`
+}
+
+type CachePurgeResponse struct {
+	Deleted int `json:"deleted" example:"12"`
+}
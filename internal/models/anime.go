@@ -129,6 +129,10 @@ type LibraryInfo struct {
 	ID              string        `json:"id" validate:"required" example:"V1StGXR8Z5jdHi6B"`
 	Status          LibraryStatus `json:"status" validate:"required" example:"watching"`
 	WatchedEpisodes int32         `json:"watchedEpisodes" validate:"required,min=0" example:"12"`
+	Score           *int32        `json:"score" example:"85"`
+	StartedAt       *string       `json:"startedAt" example:"2024-01-31"`
+	CompletedAt     *string       `json:"completedAt" example:"2024-03-20"`
+	Rewatches       int32         `json:"rewatches" validate:"required" example:"1"`
 	UpdatedAt       string        `json:"updatedAt" validate:"required" example:"2023-01-01T00:00:00Z"`
 }
 
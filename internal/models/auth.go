@@ -17,3 +17,13 @@ type ChangePasswordTokenResponse struct {
 	User      UserResponse `json:"user"`
 	ExpiresAt int64        `json:"expires_at" example:"1700000000"`
 }
+
+type OauthProviderResponse struct {
+	Provider    string `json:"provider" validate:"required" example:"myanimelist"`
+	NeedsReauth bool   `json:"needsReauth" validate:"required" example:"false"`
+}
+
+type OAuthPasswordRequest struct {
+	Username string `json:"username" validate:"required" example:"user@example.com"`
+	Password string `json:"password" validate:"required" example:"password123"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+type CollectionRequest struct {
+	Name        string               `json:"name" validate:"required,max=100" example:"Comfy winter watches"`
+	Description string               `json:"description" validate:"max=2000" example:"Slice of life to watch with a blanket"`
+	Visibility  CollectionVisibility `json:"visibility" validate:"required" example:"private"`
+}
+
+type CollectionItemRequest struct {
+	AnimeID string `json:"animeId" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+	Note    string `json:"note" validate:"max=500" example:"Start with the OVA"`
+}
+
+type CollectionItemNoteRequest struct {
+	Note string `json:"note" validate:"max=500" example:"Start with the OVA"`
+}
+
+// CollectionOrderRequest lists every anime of the collection in its new order.
+type CollectionOrderRequest struct {
+	AnimeIDs []string `json:"animeIds" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+}
+
+type CollectionResponse struct {
+	ID          string               `json:"id" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+	UserID      string               `json:"userId" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+	Slug        string               `json:"slug" validate:"required" example:"x8Fk2LmQ0pZr"`
+	Name        string               `json:"name" validate:"required" example:"Comfy winter watches"`
+	Description string               `json:"description" validate:"required" example:"Slice of life to watch with a blanket"`
+	Visibility  CollectionVisibility `json:"visibility" validate:"required" example:"private"`
+	ItemCount   int64                `json:"itemCount" validate:"required" example:"12"`
+	CreatedAt   time.Time            `json:"createdAt" validate:"required" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt   time.Time            `json:"updatedAt" validate:"required" example:"2023-01-01T00:00:00Z"`
+}
+
+type CollectionListResponse = Pagination[CollectionResponse]
+
+type CollectionItemResponse struct {
+	AnimeID   string        `json:"animeId" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+	Position  int32         `json:"position" validate:"required" example:"1"`
+	Note      string        `json:"note" validate:"required" example:"Start with the OVA"`
+	CreatedAt time.Time     `json:"createdAt" validate:"required" example:"2023-01-01T00:00:00Z"`
+	Anime     AnimeResponse `json:"anime" validate:"required"`
+}
+
+// CollectionWithItemsResponse is a collection with one page of its items in
+// their manual order.
+type CollectionWithItemsResponse struct {
+	Collection CollectionResponse       `json:"collection" validate:"required"`
+	PageInfo   PageInfo                 `json:"pageInfo" validate:"required"`
+	Items      []CollectionItemResponse `json:"items" validate:"required"`
+}
@@ -37,16 +37,90 @@ func (s LibraryImportStatus) IsValid() bool {
 	}
 }
 
+type LibraryImportStrategy string
+
+const (
+	LibraryImportStrategyRemote      LibraryImportStrategy = "remote"
+	LibraryImportStrategyLocal       LibraryImportStrategy = "local"
+	LibraryImportStrategyNewest      LibraryImportStrategy = "newest"
+	LibraryImportStrategyMaxProgress LibraryImportStrategy = "max_progress"
+)
+
+func (s LibraryImportStrategy) IsValid() bool {
+	switch s {
+	case LibraryImportStrategyRemote, LibraryImportStrategyLocal, LibraryImportStrategyNewest, LibraryImportStrategyMaxProgress:
+		return true
+	default:
+		return false
+	}
+}
+
 type OAuthProvider string
 
 const (
-	OAuthProviderAnilist OAuthProvider = "anilist"
-	OAuthProviderMAL     OAuthProvider = "myanimelist"
+	OAuthProviderAnilist   OAuthProvider = "anilist"
+	OAuthProviderMAL       OAuthProvider = "myanimelist"
+	OAuthProviderKitsu     OAuthProvider = "kitsu"
+	OAuthProviderShikimori OAuthProvider = "shikimori"
 )
 
 func (p OAuthProvider) IsValid() bool {
 	switch p {
-	case OAuthProviderAnilist, OAuthProviderMAL:
+	case OAuthProviderAnilist, OAuthProviderMAL, OAuthProviderKitsu, OAuthProviderShikimori:
+		return true
+	default:
+		return false
+	}
+}
+
+// CollectionVisibility decides who can see a collection. Unlisted and public
+// collections can be opened by slug, public ones are also listed on the
+// owner's public profile.
+type CollectionVisibility string
+
+const (
+	CollectionVisibilityPrivate  CollectionVisibility = "private"
+	CollectionVisibilityUnlisted CollectionVisibility = "unlisted"
+	CollectionVisibilityPublic   CollectionVisibility = "public"
+)
+
+func (v CollectionVisibility) IsValid() bool {
+	switch v {
+	case CollectionVisibilityPrivate, CollectionVisibilityUnlisted, CollectionVisibilityPublic:
+		return true
+	default:
+		return false
+	}
+}
+
+type LibraryActivityType string
+
+const (
+	LibraryActivityTypeAdded      LibraryActivityType = "added"
+	LibraryActivityTypeProgressed LibraryActivityType = "progressed"
+	LibraryActivityTypeCompleted  LibraryActivityType = "completed"
+	LibraryActivityTypeScored     LibraryActivityType = "scored"
+)
+
+func (t LibraryActivityType) IsValid() bool {
+	switch t {
+	case LibraryActivityTypeAdded, LibraryActivityTypeProgressed, LibraryActivityTypeCompleted, LibraryActivityTypeScored:
+		return true
+	default:
+		return false
+	}
+}
+
+type CompatibilityBasis string
+
+const (
+	CompatibilityBasisScores   CompatibilityBasis = "scores"
+	CompatibilityBasisStatuses CompatibilityBasis = "statuses"
+)
+
+func (b CompatibilityBasis) IsValid() bool {
+	switch b {
+	case CompatibilityBasisScores, CompatibilityBasisStatuses:
 		return true
 	default:
 		return false
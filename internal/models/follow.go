@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+type FollowMuteRequest struct {
+	Muted bool `json:"muted" example:"true"`
+}
+
+// PublicUserResponse is what other users can see of a user.
+type PublicUserResponse struct {
+	ID             string `json:"id" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+	Username       string `json:"username" validate:"required" example:"johndoe"`
+	ProfilePicture string `json:"profilePicture,omitempty" example:"https://res.cloudinary.com/example/avatar.jpg"`
+}
+
+type FollowResponse struct {
+	User PublicUserResponse `json:"user" validate:"required"`
+	// Muted is only set on the current user's own following list.
+	Muted      *bool     `json:"muted,omitempty" example:"false"`
+	FollowedAt time.Time `json:"followedAt" validate:"required" example:"2023-01-01T00:00:00Z"`
+}
+
+type FollowListResponse = Pagination[FollowResponse]
+
+type ActivityResponse struct {
+	ID              string              `json:"id" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+	Type            LibraryActivityType `json:"type" validate:"required" example:"progressed"`
+	WatchedEpisodes int32               `json:"watchedEpisodes" validate:"required" example:"12"`
+	Score           *int32              `json:"score" example:"85"`
+	CreatedAt       time.Time           `json:"createdAt" validate:"required" example:"2023-01-01T00:00:00Z"`
+	User            PublicUserResponse  `json:"user" validate:"required"`
+	Anime           AnimeResponse       `json:"anime" validate:"required"`
+}
+
+type FeedResponse = Pagination[ActivityResponse]
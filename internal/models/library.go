@@ -2,9 +2,16 @@ package models
 
 import "time"
 
+// LibraryRequest sets a library entry. Omitted optional fields keep their
+// current value, a score of 0 or an empty date clears it.
 type LibraryRequest struct {
 	Status          LibraryStatus `json:"status" validate:"required" example:"watching"`
 	WatchedEpisodes int32         `json:"watchedEpisodes" validate:"min=0" example:"12"`
+	Score           *int32        `json:"score,omitempty" validate:"omitempty,min=0,max=100" example:"85"`
+	StartedAt       *string       `json:"startedAt,omitempty" example:"2024-01-31"`
+	CompletedAt     *string       `json:"completedAt,omitempty" example:"2024-03-20"`
+	Rewatches       *int32        `json:"rewatches,omitempty" validate:"omitempty,min=0" example:"1"`
+	Notes           *string       `json:"notes,omitempty" validate:"omitempty,max=2000" example:"Rewatch with friends"`
 }
 
 type ImportJobResponse struct {
@@ -17,18 +24,59 @@ type LibraryResponse struct {
 	AnimeID         string        `json:"animeId" validate:"required" example:"V1StGXR8Z5jdHi6B"`
 	Status          LibraryStatus `json:"status" validate:"required" example:"watching"`
 	WatchedEpisodes int32         `json:"watchedEpisodes" validate:"required" example:"12"`
+	Score           *int32        `json:"score" example:"85"`
+	StartedAt       *string       `json:"startedAt" example:"2024-01-31"`
+	CompletedAt     *string       `json:"completedAt" example:"2024-03-20"`
+	Rewatches       int32         `json:"rewatches" validate:"required" example:"1"`
+	Notes           string        `json:"notes" validate:"required" example:"Rewatch with friends"`
 	CreatedAt       time.Time     `json:"createdAt" validate:"required" example:"2023-01-01T00:00:00Z"`
 	UpdatedAt       time.Time     `json:"updatedAt" validate:"required" example:"2023-01-01T00:00:00Z"`
 	Anime           AnimeResponse `json:"anime" validate:"required"`
 }
 
 type LibraryImportJobResponse struct {
-	ID          string              `json:"id" validate:"required" example:"V1StGXR8Z5jdHi6B"`
-	UserID      string              `json:"userId" validate:"required" example:"V1StGXR8Z5jdHi6B"`
-	Status      LibraryImportStatus `json:"status" validate:"required" example:"pending"`
-	CreatedAt   time.Time           `json:"createdAt" validate:"required" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt   time.Time           `json:"updatedAt" validate:"required" example:"2023-01-01T00:00:00Z"`
-	CompletedAt time.Time           `json:"completedAt" validate:"required" example:"2023-01-01T00:00:00Z"`
+	ID          string                      `json:"id" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+	UserID      string                      `json:"userId" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+	Status      LibraryImportStatus         `json:"status" validate:"required" example:"pending"`
+	CreatedAt   time.Time                   `json:"createdAt" validate:"required" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt   time.Time                   `json:"updatedAt" validate:"required" example:"2023-01-01T00:00:00Z"`
+	CompletedAt time.Time                   `json:"completedAt" validate:"required" example:"2023-01-01T00:00:00Z"`
+	Strategy    LibraryImportStrategy       `json:"strategy" validate:"required" example:"remote"`
+	DryRun      bool                        `json:"dryRun" validate:"required" example:"false"`
+	Report      LibraryImportReportResponse `json:"report" validate:"required"`
+}
+
+// LibraryImportReportResponse describes what an import did, or would do when
+// the job is a dry run.
+type LibraryImportReportResponse struct {
+	Matched           int32                             `json:"matched" validate:"required" example:"120"`
+	Unmatched         int32                             `json:"unmatched" validate:"required" example:"3"`
+	Ambiguous         int32                             `json:"ambiguous" validate:"required" example:"1"`
+	Added             int32                             `json:"added" validate:"required" example:"40"`
+	StatusChanged     int32                             `json:"statusChanged" validate:"required" example:"12"`
+	ProgressRegressed int32                             `json:"progressRegressed" validate:"required" example:"2"`
+	Items             []LibraryImportReportItemResponse `json:"items" validate:"required"`
+	Changes           []LibraryImportChangeResponse     `json:"changes" validate:"required"`
+}
+
+// LibraryImportChangeResponse is an entry the import adds or overwrites, the
+// local fields are empty for new entries.
+type LibraryImportChangeResponse struct {
+	AnimeID              string         `json:"animeId" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+	LocalStatus          *LibraryStatus `json:"localStatus" example:"watching"`
+	LocalWatchedEpisodes *int32         `json:"localWatchedEpisodes" example:"12"`
+	Status               LibraryStatus  `json:"status" validate:"required" example:"completed"`
+	WatchedEpisodes      int32          `json:"watchedEpisodes" validate:"required" example:"24"`
+	Anime                AnimeResponse  `json:"anime" validate:"required"`
+}
+
+type LibraryImportReportItemResponse struct {
+	Issue        string   `json:"issue" validate:"required" example:"ambiguous" enums:"unmatched,ambiguous"`
+	ExternalID   string   `json:"externalId" validate:"required" example:"anilist:21"`
+	Title        string   `json:"title" validate:"required" example:"One Piece"`
+	MalID        *int32   `json:"malId" example:"21"`
+	AnilistID    *int32   `json:"anilistId" example:"21"`
+	CandidateIDs []string `json:"candidateIds" validate:"required"`
 }
 
 type LibraryStatsResponse struct {
@@ -38,3 +86,19 @@ type LibraryStatsResponse struct {
 }
 
 type LibraryListResponse = Pagination[LibraryResponse]
+
+type LibrarySyncFailureResponse struct {
+	AnimeID       string        `json:"animeId" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+	Provider      string        `json:"provider" validate:"required" example:"myanimelist"`
+	Action        string        `json:"action" validate:"required" example:"update_progress"`
+	Status        string        `json:"status" validate:"required" example:"failed"`
+	Attempts      int32         `json:"attempts" validate:"required" example:"3"`
+	LastError     *string       `json:"lastError" example:"unexpected status code: 500"`
+	NextAttemptAt *time.Time    `json:"nextAttemptAt" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt     time.Time     `json:"updatedAt" validate:"required" example:"2023-01-01T00:00:00Z"`
+	Anime         AnimeResponse `json:"anime" validate:"required"`
+}
+
+type LibrarySyncRetryResponse struct {
+	Retried int64 `json:"retried" validate:"required" example:"2"`
+}
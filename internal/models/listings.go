@@ -44,19 +44,25 @@ func (m *GenresMode) FromString(s string) error {
 type SortBy string
 
 const (
-	SortByEname            SortBy = "ename"
-	SortByJname            SortBy = "jname"
-	SortBySeason           SortBy = "season"
-	SortByYear             SortBy = "year"
-	SortByRelevance        SortBy = "relevance"
-	SortByUpdatedAt        SortBy = "updated_at"
-	SortByAnimeUpdatedAt   SortBy = "anime_updated_at"
-	SortByLibraryUpdatedAt SortBy = "library_updated_at"
+	SortByEname              SortBy = "ename"
+	SortByJname              SortBy = "jname"
+	SortBySeason             SortBy = "season"
+	SortByYear               SortBy = "year"
+	SortByRelevance          SortBy = "relevance"
+	SortByUpdatedAt          SortBy = "updated_at"
+	SortByAnimeUpdatedAt     SortBy = "anime_updated_at"
+	SortByLibraryUpdatedAt   SortBy = "library_updated_at"
+	SortByLibraryScore       SortBy = "library_score"
+	SortByLibraryStartedAt   SortBy = "library_started_at"
+	SortByLibraryCompletedAt SortBy = "library_completed_at"
+	// SortByCollectionPosition is the manual order of the selected collection.
+	SortByCollectionPosition SortBy = "collection_position"
 )
 
 func (s SortBy) IsValid() bool {
 	switch s {
-	case SortByEname, SortByJname, SortBySeason, SortByYear, SortByRelevance, SortByUpdatedAt, SortByAnimeUpdatedAt, SortByLibraryUpdatedAt:
+	case SortByEname, SortByJname, SortBySeason, SortByYear, SortByRelevance, SortByUpdatedAt, SortByAnimeUpdatedAt, SortByLibraryUpdatedAt,
+		SortByLibraryScore, SortByLibraryStartedAt, SortByLibraryCompletedAt, SortByCollectionPosition:
 		return true
 	default:
 		return false
@@ -85,6 +91,14 @@ func (s *SortBy) FromString(str string) error {
 		*s = SortByAnimeUpdatedAt
 	case "library_updated_at":
 		*s = SortByLibraryUpdatedAt
+	case "library_score":
+		*s = SortByLibraryScore
+	case "library_started_at":
+		*s = SortByLibraryStartedAt
+	case "library_completed_at":
+		*s = SortByLibraryCompletedAt
+	case "collection_position":
+		*s = SortByCollectionPosition
 	default:
 		return fmt.Errorf("invalid SortBy: %s", str)
 	}
@@ -137,6 +151,15 @@ type GetAnimeCatalogParams struct {
 	SortOrder     SortOrder  `in:"query=sortOrder"`
 	InLibraryOnly *bool      `in:"query=inLibraryOnly"`
 	Status        *string    `in:"query=status"`
+	ScoreMin      *int       `in:"query=scoreMin"`
+	ScoreMax      *int       `in:"query=scoreMax"`
+	CollectionID  *string    `in:"query=collectionId"`
+}
+
+// FiltersLibrary reports whether the params filter on the user's library
+// entries, which needs an authenticated user.
+func (p GetAnimeCatalogParams) FiltersLibrary() bool {
+	return p.Status != nil || p.ScoreMin != nil || p.ScoreMax != nil
 }
 
 func (p GetAnimeCatalogParams) Normalize() GetAnimeCatalogParams {
@@ -186,6 +209,9 @@ func (p GetAnimeCatalogParams) Normalize() GetAnimeCatalogParams {
 	if out.YearMin != nil && out.YearMax != nil && *out.YearMin > *out.YearMax {
 		*out.YearMin, *out.YearMax = *out.YearMax, *out.YearMin
 	}
+	if out.ScoreMin != nil && out.ScoreMax != nil && *out.ScoreMin > *out.ScoreMax {
+		*out.ScoreMin, *out.ScoreMax = *out.ScoreMax, *out.ScoreMin
+	}
 
 	return out
 }
@@ -245,7 +271,9 @@ func libraryStatusOpt(ptr *string) repository.NullLibraryStatus {
 	return repository.NullLibraryStatus{LibraryStatus: libStatus, Valid: true}
 }
 
-func (p GetAnimeCatalogParams) ToRepo(limit, offset int32, userID *string) repository.GetAnimeCatalogParams {
+// ToRepo builds the catalog query. userID switches to library mode, the
+// collection filter only matches collections owned by collectionUserID.
+func (p GetAnimeCatalogParams) ToRepo(limit, offset int32, userID, collectionUserID *string) repository.GetAnimeCatalogParams {
 	n := p.Normalize()
 
 	var pgUserID pgtype.Text
@@ -254,23 +282,27 @@ func (p GetAnimeCatalogParams) ToRepo(limit, offset int32, userID *string) repos
 	}
 
 	return repository.GetAnimeCatalogParams{
-		Limit:         int32(limit),
-		Offset:        int32(offset),
-		UserID:        pgUserID,
-		Search:        textOpt(n.Search),
-		Genres:        n.Genres,
-		GenresMode:    textEnum(string(n.GenresMode), n.GenresMode.IsValid()),
-		Seasons:       n.Seasons,
-		Years:         n.toInt32s(n.Years),
-		YearMin:       int4Opt(n.YearMin),
-		YearMax:       int4Opt(n.YearMax),
-		SortBy:        textEnum(string(n.SortBy), n.SortBy.IsValid()),
-		SortOrder:     textEnum(string(n.SortOrder), n.SortOrder.IsValid()),
-		LibraryStatus: libraryStatusOpt(n.Status),
+		Limit:            int32(limit),
+		Offset:           int32(offset),
+		UserID:           pgUserID,
+		Search:           textOpt(n.Search),
+		Genres:           n.Genres,
+		GenresMode:       textEnum(string(n.GenresMode), n.GenresMode.IsValid()),
+		Seasons:          n.Seasons,
+		Years:            n.toInt32s(n.Years),
+		YearMin:          int4Opt(n.YearMin),
+		YearMax:          int4Opt(n.YearMax),
+		SortBy:           textEnum(string(n.SortBy), n.SortBy.IsValid()),
+		SortOrder:        textEnum(string(n.SortOrder), n.SortOrder.IsValid()),
+		LibraryStatus:    libraryStatusOpt(n.Status),
+		ScoreMin:         int4Opt(n.ScoreMin),
+		ScoreMax:         int4Opt(n.ScoreMax),
+		CollectionID:     textOpt(n.CollectionID),
+		CollectionUserID: textOpt(collectionUserID),
 	}
 }
 
-func (p GetAnimeCatalogParams) ToRepoCount(userID *string) repository.GetAnimeCatalogCountParams {
+func (p GetAnimeCatalogParams) ToRepoCount(userID, collectionUserID *string) repository.GetAnimeCatalogCountParams {
 	n := p.Normalize()
 
 	var pgUserID pgtype.Text
@@ -279,15 +311,19 @@ func (p GetAnimeCatalogParams) ToRepoCount(userID *string) repository.GetAnimeCa
 	}
 
 	return repository.GetAnimeCatalogCountParams{
-		UserID:        pgUserID,
-		Search:        textOpt(n.Search),
-		Genres:        n.Genres,
-		GenresMode:    textEnum(string(n.GenresMode), n.GenresMode.IsValid()),
-		Seasons:       n.Seasons,
-		Years:         n.toInt32s(n.Years),
-		YearMin:       int4Opt(n.YearMin),
-		YearMax:       int4Opt(n.YearMax),
-		LibraryStatus: libraryStatusOpt(n.Status),
+		UserID:           pgUserID,
+		Search:           textOpt(n.Search),
+		Genres:           n.Genres,
+		GenresMode:       textEnum(string(n.GenresMode), n.GenresMode.IsValid()),
+		Seasons:          n.Seasons,
+		Years:            n.toInt32s(n.Years),
+		YearMin:          int4Opt(n.YearMin),
+		YearMax:          int4Opt(n.YearMax),
+		LibraryStatus:    libraryStatusOpt(n.Status),
+		ScoreMin:         int4Opt(n.ScoreMin),
+		ScoreMax:         int4Opt(n.ScoreMax),
+		CollectionID:     textOpt(n.CollectionID),
+		CollectionUserID: textOpt(collectionUserID),
 	}
 }
 
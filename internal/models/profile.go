@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// ProfileResponse is a user's public profile. Sections the user hid are null,
+// the library is paged separately through /profiles/{username}/library.
+type ProfileResponse struct {
+	ID             string                  `json:"id" validate:"required" example:"V1StGXR8Z5jdHi6B"`
+	Username       string                  `json:"username" validate:"required" example:"johndoe"`
+	ProfilePicture string                  `json:"profilePicture,omitempty" example:"https://res.cloudinary.com/example/avatar.jpg"`
+	CreatedAt      time.Time               `json:"createdAt" validate:"required" example:"2023-01-01T00:00:00Z"`
+	Sections       ProfileSectionsResponse `json:"sections" validate:"required"`
+	Stats          *ProfileStatsResponse   `json:"stats"`
+	Favorites      []LibraryResponse       `json:"favorites"`
+	Activity       []ActivityResponse      `json:"activity"`
+}
+
+// ProfileSectionsResponse tells which sections of the profile are visible.
+type ProfileSectionsResponse struct {
+	Library   bool `json:"library" validate:"required" example:"true"`
+	Stats     bool `json:"stats" validate:"required" example:"true"`
+	Favorites bool `json:"favorites" validate:"required" example:"true"`
+	Activity  bool `json:"activity" validate:"required" example:"true"`
+}
+
+type ProfileStatsResponse struct {
+	Watching        int64   `json:"watching" validate:"required" example:"4"`
+	Planning        int64   `json:"planning" validate:"required" example:"20"`
+	Completed       int64   `json:"completed" validate:"required" example:"85"`
+	Dropped         int64   `json:"dropped" validate:"required" example:"3"`
+	Paused          int64   `json:"paused" validate:"required" example:"2"`
+	EpisodesWatched int64   `json:"episodesWatched" validate:"required" example:"1240"`
+	MeanScore       float64 `json:"meanScore" validate:"required" example:"78.5"`
+}
+
+// CompatibilityResponse compares the libraries of two users, titles are
+// matched across variations of the same show.
+type CompatibilityResponse struct {
+	User          PublicUserResponse   `json:"user" validate:"required"`
+	OtherUser     PublicUserResponse   `json:"otherUser" validate:"required"`
+	SharedTitles  int                  `json:"sharedTitles" validate:"required" example:"42"`
+	StatusOverlap StatusCountsResponse `json:"statusOverlap" validate:"required"`
+	// StatusAgreement is the share of shared titles with the same status.
+	StatusAgreement float64 `json:"statusAgreement" validate:"required" example:"0.64"`
+	// ScoreCorrelation is null until enough shared titles are scored by both.
+	ScoreCorrelation *float64           `json:"scoreCorrelation" example:"0.71"`
+	Basis            CompatibilityBasis `json:"basis" validate:"required" example:"scores"`
+	// Compatibility is a percentage based on the score correlation, or on
+	// the status agreement when there is none.
+	Compatibility int `json:"compatibility" validate:"required" example:"86"`
+	// UserCompletedOtherPlanning are titles the user completed that the
+	// other user plans to watch, OtherCompletedUserPlanning the reverse.
+	UserCompletedOtherPlanning []AnimeResponse `json:"userCompletedOtherPlanning" validate:"required"`
+	OtherCompletedUserPlanning []AnimeResponse `json:"otherCompletedUserPlanning" validate:"required"`
+}
+
+// StatusCountsResponse counts shared titles both users have in each status.
+type StatusCountsResponse struct {
+	Watching  int `json:"watching" validate:"required" example:"2"`
+	Planning  int `json:"planning" validate:"required" example:"5"`
+	Completed int `json:"completed" validate:"required" example:"20"`
+	Dropped   int `json:"dropped" validate:"required" example:"1"`
+	Paused    int `json:"paused" validate:"required" example:"0"`
+}
@@ -6,6 +6,17 @@ type SettingsRequest struct {
 	AutoResumeEpisode bool `json:"autoResumeEpisode" example:"true"`
 	IncognitoMode     bool `json:"incognitoMode" example:"false"`
 	ThemeId           int  `json:"themeId" example:"1"`
+	// The library automation and profile settings keep their current value
+	// when omitted.
+	AutoStartLibrary     *bool `json:"autoStartLibrary,omitempty" example:"true"`
+	AutoCompleteLibrary  *bool `json:"autoCompleteLibrary,omitempty" example:"true"`
+	AutoPauseLibrary     *bool `json:"autoPauseLibrary,omitempty" example:"false"`
+	AutoPauseAfterDays   *int  `json:"autoPauseAfterDays,omitempty" validate:"omitempty,min=1,max=365" example:"30"`
+	PublicProfile        *bool `json:"publicProfile,omitempty" example:"false"`
+	ProfileShowLibrary   *bool `json:"profileShowLibrary,omitempty" example:"true"`
+	ProfileShowStats     *bool `json:"profileShowStats,omitempty" example:"true"`
+	ProfileShowFavorites *bool `json:"profileShowFavorites,omitempty" example:"true"`
+	ProfileShowActivity  *bool `json:"profileShowActivity,omitempty" example:"true"`
 }
 
 type Theme struct {
@@ -22,4 +33,20 @@ type SettingsResponse struct {
 	AutoResumeEpisode bool   `json:"autoResumeEpisode" validate:"required" example:"true"`
 	IncognitoMode     bool   `json:"incognitoMode" validate:"required" example:"false"`
 	Theme             Theme  `json:"theme" validate:"required"`
+	// AutoStartLibrary moves planning entries to watching on progress.
+	AutoStartLibrary bool `json:"autoStartLibrary" validate:"required" example:"true"`
+	// AutoCompleteLibrary completes entries of finished series once every
+	// episode is watched.
+	AutoCompleteLibrary bool `json:"autoCompleteLibrary" validate:"required" example:"true"`
+	// AutoPauseLibrary pauses watching entries left untouched for
+	// AutoPauseAfterDays days.
+	AutoPauseLibrary   bool `json:"autoPauseLibrary" validate:"required" example:"false"`
+	AutoPauseAfterDays int  `json:"autoPauseAfterDays" validate:"required" example:"30"`
+	// PublicProfile opts in to /profiles/{username}, the ProfileShow settings
+	// pick the sections it shows. Activity also stays hidden in incognito mode.
+	PublicProfile        bool `json:"publicProfile" validate:"required" example:"false"`
+	ProfileShowLibrary   bool `json:"profileShowLibrary" validate:"required" example:"true"`
+	ProfileShowStats     bool `json:"profileShowStats" validate:"required" example:"true"`
+	ProfileShowFavorites bool `json:"profileShowFavorites" validate:"required" example:"true"`
+	ProfileShowActivity  bool `json:"profileShowActivity" validate:"required" example:"true"`
 }
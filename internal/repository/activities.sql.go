@@ -0,0 +1,184 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: activities.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getFeed = `-- name: GetFeed :many
+SELECT
+  library_activities.id, library_activities.user_id, library_activities.anime_id, library_activities.type, library_activities.watched_episodes, library_activities.score, library_activities.created_at,
+  users.id, users.username, users.email, users.password_hash, users.profile_picture, users.created_at, users.updated_at,
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
+FROM
+  follows
+  INNER JOIN library_activities ON library_activities.user_id = follows.followee_id
+  INNER JOIN users ON users.id = library_activities.user_id
+  INNER JOIN animes ON animes.id = library_activities.anime_id
+  LEFT JOIN settings ON settings.user_id = library_activities.user_id
+WHERE
+  follows.follower_id = $3
+  AND NOT follows.muted
+  AND coalesce(settings.public_profile, FALSE)
+  AND coalesce(settings.profile_show_activity, TRUE)
+  AND NOT coalesce(settings.incognito_mode, FALSE)
+ORDER BY
+  library_activities.created_at DESC,
+  library_activities.id
+LIMIT $1 OFFSET $2
+`
+
+type GetFeedParams struct {
+	Limit  int32
+	Offset int32
+	UserID string
+}
+
+type GetFeedRow struct {
+	LibraryActivity LibraryActivity
+	User            User
+	Anime           Anime
+}
+
+// The feed is built on read from the activities of the followed users who
+// are not muted and show their activity on a public profile.
+func (q *Queries) GetFeed(ctx context.Context, arg GetFeedParams) ([]GetFeedRow, error) {
+	rows, err := q.db.Query(ctx, getFeed, arg.Limit, arg.Offset, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFeedRow
+	for rows.Next() {
+		var i GetFeedRow
+		if err := rows.Scan(
+			&i.LibraryActivity.ID,
+			&i.LibraryActivity.UserID,
+			&i.LibraryActivity.AnimeID,
+			&i.LibraryActivity.Type,
+			&i.LibraryActivity.WatchedEpisodes,
+			&i.LibraryActivity.Score,
+			&i.LibraryActivity.CreatedAt,
+			&i.User.ID,
+			&i.User.Username,
+			&i.User.Email,
+			&i.User.PasswordHash,
+			&i.User.ProfilePicture,
+			&i.User.CreatedAt,
+			&i.User.UpdatedAt,
+			&i.Anime.ID,
+			&i.Anime.Ename,
+			&i.Anime.Jname,
+			&i.Anime.ImageUrl,
+			&i.Anime.Genre,
+			&i.Anime.HiAnimeID,
+			&i.Anime.MalID,
+			&i.Anime.AnilistID,
+			&i.Anime.LastEpisode,
+			&i.Anime.CreatedAt,
+			&i.Anime.UpdatedAt,
+			&i.Anime.SearchVector,
+			&i.Anime.Season,
+			&i.Anime.SeasonYear,
+			&i.Anime.GenresArr,
+			&i.Anime.MissingChecks,
+			&i.Anime.UnavailableAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFeedCount = `-- name: GetFeedCount :one
+SELECT
+  COUNT(*)
+FROM
+  follows
+  INNER JOIN library_activities ON library_activities.user_id = follows.followee_id
+  LEFT JOIN settings ON settings.user_id = library_activities.user_id
+WHERE
+  follows.follower_id = $1
+  AND NOT follows.muted
+  AND coalesce(settings.public_profile, FALSE)
+  AND coalesce(settings.profile_show_activity, TRUE)
+  AND NOT coalesce(settings.incognito_mode, FALSE)
+`
+
+func (q *Queries) GetFeedCount(ctx context.Context, userID string) (int64, error) {
+	row := q.db.QueryRow(ctx, getFeedCount, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const insertLibraryActivity = `-- name: InsertLibraryActivity :exec
+INSERT INTO library_activities(user_id, anime_id, type, watched_episodes, score)
+SELECT
+  $1::varchar,
+  $2::varchar,
+  $3::library_activity_type,
+  $4::integer,
+  $5::smallint
+WHERE
+  NOT EXISTS (
+    SELECT
+      1
+    FROM
+      settings
+    WHERE
+      settings.user_id = $1
+      AND settings.incognito_mode)
+`
+
+type InsertLibraryActivityParams struct {
+	UserID          string
+	AnimeID         string
+	Type            LibraryActivityType
+	WatchedEpisodes int32
+	Score           pgtype.Int2
+}
+
+// Nothing is recorded while the user is in incognito mode.
+func (q *Queries) InsertLibraryActivity(ctx context.Context, arg InsertLibraryActivityParams) error {
+	_, err := q.db.Exec(ctx, insertLibraryActivity,
+		arg.UserID,
+		arg.AnimeID,
+		arg.Type,
+		arg.WatchedEpisodes,
+		arg.Score,
+	)
+	return err
+}
+
+const relinkLibraryActivities = `-- name: RelinkLibraryActivities :execrows
+UPDATE
+  library_activities
+SET
+  anime_id = $1
+WHERE
+  anime_id = $2
+`
+
+type RelinkLibraryActivitiesParams struct {
+	ToAnimeID   string
+	FromAnimeID string
+}
+
+func (q *Queries) RelinkLibraryActivities(ctx context.Context, arg RelinkLibraryActivitiesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, relinkLibraryActivities, arg.ToAnimeID, arg.FromAnimeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -11,6 +11,75 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const findAnimeTitleCandidates = `-- name: FindAnimeTitleCandidates :many
+SELECT
+    animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at,
+    GREATEST(similarity(animes.ename, $1::text), similarity(animes.jname, $1::text))::real AS score
+FROM
+    animes
+WHERE (animes.ename % $1::text
+    OR animes.jname % $1::text)
+AND ($2::int = 0
+    OR animes.season_year = 0
+    OR abs(animes.season_year - $2::int) <= 1)
+AND animes.unavailable_at IS NULL
+ORDER BY
+    score DESC
+LIMIT 10
+`
+
+type FindAnimeTitleCandidatesParams struct {
+	Title      string
+	SeasonYear int32
+}
+
+type FindAnimeTitleCandidatesRow struct {
+	Anime Anime
+	Score float32
+}
+
+// Anime whose english or japanese title resembles the title, closest first.
+// A season year of 0 matches any year, otherwise a year either side is
+// allowed since providers disagree on releases around new year.
+func (q *Queries) FindAnimeTitleCandidates(ctx context.Context, arg FindAnimeTitleCandidatesParams) ([]FindAnimeTitleCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, findAnimeTitleCandidates, arg.Title, arg.SeasonYear)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindAnimeTitleCandidatesRow
+	for rows.Next() {
+		var i FindAnimeTitleCandidatesRow
+		if err := rows.Scan(
+			&i.Anime.ID,
+			&i.Anime.Ename,
+			&i.Anime.Jname,
+			&i.Anime.ImageUrl,
+			&i.Anime.Genre,
+			&i.Anime.HiAnimeID,
+			&i.Anime.MalID,
+			&i.Anime.AnilistID,
+			&i.Anime.LastEpisode,
+			&i.Anime.CreatedAt,
+			&i.Anime.UpdatedAt,
+			&i.Anime.SearchVector,
+			&i.Anime.Season,
+			&i.Anime.SeasonYear,
+			&i.Anime.GenresArr,
+			&i.Anime.MissingChecks,
+			&i.Anime.UnavailableAt,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllGenres = `-- name: GetAllGenres :many
 SELECT DISTINCT
     trim(unnested) AS genre
@@ -43,7 +112,7 @@ func (q *Queries) GetAllGenres(ctx context.Context) ([]string, error) {
 
 const getAnimeByAnilistId = `-- name: GetAnimeByAnilistId :many
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
 WHERE
@@ -77,6 +146,8 @@ func (q *Queries) GetAnimeByAnilistId(ctx context.Context, anilistID pgtype.Int4
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -90,11 +161,12 @@ func (q *Queries) GetAnimeByAnilistId(ctx context.Context, anilistID pgtype.Int4
 
 const getAnimeByGenre = `-- name: GetAnimeByGenre :many
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
 WHERE
     genre ILIKE '%' || $3 || '%'
+    AND unavailable_at IS NULL
 ORDER BY
     updated_at DESC
 LIMIT $1 OFFSET $2
@@ -131,6 +203,8 @@ func (q *Queries) GetAnimeByGenre(ctx context.Context, arg GetAnimeByGenreParams
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -149,6 +223,7 @@ FROM
     animes
 WHERE
     genre ILIKE '%' || $1 || '%'
+    AND unavailable_at IS NULL
 `
 
 func (q *Queries) GetAnimeByGenreCount(ctx context.Context, genre pgtype.Text) (int64, error) {
@@ -160,7 +235,7 @@ func (q *Queries) GetAnimeByGenreCount(ctx context.Context, genre pgtype.Text) (
 
 const getAnimeByHiAnimeId = `-- name: GetAnimeByHiAnimeId :one
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
 WHERE
@@ -186,13 +261,15 @@ func (q *Queries) GetAnimeByHiAnimeId(ctx context.Context, hiAnimeID string) (An
 		&i.Season,
 		&i.SeasonYear,
 		&i.GenresArr,
+		&i.MissingChecks,
+		&i.UnavailableAt,
 	)
 	return i, err
 }
 
 const getAnimeById = `-- name: GetAnimeById :one
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
 WHERE
@@ -218,13 +295,15 @@ func (q *Queries) GetAnimeById(ctx context.Context, id string) (Anime, error) {
 		&i.Season,
 		&i.SeasonYear,
 		&i.GenresArr,
+		&i.MissingChecks,
+		&i.UnavailableAt,
 	)
 	return i, err
 }
 
 const getAnimeByMalId = `-- name: GetAnimeByMalId :many
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
 WHERE
@@ -256,6 +335,8 @@ func (q *Queries) GetAnimeByMalId(ctx context.Context, malID pgtype.Int4) ([]Ani
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -269,11 +350,12 @@ func (q *Queries) GetAnimeByMalId(ctx context.Context, malID pgtype.Int4) ([]Ani
 
 const getAnimeBySeason = `-- name: GetAnimeBySeason :many
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
 WHERE
     season = $3::season
+    AND unavailable_at IS NULL
 ORDER BY
     season_year DESC
 LIMIT $1 OFFSET $2
@@ -310,6 +392,8 @@ func (q *Queries) GetAnimeBySeason(ctx context.Context, arg GetAnimeBySeasonPara
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -323,12 +407,13 @@ func (q *Queries) GetAnimeBySeason(ctx context.Context, arg GetAnimeBySeasonPara
 
 const getAnimeBySeasonAndYear = `-- name: GetAnimeBySeasonAndYear :many
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
 WHERE
     season = $3::season
     AND season_year = $4::int
+    AND unavailable_at IS NULL
 ORDER BY
     updated_at DESC
 LIMIT $1 OFFSET $2
@@ -371,6 +456,8 @@ func (q *Queries) GetAnimeBySeasonAndYear(ctx context.Context, arg GetAnimeBySea
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -390,6 +477,7 @@ FROM
 WHERE
     season = $1::season
     AND season_year = $2::int
+    AND unavailable_at IS NULL
 `
 
 type GetAnimeBySeasonAndYearCountParams struct {
@@ -411,6 +499,7 @@ FROM
     animes
 WHERE
     season = $1::season
+    AND unavailable_at IS NULL
 `
 
 func (q *Queries) GetAnimeBySeasonCount(ctx context.Context, season Season) (int64, error) {
@@ -422,11 +511,12 @@ func (q *Queries) GetAnimeBySeasonCount(ctx context.Context, season Season) (int
 
 const getAnimeByYear = `-- name: GetAnimeByYear :many
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
 WHERE
     season_year = $3::int
+    AND unavailable_at IS NULL
 ORDER BY
     updated_at DESC
 LIMIT $1 OFFSET $2
@@ -463,6 +553,8 @@ func (q *Queries) GetAnimeByYear(ctx context.Context, arg GetAnimeByYearParams)
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -481,6 +573,7 @@ FROM
     animes
 WHERE
     season_year = $1::int
+    AND unavailable_at IS NULL
 `
 
 func (q *Queries) GetAnimeByYearCount(ctx context.Context, seasonYear int32) (int64, error) {
@@ -494,25 +587,34 @@ const getAnimeCatalog = `-- name: GetAnimeCatalog :many
 WITH p AS (
     SELECT
         -- normalized/trimmed search
-        NULLIF (trim($9::text), '') AS q,
+        NULLIF (trim($12::text), '') AS q,
         -- normalized genres (lowercased, trimmed) or NULL
-        CASE WHEN $10::text[] IS NULL THEN
+        CASE WHEN $13::text[] IS NULL THEN
             NULL
         ELSE
             (
                 SELECT
                     array_agg(lower(trim(g)))
                 FROM
-                    unnest($10::text[]) AS u (g)
+                    unnest($13::text[]) AS u (g)
                 WHERE
                     trim(g) <> '')
         END AS g,
-        $11::text AS gm,
-        $12::text AS sb,
-        $13::text AS so
+        $14::text AS gm,
+        $15::text AS sb,
+        $16::text AS so,
+        -- the collection, only when it belongs to collection_user_id
+        (
+            SELECT
+                c.id
+            FROM
+                collections c
+            WHERE
+                c.id = $4::varchar
+                AND c.user_id = $17::varchar) AS cid
 )
 SELECT
-    a.id, a.ename, a.jname, a.image_url, a.genre, a.hi_anime_id, a.mal_id, a.anilist_id, a.last_episode, a.created_at, a.updated_at, a.search_vector, a.season, a.season_year, a.genres_arr,
+    a.id, a.ename, a.jname, a.image_url, a.genre, a.hi_anime_id, a.mal_id, a.anilist_id, a.last_episode, a.created_at, a.updated_at, a.search_vector, a.season, a.season_year, a.genres_arr, a.missing_checks, a.unavailable_at,
     l.id AS library_id,
     l.user_id AS library_user_id,
     l.anime_id AS library_anime_id,
@@ -520,6 +622,10 @@ SELECT
     l.watched_episodes AS library_watched_episodes,
     l.created_at AS library_created_at,
     l.updated_at AS library_updated_at,
+    l.score AS library_score,
+    l.started_at AS library_started_at,
+    l.completed_at AS library_completed_at,
+    l.rewatches AS library_rewatches,
     CASE WHEN p.q IS NOT NULL THEN
         ts_rank(a.search_vector, plainto_tsquery('english', p.q))
     ELSE
@@ -532,26 +638,33 @@ FROM
             AND a.id = l.anime_id
             AND l.user_id = $3::varchar)
     CROSS JOIN p
+    -- Only matches items when a collection is selected
+    LEFT JOIN collection_items ci ON (ci.collection_id = p.cid
+            AND ci.anime_id = a.id)
 WHERE
     -- only MAL-linked rows
     (a.mal_id IS NOT NULL
         AND a.mal_id <> 0)
+    -- hide tombstoned anime from the catalog, library and collection modes keep them
+    AND (a.unavailable_at IS NULL
+        OR $3::varchar IS NOT NULL
+        OR $4::varchar IS NOT NULL)
     -- search (skip when q is null)
     AND (p.q IS NULL
         OR a.ename % p.q
         OR a.jname % p.q
         OR a.search_vector @@ plainto_tsquery('english', p.q))
     -- seasons (skip when null)
-    AND ($4::text[] IS NULL
-        OR a.season = ANY ($4::season[]))
+    AND ($5::text[] IS NULL
+        OR a.season = ANY ($5::season[]))
     -- years list (skip when null)
-    AND ($5::int[] IS NULL
-        OR a.season_year = ANY ($5::int[]))
+    AND ($6::int[] IS NULL
+        OR a.season_year = ANY ($6::int[]))
     -- year range (skip each bound when null)
-    AND ($6::int IS NULL
-        OR a.season_year >= $6::int)
     AND ($7::int IS NULL
-        OR a.season_year <= $7::int)
+        OR a.season_year >= $7::int)
+    AND ($8::int IS NULL
+        OR a.season_year <= $8::int)
     -- genres ANY/ALL using generated genres_arr (skip when null/empty)
     AND (p.g IS NULL
         OR (
@@ -564,8 +677,16 @@ WHERE
         AND ($3::varchar IS NULL -- catalog mode
             OR l.user_id IS NOT NULL) -- library mode (must be in library)
         -- Library status filtering
-        AND ($8::library_status IS NULL
-            OR l.status = $8::library_status)
+        AND ($9::library_status IS NULL
+            OR l.status = $9::library_status)
+        -- Library score range (skip each bound when null)
+        AND ($10::int IS NULL
+            OR l.score >= $10::int)
+        AND ($11::int IS NULL
+            OR l.score <= $11::int)
+        -- Collection membership (when collection_id provided, only show its items)
+        AND ($4::varchar IS NULL
+            OR ci.anime_id IS NOT NULL)
     ORDER BY
         -- relevance
         CASE WHEN p.sb = 'relevance'
@@ -634,6 +755,42 @@ WHERE
             AND p.so = 'desc' THEN
             l.updated_at
         END DESC NULLS LAST,
+        -- library score
+        CASE WHEN p.sb = 'library_score'
+            AND p.so = 'asc' THEN
+            l.score
+        END ASC NULLS LAST,
+        CASE WHEN p.sb = 'library_score'
+            AND p.so = 'desc' THEN
+            l.score
+        END DESC NULLS LAST,
+        -- library started_at
+        CASE WHEN p.sb = 'library_started_at'
+            AND p.so = 'asc' THEN
+            l.started_at
+        END ASC NULLS LAST,
+        CASE WHEN p.sb = 'library_started_at'
+            AND p.so = 'desc' THEN
+            l.started_at
+        END DESC NULLS LAST,
+        -- library completed_at
+        CASE WHEN p.sb = 'library_completed_at'
+            AND p.so = 'asc' THEN
+            l.completed_at
+        END ASC NULLS LAST,
+        CASE WHEN p.sb = 'library_completed_at'
+            AND p.so = 'desc' THEN
+            l.completed_at
+        END DESC NULLS LAST,
+        -- manual collection order (only when a collection is selected)
+        CASE WHEN p.sb = 'collection_position'
+            AND p.so = 'asc' THEN
+            ci.position
+        END ASC NULLS LAST,
+        CASE WHEN p.sb = 'collection_position'
+            AND p.so = 'desc' THEN
+            ci.position
+        END DESC NULLS LAST,
         -- legacy updated_at (maps to anime_updated_at for backward compatibility)
         CASE WHEN p.sb = 'updated_at'
             AND p.so = 'asc' THEN
@@ -650,19 +807,23 @@ WHERE
 `
 
 type GetAnimeCatalogParams struct {
-	Limit         int32
-	Offset        int32
-	UserID        pgtype.Text
-	Seasons       []string
-	Years         []int32
-	YearMin       pgtype.Int4
-	YearMax       pgtype.Int4
-	LibraryStatus NullLibraryStatus
-	Search        pgtype.Text
-	Genres        []string
-	GenresMode    pgtype.Text
-	SortBy        pgtype.Text
-	SortOrder     pgtype.Text
+	Limit            int32
+	Offset           int32
+	UserID           pgtype.Text
+	CollectionID     pgtype.Text
+	Seasons          []string
+	Years            []int32
+	YearMin          pgtype.Int4
+	YearMax          pgtype.Int4
+	LibraryStatus    NullLibraryStatus
+	ScoreMin         pgtype.Int4
+	ScoreMax         pgtype.Int4
+	Search           pgtype.Text
+	Genres           []string
+	GenresMode       pgtype.Text
+	SortBy           pgtype.Text
+	SortOrder        pgtype.Text
+	CollectionUserID pgtype.Text
 }
 
 type GetAnimeCatalogRow struct {
@@ -681,6 +842,8 @@ type GetAnimeCatalogRow struct {
 	Season                 Season
 	SeasonYear             int32
 	GenresArr              []string
+	MissingChecks          int32
+	UnavailableAt          pgtype.Timestamp
 	LibraryID              pgtype.Text
 	LibraryUserID          pgtype.Text
 	LibraryAnimeID         pgtype.Text
@@ -688,6 +851,10 @@ type GetAnimeCatalogRow struct {
 	LibraryWatchedEpisodes pgtype.Int4
 	LibraryCreatedAt       pgtype.Timestamp
 	LibraryUpdatedAt       pgtype.Timestamp
+	LibraryScore           pgtype.Int2
+	LibraryStartedAt       pgtype.Date
+	LibraryCompletedAt     pgtype.Date
+	LibraryRewatches       pgtype.Int4
 	QueryRank              interface{}
 }
 
@@ -696,16 +863,20 @@ func (q *Queries) GetAnimeCatalog(ctx context.Context, arg GetAnimeCatalogParams
 		arg.Limit,
 		arg.Offset,
 		arg.UserID,
+		arg.CollectionID,
 		arg.Seasons,
 		arg.Years,
 		arg.YearMin,
 		arg.YearMax,
 		arg.LibraryStatus,
+		arg.ScoreMin,
+		arg.ScoreMax,
 		arg.Search,
 		arg.Genres,
 		arg.GenresMode,
 		arg.SortBy,
 		arg.SortOrder,
+		arg.CollectionUserID,
 	)
 	if err != nil {
 		return nil, err
@@ -730,6 +901,8 @@ func (q *Queries) GetAnimeCatalog(ctx context.Context, arg GetAnimeCatalogParams
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 			&i.LibraryID,
 			&i.LibraryUserID,
 			&i.LibraryAnimeID,
@@ -737,6 +910,10 @@ func (q *Queries) GetAnimeCatalog(ctx context.Context, arg GetAnimeCatalogParams
 			&i.LibraryWatchedEpisodes,
 			&i.LibraryCreatedAt,
 			&i.LibraryUpdatedAt,
+			&i.LibraryScore,
+			&i.LibraryStartedAt,
+			&i.LibraryCompletedAt,
+			&i.LibraryRewatches,
 			&i.QueryRank,
 		); err != nil {
 			return nil, err
@@ -752,19 +929,28 @@ func (q *Queries) GetAnimeCatalog(ctx context.Context, arg GetAnimeCatalogParams
 const getAnimeCatalogCount = `-- name: GetAnimeCatalogCount :one
 WITH p AS (
     SELECT
-        NULLIF (trim($7::text), '') AS q,
-        CASE WHEN $8::text[] IS NULL THEN
+        NULLIF (trim($10::text), '') AS q,
+        CASE WHEN $11::text[] IS NULL THEN
             NULL
         ELSE
             (
                 SELECT
                     array_agg(lower(trim(g)))
                 FROM
-                    unnest($8::text[]) AS u (g)
+                    unnest($11::text[]) AS u (g)
                 WHERE
                     trim(g) <> '')
         END AS g,
-        $9::text AS gm
+        $12::text AS gm,
+        -- the collection, only when it belongs to collection_user_id
+        (
+            SELECT
+                c.id
+            FROM
+                collections c
+            WHERE
+                c.id = $2::varchar
+                AND c.user_id = $13::varchar) AS cid
 )
 SELECT
     COUNT(*)
@@ -775,26 +961,33 @@ FROM
             AND a.id = l.anime_id
             AND l.user_id = $1::varchar)
     CROSS JOIN p
+    -- Only matches items when a collection is selected
+    LEFT JOIN collection_items ci ON (ci.collection_id = p.cid
+            AND ci.anime_id = a.id)
 WHERE
     -- only MAL-linked rows
     (a.mal_id IS NOT NULL
         AND a.mal_id <> 0)
+    -- hide tombstoned anime from the catalog, library and collection modes keep them
+    AND (a.unavailable_at IS NULL
+        OR $1::varchar IS NOT NULL
+        OR $2::varchar IS NOT NULL)
     -- search (skip when q is null)
     AND (p.q IS NULL
         OR a.ename % p.q
         OR a.jname % p.q
         OR a.search_vector @@ plainto_tsquery('english', p.q))
     -- seasons (skip when null)
-    AND ($2::text[] IS NULL
-        OR a.season = ANY ($2::season[]))
+    AND ($3::text[] IS NULL
+        OR a.season = ANY ($3::season[]))
     -- years list (skip when null)
-    AND ($3::int[] IS NULL
-        OR a.season_year = ANY ($3::int[]))
+    AND ($4::int[] IS NULL
+        OR a.season_year = ANY ($4::int[]))
     -- year range (skip each bound when null)
-    AND ($4::int IS NULL
-        OR a.season_year >= $4::int)
     AND ($5::int IS NULL
-        OR a.season_year <= $5::int)
+        OR a.season_year >= $5::int)
+    AND ($6::int IS NULL
+        OR a.season_year <= $6::int)
     -- genres ANY/ALL using generated genres_arr (skip when null/empty)
     AND (p.g IS NULL
         OR (
@@ -807,33 +1000,49 @@ WHERE
         AND ($1::varchar IS NULL -- catalog mode
             OR l.user_id IS NOT NULL) -- library mode (must be in library)
         -- Library status filtering
-        AND ($6::library_status IS NULL
-            OR l.status = $6::library_status)
+        AND ($7::library_status IS NULL
+            OR l.status = $7::library_status)
+        -- Library score range (skip each bound when null)
+        AND ($8::int IS NULL
+            OR l.score >= $8::int)
+        AND ($9::int IS NULL
+            OR l.score <= $9::int)
+        -- Collection membership (when collection_id provided, only show its items)
+        AND ($2::varchar IS NULL
+            OR ci.anime_id IS NOT NULL)
 `
 
 type GetAnimeCatalogCountParams struct {
-	UserID        pgtype.Text
-	Seasons       []string
-	Years         []int32
-	YearMin       pgtype.Int4
-	YearMax       pgtype.Int4
-	LibraryStatus NullLibraryStatus
-	Search        pgtype.Text
-	Genres        []string
-	GenresMode    pgtype.Text
+	UserID           pgtype.Text
+	CollectionID     pgtype.Text
+	Seasons          []string
+	Years            []int32
+	YearMin          pgtype.Int4
+	YearMax          pgtype.Int4
+	LibraryStatus    NullLibraryStatus
+	ScoreMin         pgtype.Int4
+	ScoreMax         pgtype.Int4
+	Search           pgtype.Text
+	Genres           []string
+	GenresMode       pgtype.Text
+	CollectionUserID pgtype.Text
 }
 
 func (q *Queries) GetAnimeCatalogCount(ctx context.Context, arg GetAnimeCatalogCountParams) (int64, error) {
 	row := q.db.QueryRow(ctx, getAnimeCatalogCount,
 		arg.UserID,
+		arg.CollectionID,
 		arg.Seasons,
 		arg.Years,
 		arg.YearMin,
 		arg.YearMax,
 		arg.LibraryStatus,
+		arg.ScoreMin,
+		arg.ScoreMax,
 		arg.Search,
 		arg.Genres,
 		arg.GenresMode,
+		arg.CollectionUserID,
 	)
 	var count int64
 	err := row.Scan(&count)
@@ -880,7 +1089,7 @@ func (q *Queries) GetAnimeMetadataByMalId(ctx context.Context, malID int32) (Ani
 
 const getAnimeVariations = `-- name: GetAnimeVariations :many
 SELECT
-    animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr
+    animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
 FROM
     animes
 WHERE
@@ -892,6 +1101,7 @@ WHERE
         WHERE
             a.id = $1)
     AND animes.id != $1
+    AND animes.unavailable_at IS NULL
 ORDER BY
     animes.created_at DESC
 `
@@ -921,6 +1131,8 @@ func (q *Queries) GetAnimeVariations(ctx context.Context, id string) ([]Anime, e
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -934,7 +1146,7 @@ func (q *Queries) GetAnimeVariations(ctx context.Context, id string) ([]Anime, e
 
 const getAnimesByHiAnimeIds = `-- name: GetAnimesByHiAnimeIds :many
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
 WHERE
@@ -968,6 +1180,8 @@ func (q *Queries) GetAnimesByHiAnimeIds(ctx context.Context, hiAnimeIds []string
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -981,11 +1195,12 @@ func (q *Queries) GetAnimesByHiAnimeIds(ctx context.Context, hiAnimeIds []string
 
 const getAnimesByMalIds = `-- name: GetAnimesByMalIds :many
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
 WHERE
     mal_id = ANY ($1::int[])
+    AND unavailable_at IS NULL
 ORDER BY
     updated_at DESC
 `
@@ -1015,6 +1230,8 @@ func (q *Queries) GetAnimesByMalIds(ctx context.Context, malIds []int32) ([]Anim
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1026,6 +1243,95 @@ func (q *Queries) GetAnimesByMalIds(ctx context.Context, malIds []int32) ([]Anim
 	return items, nil
 }
 
+const getAvailableAnimeHiAnimeIds = `-- name: GetAvailableAnimeHiAnimeIds :many
+SELECT
+    id,
+    hi_anime_id,
+    mal_id,
+    missing_checks
+FROM
+    animes
+WHERE
+    unavailable_at IS NULL
+`
+
+type GetAvailableAnimeHiAnimeIdsRow struct {
+	ID            string
+	HiAnimeID     string
+	MalID         pgtype.Int4
+	MissingChecks int32
+}
+
+func (q *Queries) GetAvailableAnimeHiAnimeIds(ctx context.Context) ([]GetAvailableAnimeHiAnimeIdsRow, error) {
+	rows, err := q.db.Query(ctx, getAvailableAnimeHiAnimeIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAvailableAnimeHiAnimeIdsRow
+	for rows.Next() {
+		var i GetAvailableAnimeHiAnimeIdsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.HiAnimeID,
+			&i.MalID,
+			&i.MissingChecks,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAvailableAnimeVariation = `-- name: GetAvailableAnimeVariation :one
+SELECT
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
+FROM
+    animes
+WHERE
+    mal_id = $1
+    AND id != $2
+    AND unavailable_at IS NULL
+ORDER BY
+    last_episode DESC,
+    updated_at DESC
+LIMIT 1
+`
+
+type GetAvailableAnimeVariationParams struct {
+	MalID pgtype.Int4
+	ID    string
+}
+
+func (q *Queries) GetAvailableAnimeVariation(ctx context.Context, arg GetAvailableAnimeVariationParams) (Anime, error) {
+	row := q.db.QueryRow(ctx, getAvailableAnimeVariation, arg.MalID, arg.ID)
+	var i Anime
+	err := row.Scan(
+		&i.ID,
+		&i.Ename,
+		&i.Jname,
+		&i.ImageUrl,
+		&i.Genre,
+		&i.HiAnimeID,
+		&i.MalID,
+		&i.AnilistID,
+		&i.LastEpisode,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SearchVector,
+		&i.Season,
+		&i.SeasonYear,
+		&i.GenresArr,
+		&i.MissingChecks,
+		&i.UnavailableAt,
+	)
+	return i, err
+}
+
 const getCountOfAnimes = `-- name: GetCountOfAnimes :one
 SELECT
     COUNT(*) AS count
@@ -1046,6 +1352,8 @@ WITH g AS (
         unnest(a.genres_arr) AS genre
     FROM
         animes a
+    WHERE
+        a.unavailable_at IS NULL
 )
 SELECT
     g.genre::text AS name,
@@ -1054,7 +1362,8 @@ SELECT
                 a2.image_url::text
             FROM animes a2
             WHERE
-                a2.genres_arr @> ARRAY[g.genre]::text[] ORDER BY a2.season_year DESC, a2.updated_at DESC, a2.id DESC LIMIT 6), ARRAY[]::text[]) AS previews
+                a2.genres_arr @> ARRAY[g.genre]::text[]
+                AND a2.unavailable_at IS NULL ORDER BY a2.season_year DESC, a2.updated_at DESC, a2.id DESC LIMIT 6), ARRAY[]::text[]) AS previews
 FROM
     g
 ORDER BY
@@ -1088,12 +1397,12 @@ func (q *Queries) GetGenrePreviews(ctx context.Context) ([]GetGenrePreviewsRow,
 
 const getRandomAnime = `-- name: GetRandomAnime :one
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
-WHERE
-    animes.mal_id IS NOT NULL
-    OR animes.mal_id != 0
+WHERE (animes.mal_id IS NOT NULL
+    OR animes.mal_id != 0)
+AND animes.unavailable_at IS NULL
 ORDER BY
     RANDOM()
 LIMIT 1
@@ -1118,19 +1427,22 @@ func (q *Queries) GetRandomAnime(ctx context.Context) (Anime, error) {
 		&i.Season,
 		&i.SeasonYear,
 		&i.GenresArr,
+		&i.MissingChecks,
+		&i.UnavailableAt,
 	)
 	return i, err
 }
 
 const getRandomAnimeByGenre = `-- name: GetRandomAnimeByGenre :one
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
 WHERE
     genre ILIKE '%' || $1 || '%'
     AND (animes.mal_id IS NOT NULL
         OR animes.mal_id != 0)
+    AND animes.unavailable_at IS NULL
 ORDER BY
     RANDOM()
 LIMIT 1
@@ -1155,18 +1467,20 @@ func (q *Queries) GetRandomAnimeByGenre(ctx context.Context, genre pgtype.Text)
 		&i.Season,
 		&i.SeasonYear,
 		&i.GenresArr,
+		&i.MissingChecks,
+		&i.UnavailableAt,
 	)
 	return i, err
 }
 
 const getRecentlyUpdatedAnimes = `-- name: GetRecentlyUpdatedAnimes :many
 SELECT
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 FROM
     animes
-WHERE
-    animes.mal_id IS NOT NULL
-    OR animes.mal_id != 0
+WHERE (animes.mal_id IS NOT NULL
+    OR animes.mal_id != 0)
+AND animes.unavailable_at IS NULL
 ORDER BY
     updated_at DESC
 LIMIT $1 OFFSET $2
@@ -1202,6 +1516,8 @@ func (q *Queries) GetRecentlyUpdatedAnimes(ctx context.Context, arg GetRecentlyU
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1218,9 +1534,9 @@ SELECT
     COUNT(*)
 FROM
     animes
-WHERE
-    animes.mal_id IS NOT NULL
-    OR animes.mal_id != 0
+WHERE (animes.mal_id IS NOT NULL
+    OR animes.mal_id != 0)
+AND animes.unavailable_at IS NULL
 `
 
 func (q *Queries) GetRecentlyUpdatedAnimesCount(ctx context.Context) (int64, error) {
@@ -1230,11 +1546,29 @@ func (q *Queries) GetRecentlyUpdatedAnimesCount(ctx context.Context) (int64, err
 	return count, err
 }
 
+const incrementAnimeMissingChecks = `-- name: IncrementAnimeMissingChecks :one
+UPDATE
+    animes
+SET
+    missing_checks = missing_checks + 1
+WHERE
+    id = $1
+RETURNING
+    missing_checks
+`
+
+func (q *Queries) IncrementAnimeMissingChecks(ctx context.Context, id string) (int32, error) {
+	row := q.db.QueryRow(ctx, incrementAnimeMissingChecks, id)
+	var missing_checks int32
+	err := row.Scan(&missing_checks)
+	return missing_checks, err
+}
+
 const insertAnime = `-- name: InsertAnime :exec
 INSERT INTO animes (ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, season, season_year)
     VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9, NOW()), COALESCE($10, NOW()), $11, $12)
 RETURNING
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 `
 
 type InsertAnimeParams struct {
@@ -1283,9 +1617,43 @@ type InsertMultipleAnimesParams struct {
 	SeasonYear  int32
 }
 
+const markAnimeUnavailable = `-- name: MarkAnimeUnavailable :exec
+UPDATE
+    animes
+SET
+    unavailable_at = NOW()
+WHERE
+    id = $1
+`
+
+func (q *Queries) MarkAnimeUnavailable(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, markAnimeUnavailable, id)
+	return err
+}
+
+const resetAnimeMissingChecks = `-- name: ResetAnimeMissingChecks :execrows
+UPDATE
+    animes
+SET
+    missing_checks = 0,
+    unavailable_at = NULL
+WHERE
+    hi_anime_id = ANY ($1::text[])
+    AND (missing_checks > 0
+        OR unavailable_at IS NOT NULL)
+`
+
+func (q *Queries) ResetAnimeMissingChecks(ctx context.Context, hiAnimeIds []string) (int64, error) {
+	result, err := q.db.Exec(ctx, resetAnimeMissingChecks, hiAnimeIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const searchAnimes = `-- name: SearchAnimes :many
 SELECT
-    animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr,
+    animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at,
     ts_rank(animes.search_vector, plainto_tsquery($3)) AS query_rank
 FROM
     animes
@@ -1298,6 +1666,7 @@ AND ($4 = ''
     OR $4 IS NULL
     OR genre ILIKE '%' || $4 || '%')
 AND animes.mal_id IS NOT NULL
+AND animes.unavailable_at IS NULL
 ORDER BY
     query_rank DESC
 LIMIT $1 OFFSET $2
@@ -1311,22 +1680,24 @@ type SearchAnimesParams struct {
 }
 
 type SearchAnimesRow struct {
-	ID           string
-	Ename        string
-	Jname        string
-	ImageUrl     string
-	Genre        string
-	HiAnimeID    string
-	MalID        pgtype.Int4
-	AnilistID    pgtype.Int4
-	LastEpisode  int32
-	CreatedAt    pgtype.Timestamp
-	UpdatedAt    pgtype.Timestamp
-	SearchVector string
-	Season       Season
-	SeasonYear   int32
-	GenresArr    []string
-	QueryRank    float32
+	ID            string
+	Ename         string
+	Jname         string
+	ImageUrl      string
+	Genre         string
+	HiAnimeID     string
+	MalID         pgtype.Int4
+	AnilistID     pgtype.Int4
+	LastEpisode   int32
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	SearchVector  string
+	Season        Season
+	SeasonYear    int32
+	GenresArr     []string
+	MissingChecks int32
+	UnavailableAt pgtype.Timestamp
+	QueryRank     float32
 }
 
 func (q *Queries) SearchAnimes(ctx context.Context, arg SearchAnimesParams) ([]SearchAnimesRow, error) {
@@ -1359,6 +1730,8 @@ func (q *Queries) SearchAnimes(ctx context.Context, arg SearchAnimesParams) ([]S
 			&i.Season,
 			&i.SeasonYear,
 			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
 			&i.QueryRank,
 		); err != nil {
 			return nil, err
@@ -1385,6 +1758,7 @@ AND ($2 = ''
     OR $2 IS NULL
     OR genre ILIKE '%' || $2 || '%')
 AND animes.mal_id IS NOT NULL
+AND animes.unavailable_at IS NULL
 `
 
 type SearchAnimesCountParams struct {
@@ -1417,7 +1791,7 @@ SET
 WHERE
     id = $12
 RETURNING
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 `
 
 type UpdateAnimeParams struct {
@@ -1461,7 +1835,7 @@ SET
 WHERE
     id = $2
 RETURNING
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 `
 
 type UpdateAnimeAnilistIdParams struct {
@@ -1505,7 +1879,7 @@ SET
 WHERE
     id = $3
 RETURNING
-    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr
+    id, ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, search_vector, season, season_year, genres_arr, missing_checks, unavailable_at
 `
 
 type UpdateAnimeSeasonsParams struct {
@@ -0,0 +1,567 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: collections.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const addCollectionItem = `-- name: AddCollectionItem :execrows
+INSERT INTO collection_items(collection_id, anime_id, position, note)
+SELECT
+  $1::varchar,
+  $2::varchar,
+  coalesce(max(position), 0) + 1,
+  $3::text
+FROM
+  collection_items
+WHERE
+  collection_id = $1::varchar
+ON CONFLICT (collection_id, anime_id)
+  DO NOTHING
+`
+
+type AddCollectionItemParams struct {
+	CollectionID string
+	AnimeID      string
+	Note         string
+}
+
+// New items go to the end of the collection, adding an anime twice is a no-op.
+func (q *Queries) AddCollectionItem(ctx context.Context, arg AddCollectionItemParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addCollectionItem, arg.CollectionID, arg.AnimeID, arg.Note)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createCollection = `-- name: CreateCollection :one
+INSERT INTO collections(user_id, name, description, visibility)
+  VALUES ($1, $2, $3, $4)
+RETURNING
+  id
+`
+
+type CreateCollectionParams struct {
+	UserID      string
+	Name        string
+	Description string
+	Visibility  CollectionVisibility
+}
+
+func (q *Queries) CreateCollection(ctx context.Context, arg CreateCollectionParams) (string, error) {
+	row := q.db.QueryRow(ctx, createCollection,
+		arg.UserID,
+		arg.Name,
+		arg.Description,
+		arg.Visibility,
+	)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteCollection = `-- name: DeleteCollection :execrows
+DELETE FROM collections
+WHERE id = $1
+  AND user_id = $2
+`
+
+type DeleteCollectionParams struct {
+	ID     string
+	UserID string
+}
+
+func (q *Queries) DeleteCollection(ctx context.Context, arg DeleteCollectionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCollection, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteCollectionItem = `-- name: DeleteCollectionItem :execrows
+DELETE FROM collection_items
+WHERE collection_id = $1
+  AND anime_id = $2
+`
+
+type DeleteCollectionItemParams struct {
+	CollectionID string
+	AnimeID      string
+}
+
+func (q *Queries) DeleteCollectionItem(ctx context.Context, arg DeleteCollectionItemParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCollectionItem, arg.CollectionID, arg.AnimeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getCollectionAnimeIDs = `-- name: GetCollectionAnimeIDs :many
+SELECT
+  anime_id
+FROM
+  collection_items
+WHERE
+  collection_id = $1
+`
+
+func (q *Queries) GetCollectionAnimeIDs(ctx context.Context, collectionID string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getCollectionAnimeIDs, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var anime_id string
+		if err := rows.Scan(&anime_id); err != nil {
+			return nil, err
+		}
+		items = append(items, anime_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCollectionItems = `-- name: GetCollectionItems :many
+SELECT
+  collection_items.collection_id, collection_items.anime_id, collection_items.position, collection_items.note, collection_items.created_at,
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
+FROM
+  collection_items
+  INNER JOIN animes ON animes.id = collection_items.anime_id
+WHERE
+  collection_items.collection_id = $3
+ORDER BY
+  collection_items.position ASC,
+  collection_items.created_at ASC
+LIMIT $1 OFFSET $2
+`
+
+type GetCollectionItemsParams struct {
+	Limit        int32
+	Offset       int32
+	CollectionID string
+}
+
+type GetCollectionItemsRow struct {
+	CollectionItem CollectionItem
+	Anime          Anime
+}
+
+func (q *Queries) GetCollectionItems(ctx context.Context, arg GetCollectionItemsParams) ([]GetCollectionItemsRow, error) {
+	rows, err := q.db.Query(ctx, getCollectionItems, arg.Limit, arg.Offset, arg.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCollectionItemsRow
+	for rows.Next() {
+		var i GetCollectionItemsRow
+		if err := rows.Scan(
+			&i.CollectionItem.CollectionID,
+			&i.CollectionItem.AnimeID,
+			&i.CollectionItem.Position,
+			&i.CollectionItem.Note,
+			&i.CollectionItem.CreatedAt,
+			&i.Anime.ID,
+			&i.Anime.Ename,
+			&i.Anime.Jname,
+			&i.Anime.ImageUrl,
+			&i.Anime.Genre,
+			&i.Anime.HiAnimeID,
+			&i.Anime.MalID,
+			&i.Anime.AnilistID,
+			&i.Anime.LastEpisode,
+			&i.Anime.CreatedAt,
+			&i.Anime.UpdatedAt,
+			&i.Anime.SearchVector,
+			&i.Anime.Season,
+			&i.Anime.SeasonYear,
+			&i.Anime.GenresArr,
+			&i.Anime.MissingChecks,
+			&i.Anime.UnavailableAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCollectionOfUser = `-- name: GetCollectionOfUser :one
+SELECT
+  collections.id, collections.user_id, collections.slug, collections.name, collections.description, collections.visibility, collections.created_at, collections.updated_at,
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      collection_items
+    WHERE
+      collection_items.collection_id = collections.id) AS item_count
+FROM
+  collections
+WHERE
+  collections.id = $1
+  AND collections.user_id = $2
+`
+
+type GetCollectionOfUserParams struct {
+	ID     string
+	UserID string
+}
+
+type GetCollectionOfUserRow struct {
+	Collection Collection
+	ItemCount  int64
+}
+
+func (q *Queries) GetCollectionOfUser(ctx context.Context, arg GetCollectionOfUserParams) (GetCollectionOfUserRow, error) {
+	row := q.db.QueryRow(ctx, getCollectionOfUser, arg.ID, arg.UserID)
+	var i GetCollectionOfUserRow
+	err := row.Scan(
+		&i.Collection.ID,
+		&i.Collection.UserID,
+		&i.Collection.Slug,
+		&i.Collection.Name,
+		&i.Collection.Description,
+		&i.Collection.Visibility,
+		&i.Collection.CreatedAt,
+		&i.Collection.UpdatedAt,
+		&i.ItemCount,
+	)
+	return i, err
+}
+
+const getCollectionsOfUser = `-- name: GetCollectionsOfUser :many
+SELECT
+  collections.id, collections.user_id, collections.slug, collections.name, collections.description, collections.visibility, collections.created_at, collections.updated_at,
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      collection_items
+    WHERE
+      collection_items.collection_id = collections.id) AS item_count
+FROM
+  collections
+WHERE
+  collections.user_id = $3
+ORDER BY
+  collections.updated_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type GetCollectionsOfUserParams struct {
+	Limit  int32
+	Offset int32
+	UserID string
+}
+
+type GetCollectionsOfUserRow struct {
+	Collection Collection
+	ItemCount  int64
+}
+
+func (q *Queries) GetCollectionsOfUser(ctx context.Context, arg GetCollectionsOfUserParams) ([]GetCollectionsOfUserRow, error) {
+	rows, err := q.db.Query(ctx, getCollectionsOfUser, arg.Limit, arg.Offset, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCollectionsOfUserRow
+	for rows.Next() {
+		var i GetCollectionsOfUserRow
+		if err := rows.Scan(
+			&i.Collection.ID,
+			&i.Collection.UserID,
+			&i.Collection.Slug,
+			&i.Collection.Name,
+			&i.Collection.Description,
+			&i.Collection.Visibility,
+			&i.Collection.CreatedAt,
+			&i.Collection.UpdatedAt,
+			&i.ItemCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCollectionsOfUserCount = `-- name: GetCollectionsOfUserCount :one
+SELECT
+  COUNT(*)
+FROM
+  collections
+WHERE
+  user_id = $1
+`
+
+func (q *Queries) GetCollectionsOfUserCount(ctx context.Context, userID string) (int64, error) {
+	row := q.db.QueryRow(ctx, getCollectionsOfUserCount, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getPublicCollectionsOfUser = `-- name: GetPublicCollectionsOfUser :many
+SELECT
+  collections.id, collections.user_id, collections.slug, collections.name, collections.description, collections.visibility, collections.created_at, collections.updated_at,
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      collection_items
+    WHERE
+      collection_items.collection_id = collections.id) AS item_count
+FROM
+  collections
+WHERE
+  collections.user_id = $3
+  AND collections.visibility = 'public'
+ORDER BY
+  collections.updated_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type GetPublicCollectionsOfUserParams struct {
+	Limit  int32
+	Offset int32
+	UserID string
+}
+
+type GetPublicCollectionsOfUserRow struct {
+	Collection Collection
+	ItemCount  int64
+}
+
+func (q *Queries) GetPublicCollectionsOfUser(ctx context.Context, arg GetPublicCollectionsOfUserParams) ([]GetPublicCollectionsOfUserRow, error) {
+	rows, err := q.db.Query(ctx, getPublicCollectionsOfUser, arg.Limit, arg.Offset, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPublicCollectionsOfUserRow
+	for rows.Next() {
+		var i GetPublicCollectionsOfUserRow
+		if err := rows.Scan(
+			&i.Collection.ID,
+			&i.Collection.UserID,
+			&i.Collection.Slug,
+			&i.Collection.Name,
+			&i.Collection.Description,
+			&i.Collection.Visibility,
+			&i.Collection.CreatedAt,
+			&i.Collection.UpdatedAt,
+			&i.ItemCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublicCollectionsOfUserCount = `-- name: GetPublicCollectionsOfUserCount :one
+SELECT
+  COUNT(*)
+FROM
+  collections
+WHERE
+  user_id = $1
+  AND visibility = 'public'
+`
+
+func (q *Queries) GetPublicCollectionsOfUserCount(ctx context.Context, userID string) (int64, error) {
+	row := q.db.QueryRow(ctx, getPublicCollectionsOfUserCount, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getSharedCollectionBySlug = `-- name: GetSharedCollectionBySlug :one
+SELECT
+  collections.id, collections.user_id, collections.slug, collections.name, collections.description, collections.visibility, collections.created_at, collections.updated_at,
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      collection_items
+    WHERE
+      collection_items.collection_id = collections.id) AS item_count
+FROM
+  collections
+WHERE
+  collections.slug = $1
+  AND collections.visibility <> 'private'
+`
+
+type GetSharedCollectionBySlugRow struct {
+	Collection Collection
+	ItemCount  int64
+}
+
+// Private collections are never shared, even with a valid slug.
+func (q *Queries) GetSharedCollectionBySlug(ctx context.Context, slug string) (GetSharedCollectionBySlugRow, error) {
+	row := q.db.QueryRow(ctx, getSharedCollectionBySlug, slug)
+	var i GetSharedCollectionBySlugRow
+	err := row.Scan(
+		&i.Collection.ID,
+		&i.Collection.UserID,
+		&i.Collection.Slug,
+		&i.Collection.Name,
+		&i.Collection.Description,
+		&i.Collection.Visibility,
+		&i.Collection.CreatedAt,
+		&i.Collection.UpdatedAt,
+		&i.ItemCount,
+	)
+	return i, err
+}
+
+const relinkCollectionItems = `-- name: RelinkCollectionItems :execrows
+UPDATE
+  collection_items ci
+SET
+  anime_id = $1
+WHERE
+  ci.anime_id = $2
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      collection_items ci2
+    WHERE
+      ci2.collection_id = ci.collection_id
+      AND ci2.anime_id = $1)
+`
+
+type RelinkCollectionItemsParams struct {
+	ToAnimeID   string
+	FromAnimeID string
+}
+
+func (q *Queries) RelinkCollectionItems(ctx context.Context, arg RelinkCollectionItemsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, relinkCollectionItems, arg.ToAnimeID, arg.FromAnimeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reorderCollectionItems = `-- name: ReorderCollectionItems :exec
+UPDATE
+  collection_items
+SET
+  position = o.position::integer
+FROM
+  unnest($2::varchar[])
+  WITH ORDINALITY AS o(anime_id, position)
+WHERE
+  collection_items.collection_id = $1
+  AND collection_items.anime_id = o.anime_id
+`
+
+type ReorderCollectionItemsParams struct {
+	CollectionID string
+	AnimeIds     []string
+}
+
+// anime_ids lists every item of the collection in its new order.
+func (q *Queries) ReorderCollectionItems(ctx context.Context, arg ReorderCollectionItemsParams) error {
+	_, err := q.db.Exec(ctx, reorderCollectionItems, arg.CollectionID, arg.AnimeIds)
+	return err
+}
+
+const touchCollection = `-- name: TouchCollection :exec
+UPDATE
+  collections
+SET
+  updated_at = NOW()
+WHERE
+  id = $1
+`
+
+func (q *Queries) TouchCollection(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, touchCollection, id)
+	return err
+}
+
+const updateCollection = `-- name: UpdateCollection :execrows
+UPDATE
+  collections
+SET
+  name = $1,
+  description = $2,
+  visibility = $3,
+  updated_at = NOW()
+WHERE
+  id = $4
+  AND user_id = $5
+`
+
+type UpdateCollectionParams struct {
+	Name        string
+	Description string
+	Visibility  CollectionVisibility
+	ID          string
+	UserID      string
+}
+
+func (q *Queries) UpdateCollection(ctx context.Context, arg UpdateCollectionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateCollection,
+		arg.Name,
+		arg.Description,
+		arg.Visibility,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateCollectionItemNote = `-- name: UpdateCollectionItemNote :execrows
+UPDATE
+  collection_items
+SET
+  note = $1
+WHERE
+  collection_id = $2
+  AND anime_id = $3
+`
+
+type UpdateCollectionItemNoteParams struct {
+	Note         string
+	CollectionID string
+	AnimeID      string
+}
+
+func (q *Queries) UpdateCollectionItemNote(ctx context.Context, arg UpdateCollectionItemNoteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateCollectionItemNote, arg.Note, arg.CollectionID, arg.AnimeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -9,6 +9,47 @@ import (
 	"context"
 )
 
+// iteratorForInsertAnimeOfflineMappings implements pgx.CopyFromSource.
+type iteratorForInsertAnimeOfflineMappings struct {
+	rows                 []InsertAnimeOfflineMappingsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertAnimeOfflineMappings) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertAnimeOfflineMappings) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].Title,
+		r.rows[0].Synonyms,
+		r.rows[0].MediaType,
+		r.rows[0].Episodes,
+		r.rows[0].Season,
+		r.rows[0].SeasonYear,
+		r.rows[0].MalID,
+		r.rows[0].AnilistID,
+		r.rows[0].KitsuID,
+		r.rows[0].AnidbID,
+	}, nil
+}
+
+func (r iteratorForInsertAnimeOfflineMappings) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertAnimeOfflineMappings(ctx context.Context, arg []InsertAnimeOfflineMappingsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"anime_offline_mappings"}, []string{"title", "synonyms", "media_type", "episodes", "season", "season_year", "mal_id", "anilist_id", "kitsu_id", "anidb_id"}, &iteratorForInsertAnimeOfflineMappings{rows: arg})
+}
+
 // iteratorForInsertMultipleAnimes implements pgx.CopyFromSource.
 type iteratorForInsertMultipleAnimes struct {
 	rows                 []InsertMultipleAnimesParams
@@ -7,6 +7,8 @@ package repository
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const deleteLibrarySync = `-- name: DeleteLibrarySync :exec
@@ -14,29 +16,22 @@ DELETE FROM external_library_sync
 WHERE user_id = $1
   AND anime_id = $2
   AND provider = $3
-  AND action = $4
 `
 
 type DeleteLibrarySyncParams struct {
 	UserID   string
 	AnimeID  string
 	Provider Provider
-	Action   LibraryActions
 }
 
 func (q *Queries) DeleteLibrarySync(ctx context.Context, arg DeleteLibrarySyncParams) error {
-	_, err := q.db.Exec(ctx, deleteLibrarySync,
-		arg.UserID,
-		arg.AnimeID,
-		arg.Provider,
-		arg.Action,
-	)
+	_, err := q.db.Exec(ctx, deleteLibrarySync, arg.UserID, arg.AnimeID, arg.Provider)
 	return err
 }
 
 const getAllLibrarySyncsForAnime = `-- name: GetAllLibrarySyncsForAnime :many
 SELECT
-  user_id, anime_id, provider, action, payload, status, created_at, updated_at
+  user_id, anime_id, provider, action, payload, status, created_at, updated_at, attempts, last_error, next_attempt_at, revision
 FROM
   external_library_sync
 WHERE
@@ -67,6 +62,10 @@ func (q *Queries) GetAllLibrarySyncsForAnime(ctx context.Context, arg GetAllLibr
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -80,7 +79,7 @@ func (q *Queries) GetAllLibrarySyncsForAnime(ctx context.Context, arg GetAllLibr
 
 const getFailedLibrarySyncs = `-- name: GetFailedLibrarySyncs :many
 SELECT
-  user_id, anime_id, provider, action, payload, status, created_at, updated_at
+  user_id, anime_id, provider, action, payload, status, created_at, updated_at, attempts, last_error, next_attempt_at, revision
 FROM
   external_library_sync
 WHERE
@@ -106,6 +105,10 @@ func (q *Queries) GetFailedLibrarySyncs(ctx context.Context, userID string) ([]E
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -119,11 +122,13 @@ func (q *Queries) GetFailedLibrarySyncs(ctx context.Context, userID string) ([]E
 
 const getFailedPendingLibrarySyncs = `-- name: GetFailedPendingLibrarySyncs :many
 SELECT
-  user_id, anime_id, provider, action, payload, status, created_at, updated_at
+  user_id, anime_id, provider, action, payload, status, created_at, updated_at, attempts, last_error, next_attempt_at, revision
 FROM
   external_library_sync
 WHERE
   status IN ('failed', 'pending')
+  AND (next_attempt_at IS NULL
+    OR next_attempt_at <= NOW())
 ORDER BY
   updated_at ASC
 `
@@ -146,6 +151,10 @@ func (q *Queries) GetFailedPendingLibrarySyncs(ctx context.Context) ([]ExternalL
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -157,9 +166,46 @@ func (q *Queries) GetFailedPendingLibrarySyncs(ctx context.Context) ([]ExternalL
 	return items, nil
 }
 
+const getLibrarySync = `-- name: GetLibrarySync :one
+SELECT
+  user_id, anime_id, provider, action, payload, status, created_at, updated_at, attempts, last_error, next_attempt_at, revision
+FROM
+  external_library_sync
+WHERE
+  user_id = $1
+  AND anime_id = $2
+  AND provider = $3
+`
+
+type GetLibrarySyncParams struct {
+	UserID   string
+	AnimeID  string
+	Provider Provider
+}
+
+func (q *Queries) GetLibrarySync(ctx context.Context, arg GetLibrarySyncParams) (ExternalLibrarySync, error) {
+	row := q.db.QueryRow(ctx, getLibrarySync, arg.UserID, arg.AnimeID, arg.Provider)
+	var i ExternalLibrarySync
+	err := row.Scan(
+		&i.UserID,
+		&i.AnimeID,
+		&i.Provider,
+		&i.Action,
+		&i.Payload,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Attempts,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.Revision,
+	)
+	return i, err
+}
+
 const getPendingLibrarySyncs = `-- name: GetPendingLibrarySyncs :many
 SELECT
-  user_id, anime_id, provider, action, payload, status, created_at, updated_at
+  user_id, anime_id, provider, action, payload, status, created_at, updated_at, attempts, last_error, next_attempt_at, revision
 FROM
   external_library_sync
 WHERE
@@ -185,6 +231,10 @@ func (q *Queries) GetPendingLibrarySyncs(ctx context.Context, userID string) ([]
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -198,7 +248,7 @@ func (q *Queries) GetPendingLibrarySyncs(ctx context.Context, userID string) ([]
 
 const getPendingLibrarySyncsForAllUsers = `-- name: GetPendingLibrarySyncsForAllUsers :many
 SELECT
-  user_id, anime_id, provider, action, payload, status, created_at, updated_at
+  user_id, anime_id, provider, action, payload, status, created_at, updated_at, attempts, last_error, next_attempt_at, revision
 FROM
   external_library_sync
 WHERE
@@ -225,6 +275,10 @@ func (q *Queries) GetPendingLibrarySyncsForAllUsers(ctx context.Context) ([]Exte
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -236,17 +290,187 @@ func (q *Queries) GetPendingLibrarySyncsForAllUsers(ctx context.Context) ([]Exte
 	return items, nil
 }
 
-const updateLibrarySyncStatus = `-- name: UpdateLibrarySyncStatus :exec
+const listLibrarySyncFailures = `-- name: ListLibrarySyncFailures :many
+SELECT
+  external_library_sync.user_id, external_library_sync.anime_id, external_library_sync.provider, external_library_sync.action, external_library_sync.payload, external_library_sync.status, external_library_sync.created_at, external_library_sync.updated_at, external_library_sync.attempts, external_library_sync.last_error, external_library_sync.next_attempt_at, external_library_sync.revision,
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
+FROM
+  external_library_sync
+  JOIN animes ON animes.id = external_library_sync.anime_id
+WHERE
+  external_library_sync.user_id = $1
+  AND external_library_sync.status IN ('failed', 'dead')
+ORDER BY
+  external_library_sync.updated_at DESC
+`
+
+type ListLibrarySyncFailuresRow struct {
+	ExternalLibrarySync ExternalLibrarySync
+	Anime               Anime
+}
+
+func (q *Queries) ListLibrarySyncFailures(ctx context.Context, userID string) ([]ListLibrarySyncFailuresRow, error) {
+	rows, err := q.db.Query(ctx, listLibrarySyncFailures, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLibrarySyncFailuresRow
+	for rows.Next() {
+		var i ListLibrarySyncFailuresRow
+		if err := rows.Scan(
+			&i.ExternalLibrarySync.UserID,
+			&i.ExternalLibrarySync.AnimeID,
+			&i.ExternalLibrarySync.Provider,
+			&i.ExternalLibrarySync.Action,
+			&i.ExternalLibrarySync.Payload,
+			&i.ExternalLibrarySync.Status,
+			&i.ExternalLibrarySync.CreatedAt,
+			&i.ExternalLibrarySync.UpdatedAt,
+			&i.ExternalLibrarySync.Attempts,
+			&i.ExternalLibrarySync.LastError,
+			&i.ExternalLibrarySync.NextAttemptAt,
+			&i.ExternalLibrarySync.Revision,
+			&i.Anime.ID,
+			&i.Anime.Ename,
+			&i.Anime.Jname,
+			&i.Anime.ImageUrl,
+			&i.Anime.Genre,
+			&i.Anime.HiAnimeID,
+			&i.Anime.MalID,
+			&i.Anime.AnilistID,
+			&i.Anime.LastEpisode,
+			&i.Anime.CreatedAt,
+			&i.Anime.UpdatedAt,
+			&i.Anime.SearchVector,
+			&i.Anime.Season,
+			&i.Anime.SeasonYear,
+			&i.Anime.GenresArr,
+			&i.Anime.MissingChecks,
+			&i.Anime.UnavailableAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordLibrarySyncFailure = `-- name: RecordLibrarySyncFailure :one
+UPDATE
+  external_library_sync
+SET
+  attempts = attempts + 1,
+  last_error = $1,
+  status = CASE WHEN $2::boolean
+    OR attempts + 1 >= $3::int THEN
+    'dead'::library_sync_status
+  ELSE
+    'failed'::library_sync_status
+  END,
+  next_attempt_at = CASE WHEN $2::boolean
+    OR attempts + 1 >= $3::int THEN
+    NULL
+  ELSE
+    NOW() + $4::int * INTERVAL '1 second'
+  END,
+  updated_at = NOW()
+WHERE
+  user_id = $5
+  AND anime_id = $6
+  AND provider = $7
+  AND revision = $8
+RETURNING
+  status,
+  attempts
+`
+
+type RecordLibrarySyncFailureParams struct {
+	LastError      pgtype.Text
+	Permanent      bool
+	MaxAttempts    int32
+	BackoffSeconds int32
+	UserID         string
+	AnimeID        string
+	Provider       Provider
+	Revision       int32
+}
+
+type RecordLibrarySyncFailureRow struct {
+	Status   LibrarySyncStatus
+	Attempts int32
+}
+
+// Schedules the next attempt after the backoff, or marks the sync dead once
+// attempts run out or the error is permanent. No row is returned when the
+// entry changed since the job read it.
+func (q *Queries) RecordLibrarySyncFailure(ctx context.Context, arg RecordLibrarySyncFailureParams) (RecordLibrarySyncFailureRow, error) {
+	row := q.db.QueryRow(ctx, recordLibrarySyncFailure,
+		arg.LastError,
+		arg.Permanent,
+		arg.MaxAttempts,
+		arg.BackoffSeconds,
+		arg.UserID,
+		arg.AnimeID,
+		arg.Provider,
+		arg.Revision,
+	)
+	var i RecordLibrarySyncFailureRow
+	err := row.Scan(&i.Status, &i.Attempts)
+	return i, err
+}
+
+const retryLibrarySyncs = `-- name: RetryLibrarySyncs :execrows
+UPDATE
+  external_library_sync
+SET
+  status = 'pending',
+  attempts = 0,
+  last_error = NULL,
+  next_attempt_at = NULL,
+  updated_at = NOW()
+WHERE
+  user_id = $1
+  AND ($2::text = ''
+    OR anime_id = $2)
+  AND status IN ('failed', 'dead')
+`
+
+type RetryLibrarySyncsParams struct {
+	UserID  string
+	AnimeID string
+}
+
+// Puts failed and dead syncs back to pending, which enqueues them again. An
+// empty anime_id retries every failed sync of the user.
+func (q *Queries) RetryLibrarySyncs(ctx context.Context, arg RetryLibrarySyncsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, retryLibrarySyncs, arg.UserID, arg.AnimeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateLibrarySyncStatus = `-- name: UpdateLibrarySyncStatus :execrows
 UPDATE
   external_library_sync
 SET
   status = $1,
+  last_error = CASE WHEN $1 = 'success' THEN
+    NULL
+  ELSE
+    last_error
+  END,
+  next_attempt_at = NULL,
   updated_at = NOW()
 WHERE
   user_id = $2
   AND anime_id = $3
   AND provider = $4
-  AND action = $5
+  AND revision = $5
 `
 
 type UpdateLibrarySyncStatusParams struct {
@@ -254,27 +478,37 @@ type UpdateLibrarySyncStatusParams struct {
 	UserID   string
 	AnimeID  string
 	Provider Provider
-	Action   LibraryActions
+	Revision int32
 }
 
-func (q *Queries) UpdateLibrarySyncStatus(ctx context.Context, arg UpdateLibrarySyncStatusParams) error {
-	_, err := q.db.Exec(ctx, updateLibrarySyncStatus,
+// Only applies to the revision the sync job read, a newer state stays
+// pending for the job queued after it.
+func (q *Queries) UpdateLibrarySyncStatus(ctx context.Context, arg UpdateLibrarySyncStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateLibrarySyncStatus,
 		arg.Status,
 		arg.UserID,
 		arg.AnimeID,
 		arg.Provider,
-		arg.Action,
+		arg.Revision,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const upsertLibrarySync = `-- name: UpsertLibrarySync :exec
 INSERT INTO external_library_sync(user_id, anime_id, provider, action, payload)
   VALUES ($1, $2, $3, $4, $5)
-ON CONFLICT (user_id, anime_id, provider, action)
+ON CONFLICT (user_id, anime_id, provider)
   DO UPDATE SET
+    action = EXCLUDED.action,
     payload = EXCLUDED.payload,
     status = 'pending',
+    revision = external_library_sync.revision + 1,
+    attempts = 0,
+    last_error = NULL,
+    next_attempt_at = NULL,
     updated_at = NOW()
 `
 
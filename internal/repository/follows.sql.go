@@ -0,0 +1,255 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: follows.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const createFollow = `-- name: CreateFollow :execrows
+INSERT INTO follows(follower_id, followee_id)
+  VALUES ($1, $2)
+ON CONFLICT
+  DO NOTHING
+`
+
+type CreateFollowParams struct {
+	FollowerID string
+	FolloweeID string
+}
+
+func (q *Queries) CreateFollow(ctx context.Context, arg CreateFollowParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createFollow, arg.FollowerID, arg.FolloweeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteFollow = `-- name: DeleteFollow :execrows
+DELETE FROM follows
+WHERE follower_id = $1
+  AND followee_id = $2
+`
+
+type DeleteFollowParams struct {
+	FollowerID string
+	FolloweeID string
+}
+
+func (q *Queries) DeleteFollow(ctx context.Context, arg DeleteFollowParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFollow, arg.FollowerID, arg.FolloweeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getFollowers = `-- name: GetFollowers :many
+SELECT
+  follows.follower_id, follows.followee_id, follows.muted, follows.created_at,
+  users.id, users.username, users.email, users.password_hash, users.profile_picture, users.created_at, users.updated_at
+FROM
+  follows
+  INNER JOIN users ON users.id = follows.follower_id
+  LEFT JOIN settings ON settings.user_id = follows.follower_id
+WHERE
+  follows.followee_id = $3
+  AND (NOT $4::boolean
+    OR coalesce(settings.public_profile, FALSE))
+ORDER BY
+  follows.created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type GetFollowersParams struct {
+	Limit      int32
+	Offset     int32
+	UserID     string
+	PublicOnly bool
+}
+
+type GetFollowersRow struct {
+	Follow Follow
+	User   User
+}
+
+func (q *Queries) GetFollowers(ctx context.Context, arg GetFollowersParams) ([]GetFollowersRow, error) {
+	rows, err := q.db.Query(ctx, getFollowers,
+		arg.Limit,
+		arg.Offset,
+		arg.UserID,
+		arg.PublicOnly,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFollowersRow
+	for rows.Next() {
+		var i GetFollowersRow
+		if err := rows.Scan(
+			&i.Follow.FollowerID,
+			&i.Follow.FolloweeID,
+			&i.Follow.Muted,
+			&i.Follow.CreatedAt,
+			&i.User.ID,
+			&i.User.Username,
+			&i.User.Email,
+			&i.User.PasswordHash,
+			&i.User.ProfilePicture,
+			&i.User.CreatedAt,
+			&i.User.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFollowersCount = `-- name: GetFollowersCount :one
+SELECT
+  COUNT(*)
+FROM
+  follows
+  LEFT JOIN settings ON settings.user_id = follows.follower_id
+WHERE
+  follows.followee_id = $1
+  AND (NOT $2::boolean
+    OR coalesce(settings.public_profile, FALSE))
+`
+
+type GetFollowersCountParams struct {
+	UserID     string
+	PublicOnly bool
+}
+
+func (q *Queries) GetFollowersCount(ctx context.Context, arg GetFollowersCountParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getFollowersCount, arg.UserID, arg.PublicOnly)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getFollowing = `-- name: GetFollowing :many
+SELECT
+  follows.follower_id, follows.followee_id, follows.muted, follows.created_at,
+  users.id, users.username, users.email, users.password_hash, users.profile_picture, users.created_at, users.updated_at
+FROM
+  follows
+  INNER JOIN users ON users.id = follows.followee_id
+  LEFT JOIN settings ON settings.user_id = follows.followee_id
+WHERE
+  follows.follower_id = $3
+  AND (NOT $4::boolean
+    OR coalesce(settings.public_profile, FALSE))
+ORDER BY
+  follows.created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type GetFollowingParams struct {
+	Limit      int32
+	Offset     int32
+	UserID     string
+	PublicOnly bool
+}
+
+type GetFollowingRow struct {
+	Follow Follow
+	User   User
+}
+
+// public_only leaves out users without a public profile, for lists shown to
+// other users.
+func (q *Queries) GetFollowing(ctx context.Context, arg GetFollowingParams) ([]GetFollowingRow, error) {
+	rows, err := q.db.Query(ctx, getFollowing,
+		arg.Limit,
+		arg.Offset,
+		arg.UserID,
+		arg.PublicOnly,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFollowingRow
+	for rows.Next() {
+		var i GetFollowingRow
+		if err := rows.Scan(
+			&i.Follow.FollowerID,
+			&i.Follow.FolloweeID,
+			&i.Follow.Muted,
+			&i.Follow.CreatedAt,
+			&i.User.ID,
+			&i.User.Username,
+			&i.User.Email,
+			&i.User.PasswordHash,
+			&i.User.ProfilePicture,
+			&i.User.CreatedAt,
+			&i.User.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFollowingCount = `-- name: GetFollowingCount :one
+SELECT
+  COUNT(*)
+FROM
+  follows
+  LEFT JOIN settings ON settings.user_id = follows.followee_id
+WHERE
+  follows.follower_id = $1
+  AND (NOT $2::boolean
+    OR coalesce(settings.public_profile, FALSE))
+`
+
+type GetFollowingCountParams struct {
+	UserID     string
+	PublicOnly bool
+}
+
+func (q *Queries) GetFollowingCount(ctx context.Context, arg GetFollowingCountParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getFollowingCount, arg.UserID, arg.PublicOnly)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const setFollowMuted = `-- name: SetFollowMuted :execrows
+UPDATE
+  follows
+SET
+  muted = $1
+WHERE
+  follower_id = $2
+  AND followee_id = $3
+`
+
+type SetFollowMutedParams struct {
+	Muted      bool
+	FollowerID string
+	FolloweeID string
+}
+
+func (q *Queries) SetFollowMuted(ctx context.Context, arg SetFollowMutedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setFollowMuted, arg.Muted, arg.FollowerID, arg.FolloweeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -12,8 +12,8 @@ import (
 )
 
 const createLibraryImportJob = `-- name: CreateLibraryImportJob :one
-INSERT INTO library_import_jobs(user_id, provider)
-  VALUES ($1, $2)
+INSERT INTO library_import_jobs(user_id, provider, strategy, dry_run)
+  VALUES ($1, $2, $3, $4)
 RETURNING
   id
 `
@@ -21,18 +21,89 @@ RETURNING
 type CreateLibraryImportJobParams struct {
 	UserID   string
 	Provider Provider
+	Strategy LibraryImportStrategy
+	DryRun   bool
 }
 
 func (q *Queries) CreateLibraryImportJob(ctx context.Context, arg CreateLibraryImportJobParams) (string, error) {
-	row := q.db.QueryRow(ctx, createLibraryImportJob, arg.UserID, arg.Provider)
+	row := q.db.QueryRow(ctx, createLibraryImportJob,
+		arg.UserID,
+		arg.Provider,
+		arg.Strategy,
+		arg.DryRun,
+	)
 	var id string
 	err := row.Scan(&id)
 	return id, err
 }
 
+const getLibraryImportChanges = `-- name: GetLibraryImportChanges :many
+SELECT
+  library_import_changes.id, library_import_changes.job_id, library_import_changes.anime_id, library_import_changes.local_status, library_import_changes.local_watched_episodes, library_import_changes.status, library_import_changes.watched_episodes, library_import_changes.created_at,
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
+FROM
+  library_import_changes
+  INNER JOIN animes ON animes.id = library_import_changes.anime_id
+WHERE
+  library_import_changes.job_id = $1
+ORDER BY
+  library_import_changes.id ASC
+`
+
+type GetLibraryImportChangesRow struct {
+	LibraryImportChange LibraryImportChange
+	Anime               Anime
+}
+
+func (q *Queries) GetLibraryImportChanges(ctx context.Context, jobID string) ([]GetLibraryImportChangesRow, error) {
+	rows, err := q.db.Query(ctx, getLibraryImportChanges, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLibraryImportChangesRow
+	for rows.Next() {
+		var i GetLibraryImportChangesRow
+		if err := rows.Scan(
+			&i.LibraryImportChange.ID,
+			&i.LibraryImportChange.JobID,
+			&i.LibraryImportChange.AnimeID,
+			&i.LibraryImportChange.LocalStatus,
+			&i.LibraryImportChange.LocalWatchedEpisodes,
+			&i.LibraryImportChange.Status,
+			&i.LibraryImportChange.WatchedEpisodes,
+			&i.LibraryImportChange.CreatedAt,
+			&i.Anime.ID,
+			&i.Anime.Ename,
+			&i.Anime.Jname,
+			&i.Anime.ImageUrl,
+			&i.Anime.Genre,
+			&i.Anime.HiAnimeID,
+			&i.Anime.MalID,
+			&i.Anime.AnilistID,
+			&i.Anime.LastEpisode,
+			&i.Anime.CreatedAt,
+			&i.Anime.UpdatedAt,
+			&i.Anime.SearchVector,
+			&i.Anime.Season,
+			&i.Anime.SeasonYear,
+			&i.Anime.GenresArr,
+			&i.Anime.MissingChecks,
+			&i.Anime.UnavailableAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLibraryImportJob = `-- name: GetLibraryImportJob :one
 SELECT
-  id, user_id, provider, status, error_message, created_at, updated_at, completed_at
+  id, user_id, provider, status, error_message, created_at, updated_at, completed_at, matched_count, strategy, dry_run
 FROM
   library_import_jobs
 WHERE
@@ -51,13 +122,16 @@ func (q *Queries) GetLibraryImportJob(ctx context.Context, id string) (LibraryIm
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.CompletedAt,
+		&i.MatchedCount,
+		&i.Strategy,
+		&i.DryRun,
 	)
 	return i, err
 }
 
 const getLibraryImportJobByUserId = `-- name: GetLibraryImportJobByUserId :many
 SELECT
-  id, user_id, provider, status, error_message, created_at, updated_at, completed_at
+  id, user_id, provider, status, error_message, created_at, updated_at, completed_at, matched_count, strategy, dry_run
 FROM
   library_import_jobs
 WHERE
@@ -84,6 +158,9 @@ func (q *Queries) GetLibraryImportJobByUserId(ctx context.Context, userID string
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.MatchedCount,
+			&i.Strategy,
+			&i.DryRun,
 		); err != nil {
 			return nil, err
 		}
@@ -95,6 +172,175 @@ func (q *Queries) GetLibraryImportJobByUserId(ctx context.Context, userID string
 	return items, nil
 }
 
+const getLibraryImportJobOfUser = `-- name: GetLibraryImportJobOfUser :one
+SELECT
+  id, user_id, provider, status, error_message, created_at, updated_at, completed_at, matched_count, strategy, dry_run
+FROM
+  library_import_jobs
+WHERE
+  id = $1
+  AND user_id = $2
+`
+
+type GetLibraryImportJobOfUserParams struct {
+	ID     string
+	UserID string
+}
+
+func (q *Queries) GetLibraryImportJobOfUser(ctx context.Context, arg GetLibraryImportJobOfUserParams) (LibraryImportJob, error) {
+	row := q.db.QueryRow(ctx, getLibraryImportJobOfUser, arg.ID, arg.UserID)
+	var i LibraryImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Status,
+		&i.ErrorMessage,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.MatchedCount,
+		&i.Strategy,
+		&i.DryRun,
+	)
+	return i, err
+}
+
+const getLibraryImportReportItems = `-- name: GetLibraryImportReportItems :many
+SELECT
+  id, job_id, issue, external_id, title, mal_id, anilist_id, candidate_ids, created_at
+FROM
+  library_import_report_items
+WHERE
+  job_id = $1
+ORDER BY
+  id ASC
+`
+
+func (q *Queries) GetLibraryImportReportItems(ctx context.Context, jobID string) ([]LibraryImportReportItem, error) {
+	rows, err := q.db.Query(ctx, getLibraryImportReportItems, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LibraryImportReportItem
+	for rows.Next() {
+		var i LibraryImportReportItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobID,
+			&i.Issue,
+			&i.ExternalID,
+			&i.Title,
+			&i.MalID,
+			&i.AnilistID,
+			&i.CandidateIds,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertLibraryImportChange = `-- name: InsertLibraryImportChange :exec
+INSERT INTO library_import_changes(job_id, anime_id, local_status, local_watched_episodes, status, watched_episodes)
+  VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type InsertLibraryImportChangeParams struct {
+	JobID                string
+	AnimeID              string
+	LocalStatus          NullLibraryStatus
+	LocalWatchedEpisodes pgtype.Int4
+	Status               LibraryStatus
+	WatchedEpisodes      int32
+}
+
+func (q *Queries) InsertLibraryImportChange(ctx context.Context, arg InsertLibraryImportChangeParams) error {
+	_, err := q.db.Exec(ctx, insertLibraryImportChange,
+		arg.JobID,
+		arg.AnimeID,
+		arg.LocalStatus,
+		arg.LocalWatchedEpisodes,
+		arg.Status,
+		arg.WatchedEpisodes,
+	)
+	return err
+}
+
+const insertLibraryImportReportItem = `-- name: InsertLibraryImportReportItem :exec
+INSERT INTO library_import_report_items(job_id, issue, external_id, title, mal_id, anilist_id, candidate_ids)
+  VALUES ($1, $2, $3, $4, $5, $6, $7::text[])
+`
+
+type InsertLibraryImportReportItemParams struct {
+	JobID        string
+	Issue        LibraryImportIssue
+	ExternalID   string
+	Title        string
+	MalID        pgtype.Int4
+	AnilistID    pgtype.Int4
+	CandidateIds []string
+}
+
+func (q *Queries) InsertLibraryImportReportItem(ctx context.Context, arg InsertLibraryImportReportItemParams) error {
+	_, err := q.db.Exec(ctx, insertLibraryImportReportItem,
+		arg.JobID,
+		arg.Issue,
+		arg.ExternalID,
+		arg.Title,
+		arg.MalID,
+		arg.AnilistID,
+		arg.CandidateIds,
+	)
+	return err
+}
+
+const resetLibraryImportReport = `-- name: ResetLibraryImportReport :exec
+WITH deleted_items AS (
+  DELETE FROM library_import_report_items
+  WHERE job_id = $1),
+deleted_changes AS (
+  DELETE FROM library_import_changes
+  WHERE job_id = $1)
+UPDATE
+  library_import_jobs
+SET
+  matched_count = 0
+WHERE
+  library_import_jobs.id = $1
+`
+
+// Clears what a previous attempt of the job recorded before it runs again.
+func (q *Queries) ResetLibraryImportReport(ctx context.Context, jobID string) error {
+	_, err := q.db.Exec(ctx, resetLibraryImportReport, jobID)
+	return err
+}
+
+const setLibraryImportMatchedCount = `-- name: SetLibraryImportMatchedCount :exec
+UPDATE
+  library_import_jobs
+SET
+  matched_count = $1
+WHERE
+  id = $2
+`
+
+type SetLibraryImportMatchedCountParams struct {
+	MatchedCount int32
+	ID           string
+}
+
+func (q *Queries) SetLibraryImportMatchedCount(ctx context.Context, arg SetLibraryImportMatchedCountParams) error {
+	_, err := q.db.Exec(ctx, setLibraryImportMatchedCount, arg.MatchedCount, arg.ID)
+	return err
+}
+
 const updateLibraryImportJob = `-- name: UpdateLibraryImportJob :exec
 UPDATE
   library_import_jobs
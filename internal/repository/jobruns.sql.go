@@ -0,0 +1,271 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: jobruns.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const finishJobRun = `-- name: FinishJobRun :exec
+UPDATE
+  job_runs
+SET
+  status = $1,
+  error_count = $2,
+  warn_count = $3,
+  log_summary = $4,
+  finished_at = NOW(),
+  duration_ms = (EXTRACT(EPOCH FROM NOW() - started_at) * 1000)::bigint
+WHERE
+  id = $5
+`
+
+type FinishJobRunParams struct {
+	Status     JobRunStatus
+	ErrorCount int32
+	WarnCount  int32
+	LogSummary pgtype.Text
+	ID         string
+}
+
+func (q *Queries) FinishJobRun(ctx context.Context, arg FinishJobRunParams) error {
+	_, err := q.db.Exec(ctx, finishJobRun,
+		arg.Status,
+		arg.ErrorCount,
+		arg.WarnCount,
+		arg.LogSummary,
+		arg.ID,
+	)
+	return err
+}
+
+const getJobSchedules = `-- name: GetJobSchedules :many
+SELECT
+  job_name, paused, updated_by, created_at, updated_at
+FROM
+  job_schedules
+`
+
+func (q *Queries) GetJobSchedules(ctx context.Context) ([]JobSchedule, error) {
+	rows, err := q.db.Query(ctx, getJobSchedules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobSchedule
+	for rows.Next() {
+		var i JobSchedule
+		if err := rows.Scan(
+			&i.JobName,
+			&i.Paused,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestJobRun = `-- name: GetLatestJobRun :one
+SELECT
+  id, job_name, trigger, status, holder, error_count, warn_count, log_summary, started_at, finished_at, duration_ms
+FROM
+  job_runs
+WHERE
+  job_name = $1
+ORDER BY
+  started_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestJobRun(ctx context.Context, jobName string) (JobRun, error) {
+	row := q.db.QueryRow(ctx, getLatestJobRun, jobName)
+	var i JobRun
+	err := row.Scan(
+		&i.ID,
+		&i.JobName,
+		&i.Trigger,
+		&i.Status,
+		&i.Holder,
+		&i.ErrorCount,
+		&i.WarnCount,
+		&i.LogSummary,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.DurationMs,
+	)
+	return i, err
+}
+
+const getLatestJobRuns = `-- name: GetLatestJobRuns :many
+SELECT DISTINCT ON (job_name)
+  id, job_name, trigger, status, holder, error_count, warn_count, log_summary, started_at, finished_at, duration_ms
+FROM
+  job_runs
+ORDER BY
+  job_name,
+  started_at DESC
+`
+
+func (q *Queries) GetLatestJobRuns(ctx context.Context) ([]JobRun, error) {
+	rows, err := q.db.Query(ctx, getLatestJobRuns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobRun
+	for rows.Next() {
+		var i JobRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobName,
+			&i.Trigger,
+			&i.Status,
+			&i.Holder,
+			&i.ErrorCount,
+			&i.WarnCount,
+			&i.LogSummary,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.DurationMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isJobPaused = `-- name: IsJobPaused :one
+SELECT
+  EXISTS (
+    SELECT
+      1
+    FROM
+      job_schedules
+    WHERE
+      job_name = $1
+      AND paused)
+`
+
+func (q *Queries) IsJobPaused(ctx context.Context, jobName string) (bool, error) {
+	row := q.db.QueryRow(ctx, isJobPaused, jobName)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listJobRuns = `-- name: ListJobRuns :many
+SELECT
+  id, job_name, trigger, status, holder, error_count, warn_count, log_summary, started_at, finished_at, duration_ms
+FROM
+  job_runs
+WHERE
+  job_name = $1
+ORDER BY
+  started_at DESC
+LIMIT $2
+`
+
+type ListJobRunsParams struct {
+	JobName    string
+	LimitCount int32
+}
+
+func (q *Queries) ListJobRuns(ctx context.Context, arg ListJobRunsParams) ([]JobRun, error) {
+	rows, err := q.db.Query(ctx, listJobRuns, arg.JobName, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobRun
+	for rows.Next() {
+		var i JobRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobName,
+			&i.Trigger,
+			&i.Status,
+			&i.Holder,
+			&i.ErrorCount,
+			&i.WarnCount,
+			&i.LogSummary,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.DurationMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setJobPaused = `-- name: SetJobPaused :exec
+INSERT INTO job_schedules(job_name, paused, updated_by)
+  VALUES ($1, $2, $3)
+ON CONFLICT (job_name)
+  DO UPDATE SET
+    paused = EXCLUDED.paused,
+    updated_by = EXCLUDED.updated_by
+`
+
+type SetJobPausedParams struct {
+	JobName   string
+	Paused    bool
+	UpdatedBy pgtype.Text
+}
+
+func (q *Queries) SetJobPaused(ctx context.Context, arg SetJobPausedParams) error {
+	_, err := q.db.Exec(ctx, setJobPaused, arg.JobName, arg.Paused, arg.UpdatedBy)
+	return err
+}
+
+const startJobRun = `-- name: StartJobRun :one
+INSERT INTO job_runs(job_name, trigger, holder)
+  VALUES ($1, $2, $3)
+RETURNING
+  id, job_name, trigger, status, holder, error_count, warn_count, log_summary, started_at, finished_at, duration_ms
+`
+
+type StartJobRunParams struct {
+	JobName string
+	Trigger JobRunTrigger
+	Holder  string
+}
+
+func (q *Queries) StartJobRun(ctx context.Context, arg StartJobRunParams) (JobRun, error) {
+	row := q.db.QueryRow(ctx, startJobRun, arg.JobName, arg.Trigger, arg.Holder)
+	var i JobRun
+	err := row.Scan(
+		&i.ID,
+		&i.JobName,
+		&i.Trigger,
+		&i.Status,
+		&i.Holder,
+		&i.ErrorCount,
+		&i.WarnCount,
+		&i.LogSummary,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.DurationMs,
+	)
+	return i, err
+}
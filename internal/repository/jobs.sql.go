@@ -0,0 +1,331 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: jobs.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimJobs = `-- name: ClaimJobs :many
+UPDATE
+  jobs
+SET
+  status = 'running',
+  attempts = attempts + 1,
+  locked_by = $1,
+  locked_until = NOW() + $2::int * INTERVAL '1 second'
+WHERE
+  id IN (
+    SELECT
+      j.id
+    FROM
+      jobs j
+    WHERE
+      j.queue = $3
+      AND j.status = 'queued'
+      AND j.run_at <= NOW()
+      -- jobs sharing a key run one at a time, in order
+      AND NOT EXISTS (
+        SELECT
+          1
+        FROM
+          jobs r
+        WHERE
+          r.queue = j.queue
+          AND r.dedupe_key = j.dedupe_key
+          AND r.status = 'running')
+    ORDER BY
+      j.run_at ASC
+    LIMIT $4
+    FOR UPDATE
+      SKIP LOCKED)
+RETURNING
+  id, queue, dedupe_key, payload, status, attempts, max_attempts, run_at, locked_by, locked_until, last_error, created_at, updated_at, completed_at
+`
+
+type ClaimJobsParams struct {
+	WorkerID     pgtype.Text
+	LeaseSeconds int32
+	Queue        string
+	LimitCount   int32
+}
+
+func (q *Queries) ClaimJobs(ctx context.Context, arg ClaimJobsParams) ([]Job, error) {
+	rows, err := q.db.Query(ctx, claimJobs,
+		arg.WorkerID,
+		arg.LeaseSeconds,
+		arg.Queue,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.Queue,
+			&i.DedupeKey,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.MaxAttempts,
+			&i.RunAt,
+			&i.LockedBy,
+			&i.LockedUntil,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const completeJob = `-- name: CompleteJob :exec
+UPDATE
+  jobs
+SET
+  status = 'completed',
+  locked_by = NULL,
+  locked_until = NULL,
+  completed_at = NOW()
+WHERE
+  id = $1
+  AND locked_by = $2
+`
+
+type CompleteJobParams struct {
+	ID       string
+	WorkerID pgtype.Text
+}
+
+func (q *Queries) CompleteJob(ctx context.Context, arg CompleteJobParams) error {
+	_, err := q.db.Exec(ctx, completeJob, arg.ID, arg.WorkerID)
+	return err
+}
+
+const deleteFinishedJobs = `-- name: DeleteFinishedJobs :execrows
+DELETE FROM jobs
+WHERE status IN ('completed', 'dead')
+  AND updated_at < NOW() - $1::int * INTERVAL '1 day'
+`
+
+func (q *Queries) DeleteFinishedJobs(ctx context.Context, retentionDays int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFinishedJobs, retentionDays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const enqueueJob = `-- name: EnqueueJob :exec
+SELECT
+  enqueue_job($1, $2, $3, $4)
+`
+
+type EnqueueJobParams struct {
+	Queue       string
+	DedupeKey   pgtype.Text
+	Payload     []byte
+	MaxAttempts int32
+}
+
+func (q *Queries) EnqueueJob(ctx context.Context, arg EnqueueJobParams) error {
+	_, err := q.db.Exec(ctx, enqueueJob,
+		arg.Queue,
+		arg.DedupeKey,
+		arg.Payload,
+		arg.MaxAttempts,
+	)
+	return err
+}
+
+const failJob = `-- name: FailJob :one
+UPDATE
+  jobs
+SET
+  status = CASE WHEN $1::boolean
+    OR jobs.attempts >= jobs.max_attempts THEN
+    'dead'::job_status
+  WHEN EXISTS (
+    SELECT
+      1
+    FROM
+      jobs n
+    WHERE
+      n.queue = jobs.queue
+      AND n.dedupe_key = jobs.dedupe_key
+      AND n.status = 'queued') THEN
+    'completed'::job_status
+  ELSE
+    'queued'::job_status
+  END,
+  run_at = NOW() + $2::int * INTERVAL '1 second',
+  last_error = $3,
+  locked_by = NULL,
+  locked_until = NULL,
+  completed_at = CASE WHEN $1::boolean
+    OR jobs.attempts >= jobs.max_attempts THEN
+    NOW()
+  ELSE
+    NULL
+  END
+WHERE
+  jobs.id = $4
+  AND jobs.locked_by = $5
+RETURNING
+  status
+`
+
+type FailJobParams struct {
+	Permanent      bool
+	BackoffSeconds int32
+	LastError      pgtype.Text
+	ID             string
+	WorkerID       pgtype.Text
+}
+
+// Requeues the job after the backoff, or dead-letters it once attempts run
+// out. A job that already has a queued successor is superseded instead.
+func (q *Queries) FailJob(ctx context.Context, arg FailJobParams) (JobStatus, error) {
+	row := q.db.QueryRow(ctx, failJob,
+		arg.Permanent,
+		arg.BackoffSeconds,
+		arg.LastError,
+		arg.ID,
+		arg.WorkerID,
+	)
+	var status JobStatus
+	err := row.Scan(&status)
+	return status, err
+}
+
+const getNextJobRunAt = `-- name: GetNextJobRunAt :one
+SELECT
+  MIN(run_at)::timestamptz AS run_at
+FROM
+  jobs
+WHERE
+  queue = $1
+  AND status = 'queued'
+  AND run_at > NOW()
+`
+
+func (q *Queries) GetNextJobRunAt(ctx context.Context, queue string) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getNextJobRunAt, queue)
+	var run_at pgtype.Timestamptz
+	err := row.Scan(&run_at)
+	return run_at, err
+}
+
+const heartbeatJob = `-- name: HeartbeatJob :execrows
+UPDATE
+  jobs
+SET
+  locked_until = NOW() + $1::int * INTERVAL '1 second'
+WHERE
+  id = $2
+  AND locked_by = $3
+  AND status = 'running'
+`
+
+type HeartbeatJobParams struct {
+	LeaseSeconds int32
+	ID           string
+	WorkerID     pgtype.Text
+}
+
+func (q *Queries) HeartbeatJob(ctx context.Context, arg HeartbeatJobParams) (int64, error) {
+	result, err := q.db.Exec(ctx, heartbeatJob, arg.LeaseSeconds, arg.ID, arg.WorkerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const releaseJob = `-- name: ReleaseJob :exec
+UPDATE
+  jobs
+SET
+  status = 'queued',
+  attempts = GREATEST(attempts - 1, 0),
+  locked_by = NULL,
+  locked_until = NULL
+WHERE
+  jobs.id = $1
+  AND jobs.locked_by = $2
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      jobs n
+    WHERE
+      n.queue = jobs.queue
+      AND n.dedupe_key = jobs.dedupe_key
+      AND n.status = 'queued')
+`
+
+type ReleaseJobParams struct {
+	ID       string
+	WorkerID pgtype.Text
+}
+
+// Hands a job back without counting the attempt, used on shutdown.
+func (q *Queries) ReleaseJob(ctx context.Context, arg ReleaseJobParams) error {
+	_, err := q.db.Exec(ctx, releaseJob, arg.ID, arg.WorkerID)
+	return err
+}
+
+const requeueExpiredJobs = `-- name: RequeueExpiredJobs :execrows
+UPDATE
+  jobs
+SET
+  status = CASE WHEN jobs.attempts >= jobs.max_attempts THEN
+    'dead'::job_status
+  WHEN EXISTS (
+    SELECT
+      1
+    FROM
+      jobs n
+    WHERE
+      n.queue = jobs.queue
+      AND n.dedupe_key = jobs.dedupe_key
+      AND n.status = 'queued') THEN
+    'completed'::job_status
+  ELSE
+    'queued'::job_status
+  END,
+  last_error = 'lease expired',
+  locked_by = NULL,
+  locked_until = NULL,
+  completed_at = CASE WHEN jobs.attempts >= jobs.max_attempts THEN
+    NOW()
+  ELSE
+    NULL
+  END
+WHERE
+  jobs.status = 'running'
+  AND jobs.locked_until < NOW()
+`
+
+func (q *Queries) RequeueExpiredJobs(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, requeueExpiredJobs)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
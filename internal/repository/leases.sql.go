@@ -0,0 +1,95 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: leases.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const acquireLease = `-- name: AcquireLease :execrows
+INSERT INTO worker_leases(name, holder, expires_at)
+  VALUES ($1, $2, NOW() + $3::int * INTERVAL '1 second')
+ON CONFLICT (name)
+  DO UPDATE SET
+    holder = EXCLUDED.holder,
+    expires_at = EXCLUDED.expires_at,
+    acquired_at = CASE WHEN worker_leases.holder = EXCLUDED.holder THEN
+      worker_leases.acquired_at
+    ELSE
+      NOW()
+    END
+  WHERE
+    worker_leases.holder = EXCLUDED.holder
+    OR worker_leases.expires_at < NOW()
+`
+
+type AcquireLeaseParams struct {
+	Name       string
+	Holder     string
+	TtlSeconds int32
+}
+
+// Takes the lease when it is free or expired, or extends it for its holder.
+func (q *Queries) AcquireLease(ctx context.Context, arg AcquireLeaseParams) (int64, error) {
+	result, err := q.db.Exec(ctx, acquireLease, arg.Name, arg.Holder, arg.TtlSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listLeases = `-- name: ListLeases :many
+SELECT
+  name, holder, expires_at, acquired_at, updated_at
+FROM
+  worker_leases
+WHERE
+  expires_at >= NOW()
+ORDER BY
+  name ASC
+`
+
+func (q *Queries) ListLeases(ctx context.Context) ([]WorkerLease, error) {
+	rows, err := q.db.Query(ctx, listLeases)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkerLease
+	for rows.Next() {
+		var i WorkerLease
+		if err := rows.Scan(
+			&i.Name,
+			&i.Holder,
+			&i.ExpiresAt,
+			&i.AcquiredAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const releaseLease = `-- name: ReleaseLease :exec
+DELETE FROM worker_leases
+WHERE name = $1
+  AND holder = $2
+`
+
+type ReleaseLeaseParams struct {
+	Name   string
+	Holder string
+}
+
+func (q *Queries) ReleaseLease(ctx context.Context, arg ReleaseLeaseParams) error {
+	_, err := q.db.Exec(ctx, releaseLease, arg.Name, arg.Holder)
+	return err
+}
@@ -39,8 +39,8 @@ func (q *Queries) DeleteLibrary(ctx context.Context, arg DeleteLibraryParams) er
 
 const getContinueWatchingAnime = `-- name: GetContinueWatchingAnime :many
 SELECT
-  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at,
-  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr
+  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at, library.score, library.started_at, library.completed_at, library.rewatches, library.notes,
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
 FROM
   library
   INNER JOIN animes ON animes.id = library.anime_id
@@ -81,6 +81,11 @@ func (q *Queries) GetContinueWatchingAnime(ctx context.Context, arg GetContinueW
 			&i.Library.WatchedEpisodes,
 			&i.Library.CreatedAt,
 			&i.Library.UpdatedAt,
+			&i.Library.Score,
+			&i.Library.StartedAt,
+			&i.Library.CompletedAt,
+			&i.Library.Rewatches,
+			&i.Library.Notes,
 			&i.Anime.ID,
 			&i.Anime.Ename,
 			&i.Anime.Jname,
@@ -96,6 +101,8 @@ func (q *Queries) GetContinueWatchingAnime(ctx context.Context, arg GetContinueW
 			&i.Anime.Season,
 			&i.Anime.SeasonYear,
 			&i.Anime.GenresArr,
+			&i.Anime.MissingChecks,
+			&i.Anime.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -128,8 +135,8 @@ func (q *Queries) GetContinueWatchingAnimeCount(ctx context.Context, userID stri
 
 const getLibrary = `-- name: GetLibrary :many
 SELECT
-  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at,
-  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr
+  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at, library.score, library.started_at, library.completed_at, library.rewatches, library.notes,
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
 FROM
   library
   INNER JOIN animes ON animes.id = library.anime_id
@@ -175,6 +182,11 @@ func (q *Queries) GetLibrary(ctx context.Context, arg GetLibraryParams) ([]GetLi
 			&i.Library.WatchedEpisodes,
 			&i.Library.CreatedAt,
 			&i.Library.UpdatedAt,
+			&i.Library.Score,
+			&i.Library.StartedAt,
+			&i.Library.CompletedAt,
+			&i.Library.Rewatches,
+			&i.Library.Notes,
 			&i.Anime.ID,
 			&i.Anime.Ename,
 			&i.Anime.Jname,
@@ -190,6 +202,8 @@ func (q *Queries) GetLibrary(ctx context.Context, arg GetLibraryParams) ([]GetLi
 			&i.Anime.Season,
 			&i.Anime.SeasonYear,
 			&i.Anime.GenresArr,
+			&i.Anime.MissingChecks,
+			&i.Anime.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -203,8 +217,8 @@ func (q *Queries) GetLibrary(ctx context.Context, arg GetLibraryParams) ([]GetLi
 
 const getLibraryByID = `-- name: GetLibraryByID :one
 SELECT
-  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at,
-  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr
+  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at, library.score, library.started_at, library.completed_at, library.rewatches, library.notes,
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
 FROM
   library
   INNER JOIN animes ON animes.id = library.anime_id
@@ -228,6 +242,11 @@ func (q *Queries) GetLibraryByID(ctx context.Context, id string) (GetLibraryByID
 		&i.Library.WatchedEpisodes,
 		&i.Library.CreatedAt,
 		&i.Library.UpdatedAt,
+		&i.Library.Score,
+		&i.Library.StartedAt,
+		&i.Library.CompletedAt,
+		&i.Library.Rewatches,
+		&i.Library.Notes,
 		&i.Anime.ID,
 		&i.Anime.Ename,
 		&i.Anime.Jname,
@@ -243,6 +262,8 @@ func (q *Queries) GetLibraryByID(ctx context.Context, id string) (GetLibraryByID
 		&i.Anime.Season,
 		&i.Anime.SeasonYear,
 		&i.Anime.GenresArr,
+		&i.Anime.MissingChecks,
+		&i.Anime.UnavailableAt,
 	)
 	return i, err
 }
@@ -271,8 +292,8 @@ func (q *Queries) GetLibraryCount(ctx context.Context, arg GetLibraryCountParams
 
 const getLibraryOfUserByAnimeID = `-- name: GetLibraryOfUserByAnimeID :one
 SELECT
-  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at,
-  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr
+  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at, library.score, library.started_at, library.completed_at, library.rewatches, library.notes,
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
 FROM
   library
   INNER JOIN animes ON animes.id = library.anime_id
@@ -302,6 +323,11 @@ func (q *Queries) GetLibraryOfUserByAnimeID(ctx context.Context, arg GetLibraryO
 		&i.Library.WatchedEpisodes,
 		&i.Library.CreatedAt,
 		&i.Library.UpdatedAt,
+		&i.Library.Score,
+		&i.Library.StartedAt,
+		&i.Library.CompletedAt,
+		&i.Library.Rewatches,
+		&i.Library.Notes,
 		&i.Anime.ID,
 		&i.Anime.Ename,
 		&i.Anime.Jname,
@@ -317,14 +343,56 @@ func (q *Queries) GetLibraryOfUserByAnimeID(ctx context.Context, arg GetLibraryO
 		&i.Anime.Season,
 		&i.Anime.SeasonYear,
 		&i.Anime.GenresArr,
+		&i.Anime.MissingChecks,
+		&i.Anime.UnavailableAt,
+	)
+	return i, err
+}
+
+const getLibraryTransitionRules = `-- name: GetLibraryTransitionRules :one
+SELECT
+  coalesce(settings.auto_start_library, TRUE)::boolean AS auto_start,
+  coalesce(settings.auto_complete_library, TRUE)::boolean AS auto_complete,
+  coalesce(anime_metadata.total_episodes, 0)::integer AS total_episodes,
+  coalesce(anime_metadata.airing_status = 'finished_airing', FALSE)::boolean AS finished_airing
+FROM
+  animes
+  LEFT JOIN anime_metadata ON anime_metadata.mal_id = animes.mal_id
+  LEFT JOIN settings ON settings.user_id = $1
+WHERE
+  animes.id = $2
+`
+
+type GetLibraryTransitionRulesParams struct {
+	UserID  string
+	AnimeID string
+}
+
+type GetLibraryTransitionRulesRow struct {
+	AutoStart      bool
+	AutoComplete   bool
+	TotalEpisodes  int32
+	FinishedAiring bool
+}
+
+// Users without a settings row get the column defaults. total_episodes is
+// only known to be final once the series finished airing.
+func (q *Queries) GetLibraryTransitionRules(ctx context.Context, arg GetLibraryTransitionRulesParams) (GetLibraryTransitionRulesRow, error) {
+	row := q.db.QueryRow(ctx, getLibraryTransitionRules, arg.UserID, arg.AnimeID)
+	var i GetLibraryTransitionRulesRow
+	err := row.Scan(
+		&i.AutoStart,
+		&i.AutoComplete,
+		&i.TotalEpisodes,
+		&i.FinishedAiring,
 	)
 	return i, err
 }
 
 const getPlanToWatchAnime = `-- name: GetPlanToWatchAnime :many
 SELECT
-  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at,
-  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr
+  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at, library.score, library.started_at, library.completed_at, library.rewatches, library.notes,
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
 FROM
   library
   INNER JOIN animes ON animes.id = library.anime_id
@@ -365,6 +433,11 @@ func (q *Queries) GetPlanToWatchAnime(ctx context.Context, arg GetPlanToWatchAni
 			&i.Library.WatchedEpisodes,
 			&i.Library.CreatedAt,
 			&i.Library.UpdatedAt,
+			&i.Library.Score,
+			&i.Library.StartedAt,
+			&i.Library.CompletedAt,
+			&i.Library.Rewatches,
+			&i.Library.Notes,
 			&i.Anime.ID,
 			&i.Anime.Ename,
 			&i.Anime.Jname,
@@ -380,6 +453,8 @@ func (q *Queries) GetPlanToWatchAnime(ctx context.Context, arg GetPlanToWatchAni
 			&i.Anime.Season,
 			&i.Anime.SeasonYear,
 			&i.Anime.GenresArr,
+			&i.Anime.MissingChecks,
+			&i.Anime.UnavailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -411,8 +486,8 @@ func (q *Queries) GetPlanToWatchAnimeCount(ctx context.Context, userID string) (
 }
 
 const insertLibrary = `-- name: InsertLibrary :exec
-INSERT INTO library(user_id, anime_id, status, watched_episodes, updated_at)
-  VALUES ($1, $2, $3, $4, coalesce($5, NOW()))
+INSERT INTO library(user_id, anime_id, status, watched_episodes, score, started_at, completed_at, rewatches, notes, updated_at)
+  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, coalesce($10, NOW()))
 `
 
 type InsertLibraryParams struct {
@@ -420,6 +495,11 @@ type InsertLibraryParams struct {
 	AnimeID         string
 	Status          LibraryStatus
 	WatchedEpisodes int32
+	Score           pgtype.Int2
+	StartedAt       pgtype.Date
+	CompletedAt     pgtype.Date
+	Rewatches       int32
+	Notes           string
 	UpdatedAt       interface{}
 }
 
@@ -429,6 +509,11 @@ func (q *Queries) InsertLibrary(ctx context.Context, arg InsertLibraryParams) er
 		arg.AnimeID,
 		arg.Status,
 		arg.WatchedEpisodes,
+		arg.Score,
+		arg.StartedAt,
+		arg.CompletedAt,
+		arg.Rewatches,
+		arg.Notes,
 		arg.UpdatedAt,
 	)
 	return err
@@ -456,21 +541,112 @@ func (q *Queries) IsAnimeInLibrary(ctx context.Context, arg IsAnimeInLibraryPara
 	return column_1, err
 }
 
+const pauseInactiveLibraryEntries = `-- name: PauseInactiveLibraryEntries :many
+UPDATE
+  library
+SET
+  status = 'paused',
+  updated_at = NOW()
+FROM
+  settings
+WHERE
+  settings.user_id = library.user_id
+  AND settings.auto_pause_library
+  AND library.status = 'watching'
+  AND library.updated_at < NOW() - make_interval(days => settings.auto_pause_after_days)
+RETURNING
+  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at, library.score, library.started_at, library.completed_at, library.rewatches, library.notes
+`
+
+func (q *Queries) PauseInactiveLibraryEntries(ctx context.Context) ([]Library, error) {
+	rows, err := q.db.Query(ctx, pauseInactiveLibraryEntries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Library
+	for rows.Next() {
+		var i Library
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.AnimeID,
+			&i.Status,
+			&i.WatchedEpisodes,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Score,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.Rewatches,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const relinkLibraryEntries = `-- name: RelinkLibraryEntries :execrows
+UPDATE
+  library l
+SET
+  anime_id = $1
+WHERE
+  l.anime_id = $2
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      library l2
+    WHERE
+      l2.user_id = l.user_id
+      AND l2.anime_id = $1)
+`
+
+type RelinkLibraryEntriesParams struct {
+	ToAnimeID   string
+	FromAnimeID string
+}
+
+// updated_at is kept, the user did not change the entry.
+func (q *Queries) RelinkLibraryEntries(ctx context.Context, arg RelinkLibraryEntriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, relinkLibraryEntries, arg.ToAnimeID, arg.FromAnimeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateLibrary = `-- name: UpdateLibrary :exec
 UPDATE
   library
 SET
   status = $1,
   watched_episodes = $2,
-  updated_at = coalesce($3, NOW())
+  score = $3,
+  started_at = $4,
+  completed_at = $5,
+  rewatches = $6,
+  notes = $7,
+  updated_at = coalesce($8, NOW())
 WHERE
-  user_id = $4
-  AND anime_id = $5
+  user_id = $9
+  AND anime_id = $10
 `
 
 type UpdateLibraryParams struct {
 	Status          LibraryStatus
 	WatchedEpisodes int32
+	Score           pgtype.Int2
+	StartedAt       pgtype.Date
+	CompletedAt     pgtype.Date
+	Rewatches       int32
+	Notes           string
 	UpdatedAt       pgtype.Timestamp
 	UserID          string
 	AnimeID         string
@@ -480,6 +656,11 @@ func (q *Queries) UpdateLibrary(ctx context.Context, arg UpdateLibraryParams) er
 	_, err := q.db.Exec(ctx, updateLibrary,
 		arg.Status,
 		arg.WatchedEpisodes,
+		arg.Score,
+		arg.StartedAt,
+		arg.CompletedAt,
+		arg.Rewatches,
+		arg.Notes,
 		arg.UpdatedAt,
 		arg.UserID,
 		arg.AnimeID,
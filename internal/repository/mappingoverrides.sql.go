@@ -0,0 +1,262 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: mappingoverrides.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteAnimeMappingOverride = `-- name: DeleteAnimeMappingOverride :execrows
+DELETE FROM anime_mapping_overrides
+WHERE hi_anime_id = $1
+`
+
+func (q *Queries) DeleteAnimeMappingOverride(ctx context.Context, hiAnimeID string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAnimeMappingOverride, hiAnimeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAnimeMappingOverride = `-- name: GetAnimeMappingOverride :one
+SELECT
+  hi_anime_id, mal_id, anilist_id, season, season_year, ename, jname, note, updated_by, created_at, updated_at
+FROM
+  anime_mapping_overrides
+WHERE
+  hi_anime_id = $1
+`
+
+func (q *Queries) GetAnimeMappingOverride(ctx context.Context, hiAnimeID string) (AnimeMappingOverride, error) {
+	row := q.db.QueryRow(ctx, getAnimeMappingOverride, hiAnimeID)
+	var i AnimeMappingOverride
+	err := row.Scan(
+		&i.HiAnimeID,
+		&i.MalID,
+		&i.AnilistID,
+		&i.Season,
+		&i.SeasonYear,
+		&i.Ename,
+		&i.Jname,
+		&i.Note,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getAnimeMappingOverrideAudit = `-- name: GetAnimeMappingOverrideAudit :many
+SELECT
+  id, hi_anime_id, action, changed_by, previous, current, created_at
+FROM
+  anime_mapping_override_audit
+WHERE
+  hi_anime_id = $1
+ORDER BY
+  created_at DESC
+`
+
+func (q *Queries) GetAnimeMappingOverrideAudit(ctx context.Context, hiAnimeID string) ([]AnimeMappingOverrideAudit, error) {
+	rows, err := q.db.Query(ctx, getAnimeMappingOverrideAudit, hiAnimeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AnimeMappingOverrideAudit
+	for rows.Next() {
+		var i AnimeMappingOverrideAudit
+		if err := rows.Scan(
+			&i.ID,
+			&i.HiAnimeID,
+			&i.Action,
+			&i.ChangedBy,
+			&i.Previous,
+			&i.Current,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAnimeMappingOverridesByHiAnimeIds = `-- name: GetAnimeMappingOverridesByHiAnimeIds :many
+SELECT
+  hi_anime_id, mal_id, anilist_id, season, season_year, ename, jname, note, updated_by, created_at, updated_at
+FROM
+  anime_mapping_overrides
+WHERE
+  hi_anime_id = ANY ($1::text[])
+`
+
+func (q *Queries) GetAnimeMappingOverridesByHiAnimeIds(ctx context.Context, hiAnimeIds []string) ([]AnimeMappingOverride, error) {
+	rows, err := q.db.Query(ctx, getAnimeMappingOverridesByHiAnimeIds, hiAnimeIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AnimeMappingOverride
+	for rows.Next() {
+		var i AnimeMappingOverride
+		if err := rows.Scan(
+			&i.HiAnimeID,
+			&i.MalID,
+			&i.AnilistID,
+			&i.Season,
+			&i.SeasonYear,
+			&i.Ename,
+			&i.Jname,
+			&i.Note,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertAnimeMappingOverrideAudit = `-- name: InsertAnimeMappingOverrideAudit :exec
+INSERT INTO anime_mapping_override_audit(hi_anime_id, action, changed_by, previous, current)
+  VALUES ($1, $2, $3, $4, $5)
+`
+
+type InsertAnimeMappingOverrideAuditParams struct {
+	HiAnimeID string
+	Action    MappingOverrideAction
+	ChangedBy string
+	Previous  []byte
+	Current   []byte
+}
+
+func (q *Queries) InsertAnimeMappingOverrideAudit(ctx context.Context, arg InsertAnimeMappingOverrideAuditParams) error {
+	_, err := q.db.Exec(ctx, insertAnimeMappingOverrideAudit,
+		arg.HiAnimeID,
+		arg.Action,
+		arg.ChangedBy,
+		arg.Previous,
+		arg.Current,
+	)
+	return err
+}
+
+const listAnimeMappingOverrides = `-- name: ListAnimeMappingOverrides :many
+SELECT
+  hi_anime_id, mal_id, anilist_id, season, season_year, ename, jname, note, updated_by, created_at, updated_at
+FROM
+  anime_mapping_overrides
+ORDER BY
+  updated_at DESC
+LIMIT $2 OFFSET $1
+`
+
+type ListAnimeMappingOverridesParams struct {
+	OffsetCount int32
+	LimitCount  int32
+}
+
+func (q *Queries) ListAnimeMappingOverrides(ctx context.Context, arg ListAnimeMappingOverridesParams) ([]AnimeMappingOverride, error) {
+	rows, err := q.db.Query(ctx, listAnimeMappingOverrides, arg.OffsetCount, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AnimeMappingOverride
+	for rows.Next() {
+		var i AnimeMappingOverride
+		if err := rows.Scan(
+			&i.HiAnimeID,
+			&i.MalID,
+			&i.AnilistID,
+			&i.Season,
+			&i.SeasonYear,
+			&i.Ename,
+			&i.Jname,
+			&i.Note,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertAnimeMappingOverride = `-- name: UpsertAnimeMappingOverride :one
+INSERT INTO anime_mapping_overrides(hi_anime_id, mal_id, anilist_id, season, season_year, ename, jname, note, updated_by)
+  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (hi_anime_id)
+  DO UPDATE SET
+    mal_id = EXCLUDED.mal_id,
+    anilist_id = EXCLUDED.anilist_id,
+    season = EXCLUDED.season,
+    season_year = EXCLUDED.season_year,
+    ename = EXCLUDED.ename,
+    jname = EXCLUDED.jname,
+    note = EXCLUDED.note,
+    updated_by = EXCLUDED.updated_by
+  RETURNING
+    hi_anime_id, mal_id, anilist_id, season, season_year, ename, jname, note, updated_by, created_at, updated_at
+`
+
+type UpsertAnimeMappingOverrideParams struct {
+	HiAnimeID  string
+	MalID      pgtype.Int4
+	AnilistID  pgtype.Int4
+	Season     NullSeason
+	SeasonYear pgtype.Int4
+	Ename      pgtype.Text
+	Jname      pgtype.Text
+	Note       pgtype.Text
+	UpdatedBy  string
+}
+
+func (q *Queries) UpsertAnimeMappingOverride(ctx context.Context, arg UpsertAnimeMappingOverrideParams) (AnimeMappingOverride, error) {
+	row := q.db.QueryRow(ctx, upsertAnimeMappingOverride,
+		arg.HiAnimeID,
+		arg.MalID,
+		arg.AnilistID,
+		arg.Season,
+		arg.SeasonYear,
+		arg.Ename,
+		arg.Jname,
+		arg.Note,
+		arg.UpdatedBy,
+	)
+	var i AnimeMappingOverride
+	err := row.Scan(
+		&i.HiAnimeID,
+		&i.MalID,
+		&i.AnilistID,
+		&i.Season,
+		&i.SeasonYear,
+		&i.Ename,
+		&i.Jname,
+		&i.Note,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
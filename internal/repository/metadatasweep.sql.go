@@ -0,0 +1,177 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: metadatasweep.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const clearMetadataSweepFailure = `-- name: ClearMetadataSweepFailure :exec
+DELETE FROM anime_metadata_sweep_failures
+WHERE mal_id = $1
+`
+
+func (q *Queries) ClearMetadataSweepFailure(ctx context.Context, malID int32) error {
+	_, err := q.db.Exec(ctx, clearMetadataSweepFailure, malID)
+	return err
+}
+
+const getMetadataCoverage = `-- name: GetMetadataCoverage :one
+SELECT
+  COUNT(*)::bigint AS total,
+  COUNT(m.mal_id)::bigint AS with_metadata,
+  COUNT(*) FILTER (WHERE m.mal_id IS NULL)::bigint AS missing,
+  COUNT(*) FILTER (WHERE m.updated_at >= $1::timestamp)::bigint AS fresh,
+  COUNT(*) FILTER (WHERE m.updated_at < $1::timestamp)::bigint AS stale,
+  COUNT(*) FILTER (WHERE m.airing_status = 'currently_airing')::bigint AS airing,
+  COUNT(*) FILTER (WHERE m.airing_status = 'currently_airing'
+    AND m.updated_at < $2::timestamp)::bigint AS airing_stale,
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      anime_metadata_sweep_failures)::bigint AS failing,
+  MIN(m.updated_at)::timestamp AS oldest_updated_at
+FROM (
+  SELECT DISTINCT
+    a.mal_id
+  FROM
+    animes a
+  WHERE
+    a.mal_id IS NOT NULL
+    AND a.mal_id > 0
+    AND a.unavailable_at IS NULL) ids
+  LEFT JOIN anime_metadata m ON m.mal_id = ids.mal_id
+`
+
+type GetMetadataCoverageParams struct {
+	StaleBefore       pgtype.Timestamp
+	AiringStaleBefore pgtype.Timestamp
+}
+
+type GetMetadataCoverageRow struct {
+	Total           int64
+	WithMetadata    int64
+	Missing         int64
+	Fresh           int64
+	Stale           int64
+	Airing          int64
+	AiringStale     int64
+	Failing         int64
+	OldestUpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) GetMetadataCoverage(ctx context.Context, arg GetMetadataCoverageParams) (GetMetadataCoverageRow, error) {
+	row := q.db.QueryRow(ctx, getMetadataCoverage, arg.StaleBefore, arg.AiringStaleBefore)
+	var i GetMetadataCoverageRow
+	err := row.Scan(
+		&i.Total,
+		&i.WithMetadata,
+		&i.Missing,
+		&i.Fresh,
+		&i.Stale,
+		&i.Airing,
+		&i.AiringStale,
+		&i.Failing,
+		&i.OldestUpdatedAt,
+	)
+	return i, err
+}
+
+const getMetadataSweepCandidates = `-- name: GetMetadataSweepCandidates :many
+SELECT
+  a.mal_id::int AS mal_id,
+  (m.mal_id IS NULL)::boolean AS missing
+FROM
+  animes a
+  LEFT JOIN anime_metadata m ON m.mal_id = a.mal_id
+  LEFT JOIN anime_metadata_sweep_failures f ON f.mal_id = a.mal_id
+  LEFT JOIN (
+    SELECT
+      l.anime_id,
+      COUNT(*) AS library_count
+    FROM
+      library l
+    GROUP BY
+      l.anime_id) lc ON lc.anime_id = a.id
+WHERE
+  a.mal_id IS NOT NULL
+  AND a.mal_id > 0
+  AND a.unavailable_at IS NULL
+  AND (f.mal_id IS NULL
+    OR f.next_attempt_at <= NOW())
+  AND (m.mal_id IS NULL
+    OR m.updated_at < $1::timestamp
+    OR (m.airing_status = 'currently_airing'
+      AND m.updated_at < $2::timestamp))
+GROUP BY
+  a.mal_id,
+  m.mal_id,
+  m.airing_status,
+  m.popularity
+ORDER BY
+  (m.airing_status = 'currently_airing') DESC NULLS LAST,
+  SUM(COALESCE(lc.library_count, 0)) DESC,
+  m.popularity ASC NULLS LAST
+LIMIT $3
+`
+
+type GetMetadataSweepCandidatesParams struct {
+	StaleBefore       pgtype.Timestamp
+	AiringStaleBefore pgtype.Timestamp
+	LimitCount        int32
+}
+
+type GetMetadataSweepCandidatesRow struct {
+	MalID   int32
+	Missing bool
+}
+
+// MAL IDs whose metadata is missing or older than the TTL (airing titles use
+// a shorter one), airing and library-referenced titles first, then by
+// popularity. IDs that recently failed wait for their backoff.
+func (q *Queries) GetMetadataSweepCandidates(ctx context.Context, arg GetMetadataSweepCandidatesParams) ([]GetMetadataSweepCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, getMetadataSweepCandidates, arg.StaleBefore, arg.AiringStaleBefore, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMetadataSweepCandidatesRow
+	for rows.Next() {
+		var i GetMetadataSweepCandidatesRow
+		if err := rows.Scan(&i.MalID, &i.Missing); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordMetadataSweepFailure = `-- name: RecordMetadataSweepFailure :exec
+INSERT INTO anime_metadata_sweep_failures(mal_id, last_error, next_attempt_at)
+  VALUES ($1, $2, NOW() + $3::int * INTERVAL '1 hour')
+ON CONFLICT (mal_id)
+  DO UPDATE SET
+    attempts = anime_metadata_sweep_failures.attempts + 1,
+    last_error = EXCLUDED.last_error,
+    next_attempt_at = NOW() + LEAST($3::int * POWER(2, anime_metadata_sweep_failures.attempts), 24 * 30) * INTERVAL '1 hour'
+`
+
+type RecordMetadataSweepFailureParams struct {
+	MalID        int32
+	LastError    string
+	BackoffHours int32
+}
+
+func (q *Queries) RecordMetadataSweepFailure(ctx context.Context, arg RecordMetadataSweepFailureParams) error {
+	_, err := q.db.Exec(ctx, recordMetadataSweepFailure, arg.MalID, arg.LastError, arg.BackoffHours)
+	return err
+}
@@ -55,6 +55,49 @@ func (ns NullAiringStatus) Value() (driver.Value, error) {
 	return string(ns.AiringStatus), nil
 }
 
+type CollectionVisibility string
+
+const (
+	CollectionVisibilityPrivate  CollectionVisibility = "private"
+	CollectionVisibilityUnlisted CollectionVisibility = "unlisted"
+	CollectionVisibilityPublic   CollectionVisibility = "public"
+)
+
+func (e *CollectionVisibility) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CollectionVisibility(s)
+	case string:
+		*e = CollectionVisibility(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CollectionVisibility: %T", src)
+	}
+	return nil
+}
+
+type NullCollectionVisibility struct {
+	CollectionVisibility CollectionVisibility
+	Valid                bool // Valid is true if CollectionVisibility is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCollectionVisibility) Scan(value interface{}) error {
+	if value == nil {
+		ns.CollectionVisibility, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CollectionVisibility.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCollectionVisibility) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CollectionVisibility), nil
+}
+
 type DesktopPlatform string
 
 const (
@@ -101,6 +144,135 @@ func (ns NullDesktopPlatform) Value() (driver.Value, error) {
 	return string(ns.DesktopPlatform), nil
 }
 
+type JobRunStatus string
+
+const (
+	JobRunStatusRunning   JobRunStatus = "running"
+	JobRunStatusSucceeded JobRunStatus = "succeeded"
+	JobRunStatusFailed    JobRunStatus = "failed"
+)
+
+func (e *JobRunStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = JobRunStatus(s)
+	case string:
+		*e = JobRunStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for JobRunStatus: %T", src)
+	}
+	return nil
+}
+
+type NullJobRunStatus struct {
+	JobRunStatus JobRunStatus
+	Valid        bool // Valid is true if JobRunStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullJobRunStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.JobRunStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.JobRunStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullJobRunStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.JobRunStatus), nil
+}
+
+type JobRunTrigger string
+
+const (
+	JobRunTriggerSchedule JobRunTrigger = "schedule"
+	JobRunTriggerManual   JobRunTrigger = "manual"
+)
+
+func (e *JobRunTrigger) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = JobRunTrigger(s)
+	case string:
+		*e = JobRunTrigger(s)
+	default:
+		return fmt.Errorf("unsupported scan type for JobRunTrigger: %T", src)
+	}
+	return nil
+}
+
+type NullJobRunTrigger struct {
+	JobRunTrigger JobRunTrigger
+	Valid         bool // Valid is true if JobRunTrigger is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullJobRunTrigger) Scan(value interface{}) error {
+	if value == nil {
+		ns.JobRunTrigger, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.JobRunTrigger.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullJobRunTrigger) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.JobRunTrigger), nil
+}
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusDead      JobStatus = "dead"
+)
+
+func (e *JobStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = JobStatus(s)
+	case string:
+		*e = JobStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for JobStatus: %T", src)
+	}
+	return nil
+}
+
+type NullJobStatus struct {
+	JobStatus JobStatus
+	Valid     bool // Valid is true if JobStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullJobStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.JobStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.JobStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullJobStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.JobStatus), nil
+}
+
 type LibraryActions string
 
 const (
@@ -145,6 +317,92 @@ func (ns NullLibraryActions) Value() (driver.Value, error) {
 	return string(ns.LibraryActions), nil
 }
 
+type LibraryActivityType string
+
+const (
+	LibraryActivityTypeAdded      LibraryActivityType = "added"
+	LibraryActivityTypeProgressed LibraryActivityType = "progressed"
+	LibraryActivityTypeCompleted  LibraryActivityType = "completed"
+	LibraryActivityTypeScored     LibraryActivityType = "scored"
+)
+
+func (e *LibraryActivityType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LibraryActivityType(s)
+	case string:
+		*e = LibraryActivityType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LibraryActivityType: %T", src)
+	}
+	return nil
+}
+
+type NullLibraryActivityType struct {
+	LibraryActivityType LibraryActivityType
+	Valid               bool // Valid is true if LibraryActivityType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLibraryActivityType) Scan(value interface{}) error {
+	if value == nil {
+		ns.LibraryActivityType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LibraryActivityType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLibraryActivityType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LibraryActivityType), nil
+}
+
+type LibraryImportIssue string
+
+const (
+	LibraryImportIssueUnmatched LibraryImportIssue = "unmatched"
+	LibraryImportIssueAmbiguous LibraryImportIssue = "ambiguous"
+)
+
+func (e *LibraryImportIssue) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LibraryImportIssue(s)
+	case string:
+		*e = LibraryImportIssue(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LibraryImportIssue: %T", src)
+	}
+	return nil
+}
+
+type NullLibraryImportIssue struct {
+	LibraryImportIssue LibraryImportIssue
+	Valid              bool // Valid is true if LibraryImportIssue is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLibraryImportIssue) Scan(value interface{}) error {
+	if value == nil {
+		ns.LibraryImportIssue, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LibraryImportIssue.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLibraryImportIssue) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LibraryImportIssue), nil
+}
+
 type LibraryImportStatus string
 
 const (
@@ -189,6 +447,50 @@ func (ns NullLibraryImportStatus) Value() (driver.Value, error) {
 	return string(ns.LibraryImportStatus), nil
 }
 
+type LibraryImportStrategy string
+
+const (
+	LibraryImportStrategyRemote      LibraryImportStrategy = "remote"
+	LibraryImportStrategyLocal       LibraryImportStrategy = "local"
+	LibraryImportStrategyNewest      LibraryImportStrategy = "newest"
+	LibraryImportStrategyMaxProgress LibraryImportStrategy = "max_progress"
+)
+
+func (e *LibraryImportStrategy) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LibraryImportStrategy(s)
+	case string:
+		*e = LibraryImportStrategy(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LibraryImportStrategy: %T", src)
+	}
+	return nil
+}
+
+type NullLibraryImportStrategy struct {
+	LibraryImportStrategy LibraryImportStrategy
+	Valid                 bool // Valid is true if LibraryImportStrategy is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLibraryImportStrategy) Scan(value interface{}) error {
+	if value == nil {
+		ns.LibraryImportStrategy, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LibraryImportStrategy.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLibraryImportStrategy) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LibraryImportStrategy), nil
+}
+
 type LibraryStatus string
 
 const (
@@ -241,6 +543,7 @@ const (
 	LibrarySyncStatusSuccess LibrarySyncStatus = "success"
 	LibrarySyncStatusFailed  LibrarySyncStatus = "failed"
 	LibrarySyncStatusSkipped LibrarySyncStatus = "skipped"
+	LibrarySyncStatusDead    LibrarySyncStatus = "dead"
 )
 
 func (e *LibrarySyncStatus) Scan(src interface{}) error {
@@ -278,11 +581,98 @@ func (ns NullLibrarySyncStatus) Value() (driver.Value, error) {
 	return string(ns.LibrarySyncStatus), nil
 }
 
+type MappingOverrideAction string
+
+const (
+	MappingOverrideActionUpsert MappingOverrideAction = "upsert"
+	MappingOverrideActionDelete MappingOverrideAction = "delete"
+)
+
+func (e *MappingOverrideAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MappingOverrideAction(s)
+	case string:
+		*e = MappingOverrideAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MappingOverrideAction: %T", src)
+	}
+	return nil
+}
+
+type NullMappingOverrideAction struct {
+	MappingOverrideAction MappingOverrideAction
+	Valid                 bool // Valid is true if MappingOverrideAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMappingOverrideAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.MappingOverrideAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MappingOverrideAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMappingOverrideAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MappingOverrideAction), nil
+}
+
+type MappingSuggestionStatus string
+
+const (
+	MappingSuggestionStatusPending  MappingSuggestionStatus = "pending"
+	MappingSuggestionStatusApproved MappingSuggestionStatus = "approved"
+	MappingSuggestionStatusRejected MappingSuggestionStatus = "rejected"
+)
+
+func (e *MappingSuggestionStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MappingSuggestionStatus(s)
+	case string:
+		*e = MappingSuggestionStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MappingSuggestionStatus: %T", src)
+	}
+	return nil
+}
+
+type NullMappingSuggestionStatus struct {
+	MappingSuggestionStatus MappingSuggestionStatus
+	Valid                   bool // Valid is true if MappingSuggestionStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMappingSuggestionStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.MappingSuggestionStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MappingSuggestionStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMappingSuggestionStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MappingSuggestionStatus), nil
+}
+
 type Provider string
 
 const (
 	ProviderMyanimelist Provider = "myanimelist"
 	ProviderAnilist     Provider = "anilist"
+	ProviderKitsu       Provider = "kitsu"
+	ProviderShikimori   Provider = "shikimori"
 )
 
 func (e *Provider) Scan(src interface{}) error {
@@ -412,22 +802,199 @@ func (ns NullSeason) Value() (driver.Value, error) {
 	return string(ns.Season), nil
 }
 
+type SeedRunKind string
+
+const (
+	SeedRunKindFullSeed        SeedRunKind = "full_seed"
+	SeedRunKindRecentlyUpdated SeedRunKind = "recently_updated"
+)
+
+func (e *SeedRunKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SeedRunKind(s)
+	case string:
+		*e = SeedRunKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SeedRunKind: %T", src)
+	}
+	return nil
+}
+
+type NullSeedRunKind struct {
+	SeedRunKind SeedRunKind
+	Valid       bool // Valid is true if SeedRunKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSeedRunKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.SeedRunKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SeedRunKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSeedRunKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SeedRunKind), nil
+}
+
+type SeedRunPhase string
+
+const (
+	SeedRunPhaseAzList          SeedRunPhase = "az_list"
+	SeedRunPhaseRecentlyUpdated SeedRunPhase = "recently_updated"
+)
+
+func (e *SeedRunPhase) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SeedRunPhase(s)
+	case string:
+		*e = SeedRunPhase(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SeedRunPhase: %T", src)
+	}
+	return nil
+}
+
+type NullSeedRunPhase struct {
+	SeedRunPhase SeedRunPhase
+	Valid        bool // Valid is true if SeedRunPhase is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSeedRunPhase) Scan(value interface{}) error {
+	if value == nil {
+		ns.SeedRunPhase, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SeedRunPhase.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSeedRunPhase) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SeedRunPhase), nil
+}
+
+type SeedRunStatus string
+
+const (
+	SeedRunStatusRunning   SeedRunStatus = "running"
+	SeedRunStatusCompleted SeedRunStatus = "completed"
+	SeedRunStatusFailed    SeedRunStatus = "failed"
+)
+
+func (e *SeedRunStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SeedRunStatus(s)
+	case string:
+		*e = SeedRunStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SeedRunStatus: %T", src)
+	}
+	return nil
+}
+
+type NullSeedRunStatus struct {
+	SeedRunStatus SeedRunStatus
+	Valid         bool // Valid is true if SeedRunStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSeedRunStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.SeedRunStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SeedRunStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSeedRunStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SeedRunStatus), nil
+}
+
 type Anime struct {
+	ID            string
+	Ename         string
+	Jname         string
+	ImageUrl      string
+	Genre         string
+	HiAnimeID     string
+	MalID         pgtype.Int4
+	AnilistID     pgtype.Int4
+	LastEpisode   int32
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	SearchVector  string
+	Season        Season
+	SeasonYear    int32
+	GenresArr     []string
+	MissingChecks int32
+	UnavailableAt pgtype.Timestamp
+}
+
+type AnimeMappingOverride struct {
+	HiAnimeID  string
+	MalID      pgtype.Int4
+	AnilistID  pgtype.Int4
+	Season     NullSeason
+	SeasonYear pgtype.Int4
+	Ename      pgtype.Text
+	Jname      pgtype.Text
+	Note       pgtype.Text
+	UpdatedBy  string
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+}
+
+type AnimeMappingOverrideAudit struct {
+	ID        string
+	HiAnimeID string
+	Action    MappingOverrideAction
+	ChangedBy string
+	Previous  []byte
+	Current   []byte
+	CreatedAt pgtype.Timestamp
+}
+
+type AnimeMappingSuggestion struct {
 	ID           string
-	Ename        string
-	Jname        string
-	ImageUrl     string
-	Genre        string
-	HiAnimeID    string
+	AnimeID      string
 	MalID        pgtype.Int4
 	AnilistID    pgtype.Int4
-	LastEpisode  int32
+	Confidence   float32
+	Method       string
+	MatchedTitle string
+	Status       MappingSuggestionStatus
+	ReviewedBy   pgtype.Text
 	CreatedAt    pgtype.Timestamp
 	UpdatedAt    pgtype.Timestamp
-	SearchVector string
-	Season       Season
-	SeasonYear   int32
-	GenresArr    []string
+	ReviewedAt   pgtype.Timestamp
+}
+
+type AnimeMetadataSweepFailure struct {
+	MalID         int32
+	Attempts      int32
+	LastError     string
+	NextAttemptAt pgtype.Timestamp
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
 }
 
 type AnimeMetadatum struct {
@@ -454,6 +1021,39 @@ type AnimeMetadatum struct {
 	UpdatedAt          pgtype.Timestamp
 }
 
+type AnimeOfflineMapping struct {
+	ID         int64
+	Title      string
+	Synonyms   []string
+	MediaType  pgtype.Text
+	Episodes   pgtype.Int4
+	Season     NullSeason
+	SeasonYear pgtype.Int4
+	MalID      pgtype.Int4
+	AnilistID  pgtype.Int4
+	KitsuID    pgtype.Int4
+	AnidbID    pgtype.Int4
+}
+
+type Collection struct {
+	ID          string
+	UserID      string
+	Slug        string
+	Name        string
+	Description string
+	Visibility  CollectionVisibility
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+type CollectionItem struct {
+	CollectionID string
+	AnimeID      string
+	Position     int32
+	Note         string
+	CreatedAt    pgtype.Timestamp
+}
+
 type DesktopRelease struct {
 	ID           string
 	Version      string
@@ -466,12 +1066,62 @@ type DesktopRelease struct {
 }
 
 type ExternalLibrarySync struct {
-	UserID    string
-	AnimeID   string
-	Provider  Provider
-	Action    LibraryActions
-	Payload   []byte
-	Status    LibrarySyncStatus
+	UserID        string
+	AnimeID       string
+	Provider      Provider
+	Action        LibraryActions
+	Payload       []byte
+	Status        LibrarySyncStatus
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	Attempts      int32
+	LastError     pgtype.Text
+	NextAttemptAt pgtype.Timestamp
+	Revision      int32
+}
+
+type Follow struct {
+	FollowerID string
+	FolloweeID string
+	Muted      bool
+	CreatedAt  pgtype.Timestamp
+}
+
+type Job struct {
+	ID          string
+	Queue       string
+	DedupeKey   pgtype.Text
+	Payload     []byte
+	Status      JobStatus
+	Attempts    int32
+	MaxAttempts int32
+	RunAt       pgtype.Timestamp
+	LockedBy    pgtype.Text
+	LockedUntil pgtype.Timestamp
+	LastError   pgtype.Text
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	CompletedAt pgtype.Timestamp
+}
+
+type JobRun struct {
+	ID         string
+	JobName    string
+	Trigger    JobRunTrigger
+	Status     JobRunStatus
+	Holder     string
+	ErrorCount int32
+	WarnCount  int32
+	LogSummary pgtype.Text
+	StartedAt  pgtype.Timestamp
+	FinishedAt pgtype.Timestamp
+	DurationMs pgtype.Int8
+}
+
+type JobSchedule struct {
+	JobName   string
+	Paused    bool
+	UpdatedBy pgtype.Text
 	CreatedAt pgtype.Timestamp
 	UpdatedAt pgtype.Timestamp
 }
@@ -484,6 +1134,32 @@ type Library struct {
 	WatchedEpisodes int32
 	CreatedAt       pgtype.Timestamp
 	UpdatedAt       pgtype.Timestamp
+	Score           pgtype.Int2
+	StartedAt       pgtype.Date
+	CompletedAt     pgtype.Date
+	Rewatches       int32
+	Notes           string
+}
+
+type LibraryActivity struct {
+	ID              string
+	UserID          string
+	AnimeID         string
+	Type            LibraryActivityType
+	WatchedEpisodes int32
+	Score           pgtype.Int2
+	CreatedAt       pgtype.Timestamp
+}
+
+type LibraryImportChange struct {
+	ID                   int64
+	JobID                string
+	AnimeID              string
+	LocalStatus          NullLibraryStatus
+	LocalWatchedEpisodes pgtype.Int4
+	Status               LibraryStatus
+	WatchedEpisodes      int32
+	CreatedAt            pgtype.Timestamp
 }
 
 type LibraryImportJob struct {
@@ -495,6 +1171,21 @@ type LibraryImportJob struct {
 	CreatedAt    pgtype.Timestamp
 	UpdatedAt    pgtype.Timestamp
 	CompletedAt  pgtype.Timestamp
+	MatchedCount int32
+	Strategy     LibraryImportStrategy
+	DryRun       bool
+}
+
+type LibraryImportReportItem struct {
+	ID           int64
+	JobID        string
+	Issue        LibraryImportIssue
+	ExternalID   string
+	Title        string
+	MalID        pgtype.Int4
+	AnilistID    pgtype.Int4
+	CandidateIds []string
+	CreatedAt    pgtype.Timestamp
 }
 
 type OauthToken struct {
@@ -505,6 +1196,7 @@ type OauthToken struct {
 	Provider     Provider
 	ExpiresAt    pgtype.Timestamp
 	CreatedAt    pgtype.Timestamp
+	NeedsReauth  bool
 }
 
 type ResetPasswordToken struct {
@@ -514,6 +1206,37 @@ type ResetPasswordToken struct {
 	ExpiresAt pgtype.Timestamp
 }
 
+type SeedRun struct {
+	ID           string
+	Kind         SeedRunKind
+	Phase        SeedRunPhase
+	Status       SeedRunStatus
+	NextPage     int32
+	TotalPages   int32
+	ErrorMessage pgtype.Text
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+	CompletedAt  pgtype.Timestamp
+}
+
+type SeedRunError struct {
+	SeedRunID    string
+	HiAnimeID    string
+	Phase        SeedRunPhase
+	ErrorMessage string
+	CreatedAt    pgtype.Timestamp
+}
+
+type SeedRunQuarantinedPage struct {
+	SeedRunID    string
+	Phase        SeedRunPhase
+	Page         int32
+	Attempts     int32
+	ErrorMessage string
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+}
+
 type Session struct {
 	ID        string
 	UserID    string
@@ -522,12 +1245,21 @@ type Session struct {
 }
 
 type Setting struct {
-	UserID            string
-	AutoNextEpisode   bool
-	AutoPlayEpisode   bool
-	AutoResumeEpisode bool
-	IncognitoMode     bool
-	ThemeID           int32
+	UserID               string
+	AutoNextEpisode      bool
+	AutoPlayEpisode      bool
+	AutoResumeEpisode    bool
+	IncognitoMode        bool
+	ThemeID              int32
+	AutoStartLibrary     bool
+	AutoCompleteLibrary  bool
+	AutoPauseLibrary     bool
+	AutoPauseAfterDays   int32
+	PublicProfile        bool
+	ProfileShowLibrary   bool
+	ProfileShowStats     bool
+	ProfileShowFavorites bool
+	ProfileShowActivity  bool
 }
 
 type Theme struct {
@@ -548,3 +1280,11 @@ type User struct {
 	CreatedAt      pgtype.Timestamp
 	UpdatedAt      pgtype.Timestamp
 }
+
+type WorkerLease struct {
+	Name       string
+	Holder     string
+	ExpiresAt  pgtype.Timestamp
+	AcquiredAt pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+}
@@ -29,7 +29,7 @@ func (q *Queries) DeleteOauthToken(ctx context.Context, arg DeleteOauthTokenPara
 
 const getAllOauthTokensOfUser = `-- name: GetAllOauthTokensOfUser :many
 SELECT
-  id, user_id, token, refresh_token, provider, expires_at, created_at
+  id, user_id, token, refresh_token, provider, expires_at, created_at, needs_reauth
 FROM
   oauth_tokens
 WHERE
@@ -53,6 +53,7 @@ func (q *Queries) GetAllOauthTokensOfUser(ctx context.Context, userID string) ([
 			&i.Provider,
 			&i.ExpiresAt,
 			&i.CreatedAt,
+			&i.NeedsReauth,
 		); err != nil {
 			return nil, err
 		}
@@ -66,7 +67,7 @@ func (q *Queries) GetAllOauthTokensOfUser(ctx context.Context, userID string) ([
 
 const getToken = `-- name: GetToken :one
 SELECT
-  id, user_id, token, refresh_token, provider, expires_at, created_at
+  id, user_id, token, refresh_token, provider, expires_at, created_at, needs_reauth
 FROM
   oauth_tokens
 WHERE
@@ -90,18 +91,20 @@ func (q *Queries) GetToken(ctx context.Context, arg GetTokenParams) (OauthToken,
 		&i.Provider,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.NeedsReauth,
 	)
 	return i, err
 }
 
 const getTokensNearToExpiry = `-- name: GetTokensNearToExpiry :many
 SELECT
-  id, user_id, token, refresh_token, provider, expires_at, created_at
+  id, user_id, token, refresh_token, provider, expires_at, created_at, needs_reauth
 FROM
   oauth_tokens
 WHERE
   expires_at <= NOW() + INTERVAL '10 days'
   AND expires_at > NOW()
+  AND NOT needs_reauth
 `
 
 func (q *Queries) GetTokensNearToExpiry(ctx context.Context) ([]OauthToken, error) {
@@ -121,6 +124,7 @@ func (q *Queries) GetTokensNearToExpiry(ctx context.Context) ([]OauthToken, erro
 			&i.Provider,
 			&i.ExpiresAt,
 			&i.CreatedAt,
+			&i.NeedsReauth,
 		); err != nil {
 			return nil, err
 		}
@@ -132,6 +136,26 @@ func (q *Queries) GetTokensNearToExpiry(ctx context.Context) ([]OauthToken, erro
 	return items, nil
 }
 
+const markOauthTokenNeedsReauth = `-- name: MarkOauthTokenNeedsReauth :exec
+UPDATE
+  oauth_tokens
+SET
+  needs_reauth = TRUE
+WHERE
+  user_id = $1
+  AND provider = $2
+`
+
+type MarkOauthTokenNeedsReauthParams struct {
+	UserID   string
+	Provider Provider
+}
+
+func (q *Queries) MarkOauthTokenNeedsReauth(ctx context.Context, arg MarkOauthTokenNeedsReauthParams) error {
+	_, err := q.db.Exec(ctx, markOauthTokenNeedsReauth, arg.UserID, arg.Provider)
+	return err
+}
+
 const saveOauthToken = `-- name: SaveOauthToken :exec
 INSERT INTO oauth_tokens(user_id, token, refresh_token, provider, expires_at)
   VALUES ($1, $2, $3, $4, $5)
@@ -162,7 +186,8 @@ UPDATE
 SET
   token = $1,
   refresh_token = $2,
-  expires_at = $3
+  expires_at = $3,
+  needs_reauth = FALSE
 WHERE
   user_id = $4
   AND provider = $5
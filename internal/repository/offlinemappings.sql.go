@@ -0,0 +1,436 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: offlinemappings.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteAllAnimeOfflineMappings = `-- name: DeleteAllAnimeOfflineMappings :exec
+DELETE FROM anime_offline_mappings
+`
+
+func (q *Queries) DeleteAllAnimeOfflineMappings(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteAllAnimeOfflineMappings)
+	return err
+}
+
+const findOfflineMappingCandidates = `-- name: FindOfflineMappingCandidates :many
+SELECT
+  m.id, m.title, m.synonyms, m.media_type, m.episodes, m.season, m.season_year, m.mal_id, m.anilist_id, m.kitsu_id, m.anidb_id,
+  GREATEST(similarity(m.title, $1::text), similarity(m.title, $2::text), COALESCE((
+      SELECT
+        MAX(GREATEST(similarity(s, $1::text), similarity(s, $2::text)))
+      FROM unnest(m.synonyms) AS s), 0))::real AS score
+FROM
+  anime_offline_mappings m
+WHERE
+  m.mal_id IS NOT NULL
+  AND (m.title % $1::text
+    OR m.title % $2::text)
+ORDER BY
+  score DESC
+LIMIT 5
+`
+
+type FindOfflineMappingCandidatesParams struct {
+	Ename string
+	Jname string
+}
+
+type FindOfflineMappingCandidatesRow struct {
+	ID         int64
+	Title      string
+	Synonyms   []string
+	MediaType  pgtype.Text
+	Episodes   pgtype.Int4
+	Season     NullSeason
+	SeasonYear pgtype.Int4
+	MalID      pgtype.Int4
+	AnilistID  pgtype.Int4
+	KitsuID    pgtype.Int4
+	AnidbID    pgtype.Int4
+	Score      float32
+}
+
+func (q *Queries) FindOfflineMappingCandidates(ctx context.Context, arg FindOfflineMappingCandidatesParams) ([]FindOfflineMappingCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, findOfflineMappingCandidates, arg.Ename, arg.Jname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindOfflineMappingCandidatesRow
+	for rows.Next() {
+		var i FindOfflineMappingCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Synonyms,
+			&i.MediaType,
+			&i.Episodes,
+			&i.Season,
+			&i.SeasonYear,
+			&i.MalID,
+			&i.AnilistID,
+			&i.KitsuID,
+			&i.AnidbID,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAnimeMappingSuggestion = `-- name: GetAnimeMappingSuggestion :one
+SELECT
+  s.id, s.anime_id, s.mal_id, s.anilist_id, s.confidence, s.method, s.matched_title, s.status, s.reviewed_by, s.created_at, s.updated_at, s.reviewed_at,
+  a.hi_anime_id
+FROM
+  anime_mapping_suggestions s
+  JOIN animes a ON a.id = s.anime_id
+WHERE
+  s.id = $1
+`
+
+type GetAnimeMappingSuggestionRow struct {
+	AnimeMappingSuggestion AnimeMappingSuggestion
+	HiAnimeID              string
+}
+
+func (q *Queries) GetAnimeMappingSuggestion(ctx context.Context, id string) (GetAnimeMappingSuggestionRow, error) {
+	row := q.db.QueryRow(ctx, getAnimeMappingSuggestion, id)
+	var i GetAnimeMappingSuggestionRow
+	err := row.Scan(
+		&i.AnimeMappingSuggestion.ID,
+		&i.AnimeMappingSuggestion.AnimeID,
+		&i.AnimeMappingSuggestion.MalID,
+		&i.AnimeMappingSuggestion.AnilistID,
+		&i.AnimeMappingSuggestion.Confidence,
+		&i.AnimeMappingSuggestion.Method,
+		&i.AnimeMappingSuggestion.MatchedTitle,
+		&i.AnimeMappingSuggestion.Status,
+		&i.AnimeMappingSuggestion.ReviewedBy,
+		&i.AnimeMappingSuggestion.CreatedAt,
+		&i.AnimeMappingSuggestion.UpdatedAt,
+		&i.AnimeMappingSuggestion.ReviewedAt,
+		&i.HiAnimeID,
+	)
+	return i, err
+}
+
+const getAnimeOfflineMappingCount = `-- name: GetAnimeOfflineMappingCount :one
+SELECT
+  COUNT(*)
+FROM
+  anime_offline_mappings
+`
+
+func (q *Queries) GetAnimeOfflineMappingCount(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, getAnimeOfflineMappingCount)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getAnimesMissingExternalIds = `-- name: GetAnimesMissingExternalIds :many
+SELECT
+  a.id, a.ename, a.jname, a.image_url, a.genre, a.hi_anime_id, a.mal_id, a.anilist_id, a.last_episode, a.created_at, a.updated_at, a.search_vector, a.season, a.season_year, a.genres_arr, a.missing_checks, a.unavailable_at
+FROM
+  animes a
+WHERE
+  a.unavailable_at IS NULL
+  AND (a.mal_id IS NULL
+    OR a.mal_id = 0
+    OR a.anilist_id IS NULL
+    OR a.anilist_id = 0)
+  -- admin pinned mappings always win
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      anime_mapping_overrides o
+    WHERE
+      o.hi_anime_id = a.hi_anime_id)
+  -- already queued or reviewed
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      anime_mapping_suggestions s
+    WHERE
+      s.anime_id = a.id)
+ORDER BY
+  a.updated_at DESC
+`
+
+func (q *Queries) GetAnimesMissingExternalIds(ctx context.Context) ([]Anime, error) {
+	rows, err := q.db.Query(ctx, getAnimesMissingExternalIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Anime
+	for rows.Next() {
+		var i Anime
+		if err := rows.Scan(
+			&i.ID,
+			&i.Ename,
+			&i.Jname,
+			&i.ImageUrl,
+			&i.Genre,
+			&i.HiAnimeID,
+			&i.MalID,
+			&i.AnilistID,
+			&i.LastEpisode,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SearchVector,
+			&i.Season,
+			&i.SeasonYear,
+			&i.GenresArr,
+			&i.MissingChecks,
+			&i.UnavailableAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOfflineMappingByAnilistId = `-- name: GetOfflineMappingByAnilistId :one
+SELECT
+  id, title, synonyms, media_type, episodes, season, season_year, mal_id, anilist_id, kitsu_id, anidb_id
+FROM
+  anime_offline_mappings
+WHERE
+  anilist_id = $1
+  AND mal_id IS NOT NULL
+LIMIT 1
+`
+
+func (q *Queries) GetOfflineMappingByAnilistId(ctx context.Context, anilistID pgtype.Int4) (AnimeOfflineMapping, error) {
+	row := q.db.QueryRow(ctx, getOfflineMappingByAnilistId, anilistID)
+	var i AnimeOfflineMapping
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Synonyms,
+		&i.MediaType,
+		&i.Episodes,
+		&i.Season,
+		&i.SeasonYear,
+		&i.MalID,
+		&i.AnilistID,
+		&i.KitsuID,
+		&i.AnidbID,
+	)
+	return i, err
+}
+
+const getOfflineMappingByKitsuId = `-- name: GetOfflineMappingByKitsuId :one
+SELECT
+  id, title, synonyms, media_type, episodes, season, season_year, mal_id, anilist_id, kitsu_id, anidb_id
+FROM
+  anime_offline_mappings
+WHERE
+  kitsu_id = $1
+  AND mal_id IS NOT NULL
+LIMIT 1
+`
+
+func (q *Queries) GetOfflineMappingByKitsuId(ctx context.Context, kitsuID pgtype.Int4) (AnimeOfflineMapping, error) {
+	row := q.db.QueryRow(ctx, getOfflineMappingByKitsuId, kitsuID)
+	var i AnimeOfflineMapping
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Synonyms,
+		&i.MediaType,
+		&i.Episodes,
+		&i.Season,
+		&i.SeasonYear,
+		&i.MalID,
+		&i.AnilistID,
+		&i.KitsuID,
+		&i.AnidbID,
+	)
+	return i, err
+}
+
+const getOfflineMappingByMalId = `-- name: GetOfflineMappingByMalId :one
+SELECT
+  id, title, synonyms, media_type, episodes, season, season_year, mal_id, anilist_id, kitsu_id, anidb_id
+FROM
+  anime_offline_mappings
+WHERE
+  mal_id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetOfflineMappingByMalId(ctx context.Context, malID pgtype.Int4) (AnimeOfflineMapping, error) {
+	row := q.db.QueryRow(ctx, getOfflineMappingByMalId, malID)
+	var i AnimeOfflineMapping
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Synonyms,
+		&i.MediaType,
+		&i.Episodes,
+		&i.Season,
+		&i.SeasonYear,
+		&i.MalID,
+		&i.AnilistID,
+		&i.KitsuID,
+		&i.AnidbID,
+	)
+	return i, err
+}
+
+const insertAnimeMappingSuggestion = `-- name: InsertAnimeMappingSuggestion :exec
+INSERT INTO anime_mapping_suggestions(anime_id, mal_id, anilist_id, confidence, method, matched_title)
+  VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (anime_id)
+  DO NOTHING
+`
+
+type InsertAnimeMappingSuggestionParams struct {
+	AnimeID      string
+	MalID        pgtype.Int4
+	AnilistID    pgtype.Int4
+	Confidence   float32
+	Method       string
+	MatchedTitle string
+}
+
+func (q *Queries) InsertAnimeMappingSuggestion(ctx context.Context, arg InsertAnimeMappingSuggestionParams) error {
+	_, err := q.db.Exec(ctx, insertAnimeMappingSuggestion,
+		arg.AnimeID,
+		arg.MalID,
+		arg.AnilistID,
+		arg.Confidence,
+		arg.Method,
+		arg.MatchedTitle,
+	)
+	return err
+}
+
+type InsertAnimeOfflineMappingsParams struct {
+	Title      string
+	Synonyms   []string
+	MediaType  pgtype.Text
+	Episodes   pgtype.Int4
+	Season     NullSeason
+	SeasonYear pgtype.Int4
+	MalID      pgtype.Int4
+	AnilistID  pgtype.Int4
+	KitsuID    pgtype.Int4
+	AnidbID    pgtype.Int4
+}
+
+const listAnimeMappingSuggestions = `-- name: ListAnimeMappingSuggestions :many
+SELECT
+  s.id, s.anime_id, s.mal_id, s.anilist_id, s.confidence, s.method, s.matched_title, s.status, s.reviewed_by, s.created_at, s.updated_at, s.reviewed_at,
+  a.hi_anime_id,
+  a.ename,
+  a.jname,
+  a.season,
+  a.season_year
+FROM
+  anime_mapping_suggestions s
+  JOIN animes a ON a.id = s.anime_id
+WHERE
+  s.status = $1
+ORDER BY
+  s.confidence DESC,
+  s.created_at ASC
+LIMIT $3 OFFSET $2
+`
+
+type ListAnimeMappingSuggestionsParams struct {
+	Status      MappingSuggestionStatus
+	OffsetCount int32
+	LimitCount  int32
+}
+
+type ListAnimeMappingSuggestionsRow struct {
+	AnimeMappingSuggestion AnimeMappingSuggestion
+	HiAnimeID              string
+	Ename                  string
+	Jname                  string
+	Season                 Season
+	SeasonYear             int32
+}
+
+func (q *Queries) ListAnimeMappingSuggestions(ctx context.Context, arg ListAnimeMappingSuggestionsParams) ([]ListAnimeMappingSuggestionsRow, error) {
+	rows, err := q.db.Query(ctx, listAnimeMappingSuggestions, arg.Status, arg.OffsetCount, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAnimeMappingSuggestionsRow
+	for rows.Next() {
+		var i ListAnimeMappingSuggestionsRow
+		if err := rows.Scan(
+			&i.AnimeMappingSuggestion.ID,
+			&i.AnimeMappingSuggestion.AnimeID,
+			&i.AnimeMappingSuggestion.MalID,
+			&i.AnimeMappingSuggestion.AnilistID,
+			&i.AnimeMappingSuggestion.Confidence,
+			&i.AnimeMappingSuggestion.Method,
+			&i.AnimeMappingSuggestion.MatchedTitle,
+			&i.AnimeMappingSuggestion.Status,
+			&i.AnimeMappingSuggestion.ReviewedBy,
+			&i.AnimeMappingSuggestion.CreatedAt,
+			&i.AnimeMappingSuggestion.UpdatedAt,
+			&i.AnimeMappingSuggestion.ReviewedAt,
+			&i.HiAnimeID,
+			&i.Ename,
+			&i.Jname,
+			&i.Season,
+			&i.SeasonYear,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAnimeMappingSuggestionStatus = `-- name: UpdateAnimeMappingSuggestionStatus :exec
+UPDATE
+  anime_mapping_suggestions
+SET
+  status = $1,
+  reviewed_by = $2,
+  reviewed_at = NOW()
+WHERE
+  id = $3
+`
+
+type UpdateAnimeMappingSuggestionStatusParams struct {
+	Status     MappingSuggestionStatus
+	ReviewedBy pgtype.Text
+	ID         string
+}
+
+func (q *Queries) UpdateAnimeMappingSuggestionStatus(ctx context.Context, arg UpdateAnimeMappingSuggestionStatusParams) error {
+	_, err := q.db.Exec(ctx, updateAnimeMappingSuggestionStatus, arg.Status, arg.ReviewedBy, arg.ID)
+	return err
+}
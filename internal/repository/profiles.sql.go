@@ -0,0 +1,352 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: profiles.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getProfileActivity = `-- name: GetProfileActivity :many
+SELECT
+  library_activities.id, library_activities.user_id, library_activities.anime_id, library_activities.type, library_activities.watched_episodes, library_activities.score, library_activities.created_at,
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
+FROM
+  library_activities
+  INNER JOIN animes ON animes.id = library_activities.anime_id
+WHERE
+  library_activities.user_id = $1
+ORDER BY
+  library_activities.created_at DESC,
+  library_activities.id
+LIMIT $2
+`
+
+type GetProfileActivityParams struct {
+	UserID     string
+	LimitCount int32
+}
+
+type GetProfileActivityRow struct {
+	LibraryActivity LibraryActivity
+	Anime           Anime
+}
+
+// Read from the recorded activities rather than the library, changes made in
+// incognito mode are never recorded and so never show up later.
+func (q *Queries) GetProfileActivity(ctx context.Context, arg GetProfileActivityParams) ([]GetProfileActivityRow, error) {
+	rows, err := q.db.Query(ctx, getProfileActivity, arg.UserID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProfileActivityRow
+	for rows.Next() {
+		var i GetProfileActivityRow
+		if err := rows.Scan(
+			&i.LibraryActivity.ID,
+			&i.LibraryActivity.UserID,
+			&i.LibraryActivity.AnimeID,
+			&i.LibraryActivity.Type,
+			&i.LibraryActivity.WatchedEpisodes,
+			&i.LibraryActivity.Score,
+			&i.LibraryActivity.CreatedAt,
+			&i.Anime.ID,
+			&i.Anime.Ename,
+			&i.Anime.Jname,
+			&i.Anime.ImageUrl,
+			&i.Anime.Genre,
+			&i.Anime.HiAnimeID,
+			&i.Anime.MalID,
+			&i.Anime.AnilistID,
+			&i.Anime.LastEpisode,
+			&i.Anime.CreatedAt,
+			&i.Anime.UpdatedAt,
+			&i.Anime.SearchVector,
+			&i.Anime.Season,
+			&i.Anime.SeasonYear,
+			&i.Anime.GenresArr,
+			&i.Anime.MissingChecks,
+			&i.Anime.UnavailableAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProfileByUsername = `-- name: GetProfileByUsername :one
+SELECT
+  users.id, users.username, users.email, users.password_hash, users.profile_picture, users.created_at, users.updated_at,
+  coalesce(settings.public_profile, FALSE)::boolean AS public_profile,
+  coalesce(settings.profile_show_library, TRUE)::boolean AS show_library,
+  coalesce(settings.profile_show_stats, TRUE)::boolean AS show_stats,
+  coalesce(settings.profile_show_favorites, TRUE)::boolean AS show_favorites,
+  coalesce(settings.profile_show_activity, TRUE)::boolean AS show_activity,
+  coalesce(settings.incognito_mode, FALSE)::boolean AS incognito_mode
+FROM
+  users
+  LEFT JOIN settings ON settings.user_id = users.id
+WHERE
+  users.username = $1
+`
+
+type GetProfileByUsernameRow struct {
+	User          User
+	PublicProfile bool
+	ShowLibrary   bool
+	ShowStats     bool
+	ShowFavorites bool
+	ShowActivity  bool
+	IncognitoMode bool
+}
+
+// Users without a settings row get the column defaults, so no public profile.
+func (q *Queries) GetProfileByUsername(ctx context.Context, username string) (GetProfileByUsernameRow, error) {
+	row := q.db.QueryRow(ctx, getProfileByUsername, username)
+	var i GetProfileByUsernameRow
+	err := row.Scan(
+		&i.User.ID,
+		&i.User.Username,
+		&i.User.Email,
+		&i.User.PasswordHash,
+		&i.User.ProfilePicture,
+		&i.User.CreatedAt,
+		&i.User.UpdatedAt,
+		&i.PublicProfile,
+		&i.ShowLibrary,
+		&i.ShowStats,
+		&i.ShowFavorites,
+		&i.ShowActivity,
+		&i.IncognitoMode,
+	)
+	return i, err
+}
+
+const getProfileFavorites = `-- name: GetProfileFavorites :many
+SELECT
+  library.id, library.user_id, library.anime_id, library.status, library.watched_episodes, library.created_at, library.updated_at, library.score, library.started_at, library.completed_at, library.rewatches, library.notes,
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at
+FROM
+  library
+  INNER JOIN animes ON animes.id = library.anime_id
+WHERE
+  library.user_id = $1
+  AND library.score IS NOT NULL
+ORDER BY
+  library.score DESC,
+  library.updated_at DESC
+LIMIT $2
+`
+
+type GetProfileFavoritesParams struct {
+	UserID     string
+	LimitCount int32
+}
+
+type GetProfileFavoritesRow struct {
+	Library Library
+	Anime   Anime
+}
+
+// Favorites are the user's highest scored entries.
+func (q *Queries) GetProfileFavorites(ctx context.Context, arg GetProfileFavoritesParams) ([]GetProfileFavoritesRow, error) {
+	rows, err := q.db.Query(ctx, getProfileFavorites, arg.UserID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProfileFavoritesRow
+	for rows.Next() {
+		var i GetProfileFavoritesRow
+		if err := rows.Scan(
+			&i.Library.ID,
+			&i.Library.UserID,
+			&i.Library.AnimeID,
+			&i.Library.Status,
+			&i.Library.WatchedEpisodes,
+			&i.Library.CreatedAt,
+			&i.Library.UpdatedAt,
+			&i.Library.Score,
+			&i.Library.StartedAt,
+			&i.Library.CompletedAt,
+			&i.Library.Rewatches,
+			&i.Library.Notes,
+			&i.Anime.ID,
+			&i.Anime.Ename,
+			&i.Anime.Jname,
+			&i.Anime.ImageUrl,
+			&i.Anime.Genre,
+			&i.Anime.HiAnimeID,
+			&i.Anime.MalID,
+			&i.Anime.AnilistID,
+			&i.Anime.LastEpisode,
+			&i.Anime.CreatedAt,
+			&i.Anime.UpdatedAt,
+			&i.Anime.SearchVector,
+			&i.Anime.Season,
+			&i.Anime.SeasonYear,
+			&i.Anime.GenresArr,
+			&i.Anime.MissingChecks,
+			&i.Anime.UnavailableAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProfileStats = `-- name: GetProfileStats :one
+SELECT
+  COUNT(*) FILTER (WHERE status = 'watching') AS watching,
+  COUNT(*) FILTER (WHERE status = 'planning') AS planning,
+  COUNT(*) FILTER (WHERE status = 'completed') AS completed,
+  COUNT(*) FILTER (WHERE status = 'dropped') AS dropped,
+  COUNT(*) FILTER (WHERE status = 'paused') AS paused,
+  coalesce(SUM(watched_episodes), 0)::bigint AS episodes_watched,
+  -- 0 when no entry is scored, scores start at 1
+  coalesce(AVG(score), 0)::float8 AS mean_score
+FROM
+  library
+WHERE
+  user_id = $1
+`
+
+type GetProfileStatsRow struct {
+	Watching        int64
+	Planning        int64
+	Completed       int64
+	Dropped         int64
+	Paused          int64
+	EpisodesWatched int64
+	MeanScore       float64
+}
+
+func (q *Queries) GetProfileStats(ctx context.Context, userID string) (GetProfileStatsRow, error) {
+	row := q.db.QueryRow(ctx, getProfileStats, userID)
+	var i GetProfileStatsRow
+	err := row.Scan(
+		&i.Watching,
+		&i.Planning,
+		&i.Completed,
+		&i.Dropped,
+		&i.Paused,
+		&i.EpisodesWatched,
+		&i.MeanScore,
+	)
+	return i, err
+}
+
+const getSharedLibraryEntries = `-- name: GetSharedLibraryEntries :many
+WITH user_library AS (
+  SELECT DISTINCT ON (title_key)
+    coalesce(nullif(animes.mal_id, 0)::text, animes.id) AS title_key,
+    library.anime_id,
+    library.status,
+    library.score
+  FROM
+    library
+    INNER JOIN animes ON animes.id = library.anime_id
+  WHERE
+    library.user_id = $1
+  ORDER BY
+    title_key,
+    library.updated_at DESC
+),
+other_library AS (
+  SELECT DISTINCT ON (title_key)
+    coalesce(nullif(animes.mal_id, 0)::text, animes.id) AS title_key,
+    library.status,
+    library.score
+  FROM
+    library
+    INNER JOIN animes ON animes.id = library.anime_id
+  WHERE
+    library.user_id = $2
+  ORDER BY
+    title_key,
+    library.updated_at DESC
+)
+SELECT
+  animes.id, animes.ename, animes.jname, animes.image_url, animes.genre, animes.hi_anime_id, animes.mal_id, animes.anilist_id, animes.last_episode, animes.created_at, animes.updated_at, animes.search_vector, animes.season, animes.season_year, animes.genres_arr, animes.missing_checks, animes.unavailable_at,
+  user_library.status AS user_status,
+  user_library.score AS user_score,
+  other_library.status AS other_status,
+  other_library.score AS other_score
+FROM
+  user_library
+  INNER JOIN other_library ON other_library.title_key = user_library.title_key
+  INNER JOIN animes ON animes.id = user_library.anime_id
+ORDER BY
+  animes.ename
+`
+
+type GetSharedLibraryEntriesParams struct {
+	UserID      string
+	OtherUserID string
+}
+
+type GetSharedLibraryEntriesRow struct {
+	Anime       Anime
+	UserStatus  LibraryStatus
+	UserScore   pgtype.Int2
+	OtherStatus LibraryStatus
+	OtherScore  pgtype.Int2
+}
+
+// Entries are matched on MAL ID so different variations of a show count as
+// the same title, anime without one only match themselves. A user with
+// several variations of a show is represented by the latest changed one.
+func (q *Queries) GetSharedLibraryEntries(ctx context.Context, arg GetSharedLibraryEntriesParams) ([]GetSharedLibraryEntriesRow, error) {
+	rows, err := q.db.Query(ctx, getSharedLibraryEntries, arg.UserID, arg.OtherUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSharedLibraryEntriesRow
+	for rows.Next() {
+		var i GetSharedLibraryEntriesRow
+		if err := rows.Scan(
+			&i.Anime.ID,
+			&i.Anime.Ename,
+			&i.Anime.Jname,
+			&i.Anime.ImageUrl,
+			&i.Anime.Genre,
+			&i.Anime.HiAnimeID,
+			&i.Anime.MalID,
+			&i.Anime.AnilistID,
+			&i.Anime.LastEpisode,
+			&i.Anime.CreatedAt,
+			&i.Anime.UpdatedAt,
+			&i.Anime.SearchVector,
+			&i.Anime.Season,
+			&i.Anime.SeasonYear,
+			&i.Anime.GenresArr,
+			&i.Anime.MissingChecks,
+			&i.Anime.UnavailableAt,
+			&i.UserStatus,
+			&i.UserScore,
+			&i.OtherStatus,
+			&i.OtherScore,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
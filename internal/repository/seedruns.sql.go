@@ -0,0 +1,364 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: seedruns.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createSeedRun = `-- name: CreateSeedRun :one
+INSERT INTO seed_runs(kind, phase)
+  VALUES ($1, $2)
+RETURNING
+  id, kind, phase, status, next_page, total_pages, error_message, created_at, updated_at, completed_at
+`
+
+type CreateSeedRunParams struct {
+	Kind  SeedRunKind
+	Phase SeedRunPhase
+}
+
+func (q *Queries) CreateSeedRun(ctx context.Context, arg CreateSeedRunParams) (SeedRun, error) {
+	row := q.db.QueryRow(ctx, createSeedRun, arg.Kind, arg.Phase)
+	var i SeedRun
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Phase,
+		&i.Status,
+		&i.NextPage,
+		&i.TotalPages,
+		&i.ErrorMessage,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const deleteQuarantinedSeedRunPage = `-- name: DeleteQuarantinedSeedRunPage :exec
+DELETE FROM seed_run_quarantined_pages
+WHERE seed_run_id = $1
+  AND phase = $2
+  AND page = $3
+`
+
+type DeleteQuarantinedSeedRunPageParams struct {
+	SeedRunID string
+	Phase     SeedRunPhase
+	Page      int32
+}
+
+func (q *Queries) DeleteQuarantinedSeedRunPage(ctx context.Context, arg DeleteQuarantinedSeedRunPageParams) error {
+	_, err := q.db.Exec(ctx, deleteQuarantinedSeedRunPage, arg.SeedRunID, arg.Phase, arg.Page)
+	return err
+}
+
+const getLatestUnfinishedSeedRun = `-- name: GetLatestUnfinishedSeedRun :one
+SELECT
+  id, kind, phase, status, next_page, total_pages, error_message, created_at, updated_at, completed_at
+FROM
+  seed_runs
+WHERE
+  kind = $1
+  AND status <> 'completed'
+ORDER BY
+  created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestUnfinishedSeedRun(ctx context.Context, kind SeedRunKind) (SeedRun, error) {
+	row := q.db.QueryRow(ctx, getLatestUnfinishedSeedRun, kind)
+	var i SeedRun
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Phase,
+		&i.Status,
+		&i.NextPage,
+		&i.TotalPages,
+		&i.ErrorMessage,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getQuarantinedSeedRunPages = `-- name: GetQuarantinedSeedRunPages :many
+SELECT
+  seed_run_id, phase, page, attempts, error_message, created_at, updated_at
+FROM
+  seed_run_quarantined_pages
+WHERE
+  seed_run_id = $1
+  AND phase = $2
+ORDER BY
+  page ASC
+`
+
+type GetQuarantinedSeedRunPagesParams struct {
+	SeedRunID string
+	Phase     SeedRunPhase
+}
+
+func (q *Queries) GetQuarantinedSeedRunPages(ctx context.Context, arg GetQuarantinedSeedRunPagesParams) ([]SeedRunQuarantinedPage, error) {
+	rows, err := q.db.Query(ctx, getQuarantinedSeedRunPages, arg.SeedRunID, arg.Phase)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SeedRunQuarantinedPage
+	for rows.Next() {
+		var i SeedRunQuarantinedPage
+		if err := rows.Scan(
+			&i.SeedRunID,
+			&i.Phase,
+			&i.Page,
+			&i.Attempts,
+			&i.ErrorMessage,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSeedRun = `-- name: GetSeedRun :one
+SELECT
+  id, kind, phase, status, next_page, total_pages, error_message, created_at, updated_at, completed_at
+FROM
+  seed_runs
+WHERE
+  id = $1
+`
+
+func (q *Queries) GetSeedRun(ctx context.Context, id string) (SeedRun, error) {
+	row := q.db.QueryRow(ctx, getSeedRun, id)
+	var i SeedRun
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Phase,
+		&i.Status,
+		&i.NextPage,
+		&i.TotalPages,
+		&i.ErrorMessage,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getSeedRunErroredHiAnimeIds = `-- name: GetSeedRunErroredHiAnimeIds :many
+SELECT
+  hi_anime_id
+FROM
+  seed_run_errors
+WHERE
+  seed_run_id = $1
+ORDER BY
+  created_at ASC
+`
+
+func (q *Queries) GetSeedRunErroredHiAnimeIds(ctx context.Context, seedRunID string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getSeedRunErroredHiAnimeIds, seedRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var hi_anime_id string
+		if err := rows.Scan(&hi_anime_id); err != nil {
+			return nil, err
+		}
+		items = append(items, hi_anime_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeedRuns = `-- name: ListSeedRuns :many
+SELECT
+  seed_runs.id, seed_runs.kind, seed_runs.phase, seed_runs.status, seed_runs.next_page, seed_runs.total_pages, seed_runs.error_message, seed_runs.created_at, seed_runs.updated_at, seed_runs.completed_at,
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      seed_run_errors e
+    WHERE
+      e.seed_run_id = seed_runs.id) AS errored_count,
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      seed_run_quarantined_pages q
+    WHERE
+      q.seed_run_id = seed_runs.id) AS quarantined_count
+FROM
+  seed_runs
+ORDER BY
+  created_at DESC
+LIMIT $1
+`
+
+type ListSeedRunsRow struct {
+	SeedRun          SeedRun
+	ErroredCount     int64
+	QuarantinedCount int64
+}
+
+func (q *Queries) ListSeedRuns(ctx context.Context, limitCount int32) ([]ListSeedRunsRow, error) {
+	rows, err := q.db.Query(ctx, listSeedRuns, limitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSeedRunsRow
+	for rows.Next() {
+		var i ListSeedRunsRow
+		if err := rows.Scan(
+			&i.SeedRun.ID,
+			&i.SeedRun.Kind,
+			&i.SeedRun.Phase,
+			&i.SeedRun.Status,
+			&i.SeedRun.NextPage,
+			&i.SeedRun.TotalPages,
+			&i.SeedRun.ErrorMessage,
+			&i.SeedRun.CreatedAt,
+			&i.SeedRun.UpdatedAt,
+			&i.SeedRun.CompletedAt,
+			&i.ErroredCount,
+			&i.QuarantinedCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const quarantineSeedRunPage = `-- name: QuarantineSeedRunPage :exec
+INSERT INTO seed_run_quarantined_pages(seed_run_id, phase, page, error_message)
+  VALUES ($1, $2, $3, $4)
+ON CONFLICT (seed_run_id, phase, page)
+  DO UPDATE SET
+    attempts = seed_run_quarantined_pages.attempts + 1,
+    error_message = EXCLUDED.error_message,
+    updated_at = NOW()
+`
+
+type QuarantineSeedRunPageParams struct {
+	SeedRunID    string
+	Phase        SeedRunPhase
+	Page         int32
+	ErrorMessage string
+}
+
+func (q *Queries) QuarantineSeedRunPage(ctx context.Context, arg QuarantineSeedRunPageParams) error {
+	_, err := q.db.Exec(ctx, quarantineSeedRunPage,
+		arg.SeedRunID,
+		arg.Phase,
+		arg.Page,
+		arg.ErrorMessage,
+	)
+	return err
+}
+
+const updateSeedRunCheckpoint = `-- name: UpdateSeedRunCheckpoint :exec
+UPDATE
+  seed_runs
+SET
+  phase = $1,
+  next_page = $2,
+  total_pages = $3
+WHERE
+  id = $4
+`
+
+type UpdateSeedRunCheckpointParams struct {
+	Phase      SeedRunPhase
+	NextPage   int32
+	TotalPages int32
+	ID         string
+}
+
+func (q *Queries) UpdateSeedRunCheckpoint(ctx context.Context, arg UpdateSeedRunCheckpointParams) error {
+	_, err := q.db.Exec(ctx, updateSeedRunCheckpoint,
+		arg.Phase,
+		arg.NextPage,
+		arg.TotalPages,
+		arg.ID,
+	)
+	return err
+}
+
+const updateSeedRunStatus = `-- name: UpdateSeedRunStatus :exec
+UPDATE
+  seed_runs
+SET
+  status = $1::seed_run_status,
+  error_message = $2,
+  completed_at = CASE WHEN $1::seed_run_status = 'completed' THEN
+    NOW()
+  ELSE
+    NULL
+  END
+WHERE
+  id = $3
+`
+
+type UpdateSeedRunStatusParams struct {
+	Status       SeedRunStatus
+	ErrorMessage pgtype.Text
+	ID           string
+}
+
+func (q *Queries) UpdateSeedRunStatus(ctx context.Context, arg UpdateSeedRunStatusParams) error {
+	_, err := q.db.Exec(ctx, updateSeedRunStatus, arg.Status, arg.ErrorMessage, arg.ID)
+	return err
+}
+
+const upsertSeedRunError = `-- name: UpsertSeedRunError :exec
+INSERT INTO seed_run_errors(seed_run_id, hi_anime_id, phase, error_message)
+  VALUES ($1, $2, $3, $4)
+ON CONFLICT (seed_run_id, hi_anime_id)
+  DO UPDATE SET
+    phase = EXCLUDED.phase,
+    error_message = EXCLUDED.error_message
+`
+
+type UpsertSeedRunErrorParams struct {
+	SeedRunID    string
+	HiAnimeID    string
+	Phase        SeedRunPhase
+	ErrorMessage string
+}
+
+func (q *Queries) UpsertSeedRunError(ctx context.Context, arg UpsertSeedRunErrorParams) error {
+	_, err := q.db.Exec(ctx, upsertSeedRunError,
+		arg.SeedRunID,
+		arg.HiAnimeID,
+		arg.Phase,
+		arg.ErrorMessage,
+	)
+	return err
+}
@@ -11,7 +11,7 @@ import (
 
 const getSettingsOfUser = `-- name: GetSettingsOfUser :one
 SELECT
-  settings.user_id, settings.auto_next_episode, settings.auto_play_episode, settings.auto_resume_episode, settings.incognito_mode, settings.theme_id,
+  settings.user_id, settings.auto_next_episode, settings.auto_play_episode, settings.auto_resume_episode, settings.incognito_mode, settings.theme_id, settings.auto_start_library, settings.auto_complete_library, settings.auto_pause_library, settings.auto_pause_after_days, settings.public_profile, settings.profile_show_library, settings.profile_show_stats, settings.profile_show_favorites, settings.profile_show_activity,
   themes.id, themes.name, themes.theme_class, themes.description, themes.created_at, themes.updated_at
 FROM
   settings
@@ -35,6 +35,15 @@ func (q *Queries) GetSettingsOfUser(ctx context.Context, userID string) (GetSett
 		&i.Setting.AutoResumeEpisode,
 		&i.Setting.IncognitoMode,
 		&i.Setting.ThemeID,
+		&i.Setting.AutoStartLibrary,
+		&i.Setting.AutoCompleteLibrary,
+		&i.Setting.AutoPauseLibrary,
+		&i.Setting.AutoPauseAfterDays,
+		&i.Setting.PublicProfile,
+		&i.Setting.ProfileShowLibrary,
+		&i.Setting.ProfileShowStats,
+		&i.Setting.ProfileShowFavorites,
+		&i.Setting.ProfileShowActivity,
 		&i.Theme.ID,
 		&i.Theme.Name,
 		&i.Theme.ThemeClass,
@@ -83,20 +92,29 @@ func (q *Queries) ListThemes(ctx context.Context) ([]Theme, error) {
 
 const saveSettings = `-- name: SaveSettings :one
 WITH upserted AS (
-INSERT INTO settings(user_id, auto_next_episode, auto_play_episode, auto_resume_episode, incognito_mode, theme_id)
-    VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO settings(user_id, auto_next_episode, auto_play_episode, auto_resume_episode, incognito_mode, theme_id, auto_start_library, auto_complete_library, auto_pause_library, auto_pause_after_days, public_profile, profile_show_library, profile_show_stats, profile_show_favorites, profile_show_activity)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
   ON CONFLICT (user_id)
     DO UPDATE SET
       auto_next_episode = EXCLUDED.auto_next_episode,
       auto_play_episode = EXCLUDED.auto_play_episode,
       auto_resume_episode = EXCLUDED.auto_resume_episode,
       incognito_mode = EXCLUDED.incognito_mode,
-      theme_id = EXCLUDED.theme_id
+      theme_id = EXCLUDED.theme_id,
+      auto_start_library = EXCLUDED.auto_start_library,
+      auto_complete_library = EXCLUDED.auto_complete_library,
+      auto_pause_library = EXCLUDED.auto_pause_library,
+      auto_pause_after_days = EXCLUDED.auto_pause_after_days,
+      public_profile = EXCLUDED.public_profile,
+      profile_show_library = EXCLUDED.profile_show_library,
+      profile_show_stats = EXCLUDED.profile_show_stats,
+      profile_show_favorites = EXCLUDED.profile_show_favorites,
+      profile_show_activity = EXCLUDED.profile_show_activity
     RETURNING
-      user_id, auto_next_episode, auto_play_episode, auto_resume_episode, incognito_mode, theme_id
+      user_id, auto_next_episode, auto_play_episode, auto_resume_episode, incognito_mode, theme_id, auto_start_library, auto_complete_library, auto_pause_library, auto_pause_after_days, public_profile, profile_show_library, profile_show_stats, profile_show_favorites, profile_show_activity
 )
   SELECT
-    upserted.user_id, upserted.auto_next_episode, upserted.auto_play_episode, upserted.auto_resume_episode, upserted.incognito_mode, upserted.theme_id,
+    upserted.user_id, upserted.auto_next_episode, upserted.auto_play_episode, upserted.auto_resume_episode, upserted.incognito_mode, upserted.theme_id, upserted.auto_start_library, upserted.auto_complete_library, upserted.auto_pause_library, upserted.auto_pause_after_days, upserted.public_profile, upserted.profile_show_library, upserted.profile_show_stats, upserted.profile_show_favorites, upserted.profile_show_activity,
     themes.id, themes.name, themes.theme_class, themes.description, themes.created_at, themes.updated_at
   FROM
     upserted
@@ -104,22 +122,40 @@ INSERT INTO settings(user_id, auto_next_episode, auto_play_episode, auto_resume_
 `
 
 type SaveSettingsParams struct {
-	UserID            string
-	AutoNextEpisode   bool
-	AutoPlayEpisode   bool
-	AutoResumeEpisode bool
-	IncognitoMode     bool
-	ThemeID           int32
+	UserID               string
+	AutoNextEpisode      bool
+	AutoPlayEpisode      bool
+	AutoResumeEpisode    bool
+	IncognitoMode        bool
+	ThemeID              int32
+	AutoStartLibrary     bool
+	AutoCompleteLibrary  bool
+	AutoPauseLibrary     bool
+	AutoPauseAfterDays   int32
+	PublicProfile        bool
+	ProfileShowLibrary   bool
+	ProfileShowStats     bool
+	ProfileShowFavorites bool
+	ProfileShowActivity  bool
 }
 
 type SaveSettingsRow struct {
-	UserID            string
-	AutoNextEpisode   bool
-	AutoPlayEpisode   bool
-	AutoResumeEpisode bool
-	IncognitoMode     bool
-	ThemeID           int32
-	Theme             Theme
+	UserID               string
+	AutoNextEpisode      bool
+	AutoPlayEpisode      bool
+	AutoResumeEpisode    bool
+	IncognitoMode        bool
+	ThemeID              int32
+	AutoStartLibrary     bool
+	AutoCompleteLibrary  bool
+	AutoPauseLibrary     bool
+	AutoPauseAfterDays   int32
+	PublicProfile        bool
+	ProfileShowLibrary   bool
+	ProfileShowStats     bool
+	ProfileShowFavorites bool
+	ProfileShowActivity  bool
+	Theme                Theme
 }
 
 func (q *Queries) SaveSettings(ctx context.Context, arg SaveSettingsParams) (SaveSettingsRow, error) {
@@ -130,6 +166,15 @@ func (q *Queries) SaveSettings(ctx context.Context, arg SaveSettingsParams) (Sav
 		arg.AutoResumeEpisode,
 		arg.IncognitoMode,
 		arg.ThemeID,
+		arg.AutoStartLibrary,
+		arg.AutoCompleteLibrary,
+		arg.AutoPauseLibrary,
+		arg.AutoPauseAfterDays,
+		arg.PublicProfile,
+		arg.ProfileShowLibrary,
+		arg.ProfileShowStats,
+		arg.ProfileShowFavorites,
+		arg.ProfileShowActivity,
 	)
 	var i SaveSettingsRow
 	err := row.Scan(
@@ -139,6 +184,15 @@ func (q *Queries) SaveSettings(ctx context.Context, arg SaveSettingsParams) (Sav
 		&i.AutoResumeEpisode,
 		&i.IncognitoMode,
 		&i.ThemeID,
+		&i.AutoStartLibrary,
+		&i.AutoCompleteLibrary,
+		&i.AutoPauseLibrary,
+		&i.AutoPauseAfterDays,
+		&i.PublicProfile,
+		&i.ProfileShowLibrary,
+		&i.ProfileShowStats,
+		&i.ProfileShowFavorites,
+		&i.ProfileShowActivity,
 		&i.Theme.ID,
 		&i.Theme.Name,
 		&i.Theme.ThemeClass,
@@ -0,0 +1,33 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/coeeter/aniways/internal/models"
+)
+
+// invalidateCache drops cached data derived from something the admin just
+// changed. A failure only leaves data stale until it expires.
+func (s *AdminService) invalidateCache(ctx context.Context, tag string) {
+	if _, err := s.cache.InvalidateTag(ctx, tag); err != nil {
+		slog.Warn("cache invalidate failed", "tag", tag, "err", err)
+	}
+}
+
+func (s *AdminService) PurgeCache(ctx context.Context, req models.CachePurgeRequest) (models.CachePurgeResponse, error) {
+	if req.Tag != "" {
+		deleted, err := s.cache.InvalidateTag(ctx, req.Tag)
+		if err != nil {
+			return models.CachePurgeResponse{}, fmt.Errorf("invalidate tag %s: %w", req.Tag, err)
+		}
+		return models.CachePurgeResponse{Deleted: deleted}, nil
+	}
+
+	deleted, err := s.cache.DeletePrefix(ctx, req.Prefix)
+	if err != nil {
+		return models.CachePurgeResponse{}, fmt.Errorf("delete prefix %s: %w", req.Prefix, err)
+	}
+	return models.CachePurgeResponse{Deleted: deleted}, nil
+}
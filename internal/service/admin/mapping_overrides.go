@@ -0,0 +1,214 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/coeeter/aniways/internal/infra/client/hianime"
+	"github.com/coeeter/aniways/internal/mappers"
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrMappingOverrideNotFound = errors.New("mapping override not found")
+
+func (s *AdminService) ListMappingOverrides(ctx context.Context, page, size int) ([]models.AnimeMappingOverride, error) {
+	rows, err := s.repo.ListAnimeMappingOverrides(ctx, repository.ListAnimeMappingOverridesParams{
+		LimitCount:  int32(size),
+		OffsetCount: int32((page - 1) * size),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list mapping overrides: %w", err)
+	}
+
+	overrides := make([]models.AnimeMappingOverride, 0, len(rows))
+	for _, row := range rows {
+		overrides = append(overrides, mappers.AnimeMappingOverrideFromRepository(row))
+	}
+	return overrides, nil
+}
+
+func (s *AdminService) GetMappingOverride(ctx context.Context, hiAnimeID string) (models.AnimeMappingOverride, error) {
+	row, err := s.repo.GetAnimeMappingOverride(ctx, hiAnimeID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.AnimeMappingOverride{}, ErrMappingOverrideNotFound
+	}
+	if err != nil {
+		return models.AnimeMappingOverride{}, fmt.Errorf("get mapping override: %w", err)
+	}
+	return mappers.AnimeMappingOverrideFromRepository(row), nil
+}
+
+func (s *AdminService) GetMappingOverrideAudit(ctx context.Context, hiAnimeID string) ([]models.AnimeMappingOverrideAuditEntry, error) {
+	rows, err := s.repo.GetAnimeMappingOverrideAudit(ctx, hiAnimeID)
+	if err != nil {
+		return nil, fmt.Errorf("get mapping override audit: %w", err)
+	}
+
+	entries := make([]models.AnimeMappingOverrideAuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, mappers.AnimeMappingOverrideAuditFromRepository(row))
+	}
+	return entries, nil
+}
+
+// UpsertMappingOverride pins the given fields for a HiAnime ID, records the
+// change in the audit trail and applies it to the anime row right away so the
+// fix does not wait for the next scrape.
+func (s *AdminService) UpsertMappingOverride(
+	ctx context.Context,
+	hiAnimeID, changedBy string,
+	req models.AnimeMappingOverrideRequest,
+) (models.AnimeMappingOverride, error) {
+	previous, err := s.findMappingOverride(ctx, hiAnimeID)
+	if err != nil {
+		return models.AnimeMappingOverride{}, err
+	}
+
+	params := repository.UpsertAnimeMappingOverrideParams{
+		HiAnimeID: hiAnimeID,
+		UpdatedBy: changedBy,
+	}
+	if req.MalID != nil {
+		params.MalID = pgtype.Int4{Int32: *req.MalID, Valid: true}
+	}
+	if req.AnilistID != nil {
+		params.AnilistID = pgtype.Int4{Int32: *req.AnilistID, Valid: true}
+	}
+	if req.Season != nil {
+		params.Season = repository.NullSeason{Season: repository.Season(*req.Season), Valid: true}
+	}
+	if req.SeasonYear != nil {
+		params.SeasonYear = pgtype.Int4{Int32: *req.SeasonYear, Valid: true}
+	}
+	if req.EName != nil {
+		params.Ename = pgtype.Text{String: *req.EName, Valid: true}
+	}
+	if req.JName != nil {
+		params.Jname = pgtype.Text{String: *req.JName, Valid: true}
+	}
+	if req.Note != nil {
+		params.Note = pgtype.Text{String: *req.Note, Valid: true}
+	}
+
+	row, err := s.repo.UpsertAnimeMappingOverride(ctx, params)
+	if err != nil {
+		return models.AnimeMappingOverride{}, fmt.Errorf("upsert mapping override: %w", err)
+	}
+
+	current := mappers.AnimeMappingOverrideFromRepository(row)
+	if err := s.auditMappingOverride(ctx, hiAnimeID, repository.MappingOverrideActionUpsert, changedBy, previous, &current); err != nil {
+		return models.AnimeMappingOverride{}, err
+	}
+
+	if err := s.applyMappingOverrideToAnime(ctx, row); err != nil {
+		return models.AnimeMappingOverride{}, err
+	}
+
+	return current, nil
+}
+
+func (s *AdminService) DeleteMappingOverride(ctx context.Context, hiAnimeID, changedBy string) error {
+	previous, err := s.findMappingOverride(ctx, hiAnimeID)
+	if err != nil {
+		return err
+	}
+	if previous == nil {
+		return ErrMappingOverrideNotFound
+	}
+
+	if _, err := s.repo.DeleteAnimeMappingOverride(ctx, hiAnimeID); err != nil {
+		return fmt.Errorf("delete mapping override: %w", err)
+	}
+
+	return s.auditMappingOverride(ctx, hiAnimeID, repository.MappingOverrideActionDelete, changedBy, previous, nil)
+}
+
+func (s *AdminService) findMappingOverride(ctx context.Context, hiAnimeID string) (*models.AnimeMappingOverride, error) {
+	row, err := s.repo.GetAnimeMappingOverride(ctx, hiAnimeID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get mapping override: %w", err)
+	}
+	override := mappers.AnimeMappingOverrideFromRepository(row)
+	return &override, nil
+}
+
+func (s *AdminService) auditMappingOverride(
+	ctx context.Context,
+	hiAnimeID string,
+	action repository.MappingOverrideAction,
+	changedBy string,
+	previous, current *models.AnimeMappingOverride,
+) error {
+	params := repository.InsertAnimeMappingOverrideAuditParams{
+		HiAnimeID: hiAnimeID,
+		Action:    action,
+		ChangedBy: changedBy,
+	}
+
+	var err error
+	if previous != nil {
+		if params.Previous, err = json.Marshal(previous); err != nil {
+			return fmt.Errorf("marshal previous override: %w", err)
+		}
+	}
+	if current != nil {
+		if params.Current, err = json.Marshal(current); err != nil {
+			return fmt.Errorf("marshal current override: %w", err)
+		}
+	}
+
+	if err := s.repo.InsertAnimeMappingOverrideAudit(ctx, params); err != nil {
+		return fmt.Errorf("insert mapping override audit: %w", err)
+	}
+	return nil
+}
+
+func (s *AdminService) applyMappingOverrideToAnime(ctx context.Context, o repository.AnimeMappingOverride) error {
+	existing, err := s.repo.GetAnimeByHiAnimeId(ctx, o.HiAnimeID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// picked up when the scraper first inserts it
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get anime for override: %w", err)
+	}
+
+	info := hianime.ScrapedAnimeInfoDto{
+		HiAnimeID:  existing.HiAnimeID,
+		EName:      existing.Ename,
+		JName:      existing.Jname,
+		MalID:      int(existing.MalID.Int32),
+		AnilistID:  int(existing.AnilistID.Int32),
+		Season:     string(existing.Season),
+		SeasonYear: int(existing.SeasonYear),
+	}
+	mappers.ApplyMappingOverride(&info, o)
+
+	params := repository.UpdateAnimeParams{
+		ID:          existing.ID,
+		Ename:       info.EName,
+		Jname:       info.JName,
+		ImageUrl:    existing.ImageUrl,
+		Genre:       existing.Genre,
+		HiAnimeID:   existing.HiAnimeID,
+		MalID:       pgtype.Int4{Int32: int32(info.MalID), Valid: info.MalID > 0},
+		AnilistID:   pgtype.Int4{Int32: int32(info.AnilistID), Valid: info.AnilistID > 0},
+		LastEpisode: existing.LastEpisode,
+		UpdatedAt:   existing.UpdatedAt,
+		Season:      repository.Season(info.Season),
+		SeasonYear:  int32(info.SeasonYear),
+	}
+
+	if err := s.repo.UpdateAnime(ctx, params); err != nil {
+		return fmt.Errorf("apply override to anime: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrMappingSuggestionNotFound = errors.New("mapping suggestion not found")
+	ErrMappingSuggestionReviewed = errors.New("mapping suggestion already reviewed")
+)
+
+func (s *AdminService) ListMappingSuggestions(ctx context.Context, status string, page, size int) ([]models.AnimeMappingSuggestion, error) {
+	rows, err := s.repo.ListAnimeMappingSuggestions(ctx, repository.ListAnimeMappingSuggestionsParams{
+		Status:      repository.MappingSuggestionStatus(status),
+		LimitCount:  int32(size),
+		OffsetCount: int32((page - 1) * size),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list mapping suggestions: %w", err)
+	}
+
+	suggestions := make([]models.AnimeMappingSuggestion, 0, len(rows))
+	for _, row := range rows {
+		sg := row.AnimeMappingSuggestion
+		suggestion := models.AnimeMappingSuggestion{
+			ID:           sg.ID,
+			AnimeID:      sg.AnimeID,
+			HiAnimeID:    row.HiAnimeID,
+			EName:        row.Ename,
+			JName:        row.Jname,
+			Season:       string(row.Season),
+			SeasonYear:   row.SeasonYear,
+			Confidence:   sg.Confidence,
+			Method:       sg.Method,
+			MatchedTitle: sg.MatchedTitle,
+			Status:       string(sg.Status),
+			CreatedAt:    sg.CreatedAt.Time.Format(time.RFC3339),
+		}
+		if sg.MalID.Valid {
+			suggestion.MalID = &sg.MalID.Int32
+		}
+		if sg.AnilistID.Valid {
+			suggestion.AnilistID = &sg.AnilistID.Int32
+		}
+		if sg.ReviewedBy.Valid {
+			suggestion.ReviewedBy = &sg.ReviewedBy.String
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, nil
+}
+
+// ApproveMappingSuggestion pins the suggested IDs as a mapping override,
+// keeping any other fields an admin already pinned for the anime.
+func (s *AdminService) ApproveMappingSuggestion(ctx context.Context, id, reviewedBy string) (models.AnimeMappingOverride, error) {
+	row, err := s.getPendingMappingSuggestion(ctx, id)
+	if err != nil {
+		return models.AnimeMappingOverride{}, err
+	}
+	sg := row.AnimeMappingSuggestion
+
+	req := models.AnimeMappingOverrideRequest{}
+	existing, err := s.findMappingOverride(ctx, row.HiAnimeID)
+	if err != nil {
+		return models.AnimeMappingOverride{}, err
+	}
+	if existing != nil {
+		req = models.AnimeMappingOverrideRequest{
+			MalID:      existing.MalID,
+			AnilistID:  existing.AnilistID,
+			Season:     existing.Season,
+			SeasonYear: existing.SeasonYear,
+			EName:      existing.EName,
+			JName:      existing.JName,
+			Note:       existing.Note,
+		}
+	}
+	if sg.MalID.Valid {
+		req.MalID = &sg.MalID.Int32
+	}
+	if sg.AnilistID.Valid {
+		req.AnilistID = &sg.AnilistID.Int32
+	}
+
+	override, err := s.UpsertMappingOverride(ctx, row.HiAnimeID, reviewedBy, req)
+	if err != nil {
+		return models.AnimeMappingOverride{}, err
+	}
+
+	if err := s.setMappingSuggestionStatus(ctx, id, repository.MappingSuggestionStatusApproved, reviewedBy); err != nil {
+		return models.AnimeMappingOverride{}, err
+	}
+	return override, nil
+}
+
+func (s *AdminService) RejectMappingSuggestion(ctx context.Context, id, reviewedBy string) error {
+	if _, err := s.getPendingMappingSuggestion(ctx, id); err != nil {
+		return err
+	}
+	return s.setMappingSuggestionStatus(ctx, id, repository.MappingSuggestionStatusRejected, reviewedBy)
+}
+
+func (s *AdminService) getPendingMappingSuggestion(ctx context.Context, id string) (repository.GetAnimeMappingSuggestionRow, error) {
+	row, err := s.repo.GetAnimeMappingSuggestion(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return row, ErrMappingSuggestionNotFound
+	}
+	if err != nil {
+		return row, fmt.Errorf("get mapping suggestion: %w", err)
+	}
+	if row.AnimeMappingSuggestion.Status != repository.MappingSuggestionStatusPending {
+		return row, ErrMappingSuggestionReviewed
+	}
+	return row, nil
+}
+
+func (s *AdminService) setMappingSuggestionStatus(ctx context.Context, id string, status repository.MappingSuggestionStatus, reviewedBy string) error {
+	if err := s.repo.UpdateAnimeMappingSuggestionStatus(ctx, repository.UpdateAnimeMappingSuggestionStatusParams{
+		ID:         id,
+		Status:     status,
+		ReviewedBy: pgtype.Text{String: reviewedBy, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("update mapping suggestion: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/service/anime"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// GetMetadataCoverage reports how much of the catalog has MAL metadata and how
+// much of it is past the refresh TTLs.
+func (s *AdminService) GetMetadataCoverage(ctx context.Context) (*models.MetadataCoverage, error) {
+	now := time.Now()
+	row, err := s.repo.GetMetadataCoverage(ctx, repository.GetMetadataCoverageParams{
+		StaleBefore:       pgtype.Timestamp{Time: now.Add(-anime.MetadataTTL), Valid: true},
+		AiringStaleBefore: pgtype.Timestamp{Time: now.Add(-anime.AiringMetadataTTL), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get metadata coverage: %w", err)
+	}
+
+	coverage := &models.MetadataCoverage{
+		Total:        row.Total,
+		WithMetadata: row.WithMetadata,
+		Missing:      row.Missing,
+		Fresh:        row.Fresh,
+		Stale:        row.Stale,
+		Airing:       row.Airing,
+		AiringStale:  row.AiringStale,
+		Failing:      row.Failing,
+	}
+	if row.OldestUpdatedAt.Valid {
+		oldest := row.OldestUpdatedAt.Time.Format(time.RFC3339)
+		coverage.OldestUpdatedAt = &oldest
+	}
+	return coverage, nil
+}
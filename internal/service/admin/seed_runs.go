@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrSeedRunNotFound  = errors.New("seed run not found")
+	ErrSeedRunNoErrored = errors.New("seed run has no errored anime")
+)
+
+func (s *AdminService) ListSeedRuns(ctx context.Context, limit int32) ([]models.SeedRun, error) {
+	rows, err := s.repo.ListSeedRuns(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list seed runs: %w", err)
+	}
+
+	runs := make([]models.SeedRun, 0, len(rows))
+	for _, row := range rows {
+		run := models.SeedRun{
+			ID:               row.SeedRun.ID,
+			Kind:             string(row.SeedRun.Kind),
+			Phase:            string(row.SeedRun.Phase),
+			Status:           string(row.SeedRun.Status),
+			NextPage:         row.SeedRun.NextPage,
+			TotalPages:       row.SeedRun.TotalPages,
+			ErroredCount:     row.ErroredCount,
+			QuarantinedCount: row.QuarantinedCount,
+			CreatedAt:        row.SeedRun.CreatedAt.Time.Format(time.RFC3339),
+			UpdatedAt:        row.SeedRun.UpdatedAt.Time.Format(time.RFC3339),
+		}
+		if row.SeedRun.ErrorMessage.Valid {
+			run.ErrorMessage = &row.SeedRun.ErrorMessage.String
+		}
+		if row.SeedRun.CompletedAt.Valid {
+			completedAt := row.SeedRun.CompletedAt.Time.Format(time.RFC3339)
+			run.CompletedAt = &completedAt
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// StartBulkReprocessFromSeedRun feeds the anime that errored during a seed run
+// into the regular bulk reprocess job.
+func (s *AdminService) StartBulkReprocessFromSeedRun(ctx context.Context, runID string) (string, int, error) {
+	if _, err := s.repo.GetSeedRun(ctx, runID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", 0, ErrSeedRunNotFound
+		}
+		return "", 0, fmt.Errorf("get seed run: %w", err)
+	}
+
+	hiAnimeIDs, err := s.repo.GetSeedRunErroredHiAnimeIds(ctx, runID)
+	if err != nil {
+		return "", 0, fmt.Errorf("get errored anime: %w", err)
+	}
+	if len(hiAnimeIDs) == 0 {
+		return "", 0, ErrSeedRunNoErrored
+	}
+
+	jobID, err := s.StartBulkReprocessFromIDs(hiAnimeIDs)
+	if err != nil {
+		return "", 0, err
+	}
+	return jobID, len(hiAnimeIDs), nil
+}
@@ -10,7 +10,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/coeeter/aniways/internal/infra/cache"
 	"github.com/coeeter/aniways/internal/infra/client/hianime"
+	"github.com/coeeter/aniways/internal/mappers"
 	"github.com/coeeter/aniways/internal/models"
 	"github.com/coeeter/aniways/internal/repository"
 	"github.com/jackc/pgx/v5"
@@ -25,13 +27,15 @@ const (
 type AdminService struct {
 	repo       *repository.Queries
 	scraper    *hianime.HianimeScraper
+	cache      cache.Cache
 	jobManager *JobManager
 }
 
-func NewAdminService(repo *repository.Queries, scraper *hianime.HianimeScraper) *AdminService {
+func NewAdminService(repo *repository.Queries, scraper *hianime.HianimeScraper, cache cache.Cache) *AdminService {
 	return &AdminService{
 		repo:       repo,
 		scraper:    scraper,
+		cache:      cache,
 		jobManager: NewJobManager(),
 	}
 }
@@ -58,6 +62,18 @@ func (s *AdminService) processAnimeDetails(ctx context.Context, hiAnimeID string
 }
 
 func (s *AdminService) updateOrCreateAnimeInDatabase(ctx context.Context, hiAnimeID string, info hianime.ScrapedAnimeInfoDto) models.ReprocessResult {
+	override, err := s.repo.GetAnimeMappingOverride(ctx, hiAnimeID)
+	switch {
+	case err == nil:
+		mappers.ApplyMappingOverride(&info, override)
+	case !errors.Is(err, pgx.ErrNoRows):
+		return models.ReprocessResult{
+			HiAnimeID: hiAnimeID,
+			Success:   false,
+			Message:   fmt.Sprintf("Failed to load mapping override: %v", err),
+		}
+	}
+
 	existingAnime, err := s.repo.GetAnimeByHiAnimeId(ctx, hiAnimeID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -94,6 +110,7 @@ func (s *AdminService) updateOrCreateAnimeInDatabase(ctx context.Context, hiAnim
 				Message:   fmt.Sprintf("Failed to update anime in database: %v", err),
 			}
 		}
+		s.invalidateCache(ctx, cache.AnimeTag(existingAnime.ID))
 
 		return models.ReprocessResult{
 			HiAnimeID: hiAnimeID,
@@ -132,6 +149,7 @@ func (s *AdminService) createNewAnime(ctx context.Context, hiAnimeID string, inf
 			Message:   fmt.Sprintf("Failed to create new anime in database: %v", err),
 		}
 	}
+	s.invalidateCache(ctx, cache.TagListings)
 
 	return models.ReprocessResult{
 		HiAnimeID: hiAnimeID,
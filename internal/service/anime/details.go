@@ -19,6 +19,7 @@ import (
 
 var (
 	ErrAnimeNotFound     = errors.New("anime not found")
+	ErrAnimeUnavailable  = errors.New("anime is no longer available")
 	ErrCharacterNotFound = errors.New("character not found")
 	ErrPersonNotFound    = errors.New("person not found")
 	TrailerNotFound      = errors.New("trailer not found")
@@ -129,7 +130,7 @@ func (s *AnimeService) GetAnimeBanner(ctx context.Context, id string) (models.Ba
 		}
 
 		return anime.Media.BannerImage, nil
-	})
+	}, cache.Tags(cache.AnimeTag(id)))
 
 	if err != nil {
 		return models.BannerResponse{}, err
@@ -219,7 +220,7 @@ func (s *AnimeService) GetAnimeRelations(ctx context.Context, id string) (models
 		}
 
 		return relations, nil
-	})
+	}, cache.Tags(cache.AnimeTag(id)))
 }
 
 func (s *AnimeService) GetAnimeCharacters(ctx context.Context, id string) (models.CharactersResponse, error) {
@@ -260,7 +261,7 @@ func (s *AnimeService) GetAnimeCharacters(ctx context.Context, id string) (model
 		})
 
 		return dto, nil
-	})
+	}, cache.Tags(cache.AnimeTag(id)))
 }
 
 func (s *AnimeService) GetCharacterFull(ctx context.Context, malID int32) (models.CharacterFullResponse, error) {
@@ -21,6 +21,9 @@ func (s *AnimeService) GetAnimeEpisodes(ctx context.Context, id string) (models.
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch anime by ID %s: %v", id, err)
 		}
+		if a.UnavailableAt.Valid {
+			return nil, ErrAnimeUnavailable
+		}
 
 		episodes, err := s.scraper.GetAnimeEpisodes(ctx, a.HiAnimeID)
 		if err != nil {
@@ -36,7 +39,7 @@ func (s *AnimeService) GetAnimeEpisodes(ctx context.Context, id string) (models.
 			episodeResponses[i] = mappers.EpisodeFromScraper(ep)
 		}
 		return episodeResponses, nil
-	})
+	}, cache.Tags(cache.AnimeTag(id)))
 }
 
 func (s *AnimeService) GetEpisodeServers(ctx context.Context, id, episodeID string) (models.EpisodeServerListResponse, error) {
@@ -61,7 +64,7 @@ func (s *AnimeService) GetEpisodeServers(ctx context.Context, id, episodeID stri
 		}
 
 		return serverResponses, nil
-	})
+	}, cache.Tags(cache.AnimeTag(id)))
 }
 
 func (s *AnimeService) GetEpisodeStream(ctx context.Context, id, serverID, serverName, streamType string) (models.StreamingDataResponse, error) {
@@ -81,5 +84,5 @@ func (s *AnimeService) GetEpisodeStream(ctx context.Context, id, serverID, serve
 		}
 
 		return mappers.StreamingDataFromScraper(streamData), nil
-	})
+	}, cache.Tags(cache.AnimeTag(id)))
 }
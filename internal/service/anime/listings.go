@@ -17,14 +17,14 @@ import (
 func (s *AnimeService) GetAnimeCatalog(
 	ctx context.Context,
 	input *models.GetAnimeCatalogParams,
-	userID *string,
+	userID, collectionUserID *string,
 ) (models.AnimeWithLibraryListResponse, error) {
 	limit, offset, err := utils.ValidatePaginationParams(input.Page, input.ItemsPerPage)
 	if err != nil {
 		return models.AnimeWithLibraryListResponse{}, err
 	}
 
-	rows, err := s.repo.GetAnimeCatalog(ctx, input.ToRepo(limit, offset, userID))
+	rows, err := s.repo.GetAnimeCatalog(ctx, input.ToRepo(limit, offset, userID, collectionUserID))
 	if err != nil {
 		return models.AnimeWithLibraryListResponse{}, err
 	}
@@ -33,7 +33,7 @@ func (s *AnimeService) GetAnimeCatalog(
 		s.refresher.Enqueue(r.MalID.Int32)
 	}
 
-	total, err := s.repo.GetAnimeCatalogCount(ctx, input.ToRepoCount(userID))
+	total, err := s.repo.GetAnimeCatalogCount(ctx, input.ToRepoCount(userID, collectionUserID))
 	if err != nil {
 		return models.AnimeWithLibraryListResponse{}, err
 	}
@@ -379,6 +379,10 @@ func fetchAnimeDtosFromAnilist[T any](
 	return rows, rowMap, nil
 }
 
+// listingStaleFor is how long the hot listings keep serving an expired value
+// while a background fetch replaces it.
+const listingStaleFor = 24 * time.Hour
+
 func (s *AnimeService) GetSeasonalAnimes(ctx context.Context) (models.SeasonalAnimeListResponse, error) {
 	return cache.GetOrFill(ctx, s.redis, "seasonal_animes", 30*24*time.Hour, func(ctx context.Context) (models.SeasonalAnimeListResponse, error) {
 		now := time.Now()
@@ -434,7 +438,7 @@ func (s *AnimeService) GetSeasonalAnimes(ctx context.Context) (models.SeasonalAn
 		}
 
 		return seasonalAnimes, nil
-	})
+	}, cache.StaleFor(listingStaleFor), cache.Tags(cache.TagListings))
 }
 
 func (s *AnimeService) GetTrendingAnimes(ctx context.Context) (models.TrendingAnimeListResponse, error) {
@@ -462,7 +466,7 @@ func (s *AnimeService) GetTrendingAnimes(ctx context.Context) (models.TrendingAn
 		}
 
 		return trendingAnimes, nil
-	})
+	}, cache.StaleFor(listingStaleFor), cache.Tags(cache.TagListings))
 }
 
 func (s *AnimeService) GetPopularAnimes(ctx context.Context) (models.PopularAnimeListResponse, error) {
@@ -490,7 +494,7 @@ func (s *AnimeService) GetPopularAnimes(ctx context.Context) (models.PopularAnim
 		}
 
 		return popularAnimes, nil
-	})
+	}, cache.StaleFor(listingStaleFor), cache.Tags(cache.TagListings))
 }
 
 func (s *AnimeService) GetGenrePreviews(ctx context.Context) ([]models.GenrePreview, error) {
@@ -518,5 +522,5 @@ func (s *AnimeService) GetGenrePreviews(ctx context.Context) ([]models.GenrePrev
 			})
 		}
 		return out, nil
-	})
+	}, cache.StaleFor(listingStaleFor), cache.Tags(cache.TagListings))
 }
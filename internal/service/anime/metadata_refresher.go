@@ -15,7 +15,11 @@ import (
 const (
 	defaultWorkerCount = 20
 	defaultQueueSize   = 1000
-	defaultTTL         = 30 * 24 * time.Hour // 30 days
+
+	MetadataTTL = 30 * 24 * time.Hour // 30 days
+	// AiringMetadataTTL is used by the background sweeper for currently airing
+	// titles, whose episode counts and scores change weekly.
+	AiringMetadataTTL = 24 * time.Hour
 )
 
 var defaultMALRate = rate.Every(time.Minute / 60) // ~60 req/min
@@ -34,7 +38,7 @@ func NewRefresher(repo *repository.Queries, malClient *myanimelist.Client) *Meta
 	m := &MetadataRefresher{
 		repo:      repo,
 		malClient: malClient,
-		ttl:       defaultTTL,
+		ttl:       MetadataTTL,
 		limiter:   rate.NewLimiter(defaultMALRate, 1),
 		queue:     make(chan int32, defaultQueueSize),
 		inFlight:  make(map[int32]struct{}),
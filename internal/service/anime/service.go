@@ -18,7 +18,7 @@ type AnimeService struct {
 	jikanClient     *jikan.Client
 	anilistClient   *anilist.Client
 	shikimoriClient *shikimori.Client
-	redis           *cache.RedisClient
+	redis           cache.Cache
 }
 
 func NewAnimeService(
@@ -28,7 +28,7 @@ func NewAnimeService(
 	jikanClient *jikan.Client,
 	anilistClient *anilist.Client,
 	shikimoriClient *shikimori.Client,
-	redis *cache.RedisClient,
+	redis cache.Cache,
 ) *AnimeService {
 	return &AnimeService{
 		repo:            repo,
@@ -0,0 +1,148 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// KitsuTokenURL is the Kitsu token endpoint outside of tests.
+const KitsuTokenURL = "https://kitsu.io/api/oauth/token"
+
+// KitsuProvider connects Kitsu accounts with the OAuth password grant, Kitsu
+// has no authorization code flow for third party apps.
+type KitsuProvider struct {
+	clientID     string
+	clientSecret string
+	tokenURL     string
+	repo         *repository.Queries
+}
+
+func NewKitsuProvider(clientID, clientSecret, tokenURL string, repo *repository.Queries) *KitsuProvider {
+	return &KitsuProvider{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		tokenURL:     tokenURL,
+		repo:         repo,
+	}
+}
+
+func (k *KitsuProvider) Name() string {
+	return KitsuProviderName.String()
+}
+
+func (k *KitsuProvider) AuthURL(ctx context.Context, state string) (string, error) {
+	return "", ErrUnsupportedOperation
+}
+
+func (k *KitsuProvider) ExchangeToken(ctx context.Context, userID, state, code string) error {
+	return ErrUnsupportedOperation
+}
+
+func (k *KitsuProvider) ExchangePassword(ctx context.Context, userID, username, password string) error {
+	form := url.Values{}
+	form.Add("grant_type", "password")
+	form.Add("username", username)
+	form.Add("password", password)
+
+	token, err := k.requestToken(ctx, form)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := pgtype.Timestamp{
+		Time:  time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+		Valid: true,
+	}
+
+	_, err = k.repo.GetToken(ctx, repository.GetTokenParams{
+		UserID:   userID,
+		Provider: repository.Provider(k.Name()),
+	})
+
+	if err == nil {
+		return k.repo.UpdateOauthToken(ctx, repository.UpdateOauthTokenParams{
+			UserID:       userID,
+			Token:        token.AccessToken,
+			RefreshToken: token.RefreshToken,
+			Provider:     repository.Provider(k.Name()),
+			ExpiresAt:    expiresAt,
+		})
+	}
+
+	return k.repo.SaveOauthToken(ctx, repository.SaveOauthTokenParams{
+		UserID:       userID,
+		Token:        token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Provider:     repository.Provider(k.Name()),
+		ExpiresAt:    expiresAt,
+	})
+}
+
+func (k *KitsuProvider) RefreshToken(ctx context.Context, userID, refreshToken string) error {
+	form := url.Values{}
+	form.Add("grant_type", "refresh_token")
+	form.Add("refresh_token", refreshToken)
+
+	token, err := k.requestToken(ctx, form)
+	if errors.Is(err, ErrInvalidCredentials) {
+		return ErrRefreshRevoked
+	}
+	if err != nil {
+		return err
+	}
+
+	return k.repo.UpdateOauthToken(ctx, repository.UpdateOauthTokenParams{
+		UserID:       userID,
+		Token:        token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Provider:     repository.Provider(k.Name()),
+		ExpiresAt: pgtype.Timestamp{
+			Time:  time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+			Valid: true,
+		},
+	})
+}
+
+func (k *KitsuProvider) requestToken(ctx context.Context, form url.Values) (TokenResponse, error) {
+	form.Add("client_id", k.clientID)
+	form.Add("client_secret", k.clientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", k.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusUnauthorized:
+		// invalid_grant for a wrong password or a revoked refresh token
+		return TokenResponse{}, ErrInvalidCredentials
+	default:
+		return TokenResponse{}, fmt.Errorf("failed to request kitsu token: unexpected status code %d", resp.StatusCode)
+	}
+
+	var token TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return TokenResponse{}, err
+	}
+	return token, nil
+}
+
+var _ PasswordProvider = (*KitsuProvider)(nil)
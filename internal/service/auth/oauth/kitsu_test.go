@@ -0,0 +1,163 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeDB records the statements the provider runs. GetToken finds a row
+// only when hasToken is set.
+type fakeDB struct {
+	hasToken bool
+	execs    []exec
+}
+
+type exec struct {
+	SQL  string
+	Args []any
+}
+
+func (db *fakeDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	db.execs = append(db.execs, exec{SQL: sql, Args: args})
+	return pgconn.CommandTag{}, nil
+}
+
+func (db *fakeDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query")
+}
+
+func (db *fakeDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return fakeRow{found: db.hasToken}
+}
+
+func (db *fakeDB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return 0, errors.New("unexpected copy")
+}
+
+type fakeRow struct {
+	found bool
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if !r.found {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// newKitsuTokenServer answers grants it accepts with a fixed token pair and
+// rejects everything else with invalid_grant.
+func newKitsuTokenServer(t *testing.T, accept func(form url.Values) bool) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parse form: %v", err)
+			return
+		}
+		if r.PostForm.Get("client_id") != "id" || r.PostForm.Get("client_secret") != "secret" {
+			t.Errorf("missing client credentials in %v", r.PostForm)
+		}
+		if !accept(r.PostForm) {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"error":"invalid_grant"}`)
+			return
+		}
+		io.WriteString(w, `{"access_token":"access","refresh_token":"refresh","expires_in":3600,"token_type":"Bearer"}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func hasArgs(e exec, want ...string) bool {
+	for _, w := range want {
+		found := false
+		for _, a := range e.Args {
+			if a == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func TestKitsuPasswordGrant(t *testing.T) {
+	tokenURL := newKitsuTokenServer(t, func(form url.Values) bool {
+		return form.Get("grant_type") == "password" &&
+			form.Get("username") == "spike" &&
+			form.Get("password") == "bang"
+	})
+
+	for _, hasToken := range []bool{false, true} {
+		db := &fakeDB{hasToken: hasToken}
+		provider := NewKitsuProvider("id", "secret", tokenURL, repository.New(db))
+
+		if err := provider.ExchangePassword(context.Background(), "user", "spike", "bang"); err != nil {
+			t.Fatalf("ExchangePassword (existing token %v): %v", hasToken, err)
+		}
+		if len(db.execs) != 1 {
+			t.Fatalf("got %d statements, want 1", len(db.execs))
+		}
+
+		stmt := db.execs[0]
+		wantStmt := "SaveOauthToken"
+		if hasToken {
+			wantStmt = "UpdateOauthToken"
+		}
+		if !strings.Contains(stmt.SQL, wantStmt) {
+			t.Errorf("ran %q, want %s", stmt.SQL, wantStmt)
+		}
+		if !hasArgs(stmt, "user", "access", "refresh") {
+			t.Errorf("token not stored, args %v", stmt.Args)
+		}
+	}
+
+	db := &fakeDB{}
+	provider := NewKitsuProvider("id", "secret", tokenURL, repository.New(db))
+	err := provider.ExchangePassword(context.Background(), "user", "spike", "wrong")
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("wrong password error = %v, want ErrInvalidCredentials", err)
+	}
+	if len(db.execs) != 0 {
+		t.Errorf("wrong password stored a token")
+	}
+}
+
+func TestKitsuRefreshGrant(t *testing.T) {
+	tokenURL := newKitsuTokenServer(t, func(form url.Values) bool {
+		return form.Get("grant_type") == "refresh_token" && form.Get("refresh_token") == "old"
+	})
+
+	db := &fakeDB{hasToken: true}
+	provider := NewKitsuProvider("id", "secret", tokenURL, repository.New(db))
+
+	if err := provider.RefreshToken(context.Background(), "user", "old"); err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+	if len(db.execs) != 1 || !strings.Contains(db.execs[0].SQL, "UpdateOauthToken") {
+		t.Fatalf("unexpected statements %+v", db.execs)
+	}
+	if !hasArgs(db.execs[0], "user", "access", "refresh") {
+		t.Errorf("token not stored, args %v", db.execs[0].Args)
+	}
+
+	err := provider.RefreshToken(context.Background(), "user", "revoked")
+	if !errors.Is(err, ErrRefreshRevoked) {
+		t.Errorf("revoked refresh error = %v, want ErrRefreshRevoked", err)
+	}
+}
@@ -19,10 +19,10 @@ type MALProvider struct {
 	clientSecret string
 	redirectURL  string
 	repo         *repository.Queries
-	redis        *cache.RedisClient
+	redis        cache.Cache
 }
 
-func NewMALProvider(clientID, clientSecret, redirectURL string, repo *repository.Queries, redis *cache.RedisClient) *MALProvider {
+func NewMALProvider(clientID, clientSecret, redirectURL string, repo *repository.Queries, redis cache.Cache) *MALProvider {
 	return &MALProvider{
 		clientID:     clientID,
 		clientSecret: clientSecret,
@@ -94,6 +94,22 @@ func (m *MALProvider) ExchangeToken(ctx context.Context, userID, state, code str
 		Valid: true,
 	}
 
+	_, err = m.repo.GetToken(ctx, repository.GetTokenParams{
+		UserID:   userID,
+		Provider: repository.Provider(m.Name()),
+	})
+
+	if err == nil {
+		// reconnecting replaces the old token and clears needs_reauth
+		return m.repo.UpdateOauthToken(ctx, repository.UpdateOauthTokenParams{
+			UserID:       userID,
+			Token:        tokenResponse.AccessToken,
+			RefreshToken: tokenResponse.RefreshToken,
+			Provider:     repository.Provider(m.Name()),
+			ExpiresAt:    expiresAt,
+		})
+	}
+
 	return m.repo.SaveOauthToken(ctx, repository.SaveOauthTokenParams{
 		UserID:       userID,
 		Token:        tokenResponse.AccessToken,
@@ -110,7 +126,10 @@ func (m *MALProvider) RefreshToken(ctx context.Context, userID, refreshToken str
 	form.Add("grant_type", "refresh_token")
 	form.Add("refresh_token", refreshToken)
 
-	req, _ := http.NewRequest("POST", "https://myanimelist.net/v1/oauth2/token", strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://myanimelist.net/v1/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := http.DefaultClient.Do(req)
@@ -119,6 +138,15 @@ func (m *MALProvider) RefreshToken(ctx context.Context, userID, refreshToken str
 	}
 	defer resp.Body.Close()
 
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusUnauthorized:
+		// invalid_grant, the refresh token expired or the app was unlinked
+		return ErrRefreshRevoked
+	default:
+		return fmt.Errorf("failed to refresh token: unexpected status code %d", resp.StatusCode)
+	}
+
 	tokenResponse := TokenResponse{}
 	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
 		return err
@@ -129,21 +157,6 @@ func (m *MALProvider) RefreshToken(ctx context.Context, userID, refreshToken str
 		Valid: true,
 	}
 
-	_, err = m.repo.GetToken(ctx, repository.GetTokenParams{
-		UserID:   userID,
-		Provider: repository.Provider(m.Name()),
-	})
-
-	if err == nil {
-		return m.repo.UpdateOauthToken(ctx, repository.UpdateOauthTokenParams{
-			UserID:       userID,
-			Token:        tokenResponse.AccessToken,
-			RefreshToken: tokenResponse.RefreshToken,
-			Provider:     repository.Provider(m.Name()),
-			ExpiresAt:    expiresAt,
-		})
-	}
-
 	return m.repo.UpdateOauthToken(ctx, repository.UpdateOauthTokenParams{
 		UserID:       userID,
 		Token:        tokenResponse.AccessToken,
@@ -10,8 +10,10 @@ import (
 type ProviderName string
 
 const (
-	MALProviderName     ProviderName = "myanimelist"
-	AnilistProviderName ProviderName = "anilist"
+	MALProviderName       ProviderName = "myanimelist"
+	AnilistProviderName   ProviderName = "anilist"
+	KitsuProviderName     ProviderName = "kitsu"
+	ShikimoriProviderName ProviderName = "shikimori"
 )
 
 func (p ProviderName) String() string {
@@ -32,8 +34,21 @@ type Provider interface {
 	RefreshToken(ctx context.Context, userID, refreshToken string) error
 }
 
+// PasswordProvider is implemented by providers that are connected with the
+// user's credentials for that provider instead of a redirect.
+type PasswordProvider interface {
+	Provider
+	ExchangePassword(ctx context.Context, userID, username, password string) error
+}
+
 var (
 	ErrInvalidCodeVerifierLength = errors.New("invalid code verifier length")
+	// ErrRefreshRevoked is returned by RefreshToken when the provider rejects
+	// the refresh token, the user has to connect the provider again.
+	ErrRefreshRevoked = errors.New("refresh token revoked")
+	// ErrInvalidCredentials is returned by ExchangePassword when the provider
+	// rejects the username or password.
+	ErrInvalidCredentials = errors.New("invalid credentials")
 )
 
 type generateCodeVerifierParams struct {
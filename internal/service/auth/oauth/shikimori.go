@@ -0,0 +1,147 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/coeeter/aniways/internal/infra/client/shikimori"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const shikimoriTokenURL = "https://shikimori.one/oauth/token"
+
+type ShikimoriProvider struct {
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	repo         *repository.Queries
+}
+
+func NewShikimoriProvider(clientID, clientSecret, redirectURL string, repo *repository.Queries) *ShikimoriProvider {
+	return &ShikimoriProvider{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		repo:         repo,
+	}
+}
+
+func (s *ShikimoriProvider) Name() string {
+	return ShikimoriProviderName.String()
+}
+
+func (s *ShikimoriProvider) AuthURL(ctx context.Context, state string) (string, error) {
+	return fmt.Sprintf(
+		"https://shikimori.one/oauth/authorize?client_id=%s&redirect_uri=%s&response_type=code&scope=user_rates&state=%s",
+		url.QueryEscape(s.clientID),
+		url.QueryEscape(s.redirectURL),
+		url.QueryEscape(state),
+	), nil
+}
+
+func (s *ShikimoriProvider) ExchangeToken(ctx context.Context, userID, _, code string) error {
+	form := url.Values{}
+	form.Add("grant_type", "authorization_code")
+	form.Add("code", code)
+	form.Add("redirect_uri", s.redirectURL)
+
+	token, err := s.requestToken(ctx, form)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := pgtype.Timestamp{
+		Time:  time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+		Valid: true,
+	}
+
+	_, err = s.repo.GetToken(ctx, repository.GetTokenParams{
+		UserID:   userID,
+		Provider: repository.Provider(s.Name()),
+	})
+
+	if err == nil {
+		return s.repo.UpdateOauthToken(ctx, repository.UpdateOauthTokenParams{
+			UserID:       userID,
+			Token:        token.AccessToken,
+			RefreshToken: token.RefreshToken,
+			Provider:     repository.Provider(s.Name()),
+			ExpiresAt:    expiresAt,
+		})
+	}
+
+	return s.repo.SaveOauthToken(ctx, repository.SaveOauthTokenParams{
+		UserID:       userID,
+		Token:        token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Provider:     repository.Provider(s.Name()),
+		ExpiresAt:    expiresAt,
+	})
+}
+
+func (s *ShikimoriProvider) RefreshToken(ctx context.Context, userID, refreshToken string) error {
+	form := url.Values{}
+	form.Add("grant_type", "refresh_token")
+	form.Add("refresh_token", refreshToken)
+
+	token, err := s.requestToken(ctx, form)
+	if errors.Is(err, ErrInvalidCredentials) {
+		return ErrRefreshRevoked
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdateOauthToken(ctx, repository.UpdateOauthTokenParams{
+		UserID:       userID,
+		Token:        token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Provider:     repository.Provider(s.Name()),
+		ExpiresAt: pgtype.Timestamp{
+			Time:  time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+			Valid: true,
+		},
+	})
+}
+
+func (s *ShikimoriProvider) requestToken(ctx context.Context, form url.Values) (TokenResponse, error) {
+	form.Add("client_id", s.clientID)
+	form.Add("client_secret", s.clientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", shikimoriTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", shikimori.UserAgent)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return TokenResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusUnauthorized:
+		// invalid_grant, the code was used or the refresh token revoked
+		return TokenResponse{}, ErrInvalidCredentials
+	default:
+		return TokenResponse{}, fmt.Errorf("failed to request shikimori token: unexpected status code %d", resp.StatusCode)
+	}
+
+	var token TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return TokenResponse{}, err
+	}
+	return token, nil
+}
+
+var _ Provider = (*ShikimoriProvider)(nil)
@@ -95,15 +95,18 @@ func (s *AuthService) ResetPassword(ctx context.Context, userService *users.User
 	return nil
 }
 
-func (s *AuthService) GetConnectedProviders(ctx context.Context, userID string) ([]string, error) {
+func (s *AuthService) GetConnectedProviders(ctx context.Context, userID string) ([]models.OauthProviderResponse, error) {
 	providers, err := s.repo.GetAllOauthTokensOfUser(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	out := make([]string, len(providers))
+	out := make([]models.OauthProviderResponse, len(providers))
 	for i, provider := range providers {
-		out[i] = string(provider.Provider)
+		out[i] = models.OauthProviderResponse{
+			Provider:    string(provider.Provider),
+			NeedsReauth: provider.NeedsReauth,
+		}
 	}
 	return out, nil
 }
@@ -0,0 +1,330 @@
+package collections
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coeeter/aniways/internal/mappers"
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/service/anime"
+	"github.com/coeeter/aniways/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+type CollectionService struct {
+	repo *repository.Queries
+}
+
+func NewCollectionService(repo *repository.Queries) *CollectionService {
+	return &CollectionService{
+		repo: repo,
+	}
+}
+
+var (
+	ErrCollectionNotFound = errors.New("collection not found")
+	ErrInvalidVisibility  = errors.New("invalid visibility")
+	ErrItemExists         = errors.New("anime already in collection")
+	ErrItemNotFound       = errors.New("anime not in collection")
+	ErrInvalidOrder       = errors.New("order must list every anime in the collection once")
+)
+
+type CollectionParams struct {
+	Name        string
+	Description string
+	Visibility  string
+}
+
+func (p CollectionParams) visibility() (repository.CollectionVisibility, error) {
+	if !models.CollectionVisibility(p.Visibility).IsValid() {
+		return "", ErrInvalidVisibility
+	}
+	return repository.CollectionVisibility(p.Visibility), nil
+}
+
+type GetCollectionsParams struct {
+	UserID       string
+	Page         int
+	ItemsPerPage int
+}
+
+func (s *CollectionService) GetCollections(ctx context.Context, params GetCollectionsParams) (models.CollectionListResponse, error) {
+	limit, offset, err := utils.ValidatePaginationParams(params.Page, params.ItemsPerPage)
+	if err != nil {
+		return models.CollectionListResponse{}, err
+	}
+
+	rows, err := s.repo.GetCollectionsOfUser(ctx, repository.GetCollectionsOfUserParams{
+		UserID: params.UserID,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return models.CollectionListResponse{}, err
+	}
+
+	total, err := s.repo.GetCollectionsOfUserCount(ctx, params.UserID)
+	if err != nil {
+		return models.CollectionListResponse{}, err
+	}
+
+	out := make([]models.CollectionResponse, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, mappers.CollectionFromRepository(row.Collection, row.ItemCount))
+	}
+
+	pageSize := int64(limit)
+	pageInfo := utils.PageInfo(params.Page, pageSize, total)
+	return models.CollectionListResponse{
+		Items:    out,
+		PageInfo: pageInfo,
+	}, nil
+}
+
+func (s *CollectionService) CreateCollection(ctx context.Context, userID string, params CollectionParams) (models.CollectionResponse, error) {
+	visibility, err := params.visibility()
+	if err != nil {
+		return models.CollectionResponse{}, err
+	}
+
+	id, err := s.repo.CreateCollection(ctx, repository.CreateCollectionParams{
+		UserID:      userID,
+		Name:        params.Name,
+		Description: params.Description,
+		Visibility:  visibility,
+	})
+	if err != nil {
+		return models.CollectionResponse{}, err
+	}
+
+	return s.getCollection(ctx, userID, id)
+}
+
+func (s *CollectionService) UpdateCollection(ctx context.Context, userID, collectionID string, params CollectionParams) (models.CollectionResponse, error) {
+	visibility, err := params.visibility()
+	if err != nil {
+		return models.CollectionResponse{}, err
+	}
+
+	updated, err := s.repo.UpdateCollection(ctx, repository.UpdateCollectionParams{
+		ID:          collectionID,
+		UserID:      userID,
+		Name:        params.Name,
+		Description: params.Description,
+		Visibility:  visibility,
+	})
+	if err != nil {
+		return models.CollectionResponse{}, err
+	}
+	if updated == 0 {
+		return models.CollectionResponse{}, ErrCollectionNotFound
+	}
+
+	return s.getCollection(ctx, userID, collectionID)
+}
+
+func (s *CollectionService) DeleteCollection(ctx context.Context, userID, collectionID string) error {
+	deleted, err := s.repo.DeleteCollection(ctx, repository.DeleteCollectionParams{
+		ID:     collectionID,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (s *CollectionService) getCollection(ctx context.Context, userID, collectionID string) (models.CollectionResponse, error) {
+	row, err := s.repo.GetCollectionOfUser(ctx, repository.GetCollectionOfUserParams{
+		ID:     collectionID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.CollectionResponse{}, ErrCollectionNotFound
+	}
+	if err != nil {
+		return models.CollectionResponse{}, err
+	}
+	return mappers.CollectionFromRepository(row.Collection, row.ItemCount), nil
+}
+
+type GetCollectionParams struct {
+	UserID       string
+	CollectionID string
+	Page         int
+	ItemsPerPage int
+}
+
+// GetCollection returns one of the user's own collections, whatever its
+// visibility.
+func (s *CollectionService) GetCollection(ctx context.Context, params GetCollectionParams) (models.CollectionWithItemsResponse, error) {
+	collection, err := s.getCollection(ctx, params.UserID, params.CollectionID)
+	if err != nil {
+		return models.CollectionWithItemsResponse{}, err
+	}
+	return s.withItems(ctx, collection, params.Page, params.ItemsPerPage)
+}
+
+type GetSharedCollectionParams struct {
+	Slug         string
+	Page         int
+	ItemsPerPage int
+}
+
+// GetSharedCollection returns an unlisted or public collection by its slug.
+func (s *CollectionService) GetSharedCollection(ctx context.Context, params GetSharedCollectionParams) (models.CollectionWithItemsResponse, error) {
+	row, err := s.repo.GetSharedCollectionBySlug(ctx, params.Slug)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.CollectionWithItemsResponse{}, ErrCollectionNotFound
+	}
+	if err != nil {
+		return models.CollectionWithItemsResponse{}, err
+	}
+
+	collection := mappers.CollectionFromRepository(row.Collection, row.ItemCount)
+	return s.withItems(ctx, collection, params.Page, params.ItemsPerPage)
+}
+
+func (s *CollectionService) withItems(ctx context.Context, collection models.CollectionResponse, page, itemsPerPage int) (models.CollectionWithItemsResponse, error) {
+	limit, offset, err := utils.ValidatePaginationParams(page, itemsPerPage)
+	if err != nil {
+		return models.CollectionWithItemsResponse{}, err
+	}
+
+	rows, err := s.repo.GetCollectionItems(ctx, repository.GetCollectionItemsParams{
+		CollectionID: collection.ID,
+		Limit:        int32(limit),
+		Offset:       int32(offset),
+	})
+	if err != nil {
+		return models.CollectionWithItemsResponse{}, err
+	}
+
+	items := make([]models.CollectionItemResponse, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mappers.CollectionItemFromRepository(row.CollectionItem, row.Anime))
+	}
+
+	pageSize := int64(limit)
+	pageInfo := utils.PageInfo(page, pageSize, collection.ItemCount)
+	return models.CollectionWithItemsResponse{
+		Collection: collection,
+		PageInfo:   pageInfo,
+		Items:      items,
+	}, nil
+}
+
+type ItemParams struct {
+	UserID       string
+	CollectionID string
+	AnimeID      string
+	Note         string
+}
+
+// AddItem appends an anime to the end of the collection.
+func (s *CollectionService) AddItem(ctx context.Context, params ItemParams) error {
+	if _, err := s.getCollection(ctx, params.UserID, params.CollectionID); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.GetAnimeById(ctx, params.AnimeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return anime.ErrAnimeNotFound
+		}
+		return err
+	}
+
+	added, err := s.repo.AddCollectionItem(ctx, repository.AddCollectionItemParams{
+		CollectionID: params.CollectionID,
+		AnimeID:      params.AnimeID,
+		Note:         params.Note,
+	})
+	if err != nil {
+		return err
+	}
+	if added == 0 {
+		return ErrItemExists
+	}
+
+	return s.repo.TouchCollection(ctx, params.CollectionID)
+}
+
+func (s *CollectionService) UpdateItemNote(ctx context.Context, params ItemParams) error {
+	if _, err := s.getCollection(ctx, params.UserID, params.CollectionID); err != nil {
+		return err
+	}
+
+	updated, err := s.repo.UpdateCollectionItemNote(ctx, repository.UpdateCollectionItemNoteParams{
+		CollectionID: params.CollectionID,
+		AnimeID:      params.AnimeID,
+		Note:         params.Note,
+	})
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrItemNotFound
+	}
+
+	return s.repo.TouchCollection(ctx, params.CollectionID)
+}
+
+func (s *CollectionService) RemoveItem(ctx context.Context, userID, collectionID, animeID string) error {
+	if _, err := s.getCollection(ctx, userID, collectionID); err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeleteCollectionItem(ctx, repository.DeleteCollectionItemParams{
+		CollectionID: collectionID,
+		AnimeID:      animeID,
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrItemNotFound
+	}
+
+	return s.repo.TouchCollection(ctx, collectionID)
+}
+
+// ReorderItems sets the manual order of the collection. animeIDs must hold
+// every anime in the collection exactly once.
+func (s *CollectionService) ReorderItems(ctx context.Context, userID, collectionID string, animeIDs []string) error {
+	if _, err := s.getCollection(ctx, userID, collectionID); err != nil {
+		return err
+	}
+
+	current, err := s.repo.GetCollectionAnimeIDs(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	if len(current) != len(animeIDs) {
+		return ErrInvalidOrder
+	}
+
+	remaining := make(map[string]bool, len(current))
+	for _, id := range current {
+		remaining[id] = true
+	}
+	for _, id := range animeIDs {
+		if !remaining[id] {
+			return ErrInvalidOrder
+		}
+		delete(remaining, id)
+	}
+
+	err = s.repo.ReorderCollectionItems(ctx, repository.ReorderCollectionItemsParams{
+		CollectionID: collectionID,
+		AnimeIds:     animeIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.repo.TouchCollection(ctx, collectionID)
+}
@@ -0,0 +1,262 @@
+package follows
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coeeter/aniways/internal/mappers"
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+type FollowService struct {
+	repo *repository.Queries
+}
+
+func NewFollowService(repo *repository.Queries) *FollowService {
+	return &FollowService{
+		repo: repo,
+	}
+}
+
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrCannotFollowSelf = errors.New("cannot follow yourself")
+	ErrAlreadyFollowing = errors.New("already following user")
+	ErrNotFollowing     = errors.New("not following user")
+)
+
+// user looks a user up by username. Only users with a public profile can be
+// found unless includePrivate is set, which lets users unfollow or mute
+// someone who made their profile private after being followed.
+func (s *FollowService) user(ctx context.Context, username string, includePrivate bool) (repository.User, error) {
+	row, err := s.repo.GetProfileByUsername(ctx, username)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !includePrivate && !row.PublicProfile) {
+		return repository.User{}, ErrUserNotFound
+	}
+	return row.User, err
+}
+
+func (s *FollowService) Follow(ctx context.Context, userID, username string) error {
+	followee, err := s.user(ctx, username, false)
+	if err != nil {
+		return err
+	}
+	if followee.ID == userID {
+		return ErrCannotFollowSelf
+	}
+
+	created, err := s.repo.CreateFollow(ctx, repository.CreateFollowParams{
+		FollowerID: userID,
+		FolloweeID: followee.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if created == 0 {
+		return ErrAlreadyFollowing
+	}
+	return nil
+}
+
+func (s *FollowService) Unfollow(ctx context.Context, userID, username string) error {
+	followee, err := s.user(ctx, username, true)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeleteFollow(ctx, repository.DeleteFollowParams{
+		FollowerID: userID,
+		FolloweeID: followee.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNotFollowing
+	}
+	return nil
+}
+
+// SetMuted keeps a followed user out of the feed without unfollowing them.
+func (s *FollowService) SetMuted(ctx context.Context, userID, username string, muted bool) error {
+	followee, err := s.user(ctx, username, true)
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.repo.SetFollowMuted(ctx, repository.SetFollowMutedParams{
+		FollowerID: userID,
+		FolloweeID: followee.ID,
+		Muted:      muted,
+	})
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrNotFollowing
+	}
+	return nil
+}
+
+type GetFollowsParams struct {
+	UserID       string
+	Page         int
+	ItemsPerPage int
+}
+
+// GetFollowing lists the users the current user follows, with their mute
+// state.
+func (s *FollowService) GetFollowing(ctx context.Context, params GetFollowsParams) (models.FollowListResponse, error) {
+	return s.list(ctx, true, params, false)
+}
+
+func (s *FollowService) GetFollowers(ctx context.Context, params GetFollowsParams) (models.FollowListResponse, error) {
+	return s.list(ctx, false, params, false)
+}
+
+type GetProfileFollowsParams struct {
+	Username     string
+	Page         int
+	ItemsPerPage int
+}
+
+// GetProfileFollowing lists who a user with a public profile follows. Only
+// users who have a public profile themselves are listed.
+func (s *FollowService) GetProfileFollowing(ctx context.Context, params GetProfileFollowsParams) (models.FollowListResponse, error) {
+	return s.profileList(ctx, true, params)
+}
+
+func (s *FollowService) GetProfileFollowers(ctx context.Context, params GetProfileFollowsParams) (models.FollowListResponse, error) {
+	return s.profileList(ctx, false, params)
+}
+
+func (s *FollowService) profileList(ctx context.Context, following bool, params GetProfileFollowsParams) (models.FollowListResponse, error) {
+	user, err := s.user(ctx, params.Username, false)
+	if err != nil {
+		return models.FollowListResponse{}, err
+	}
+	return s.list(ctx, following, GetFollowsParams{
+		UserID:       user.ID,
+		Page:         params.Page,
+		ItemsPerPage: params.ItemsPerPage,
+	}, true)
+}
+
+// list pages who the user follows when following is set, otherwise who
+// follows the user.
+func (s *FollowService) list(ctx context.Context, following bool, params GetFollowsParams, publicOnly bool) (models.FollowListResponse, error) {
+	limit, offset, err := utils.ValidatePaginationParams(params.Page, params.ItemsPerPage)
+	if err != nil {
+		return models.FollowListResponse{}, err
+	}
+
+	var (
+		follows []repository.Follow
+		users   []repository.User
+		total   int64
+	)
+	if following {
+		rows, err := s.repo.GetFollowing(ctx, repository.GetFollowingParams{
+			UserID:     params.UserID,
+			PublicOnly: publicOnly,
+			Limit:      int32(limit),
+			Offset:     int32(offset),
+		})
+		if err != nil {
+			return models.FollowListResponse{}, err
+		}
+		for _, r := range rows {
+			follows = append(follows, r.Follow)
+			users = append(users, r.User)
+		}
+		total, err = s.repo.GetFollowingCount(ctx, repository.GetFollowingCountParams{
+			UserID:     params.UserID,
+			PublicOnly: publicOnly,
+		})
+		if err != nil {
+			return models.FollowListResponse{}, err
+		}
+	} else {
+		rows, err := s.repo.GetFollowers(ctx, repository.GetFollowersParams{
+			UserID:     params.UserID,
+			PublicOnly: publicOnly,
+			Limit:      int32(limit),
+			Offset:     int32(offset),
+		})
+		if err != nil {
+			return models.FollowListResponse{}, err
+		}
+		for _, r := range rows {
+			follows = append(follows, r.Follow)
+			users = append(users, r.User)
+		}
+		total, err = s.repo.GetFollowersCount(ctx, repository.GetFollowersCountParams{
+			UserID:     params.UserID,
+			PublicOnly: publicOnly,
+		})
+		if err != nil {
+			return models.FollowListResponse{}, err
+		}
+	}
+
+	out := make([]models.FollowResponse, 0, len(follows))
+	for i, f := range follows {
+		item := models.FollowResponse{
+			User:       mappers.PublicUserFromRepository(users[i]),
+			FollowedAt: f.CreatedAt.Time,
+		}
+		// mutes are private to the follower
+		if following && !publicOnly {
+			muted := f.Muted
+			item.Muted = &muted
+		}
+		out = append(out, item)
+	}
+
+	return models.FollowListResponse{
+		Items:    out,
+		PageInfo: utils.PageInfo(params.Page, int64(limit), total),
+	}, nil
+}
+
+type GetFeedParams struct {
+	UserID       string
+	Page         int
+	ItemsPerPage int
+}
+
+// GetFeed pages the library activity of the users the current user follows,
+// newest first. Muted users and users who hide their activity are left out.
+func (s *FollowService) GetFeed(ctx context.Context, params GetFeedParams) (models.FeedResponse, error) {
+	limit, offset, err := utils.ValidatePaginationParams(params.Page, params.ItemsPerPage)
+	if err != nil {
+		return models.FeedResponse{}, err
+	}
+
+	rows, err := s.repo.GetFeed(ctx, repository.GetFeedParams{
+		UserID: params.UserID,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return models.FeedResponse{}, err
+	}
+
+	total, err := s.repo.GetFeedCount(ctx, params.UserID)
+	if err != nil {
+		return models.FeedResponse{}, err
+	}
+
+	out := make([]models.ActivityResponse, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, mappers.ActivityFromRepository(r.LibraryActivity, r.User, r.Anime))
+	}
+
+	return models.FeedResponse{
+		Items:    out,
+		PageInfo: utils.PageInfo(params.Page, int64(limit), total),
+	}, nil
+}
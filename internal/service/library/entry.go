@@ -0,0 +1,180 @@
+package library
+
+import (
+	"errors"
+	"time"
+
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrInvalidScore     = errors.New("invalid score")
+	ErrInvalidDate      = errors.New("invalid date")
+	ErrInvalidRewatches = errors.New("invalid rewatches")
+)
+
+// EntryParams are the fields a user sets on a library entry. Nil optional
+// fields keep their current value, a score of 0 or an empty date clears it.
+type EntryParams struct {
+	Status          string
+	WatchedEpisodes int32
+	// Score is out of 100.
+	Score *int32
+	// StartedAt and CompletedAt are formatted as 2006-01-02.
+	StartedAt   *string
+	CompletedAt *string
+	Rewatches   *int32
+	Notes       *string
+}
+
+// apply writes the params over the entry, which is empty for new entries.
+// Progress moves the status along as the user's rules allow, then dates the
+// user did not set are filled in with today: the start date on the first
+// watched episode and the finish date once the entry is completed.
+func (p EntryParams) apply(entry *repository.Library, rules repository.GetLibraryTransitionRulesRow) error {
+	if !isValidStatus(p.Status) {
+		return ErrInvalidStatus
+	}
+	if p.WatchedEpisodes < 0 {
+		return ErrInvalidWatchedEpisodes
+	}
+
+	wasStarted := entry.WatchedEpisodes > 0
+	wasCompleted := entry.Status == repository.LibraryStatusCompleted
+	progressed := p.WatchedEpisodes > entry.WatchedEpisodes
+
+	entry.Status = repository.LibraryStatus(p.Status)
+	entry.WatchedEpisodes = p.WatchedEpisodes
+
+	if progressed {
+		transition(entry, rules)
+	}
+
+	if p.Score != nil {
+		if *p.Score < 0 || *p.Score > 100 {
+			return ErrInvalidScore
+		}
+		entry.Score = pgtype.Int2{Int16: int16(*p.Score), Valid: *p.Score > 0}
+	}
+	if p.StartedAt != nil {
+		date, err := parseDate(*p.StartedAt)
+		if err != nil {
+			return err
+		}
+		entry.StartedAt = date
+	}
+	if p.CompletedAt != nil {
+		date, err := parseDate(*p.CompletedAt)
+		if err != nil {
+			return err
+		}
+		entry.CompletedAt = date
+	}
+	if entry.StartedAt.Valid && entry.CompletedAt.Valid && entry.CompletedAt.Time.Before(entry.StartedAt.Time) {
+		return ErrInvalidDate
+	}
+	if p.Rewatches != nil {
+		if *p.Rewatches < 0 {
+			return ErrInvalidRewatches
+		}
+		entry.Rewatches = *p.Rewatches
+	}
+	if p.Notes != nil {
+		entry.Notes = *p.Notes
+	}
+
+	today := pgtype.Date{Time: time.Now().UTC().Truncate(24 * time.Hour), Valid: true}
+	if p.StartedAt == nil && !entry.StartedAt.Valid && !wasStarted && entry.WatchedEpisodes > 0 {
+		entry.StartedAt = today
+	}
+	if p.CompletedAt == nil && !entry.CompletedAt.Valid && !wasCompleted && entry.Status == repository.LibraryStatusCompleted {
+		entry.CompletedAt = today
+	}
+
+	return nil
+}
+
+// transition applies the automatic status changes that follow progress. An
+// entry is only completed once the series finished airing, before that the
+// episode total can still change.
+func transition(entry *repository.Library, rules repository.GetLibraryTransitionRulesRow) {
+	if rules.AutoStart && entry.Status == repository.LibraryStatusPlanning {
+		entry.Status = repository.LibraryStatusWatching
+	}
+	if rules.AutoComplete && rules.FinishedAiring && rules.TotalEpisodes > 0 &&
+		entry.Status == repository.LibraryStatusWatching && entry.WatchedEpisodes >= rules.TotalEpisodes {
+		entry.Status = repository.LibraryStatusCompleted
+	}
+}
+
+func parseDate(s string) (pgtype.Date, error) {
+	if s == "" {
+		return pgtype.Date{}, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return pgtype.Date{}, ErrInvalidDate
+	}
+	return pgtype.Date{Time: t, Valid: true}, nil
+}
+
+func formatDate(d pgtype.Date) string {
+	if !d.Valid {
+		return ""
+	}
+	return d.Time.Format(time.DateOnly)
+}
+
+// entryChanged reports whether any field pushed to providers differs.
+func entryChanged(a, b repository.Library) bool {
+	return a.Status != b.Status ||
+		a.WatchedEpisodes != b.WatchedEpisodes ||
+		a.Score != b.Score ||
+		formatDate(a.StartedAt) != formatDate(b.StartedAt) ||
+		formatDate(a.CompletedAt) != formatDate(b.CompletedAt) ||
+		a.Rewatches != b.Rewatches ||
+		a.Notes != b.Notes
+}
+
+// activitiesOf lists the feed activities a change to an entry produces, old
+// is empty for new entries. Completing an entry says more than progressing
+// it, so only one of the two is recorded.
+func activitiesOf(old, entry repository.Library, created bool) []repository.LibraryActivityType {
+	var types []repository.LibraryActivityType
+	if created {
+		types = append(types, repository.LibraryActivityTypeAdded)
+	}
+	switch {
+	case entry.Status == repository.LibraryStatusCompleted && old.Status != repository.LibraryStatusCompleted:
+		types = append(types, repository.LibraryActivityTypeCompleted)
+	case !created && entry.WatchedEpisodes > old.WatchedEpisodes:
+		types = append(types, repository.LibraryActivityTypeProgressed)
+	}
+	if entry.Score.Valid && entry.Score != old.Score {
+		types = append(types, repository.LibraryActivityTypeScored)
+	}
+	return types
+}
+
+// syncPayloadOf is the full state of the entry for providers, a score of 0
+// and empty dates mean the entry has none.
+func syncPayloadOf(l repository.Library) *SyncPayload {
+	status := string(l.Status)
+	score := int32(0)
+	if l.Score.Valid {
+		score = int32(l.Score.Int16)
+	}
+	startedAt := formatDate(l.StartedAt)
+	completedAt := formatDate(l.CompletedAt)
+
+	return &SyncPayload{
+		Status:          &status,
+		WatchedEpisodes: &l.WatchedEpisodes,
+		Score:           &score,
+		StartedAt:       &startedAt,
+		CompletedAt:     &completedAt,
+		Rewatches:       &l.Rewatches,
+		Notes:           &l.Notes,
+	}
+}
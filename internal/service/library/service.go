@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 
+	"github.com/coeeter/aniways/internal/infra/cache"
 	"github.com/coeeter/aniways/internal/mappers"
 	"github.com/coeeter/aniways/internal/models"
 	"github.com/coeeter/aniways/internal/repository"
@@ -17,12 +18,14 @@ import (
 type LibraryService struct {
 	repo      *repository.Queries
 	refresher *anime.MetadataRefresher
+	cache     cache.Cache
 }
 
-func NewLibraryService(repo *repository.Queries, refresher *anime.MetadataRefresher) *LibraryService {
+func NewLibraryService(repo *repository.Queries, refresher *anime.MetadataRefresher, cache cache.Cache) *LibraryService {
 	return &LibraryService{
 		repo:      repo,
 		refresher: refresher,
+		cache:     cache,
 	}
 }
 
@@ -112,29 +115,34 @@ func (s *LibraryService) GetLibraryByAnimeID(ctx context.Context, userID, animeI
 
 var ErrInvalidWatchedEpisodes = errors.New("invalid watched episodes")
 
-func (s *LibraryService) CreateLibrary(ctx context.Context, userID, animeID, status string, watchedEpisodes int32) (models.LibraryResponse, error) {
-	if !isValidStatus(status) {
-		return models.LibraryResponse{}, ErrInvalidStatus
+func (s *LibraryService) CreateLibrary(ctx context.Context, userID, animeID string, params EntryParams) (models.LibraryResponse, error) {
+	rules, err := s.transitionRules(ctx, userID, animeID)
+	if err != nil {
+		return models.LibraryResponse{}, err
 	}
 
-	if watchedEpisodes < 0 {
-		return models.LibraryResponse{}, ErrInvalidWatchedEpisodes
+	var entry repository.Library
+	if err := params.apply(&entry, rules); err != nil {
+		return models.LibraryResponse{}, err
 	}
 
-	err := s.repo.InsertLibrary(ctx, repository.InsertLibraryParams{
+	err = s.repo.InsertLibrary(ctx, repository.InsertLibraryParams{
 		UserID:          userID,
 		AnimeID:         animeID,
-		Status:          repository.LibraryStatus(status),
-		WatchedEpisodes: watchedEpisodes,
+		Status:          entry.Status,
+		WatchedEpisodes: entry.WatchedEpisodes,
+		Score:           entry.Score,
+		StartedAt:       entry.StartedAt,
+		CompletedAt:     entry.CompletedAt,
+		Rewatches:       entry.Rewatches,
+		Notes:           entry.Notes,
 	})
 	if err != nil {
 		return models.LibraryResponse{}, err
 	}
 
-	s.queueSync(ctx, userID, animeID, repository.LibraryActionsAddEntry, SyncPayload{
-		Status:          &status,
-		WatchedEpisodes: &watchedEpisodes,
-	})
+	s.queueSync(ctx, userID, animeID, syncPayloadOf(entry))
+	s.recordActivities(ctx, userID, animeID, entry, activitiesOf(repository.Library{}, entry, true))
 
 	lib, err := s.GetLibraryByAnimeID(ctx, userID, animeID)
 	if err != nil {
@@ -144,25 +152,39 @@ func (s *LibraryService) CreateLibrary(ctx context.Context, userID, animeID, sta
 	return lib, nil
 }
 
-func (s *LibraryService) UpdateLibrary(ctx context.Context, userID, animeID, status string, watchedEpisodes int32) (models.LibraryResponse, error) {
-	if !isValidStatus(status) {
-		return models.LibraryResponse{}, ErrInvalidStatus
+func (s *LibraryService) UpdateLibrary(ctx context.Context, userID, animeID string, params EntryParams) (models.LibraryResponse, error) {
+	row, err := s.repo.GetLibraryOfUserByAnimeID(ctx, repository.GetLibraryOfUserByAnimeIDParams{
+		UserID:  userID,
+		AnimeID: animeID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.LibraryResponse{}, ErrLibraryNotFound
 	}
-
-	if watchedEpisodes < 0 {
-		return models.LibraryResponse{}, ErrInvalidWatchedEpisodes
+	if err != nil {
+		return models.LibraryResponse{}, err
 	}
 
-	old, err := s.GetLibraryByAnimeID(ctx, userID, animeID)
+	rules, err := s.transitionRules(ctx, userID, animeID)
 	if err != nil {
 		return models.LibraryResponse{}, err
 	}
 
+	old := row.Library
+	entry := old
+	if err := params.apply(&entry, rules); err != nil {
+		return models.LibraryResponse{}, err
+	}
+
 	err = s.repo.UpdateLibrary(ctx, repository.UpdateLibraryParams{
 		UserID:          userID,
 		AnimeID:         animeID,
-		Status:          repository.LibraryStatus(status),
-		WatchedEpisodes: watchedEpisodes,
+		Status:          entry.Status,
+		WatchedEpisodes: entry.WatchedEpisodes,
+		Score:           entry.Score,
+		StartedAt:       entry.StartedAt,
+		CompletedAt:     entry.CompletedAt,
+		Rewatches:       entry.Rewatches,
+		Notes:           entry.Notes,
 	})
 	if err != nil {
 		return models.LibraryResponse{}, err
@@ -173,19 +195,41 @@ func (s *LibraryService) UpdateLibrary(ctx context.Context, userID, animeID, sta
 		return models.LibraryResponse{}, err
 	}
 
-	if old.Status != models.LibraryStatus(status) {
-		s.queueSync(ctx, userID, animeID, repository.LibraryActionsUpdateStatus, SyncPayload{
-			Status: &status,
-		})
+	if entryChanged(old, entry) {
+		s.queueSync(ctx, userID, animeID, syncPayloadOf(entry))
 	}
+	s.recordActivities(ctx, userID, animeID, entry, activitiesOf(old, entry, false))
 
-	if old.WatchedEpisodes != watchedEpisodes {
-		s.queueSync(ctx, userID, animeID, repository.LibraryActionsUpdateProgress, SyncPayload{
-			WatchedEpisodes: &watchedEpisodes,
-		})
+	return lib, nil
+}
+
+// transitionRules loads the user's automatic status settings together with
+// the episode total of the anime. Unknown anime get no transitions.
+func (s *LibraryService) transitionRules(ctx context.Context, userID, animeID string) (repository.GetLibraryTransitionRulesRow, error) {
+	rules, err := s.repo.GetLibraryTransitionRules(ctx, repository.GetLibraryTransitionRulesParams{
+		UserID:  userID,
+		AnimeID: animeID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repository.GetLibraryTransitionRulesRow{}, nil
 	}
+	return rules, err
+}
 
-	return lib, nil
+// PauseInactiveEntries pauses watching entries of users who opted in once
+// they have not changed for the user's configured number of days, and
+// queues the new status to every provider.
+func (s *LibraryService) PauseInactiveEntries(ctx context.Context) (int, error) {
+	entries, err := s.repo.PauseInactiveLibraryEntries(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, entry := range entries {
+		s.queueSync(ctx, entry.UserID, entry.AnimeID, syncPayloadOf(entry))
+	}
+
+	return len(entries), nil
 }
 
 func (s *LibraryService) DeleteLibrary(ctx context.Context, userID, animeID string) error {
@@ -193,7 +237,7 @@ func (s *LibraryService) DeleteLibrary(ctx context.Context, userID, animeID stri
 		UserID:  userID,
 		AnimeID: animeID,
 	})
-	s.queueSync(ctx, userID, animeID, repository.LibraryActionsDeleteEntry, SyncPayload{})
+	s.queueSync(ctx, userID, animeID, nil)
 	return err
 }
 
@@ -307,10 +351,24 @@ func (s *LibraryService) GetLibraryStats(ctx context.Context, userID string) (mo
 type SyncPayload struct {
 	Status          *string `json:"status,omitempty"`
 	WatchedEpisodes *int32  `json:"watched_episodes,omitempty"`
+	Score           *int32  `json:"score,omitempty"`
+	StartedAt       *string `json:"started_at,omitempty"`
+	CompletedAt     *string `json:"completed_at,omitempty"`
+	Rewatches       *int32  `json:"rewatches,omitempty"`
+	Notes           *string `json:"notes,omitempty"`
 }
 
-func (s *LibraryService) queueSync(ctx context.Context, userID, animeID string, action repository.LibraryActions, payload SyncPayload) {
-	data, err := json.Marshal(payload)
+// queueSync records the state the entry should have on every provider, nil
+// when it was deleted. Each provider keeps one pending sync per entry, so
+// quick successive changes collapse into a single push of the latest state.
+func (s *LibraryService) queueSync(ctx context.Context, userID, animeID string, state *SyncPayload) {
+	action := repository.LibraryActionsAddEntry
+	if state == nil {
+		action = repository.LibraryActionsDeleteEntry
+		state = &SyncPayload{}
+	}
+
+	data, err := json.Marshal(state)
 	if err != nil {
 		return
 	}
@@ -318,6 +376,8 @@ func (s *LibraryService) queueSync(ctx context.Context, userID, animeID string,
 	providers := []repository.Provider{
 		repository.ProviderMyanimelist,
 		repository.ProviderAnilist,
+		repository.ProviderKitsu,
+		repository.ProviderShikimori,
 	}
 
 	for _, p := range providers {
@@ -331,23 +391,67 @@ func (s *LibraryService) queueSync(ctx context.Context, userID, animeID string,
 	}
 }
 
-var ErrInvalidProvider = errors.New("invalid provider")
+// recordActivities adds the entry's activities to the feed of its followers.
+// Like syncs they are best effort and never fail the library change.
+func (s *LibraryService) recordActivities(ctx context.Context, userID, animeID string, entry repository.Library, types []repository.LibraryActivityType) {
+	for _, t := range types {
+		_ = s.repo.InsertLibraryActivity(ctx, repository.InsertLibraryActivityParams{
+			UserID:          userID,
+			AnimeID:         animeID,
+			Type:            t,
+			WatchedEpisodes: entry.WatchedEpisodes,
+			Score:           entry.Score,
+		})
+	}
+}
+
+var (
+	ErrInvalidProvider       = errors.New("invalid provider")
+	ErrInvalidImportStrategy = errors.New("invalid import strategy")
+)
+
+type ImportLibraryParams struct {
+	UserID   string
+	Provider string
+	// Strategy decides which side wins when an entry exists locally and on
+	// the provider, remote when empty.
+	Strategy string
+	// DryRun only records what the import would change.
+	DryRun bool
+}
 
-func (s *LibraryService) ImportLibrary(ctx context.Context, userID, provider string) (string, error) {
-	if provider != string(repository.ProviderMyanimelist) && provider != string(repository.ProviderAnilist) {
+func (s *LibraryService) ImportLibrary(ctx context.Context, params ImportLibraryParams) (string, error) {
+	switch repository.Provider(params.Provider) {
+	case repository.ProviderMyanimelist, repository.ProviderAnilist, repository.ProviderKitsu, repository.ProviderShikimori:
+	default:
 		return "", ErrInvalidProvider
 	}
 
+	strategy := models.LibraryImportStrategy(params.Strategy)
+	if strategy == "" {
+		strategy = models.LibraryImportStrategyRemote
+	}
+	if !strategy.IsValid() {
+		return "", ErrInvalidImportStrategy
+	}
+
 	return s.repo.CreateLibraryImportJob(ctx, repository.CreateLibraryImportJobParams{
-		UserID:   userID,
-		Provider: repository.Provider(provider),
+		UserID:   params.UserID,
+		Provider: repository.Provider(params.Provider),
+		Strategy: repository.LibraryImportStrategy(strategy),
+		DryRun:   params.DryRun,
 	})
 }
 
 var ErrJobNotFound = errors.New("job not found")
 
-func (s *LibraryService) GetImportLibraryStatus(ctx context.Context, jobID string) (models.LibraryImportJobResponse, error) {
-	status, err := s.repo.GetLibraryImportJob(ctx, jobID)
+// GetImportLibraryStatus returns the user's import job with its report, jobs
+// of other users are not found.
+func (s *LibraryService) GetImportLibraryStatus(ctx context.Context, userID, jobID string) (models.LibraryImportJobResponse, error) {
+	status, err := s.repo.GetLibraryImportJobOfUser(ctx, repository.GetLibraryImportJobOfUserParams{
+		ID:     jobID,
+		UserID: userID,
+	})
 	if errors.Is(err, pgx.ErrNoRows) {
 		return models.LibraryImportJobResponse{}, ErrJobNotFound
 	}
@@ -355,7 +459,17 @@ func (s *LibraryService) GetImportLibraryStatus(ctx context.Context, jobID strin
 		return models.LibraryImportJobResponse{}, err
 	}
 
-	return mappers.LibraryImportJobFromRepository(status), nil
+	items, err := s.repo.GetLibraryImportReportItems(ctx, jobID)
+	if err != nil {
+		return models.LibraryImportJobResponse{}, err
+	}
+
+	changes, err := s.repo.GetLibraryImportChanges(ctx, jobID)
+	if err != nil {
+		return models.LibraryImportJobResponse{}, err
+	}
+
+	return mappers.LibraryImportJobFromRepository(status, items, changes), nil
 }
 
 func (s *LibraryService) ClearLibrary(ctx context.Context, userID string) error {
@@ -392,11 +506,18 @@ func (s *LibraryService) SwitchLibraryVariation(ctx context.Context, userID, cur
 
 	malID := currentAnime.MalID.Int32
 
-	// Get current library entry to preserve status and episodes
-	currentLib, err := s.GetLibraryByAnimeID(ctx, userID, currentAnimeID)
+	// Get current library entry to preserve its fields
+	currentRow, err := s.repo.GetLibraryOfUserByAnimeID(ctx, repository.GetLibraryOfUserByAnimeIDParams{
+		UserID:  userID,
+		AnimeID: currentAnimeID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.LibraryResponse{}, ErrLibraryNotFound
+	}
 	if err != nil {
 		return models.LibraryResponse{}, err
 	}
+	currentLib := currentRow.Library
 
 	// Get all animes with the same MAL ID
 	allVariations, err := s.repo.GetAnimeByMalId(ctx, pgtype.Int4{Int32: malID, Valid: true})
@@ -425,17 +546,28 @@ func (s *LibraryService) SwitchLibraryVariation(ctx context.Context, userID, cur
 		// If ErrLibraryNotFound, just continue - nothing to delete
 	}
 
-	// Insert new entry with variation ID, preserving status and episodes
+	// Insert new entry with variation ID, preserving every field
 	err = s.repo.InsertLibrary(ctx, repository.InsertLibraryParams{
 		UserID:          userID,
 		AnimeID:         variationID,
-		Status:          repository.LibraryStatus(currentLib.Status),
+		Status:          currentLib.Status,
 		WatchedEpisodes: currentLib.WatchedEpisodes,
+		Score:           currentLib.Score,
+		StartedAt:       currentLib.StartedAt,
+		CompletedAt:     currentLib.CompletedAt,
+		Rewatches:       currentLib.Rewatches,
+		Notes:           currentLib.Notes,
 	})
 	if err != nil {
 		return models.LibraryResponse{}, err
 	}
 
+	// drop what is cached for both variations, the switch already happened
+	// so a failed invalidation only leaves stale entries until they expire
+	for _, id := range []string{currentAnimeID, variationID} {
+		_, _ = s.cache.InvalidateTag(ctx, cache.AnimeTag(id))
+	}
+
 	// Return the new library entry
 	return s.GetLibraryByAnimeID(ctx, userID, variationID)
 }
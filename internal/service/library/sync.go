@@ -0,0 +1,43 @@
+package library
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coeeter/aniways/internal/mappers"
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+)
+
+var ErrNoFailedSyncs = errors.New("no failed syncs to retry")
+
+// GetSyncFailures lists the user's syncs to external providers that are
+// waiting on a retry or gave up.
+func (s *LibraryService) GetSyncFailures(ctx context.Context, userID string) ([]models.LibrarySyncFailureResponse, error) {
+	rows, err := s.repo.ListLibrarySyncFailures(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := make([]models.LibrarySyncFailureResponse, 0, len(rows))
+	for _, row := range rows {
+		failures = append(failures, mappers.LibrarySyncFailureFromRepository(row.ExternalLibrarySync, row.Anime))
+	}
+	return failures, nil
+}
+
+// RetrySyncs queues failed and dead syncs again with a fresh attempt count.
+// An empty animeID retries every failed sync of the user.
+func (s *LibraryService) RetrySyncs(ctx context.Context, userID, animeID string) (models.LibrarySyncRetryResponse, error) {
+	retried, err := s.repo.RetryLibrarySyncs(ctx, repository.RetryLibrarySyncsParams{
+		UserID:  userID,
+		AnimeID: animeID,
+	})
+	if err != nil {
+		return models.LibrarySyncRetryResponse{}, err
+	}
+	if retried == 0 {
+		return models.LibrarySyncRetryResponse{}, ErrNoFailedSyncs
+	}
+	return models.LibrarySyncRetryResponse{Retried: retried}, nil
+}
@@ -0,0 +1,155 @@
+package profiles
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/coeeter/aniways/internal/mappers"
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrSameUser = errors.New("cannot compare a library with itself")
+
+// minScoredPairs is how many shared titles both users must have scored
+// before the score correlation means anything.
+const minScoredPairs = 5
+
+type GetCompatibilityParams struct {
+	Username      string
+	OtherUsername string
+	// ViewerID is the current user, who can always compare their own
+	// library even when their profile is private. Empty when logged out.
+	ViewerID string
+}
+
+// libraryOwner loads a user whose library the viewer may see.
+func (s *ProfileService) libraryOwner(ctx context.Context, username, viewerID string) (repository.User, error) {
+	row, err := s.repo.GetProfileByUsername(ctx, username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return repository.User{}, ErrProfileNotFound
+	}
+	if err != nil {
+		return repository.User{}, err
+	}
+	if viewerID != "" && row.User.ID == viewerID {
+		return row.User, nil
+	}
+	if !row.PublicProfile {
+		return repository.User{}, ErrProfileNotFound
+	}
+	if !row.ShowLibrary {
+		return repository.User{}, ErrSectionHidden
+	}
+	return row.User, nil
+}
+
+func (s *ProfileService) GetCompatibility(ctx context.Context, params GetCompatibilityParams) (models.CompatibilityResponse, error) {
+	user, err := s.libraryOwner(ctx, params.Username, params.ViewerID)
+	if err != nil {
+		return models.CompatibilityResponse{}, err
+	}
+	other, err := s.libraryOwner(ctx, params.OtherUsername, params.ViewerID)
+	if err != nil {
+		return models.CompatibilityResponse{}, err
+	}
+	if user.ID == other.ID {
+		return models.CompatibilityResponse{}, ErrSameUser
+	}
+
+	rows, err := s.repo.GetSharedLibraryEntries(ctx, repository.GetSharedLibraryEntriesParams{
+		UserID:      user.ID,
+		OtherUserID: other.ID,
+	})
+	if err != nil {
+		return models.CompatibilityResponse{}, err
+	}
+
+	resp := compatibilityOf(rows)
+	resp.User = mappers.PublicUserFromRepository(user)
+	resp.OtherUser = mappers.PublicUserFromRepository(other)
+	return resp, nil
+}
+
+func compatibilityOf(rows []repository.GetSharedLibraryEntriesRow) models.CompatibilityResponse {
+	resp := models.CompatibilityResponse{
+		SharedTitles:               len(rows),
+		Basis:                      models.CompatibilityBasisStatuses,
+		UserCompletedOtherPlanning: []models.AnimeResponse{},
+		OtherCompletedUserPlanning: []models.AnimeResponse{},
+	}
+
+	var agreed int
+	var userScores, otherScores []float64
+	for _, r := range rows {
+		if r.UserStatus == r.OtherStatus {
+			agreed++
+			switch r.UserStatus {
+			case repository.LibraryStatusWatching:
+				resp.StatusOverlap.Watching++
+			case repository.LibraryStatusPlanning:
+				resp.StatusOverlap.Planning++
+			case repository.LibraryStatusCompleted:
+				resp.StatusOverlap.Completed++
+			case repository.LibraryStatusDropped:
+				resp.StatusOverlap.Dropped++
+			case repository.LibraryStatusPaused:
+				resp.StatusOverlap.Paused++
+			}
+		}
+
+		switch {
+		case r.UserStatus == repository.LibraryStatusCompleted && r.OtherStatus == repository.LibraryStatusPlanning:
+			resp.UserCompletedOtherPlanning = append(resp.UserCompletedOtherPlanning, mappers.AnimeFromRepository(r.Anime))
+		case r.OtherStatus == repository.LibraryStatusCompleted && r.UserStatus == repository.LibraryStatusPlanning:
+			resp.OtherCompletedUserPlanning = append(resp.OtherCompletedUserPlanning, mappers.AnimeFromRepository(r.Anime))
+		}
+
+		if r.UserScore.Valid && r.OtherScore.Valid {
+			userScores = append(userScores, float64(r.UserScore.Int16))
+			otherScores = append(otherScores, float64(r.OtherScore.Int16))
+		}
+	}
+
+	if len(rows) > 0 {
+		resp.StatusAgreement = float64(agreed) / float64(len(rows))
+		resp.Compatibility = int(math.Round(resp.StatusAgreement * 100))
+	}
+
+	if len(userScores) >= minScoredPairs {
+		if r, ok := correlation(userScores, otherScores); ok {
+			resp.ScoreCorrelation = &r
+			resp.Basis = models.CompatibilityBasisScores
+			// a correlation of -1 is no compatibility at all, 1 is full
+			resp.Compatibility = int(math.Round((r + 1) / 2 * 100))
+		}
+	}
+
+	return resp
+}
+
+// correlation is the Pearson correlation of xs and ys. It is undefined when
+// either side gave every title the same score.
+func correlation(xs, ys []float64) (float64, bool) {
+	n := float64(len(xs))
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(varX*varY), true
+}
@@ -0,0 +1,198 @@
+package profiles
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coeeter/aniways/internal/mappers"
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+type ProfileService struct {
+	repo *repository.Queries
+}
+
+func NewProfileService(repo *repository.Queries) *ProfileService {
+	return &ProfileService{
+		repo: repo,
+	}
+}
+
+var (
+	ErrProfileNotFound = errors.New("profile not found")
+	ErrSectionHidden   = errors.New("section is hidden by the user")
+	ErrInvalidStatus   = errors.New("invalid status")
+)
+
+// sectionSize is how many favorites and activity entries a profile shows.
+const sectionSize = 10
+
+// profile loads a user whose profile is public. Private profiles look the
+// same as missing users so usernames cannot be probed.
+func (s *ProfileService) profile(ctx context.Context, username string) (repository.GetProfileByUsernameRow, error) {
+	row, err := s.repo.GetProfileByUsername(ctx, username)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !row.PublicProfile) {
+		return repository.GetProfileByUsernameRow{}, ErrProfileNotFound
+	}
+	return row, err
+}
+
+func (s *ProfileService) GetProfile(ctx context.Context, username string) (models.ProfileResponse, error) {
+	row, err := s.profile(ctx, username)
+	if err != nil {
+		return models.ProfileResponse{}, err
+	}
+
+	resp := models.ProfileResponse{
+		ID:             row.User.ID,
+		Username:       row.User.Username,
+		ProfilePicture: row.User.ProfilePicture.String,
+		CreatedAt:      row.User.CreatedAt.Time,
+		Sections: models.ProfileSectionsResponse{
+			Library:   row.ShowLibrary,
+			Stats:     row.ShowStats,
+			Favorites: row.ShowFavorites,
+			// watching in incognito mode should not show up anywhere
+			Activity: row.ShowActivity && !row.IncognitoMode,
+		},
+	}
+
+	if resp.Sections.Stats {
+		stats, err := s.repo.GetProfileStats(ctx, row.User.ID)
+		if err != nil {
+			return models.ProfileResponse{}, err
+		}
+		resp.Stats = mappers.ProfileStatsFromRepository(stats)
+	}
+
+	if resp.Sections.Favorites {
+		rows, err := s.repo.GetProfileFavorites(ctx, repository.GetProfileFavoritesParams{
+			UserID:     row.User.ID,
+			LimitCount: sectionSize,
+		})
+		if err != nil {
+			return models.ProfileResponse{}, err
+		}
+		resp.Favorites = make([]models.LibraryResponse, 0, len(rows))
+		for _, r := range rows {
+			resp.Favorites = append(resp.Favorites, mappers.PublicLibraryFromRepository(r.Library, r.Anime))
+		}
+	}
+
+	if resp.Sections.Activity {
+		rows, err := s.repo.GetProfileActivity(ctx, repository.GetProfileActivityParams{
+			UserID:     row.User.ID,
+			LimitCount: sectionSize,
+		})
+		if err != nil {
+			return models.ProfileResponse{}, err
+		}
+		resp.Activity = make([]models.ActivityResponse, 0, len(rows))
+		for _, r := range rows {
+			resp.Activity = append(resp.Activity, mappers.ActivityFromRepository(r.LibraryActivity, row.User, r.Anime))
+		}
+	}
+
+	return resp, nil
+}
+
+type GetProfileLibraryParams struct {
+	Username     string
+	Status       string
+	Page         int
+	ItemsPerPage int
+}
+
+func (s *ProfileService) GetProfileLibrary(ctx context.Context, params GetProfileLibraryParams) (models.LibraryListResponse, error) {
+	limit, offset, err := utils.ValidatePaginationParams(params.Page, params.ItemsPerPage)
+	if err != nil {
+		return models.LibraryListResponse{}, err
+	}
+
+	if !models.LibraryStatus(params.Status).IsValid() {
+		return models.LibraryListResponse{}, ErrInvalidStatus
+	}
+
+	row, err := s.profile(ctx, params.Username)
+	if err != nil {
+		return models.LibraryListResponse{}, err
+	}
+	if !row.ShowLibrary {
+		return models.LibraryListResponse{}, ErrSectionHidden
+	}
+
+	rows, err := s.repo.GetLibrary(ctx, repository.GetLibraryParams{
+		UserID: row.User.ID,
+		Status: repository.LibraryStatus(params.Status),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return models.LibraryListResponse{}, err
+	}
+
+	total, err := s.repo.GetLibraryCount(ctx, repository.GetLibraryCountParams{
+		UserID: row.User.ID,
+		Status: repository.LibraryStatus(params.Status),
+	})
+	if err != nil {
+		return models.LibraryListResponse{}, err
+	}
+
+	out := make([]models.LibraryResponse, 0, len(rows))
+	for _, item := range rows {
+		out = append(out, mappers.PublicLibraryFromRepository(item.Library, item.Anime))
+	}
+
+	return models.LibraryListResponse{
+		Items:    out,
+		PageInfo: utils.PageInfo(params.Page, int64(limit), total),
+	}, nil
+}
+
+type GetProfileCollectionsParams struct {
+	Username     string
+	Page         int
+	ItemsPerPage int
+}
+
+// GetProfileCollections lists the public collections of a user with a public
+// profile. Unlisted collections are only reachable through their slug.
+func (s *ProfileService) GetProfileCollections(ctx context.Context, params GetProfileCollectionsParams) (models.CollectionListResponse, error) {
+	limit, offset, err := utils.ValidatePaginationParams(params.Page, params.ItemsPerPage)
+	if err != nil {
+		return models.CollectionListResponse{}, err
+	}
+
+	row, err := s.profile(ctx, params.Username)
+	if err != nil {
+		return models.CollectionListResponse{}, err
+	}
+
+	rows, err := s.repo.GetPublicCollectionsOfUser(ctx, repository.GetPublicCollectionsOfUserParams{
+		UserID: row.User.ID,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return models.CollectionListResponse{}, err
+	}
+
+	total, err := s.repo.GetPublicCollectionsOfUserCount(ctx, row.User.ID)
+	if err != nil {
+		return models.CollectionListResponse{}, err
+	}
+
+	out := make([]models.CollectionResponse, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, mappers.CollectionFromRepository(r.Collection, r.ItemCount))
+	}
+
+	return models.CollectionListResponse{
+		Items:    out,
+		PageInfo: utils.PageInfo(params.Page, int64(limit), total),
+	}, nil
+}
@@ -5,39 +5,51 @@ import (
 	"github.com/coeeter/aniways/internal/service/admin"
 	"github.com/coeeter/aniways/internal/service/anime"
 	"github.com/coeeter/aniways/internal/service/auth"
+	"github.com/coeeter/aniways/internal/service/collections"
 	"github.com/coeeter/aniways/internal/service/desktop"
+	"github.com/coeeter/aniways/internal/service/follows"
 	"github.com/coeeter/aniways/internal/service/library"
+	"github.com/coeeter/aniways/internal/service/profiles"
 	"github.com/coeeter/aniways/internal/service/settings"
 	"github.com/coeeter/aniways/internal/service/users"
 )
 
 type Services struct {
-	Anime    *anime.AnimeService
-	Library  *library.LibraryService
-	Auth     *auth.AuthService
-	Users    *users.UserService
-	Settings *settings.SettingsService
-	Admin    *admin.AdminService
-	Desktop  *desktop.DesktopService
+	Anime       *anime.AnimeService
+	Library     *library.LibraryService
+	Auth        *auth.AuthService
+	Users       *users.UserService
+	Settings    *settings.SettingsService
+	Admin       *admin.AdminService
+	Desktop     *desktop.DesktopService
+	Collections *collections.CollectionService
+	Profiles    *profiles.ProfileService
+	Follows     *follows.FollowService
 }
 
 func NewServices(deps *app.Deps) *Services {
 	refresher := anime.NewRefresher(deps.Repo, deps.MAL)
 	animeService := anime.NewAnimeService(deps.Repo, refresher, deps.MAL, deps.Jikan, deps.Anilist, deps.Shiki, deps.Cache)
-	libraryService := library.NewLibraryService(deps.Repo, refresher)
+	libraryService := library.NewLibraryService(deps.Repo, refresher, deps.Cache)
 	authService := auth.NewAuthService(deps.Repo, deps.EmailClient, deps.Env.FrontendURL)
 	userService := users.NewUserService(deps.Repo, deps.Cld)
 	settingsService := settings.NewSettingsService(deps.Repo)
-	adminService := admin.NewAdminService(deps.Repo, deps.Scraper)
+	adminService := admin.NewAdminService(deps.Repo, deps.Scraper, deps.Cache)
 	desktopService := desktop.NewDesktopService(deps.Repo)
+	collectionService := collections.NewCollectionService(deps.Repo)
+	profileService := profiles.NewProfileService(deps.Repo)
+	followService := follows.NewFollowService(deps.Repo)
 
 	return &Services{
-		Anime:    animeService,
-		Library:  libraryService,
-		Auth:     authService,
-		Users:    userService,
-		Settings: settingsService,
-		Admin:    adminService,
-		Desktop:  desktopService,
+		Anime:       animeService,
+		Library:     libraryService,
+		Auth:        authService,
+		Users:       userService,
+		Settings:    settingsService,
+		Admin:       adminService,
+		Desktop:     desktopService,
+		Collections: collectionService,
+		Profiles:    profileService,
+		Follows:     followService,
 	}
 }
@@ -33,6 +33,16 @@ func (s *SettingsService) GetSettings(ctx context.Context, userID string) (model
 			AutoResumeEpisode: false,
 			IncognitoMode:     false,
 			ThemeID:           1,
+			// keep in line with the column defaults
+			AutoStartLibrary:     true,
+			AutoCompleteLibrary:  true,
+			AutoPauseLibrary:     false,
+			AutoPauseAfterDays:   30,
+			PublicProfile:        false,
+			ProfileShowLibrary:   true,
+			ProfileShowStats:     true,
+			ProfileShowFavorites: true,
+			ProfileShowActivity:  true,
 		})
 		if err != nil {
 			return models.SettingsResponse{}, err
@@ -50,16 +60,48 @@ type SaveSettingsParams struct {
 	AutoResumeEpisode bool
 	IncognitoMode     bool
 	ThemeID           int
+	// The library automation and profile settings keep their current value
+	// when nil.
+	AutoStartLibrary     *bool
+	AutoCompleteLibrary  *bool
+	AutoPauseLibrary     *bool
+	AutoPauseAfterDays   *int
+	PublicProfile        *bool
+	ProfileShowLibrary   *bool
+	ProfileShowStats     *bool
+	ProfileShowFavorites *bool
+	ProfileShowActivity  *bool
+}
+
+func valueOr[T any](v *T, current T) T {
+	if v == nil {
+		return current
+	}
+	return *v
 }
 
 func (s *SettingsService) SaveSettings(ctx context.Context, params SaveSettingsParams) (models.SettingsResponse, error) {
+	current, err := s.GetSettings(ctx, params.UserID)
+	if err != nil {
+		return models.SettingsResponse{}, err
+	}
+
 	settings, err := s.repo.SaveSettings(ctx, repository.SaveSettingsParams{
-		UserID:            params.UserID,
-		AutoNextEpisode:   params.AutoNextEpisode,
-		AutoPlayEpisode:   params.AutoPlayEpisode,
-		AutoResumeEpisode: params.AutoResumeEpisode,
-		IncognitoMode:     params.IncognitoMode,
-		ThemeID:           int32(params.ThemeID),
+		UserID:               params.UserID,
+		AutoNextEpisode:      params.AutoNextEpisode,
+		AutoPlayEpisode:      params.AutoPlayEpisode,
+		AutoResumeEpisode:    params.AutoResumeEpisode,
+		IncognitoMode:        params.IncognitoMode,
+		ThemeID:              int32(params.ThemeID),
+		AutoStartLibrary:     valueOr(params.AutoStartLibrary, current.AutoStartLibrary),
+		AutoCompleteLibrary:  valueOr(params.AutoCompleteLibrary, current.AutoCompleteLibrary),
+		AutoPauseLibrary:     valueOr(params.AutoPauseLibrary, current.AutoPauseLibrary),
+		AutoPauseAfterDays:   int32(valueOr(params.AutoPauseAfterDays, current.AutoPauseAfterDays)),
+		PublicProfile:        valueOr(params.PublicProfile, current.PublicProfile),
+		ProfileShowLibrary:   valueOr(params.ProfileShowLibrary, current.ProfileShowLibrary),
+		ProfileShowStats:     valueOr(params.ProfileShowStats, current.ProfileShowStats),
+		ProfileShowFavorites: valueOr(params.ProfileShowFavorites, current.ProfileShowFavorites),
+		ProfileShowActivity:  valueOr(params.ProfileShowActivity, current.ProfileShowActivity),
 	})
 
 	if err != nil {
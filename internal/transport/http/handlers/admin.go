@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/coeeter/aniways/internal/models"
 	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/service/admin"
 	"github.com/coeeter/aniways/internal/transport/http/middleware"
 	"github.com/go-chi/chi/v5"
 )
@@ -21,6 +23,18 @@ func (h *Handler) AdminRoutes() {
 		r.Get("/bulk-job/{jobId}/failed-ids", h.downloadFailedIds)
 		r.Post("/bulk-job/{jobId}/retry", h.retryFailedIds)
 		r.Post("/unknown-season-fix", h.unknownSeasonFix)
+		r.Get("/seed-runs", h.listSeedRuns)
+		r.Post("/seed-runs/{runId}/reprocess", h.reprocessSeedRunErrors)
+		r.Get("/mapping-overrides", h.listMappingOverrides)
+		r.Get("/mapping-overrides/{hiAnimeId}", h.getMappingOverride)
+		r.Put("/mapping-overrides/{hiAnimeId}", h.upsertMappingOverride)
+		r.Delete("/mapping-overrides/{hiAnimeId}", h.deleteMappingOverride)
+		r.Get("/mapping-overrides/{hiAnimeId}/audit", h.getMappingOverrideAudit)
+		r.Get("/mapping-suggestions", h.listMappingSuggestions)
+		r.Post("/mapping-suggestions/{suggestionId}/approve", h.approveMappingSuggestion)
+		r.Post("/mapping-suggestions/{suggestionId}/reject", h.rejectMappingSuggestion)
+		r.Get("/metadata/coverage", h.getMetadataCoverage)
+		r.Post("/cache/purge", h.purgeCache)
 	})
 }
 
@@ -258,3 +272,267 @@ func (h *Handler) unknownSeasonFix(w http.ResponseWriter, r *http.Request) {
 		"count":   count,
 	})
 }
+
+func (h *Handler) listSeedRuns(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	runs, err := h.services.Admin.ListSeedRuns(r.Context(), 20)
+	if err != nil {
+		log.Error("Failed to list seed runs", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to list seed runs")
+		return
+	}
+
+	h.jsonOK(w, runs)
+}
+
+func (h *Handler) reprocessSeedRunErrors(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	runID, err := h.pathParam(r, "runId")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	jobID, count, err := h.services.Admin.StartBulkReprocessFromSeedRun(r.Context(), runID)
+	switch err {
+	case nil:
+	case admin.ErrSeedRunNotFound:
+		h.jsonError(w, http.StatusNotFound, "Seed run not found")
+		return
+	case admin.ErrSeedRunNoErrored:
+		h.jsonError(w, http.StatusBadRequest, "No errored anime to reprocess")
+		return
+	default:
+		log.Error("Failed to start seed run reprocess job", "runId", runID, "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to start reprocess job")
+		return
+	}
+
+	h.jsonOK(w, map[string]any{
+		"jobId":   jobID,
+		"message": fmt.Sprintf("Reprocess job started with %d errored IDs", count),
+	})
+}
+
+func (h *Handler) listMappingOverrides(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	page, size, err := h.parsePagination(r, 1, 50)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	overrides, err := h.services.Admin.ListMappingOverrides(r.Context(), page, size)
+	if err != nil {
+		log.Error("Failed to list mapping overrides", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to list mapping overrides")
+		return
+	}
+
+	h.jsonOK(w, overrides)
+}
+
+func (h *Handler) getMappingOverride(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	hiAnimeID, err := h.pathParam(r, "hiAnimeId")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	override, err := h.services.Admin.GetMappingOverride(r.Context(), hiAnimeID)
+	switch err {
+	case nil:
+		h.jsonOK(w, override)
+	case admin.ErrMappingOverrideNotFound:
+		h.jsonError(w, http.StatusNotFound, "Mapping override not found")
+	default:
+		log.Error("Failed to get mapping override", "hiAnimeId", hiAnimeID, "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to get mapping override")
+	}
+}
+
+func (h *Handler) upsertMappingOverride(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	hiAnimeID, err := h.pathParam(r, "hiAnimeId")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req models.AnimeMappingOverrideRequest
+	if !h.parseAndValidate(w, r, &req) {
+		return
+	}
+
+	actor := middleware.AdminActor(r)
+	override, err := h.services.Admin.UpsertMappingOverride(r.Context(), hiAnimeID, actor, req)
+	if err != nil {
+		log.Error("Failed to save mapping override", "hiAnimeId", hiAnimeID, "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to save mapping override")
+		return
+	}
+
+	log.Info("Mapping override saved", "hiAnimeId", hiAnimeID, "by", actor)
+	h.jsonOK(w, override)
+}
+
+func (h *Handler) deleteMappingOverride(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	hiAnimeID, err := h.pathParam(r, "hiAnimeId")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor := middleware.AdminActor(r)
+	err = h.services.Admin.DeleteMappingOverride(r.Context(), hiAnimeID, actor)
+	switch err {
+	case nil:
+		log.Info("Mapping override deleted", "hiAnimeId", hiAnimeID, "by", actor)
+		w.WriteHeader(http.StatusNoContent)
+	case admin.ErrMappingOverrideNotFound:
+		h.jsonError(w, http.StatusNotFound, "Mapping override not found")
+	default:
+		log.Error("Failed to delete mapping override", "hiAnimeId", hiAnimeID, "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to delete mapping override")
+	}
+}
+
+func (h *Handler) getMappingOverrideAudit(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	hiAnimeID, err := h.pathParam(r, "hiAnimeId")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entries, err := h.services.Admin.GetMappingOverrideAudit(r.Context(), hiAnimeID)
+	if err != nil {
+		log.Error("Failed to get mapping override audit", "hiAnimeId", hiAnimeID, "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to get mapping override audit")
+		return
+	}
+
+	h.jsonOK(w, entries)
+}
+
+func (h *Handler) listMappingSuggestions(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	page, size, err := h.parsePagination(r, 1, 50)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch repository.MappingSuggestionStatus(status) {
+	case "":
+		status = string(repository.MappingSuggestionStatusPending)
+	case repository.MappingSuggestionStatusPending,
+		repository.MappingSuggestionStatusApproved,
+		repository.MappingSuggestionStatusRejected:
+	default:
+		h.jsonError(w, http.StatusBadRequest, "Invalid status")
+		return
+	}
+
+	suggestions, err := h.services.Admin.ListMappingSuggestions(r.Context(), status, page, size)
+	if err != nil {
+		log.Error("Failed to list mapping suggestions", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to list mapping suggestions")
+		return
+	}
+
+	h.jsonOK(w, suggestions)
+}
+
+func (h *Handler) approveMappingSuggestion(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	suggestionID, err := h.pathParam(r, "suggestionId")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor := middleware.AdminActor(r)
+	override, err := h.services.Admin.ApproveMappingSuggestion(r.Context(), suggestionID, actor)
+	switch err {
+	case nil:
+		log.Info("Mapping suggestion approved", "suggestionId", suggestionID, "by", actor)
+		h.jsonOK(w, override)
+	case admin.ErrMappingSuggestionNotFound:
+		h.jsonError(w, http.StatusNotFound, "Mapping suggestion not found")
+	case admin.ErrMappingSuggestionReviewed:
+		h.jsonError(w, http.StatusConflict, "Mapping suggestion already reviewed")
+	default:
+		log.Error("Failed to approve mapping suggestion", "suggestionId", suggestionID, "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to approve mapping suggestion")
+	}
+}
+
+func (h *Handler) rejectMappingSuggestion(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	suggestionID, err := h.pathParam(r, "suggestionId")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor := middleware.AdminActor(r)
+	err = h.services.Admin.RejectMappingSuggestion(r.Context(), suggestionID, actor)
+	switch err {
+	case nil:
+		log.Info("Mapping suggestion rejected", "suggestionId", suggestionID, "by", actor)
+		w.WriteHeader(http.StatusNoContent)
+	case admin.ErrMappingSuggestionNotFound:
+		h.jsonError(w, http.StatusNotFound, "Mapping suggestion not found")
+	case admin.ErrMappingSuggestionReviewed:
+		h.jsonError(w, http.StatusConflict, "Mapping suggestion already reviewed")
+	default:
+		log.Error("Failed to reject mapping suggestion", "suggestionId", suggestionID, "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to reject mapping suggestion")
+	}
+}
+
+func (h *Handler) getMetadataCoverage(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	coverage, err := h.services.Admin.GetMetadataCoverage(r.Context())
+	if err != nil {
+		log.Error("Failed to get metadata coverage", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to get metadata coverage")
+		return
+	}
+
+	h.jsonOK(w, coverage)
+}
+
+func (h *Handler) purgeCache(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	var req models.CachePurgeRequest
+	if !h.parseAndValidate(w, r, &req) {
+		return
+	}
+
+	resp, err := h.services.Admin.PurgeCache(r.Context(), req)
+	if err != nil {
+		log.Error("Failed to purge cache", "tag", req.Tag, "prefix", req.Prefix, "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "Failed to purge cache")
+		return
+	}
+
+	log.Info("Cache purged", "tag", req.Tag, "prefix", req.Prefix, "deleted", resp.Deleted, "by", middleware.AdminActor(r))
+	h.jsonOK(w, resp)
+}
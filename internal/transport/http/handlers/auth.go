@@ -244,12 +244,12 @@ func (h *Handler) resetPassword(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary Get connected OAuth providers
-// @Description Get connected OAuth providers
+// @Description Get connected OAuth providers, needsReauth is set when the provider revoked the connection and it has to be connected again
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Security cookieAuth
-// @Success 200 {array} string
+// @Success 200 {array} models.OauthProviderResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /auth/providers [get]
 func (h *Handler) getProviders(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,454 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/service/anime"
+	"github.com/coeeter/aniways/internal/service/collections"
+	"github.com/coeeter/aniways/internal/transport/http/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *Handler) CollectionRoutes() {
+	h.r.Route("/collections", func(r chi.Router) {
+		r.Get("/shared/{slug}", h.getSharedCollection)
+		r.With(middleware.RequireUser).Group(func(r chi.Router) {
+			r.Get("/", h.getCollections)
+			r.Post("/", h.createCollection)
+			r.Get("/{collectionID}", h.getCollection)
+			r.Put("/{collectionID}", h.updateCollection)
+			r.Delete("/{collectionID}", h.deleteCollection)
+			r.Post("/{collectionID}/items", h.addCollectionItem)
+			r.Put("/{collectionID}/items/order", h.reorderCollectionItems)
+			r.Put("/{collectionID}/items/{animeID}", h.updateCollectionItem)
+			r.Delete("/{collectionID}/items/{animeID}", h.removeCollectionItem)
+		})
+	})
+}
+
+// @Summary Get user's collections
+// @Description Get the collections of the current user, most recently changed first
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param page query int false "Page number"
+// @Param itemsPerPage query int false "Number of items per page"
+// @Success 200 {object} models.CollectionListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /collections [get]
+func (h *Handler) getCollections(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	page, size, err := h.parsePagination(r, 1, 30)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.Collections.GetCollections(r.Context(), collections.GetCollectionsParams{
+		UserID:       user.ID,
+		Page:         page,
+		ItemsPerPage: size,
+	})
+	if err != nil {
+		log.Error("failed to get collections", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get collections")
+		return
+	}
+
+	h.jsonOK(w, resp)
+}
+
+// @Summary Create collection
+// @Description Create a collection for the current user
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param collection body models.CollectionRequest true "Collection object"
+// @Success 200 {object} models.CollectionResponse
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /collections [post]
+func (h *Handler) createCollection(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	var req models.CollectionRequest
+	if !h.parseAndValidate(w, r, &req) {
+		return
+	}
+
+	resp, err := h.services.Collections.CreateCollection(r.Context(), user.ID, collections.CollectionParams{
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  string(req.Visibility),
+	})
+	switch err {
+	case nil:
+		h.jsonOK(w, resp)
+	case collections.ErrInvalidVisibility:
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	default:
+		log.Error("failed to create collection", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to create collection")
+	}
+}
+
+// @Summary Get collection
+// @Description Get one of the current user's collections with a page of its items in order
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param collectionID path string true "Collection ID"
+// @Param page query int false "Page number"
+// @Param itemsPerPage query int false "Number of items per page"
+// @Success 200 {object} models.CollectionWithItemsResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /collections/{collectionID} [get]
+func (h *Handler) getCollection(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	collectionID, err := h.pathParam(r, "collectionID")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, size, err := h.parsePagination(r, 1, 30)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.Collections.GetCollection(r.Context(), collections.GetCollectionParams{
+		UserID:       user.ID,
+		CollectionID: collectionID,
+		Page:         page,
+		ItemsPerPage: size,
+	})
+	switch err {
+	case nil:
+		h.jsonOK(w, resp)
+	case collections.ErrCollectionNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to get collection", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get collection")
+	}
+}
+
+// @Summary Get shared collection
+// @Description Get an unlisted or public collection by its slug with a page of its items in order. Public collections are also listed on the owner's public profile
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param slug path string true "Collection slug"
+// @Param page query int false "Page number"
+// @Param itemsPerPage query int false "Number of items per page"
+// @Success 200 {object} models.CollectionWithItemsResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /collections/shared/{slug} [get]
+func (h *Handler) getSharedCollection(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	slug, err := h.pathParam(r, "slug")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, size, err := h.parsePagination(r, 1, 30)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.Collections.GetSharedCollection(r.Context(), collections.GetSharedCollectionParams{
+		Slug:         slug,
+		Page:         page,
+		ItemsPerPage: size,
+	})
+	switch err {
+	case nil:
+		h.jsonOK(w, resp)
+	case collections.ErrCollectionNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to get shared collection", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get shared collection")
+	}
+}
+
+// @Summary Update collection
+// @Description Update the name, description and visibility of a collection
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param collectionID path string true "Collection ID"
+// @Param collection body models.CollectionRequest true "Collection object"
+// @Success 200 {object} models.CollectionResponse
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /collections/{collectionID} [put]
+func (h *Handler) updateCollection(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	collectionID, err := h.pathParam(r, "collectionID")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req models.CollectionRequest
+	if !h.parseAndValidate(w, r, &req) {
+		return
+	}
+
+	resp, err := h.services.Collections.UpdateCollection(r.Context(), user.ID, collectionID, collections.CollectionParams{
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  string(req.Visibility),
+	})
+	switch err {
+	case nil:
+		h.jsonOK(w, resp)
+	case collections.ErrInvalidVisibility:
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case collections.ErrCollectionNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to update collection", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to update collection")
+	}
+}
+
+// @Summary Delete collection
+// @Description Delete a collection and its items
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param collectionID path string true "Collection ID"
+// @Success 200
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /collections/{collectionID} [delete]
+func (h *Handler) deleteCollection(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	collectionID, err := h.pathParam(r, "collectionID")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.services.Collections.DeleteCollection(r.Context(), user.ID, collectionID)
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusOK)
+	case collections.ErrCollectionNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to delete collection", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to delete collection")
+	}
+}
+
+// @Summary Add anime to collection
+// @Description Add an anime with an optional note to the end of a collection
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param collectionID path string true "Collection ID"
+// @Param item body models.CollectionItemRequest true "Collection item"
+// @Success 200
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /collections/{collectionID}/items [post]
+func (h *Handler) addCollectionItem(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	collectionID, err := h.pathParam(r, "collectionID")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req models.CollectionItemRequest
+	if !h.parseAndValidate(w, r, &req) {
+		return
+	}
+
+	err = h.services.Collections.AddItem(r.Context(), collections.ItemParams{
+		UserID:       user.ID,
+		CollectionID: collectionID,
+		AnimeID:      req.AnimeID,
+		Note:         req.Note,
+	})
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusOK)
+	case collections.ErrCollectionNotFound, anime.ErrAnimeNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case collections.ErrItemExists:
+		h.jsonError(w, http.StatusConflict, err.Error())
+	default:
+		log.Error("failed to add anime to collection", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to add anime to collection")
+	}
+}
+
+// @Summary Update collection item
+// @Description Update the note of an anime in a collection
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param collectionID path string true "Collection ID"
+// @Param animeID path string true "Anime ID"
+// @Param item body models.CollectionItemNoteRequest true "Collection item note"
+// @Success 200
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /collections/{collectionID}/items/{animeID} [put]
+func (h *Handler) updateCollectionItem(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	collectionID, err := h.pathParam(r, "collectionID")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	animeID, err := h.pathParam(r, "animeID")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req models.CollectionItemNoteRequest
+	if !h.parseAndValidate(w, r, &req) {
+		return
+	}
+
+	err = h.services.Collections.UpdateItemNote(r.Context(), collections.ItemParams{
+		UserID:       user.ID,
+		CollectionID: collectionID,
+		AnimeID:      animeID,
+		Note:         req.Note,
+	})
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusOK)
+	case collections.ErrCollectionNotFound, collections.ErrItemNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to update collection item", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to update collection item")
+	}
+}
+
+// @Summary Remove anime from collection
+// @Description Remove an anime from a collection
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param collectionID path string true "Collection ID"
+// @Param animeID path string true "Anime ID"
+// @Success 200
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /collections/{collectionID}/items/{animeID} [delete]
+func (h *Handler) removeCollectionItem(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	collectionID, err := h.pathParam(r, "collectionID")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	animeID, err := h.pathParam(r, "animeID")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.services.Collections.RemoveItem(r.Context(), user.ID, collectionID, animeID)
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusOK)
+	case collections.ErrCollectionNotFound, collections.ErrItemNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to remove anime from collection", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to remove anime from collection")
+	}
+}
+
+// @Summary Reorder collection
+// @Description Set the manual order of a collection, listing every anime in it once
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param collectionID path string true "Collection ID"
+// @Param order body models.CollectionOrderRequest true "New order"
+// @Success 200
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /collections/{collectionID}/items/order [put]
+func (h *Handler) reorderCollectionItems(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	collectionID, err := h.pathParam(r, "collectionID")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req models.CollectionOrderRequest
+	if !h.parseAndValidate(w, r, &req) {
+		return
+	}
+
+	err = h.services.Collections.ReorderItems(r.Context(), user.ID, collectionID, req.AnimeIDs)
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusOK)
+	case collections.ErrInvalidOrder:
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case collections.ErrCollectionNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to reorder collection", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to reorder collection")
+	}
+}
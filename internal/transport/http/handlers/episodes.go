@@ -24,6 +24,7 @@ func (h *Handler) AnimeEpisodeRoutes() {
 // @Success 200 {object} models.EpisodeListResponse
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
+// @Failure 410 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /anime/{id}/episodes [get]
 func (h *Handler) getAnimeEpisodes(w http.ResponseWriter, r *http.Request) {
@@ -41,6 +42,10 @@ func (h *Handler) getAnimeEpisodes(w http.ResponseWriter, r *http.Request) {
 		log.Warn("anime not found", "id", id, "err", err)
 		h.jsonError(w, http.StatusNotFound, "anime not found")
 		return
+	case anime.ErrAnimeUnavailable:
+		log.Warn("anime unavailable", "id", id, "err", err)
+		h.jsonError(w, http.StatusGone, "anime is no longer available")
+		return
 	case nil:
 		h.jsonOK(w, resp)
 		return
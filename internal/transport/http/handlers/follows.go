@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/service/follows"
+	"github.com/coeeter/aniways/internal/transport/http/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *Handler) FollowRoutes() {
+	h.r.With(middleware.RequireUser).Route("/follows", func(r chi.Router) {
+		r.Get("/following", h.getFollowing)
+		r.Get("/followers", h.getFollowers)
+		r.Post("/{username}", h.followUser)
+		r.Delete("/{username}", h.unfollowUser)
+		r.Put("/{username}/mute", h.muteUser)
+	})
+
+	h.r.With(middleware.RequireUser).Get("/feed", h.getFeed)
+}
+
+// @Summary Get followed users
+// @Description Get the users the current user follows with whether each is muted, most recently followed first
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param page query int false "Page number"
+// @Param itemsPerPage query int false "Number of items per page"
+// @Success 200 {object} models.FollowListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /follows/following [get]
+func (h *Handler) getFollowing(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	page, size, err := h.parsePagination(r, 1, 30)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.Follows.GetFollowing(r.Context(), follows.GetFollowsParams{
+		UserID:       user.ID,
+		Page:         page,
+		ItemsPerPage: size,
+	})
+	if err != nil {
+		log.Error("failed to get followed users", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get followed users")
+		return
+	}
+
+	h.jsonOK(w, resp)
+}
+
+// @Summary Get followers
+// @Description Get the users following the current user, most recent first
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param page query int false "Page number"
+// @Param itemsPerPage query int false "Number of items per page"
+// @Success 200 {object} models.FollowListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /follows/followers [get]
+func (h *Handler) getFollowers(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	page, size, err := h.parsePagination(r, 1, 30)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.Follows.GetFollowers(r.Context(), follows.GetFollowsParams{
+		UserID:       user.ID,
+		Page:         page,
+		ItemsPerPage: size,
+	})
+	if err != nil {
+		log.Error("failed to get followers", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get followers")
+		return
+	}
+
+	h.jsonOK(w, resp)
+}
+
+// @Summary Follow user
+// @Description Follow a user with a public profile
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param username path string true "Username"
+// @Success 200
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /follows/{username} [post]
+func (h *Handler) followUser(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	username, err := h.pathParam(r, "username")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.services.Follows.Follow(r.Context(), user.ID, username)
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusOK)
+	case follows.ErrCannotFollowSelf:
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case follows.ErrUserNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case follows.ErrAlreadyFollowing:
+		h.jsonError(w, http.StatusConflict, err.Error())
+	default:
+		log.Error("failed to follow user", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to follow user")
+	}
+}
+
+// @Summary Unfollow user
+// @Description Stop following a user
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param username path string true "Username"
+// @Success 200
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /follows/{username} [delete]
+func (h *Handler) unfollowUser(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	username, err := h.pathParam(r, "username")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.services.Follows.Unfollow(r.Context(), user.ID, username)
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusOK)
+	case follows.ErrUserNotFound, follows.ErrNotFollowing:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to unfollow user", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to unfollow user")
+	}
+}
+
+// @Summary Mute followed user
+// @Description Mute or unmute a followed user, muted users stay followed but are left out of the feed
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param username path string true "Username"
+// @Param mute body models.FollowMuteRequest true "Mute object"
+// @Success 200
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /follows/{username}/mute [put]
+func (h *Handler) muteUser(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	username, err := h.pathParam(r, "username")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req models.FollowMuteRequest
+	if !h.parseAndValidate(w, r, &req) {
+		return
+	}
+
+	err = h.services.Follows.SetMuted(r.Context(), user.ID, username, req.Muted)
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusOK)
+	case follows.ErrUserNotFound, follows.ErrNotFollowing:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to mute user", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to mute user")
+	}
+}
+
+// @Summary Get activity feed
+// @Description Get the library activity of followed users, newest first. Muted users and users who hide their activity are left out
+// @Tags Follows
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param page query int false "Page number"
+// @Param itemsPerPage query int false "Number of items per page"
+// @Success 200 {object} models.FeedResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /feed [get]
+func (h *Handler) getFeed(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	page, size, err := h.parsePagination(r, 1, 30)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.Follows.GetFeed(r.Context(), follows.GetFeedParams{
+		UserID:       user.ID,
+		Page:         page,
+		ItemsPerPage: size,
+	})
+	if err != nil {
+		log.Error("failed to get feed", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get feed")
+		return
+	}
+
+	h.jsonOK(w, resp)
+}
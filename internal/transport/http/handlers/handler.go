@@ -54,6 +54,9 @@ func (h *Handler) RegisterRoutes() {
 	h.SettingsRoutes()
 	h.AdminRoutes()
 	h.DesktopRoutes()
+	h.CollectionRoutes()
+	h.ProfileRoutes()
+	h.FollowRoutes()
 
 	h.RegisterOpenAPIRoutes()
 
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/coeeter/aniways/internal/models"
 	"github.com/coeeter/aniways/internal/service/library"
@@ -24,6 +25,10 @@ func (h *Handler) LibraryRoutes() {
 
 		r.Post("/import", h.importLibrary)
 		r.Get("/import/{id}", h.getLibraryImportStatus)
+
+		r.Get("/sync", h.getLibrarySyncFailures)
+		r.Post("/sync/retry", h.retryLibrarySyncs)
+		r.Post("/sync/{animeID}/retry", h.retryLibrarySyncs)
 	})
 }
 
@@ -267,9 +272,18 @@ func (h *Handler) createLibrary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	lib, err := h.services.Library.CreateLibrary(r.Context(), user.ID, animeID, string(req.Status), req.WatchedEpisodes)
+	lib, err := h.services.Library.CreateLibrary(r.Context(), user.ID, animeID, library.EntryParams{
+		Status:          string(req.Status),
+		WatchedEpisodes: req.WatchedEpisodes,
+		Score:           req.Score,
+		StartedAt:       req.StartedAt,
+		CompletedAt:     req.CompletedAt,
+		Rewatches:       req.Rewatches,
+		Notes:           req.Notes,
+	})
 	switch err {
-	case library.ErrInvalidStatus, library.ErrInvalidWatchedEpisodes:
+	case library.ErrInvalidStatus, library.ErrInvalidWatchedEpisodes,
+		library.ErrInvalidScore, library.ErrInvalidDate, library.ErrInvalidRewatches:
 		h.jsonError(w, http.StatusBadRequest, err.Error())
 	case nil:
 		h.jsonOK(w, lib)
@@ -311,9 +325,18 @@ func (h *Handler) updateLibrary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	lib, err := h.services.Library.UpdateLibrary(r.Context(), user.ID, animeID, string(req.Status), req.WatchedEpisodes)
+	lib, err := h.services.Library.UpdateLibrary(r.Context(), user.ID, animeID, library.EntryParams{
+		Status:          string(req.Status),
+		WatchedEpisodes: req.WatchedEpisodes,
+		Score:           req.Score,
+		StartedAt:       req.StartedAt,
+		CompletedAt:     req.CompletedAt,
+		Rewatches:       req.Rewatches,
+		Notes:           req.Notes,
+	})
 	switch err {
-	case library.ErrInvalidStatus, library.ErrInvalidWatchedEpisodes:
+	case library.ErrInvalidStatus, library.ErrInvalidWatchedEpisodes,
+		library.ErrInvalidScore, library.ErrInvalidDate, library.ErrInvalidRewatches:
 		h.jsonError(w, http.StatusBadRequest, err.Error())
 	case nil:
 		h.jsonOK(w, lib)
@@ -367,12 +390,14 @@ func (h *Handler) switchLibraryVariation(w http.ResponseWriter, r *http.Request)
 }
 
 // @Summary Import library from external provider
-// @Description Import library from external provider
+// @Description Import library from external provider. Entries are matched by MAL ID, then AniList ID, then title and season year. Of several variations of a show the one already in the library is picked, otherwise the available one with the most episodes. Sub or dub preference is not applied, the catalogue does not record the audio language of a variation
 // @Tags Library
 // @Accept json
 // @Produce json
 // @Security cookieAuth
 // @Param provider query string true "External provider to import from"
+// @Param strategy query string false "Which side wins for entries in both libraries (default: 'remote')" Enums(remote,local,newest,max_progress)
+// @Param dryRun query bool false "Only report what the import would change"
 // @Success 200 {object} models.ImportJobResponse
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
@@ -387,9 +412,24 @@ func (h *Handler) importLibrary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := h.services.Library.ImportLibrary(r.Context(), user.ID, provider)
+	dryRun := false
+	if v := r.URL.Query().Get("dryRun"); v != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(v)
+		if err != nil {
+			h.jsonError(w, http.StatusBadRequest, "invalid dryRun")
+			return
+		}
+	}
+
+	id, err := h.services.Library.ImportLibrary(r.Context(), library.ImportLibraryParams{
+		UserID:   user.ID,
+		Provider: provider,
+		Strategy: r.URL.Query().Get("strategy"),
+		DryRun:   dryRun,
+	})
 	switch err {
-	case library.ErrInvalidProvider:
+	case library.ErrInvalidProvider, library.ErrInvalidImportStrategy:
 		h.jsonError(w, http.StatusBadRequest, err.Error())
 	case nil:
 		h.jsonOK(w, models.ImportJobResponse{ID: id})
@@ -400,7 +440,7 @@ func (h *Handler) importLibrary(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary Get library import status
-// @Description Get library import status
+// @Description Get the status and report of one of the current user's library imports
 // @Tags Library
 // @Accept json
 // @Produce json
@@ -413,6 +453,7 @@ func (h *Handler) importLibrary(w http.ResponseWriter, r *http.Request) {
 // @Router /library/import/{id} [get]
 func (h *Handler) getLibraryImportStatus(w http.ResponseWriter, r *http.Request) {
 	log := h.logger(r)
+	user := middleware.GetUser(r)
 
 	id, err := h.pathParam(r, "id")
 	if err != nil {
@@ -420,7 +461,7 @@ func (h *Handler) getLibraryImportStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	status, err := h.services.Library.GetImportLibraryStatus(r.Context(), id)
+	status, err := h.services.Library.GetImportLibraryStatus(r.Context(), user.ID, id)
 	switch err {
 	case library.ErrJobNotFound:
 		h.jsonError(w, http.StatusNotFound, err.Error())
@@ -454,3 +495,56 @@ func (h *Handler) clearLibrary(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusOK)
 }
+
+// @Summary Get failed library syncs
+// @Description Get syncs to external providers that failed and are waiting on a retry, or gave up
+// @Tags Library
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Success 200 {array} models.LibrarySyncFailureResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /library/sync [get]
+func (h *Handler) getLibrarySyncFailures(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	failures, err := h.services.Library.GetSyncFailures(r.Context(), user.ID)
+	if err != nil {
+		log.Error("failed to get library sync failures", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get library sync failures")
+		return
+	}
+
+	h.jsonOK(w, failures)
+}
+
+// @Summary Retry failed library syncs
+// @Description Retry failed syncs to external providers, for one anime or the whole library
+// @Tags Library
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param animeID path string false "Anime ID"
+// @Success 200 {object} models.LibrarySyncRetryResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /library/sync/retry [post]
+// @Router /library/sync/{animeID}/retry [post]
+func (h *Handler) retryLibrarySyncs(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	animeID := chi.URLParam(r, "animeID")
+
+	resp, err := h.services.Library.RetrySyncs(r.Context(), user.ID, animeID)
+	switch err {
+	case library.ErrNoFailedSyncs:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	case nil:
+		h.jsonOK(w, resp)
+	default:
+		log.Error("failed to retry library syncs", "animeID", animeID, "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to retry library syncs")
+	}
+}
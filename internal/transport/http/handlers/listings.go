@@ -50,10 +50,13 @@ func (h *Handler) catalogInput() func(http.Handler) http.Handler {
 // @Param years query []int false "Filter by specific years (repeat for multiple)" collectionFormat(multi)
 // @Param yearMin query int false "Filter by minimum year (inclusive)"
 // @Param yearMax query int false "Filter by maximum year (inclusive)"
-// @Param sortBy query string false "Sort field" Enums(ename,jname,season,year,relevance,updated_at,anime_updated_at,library_updated_at)
+// @Param sortBy query string false "Sort field" Enums(ename,jname,season,year,relevance,updated_at,anime_updated_at,library_updated_at,library_score,library_started_at,library_completed_at,collection_position)
 // @Param sortOrder query string false "Sort order: 'asc' or 'desc' (default: 'desc')" Enums(asc,desc)
 // @Param inLibraryOnly query bool false "Only show anime in user's library (requires authentication)"
 // @Param status query string false "Filter by library status (requires authentication)" Enums(watching,completed,planning,dropped,paused)
+// @Param scoreMin query int false "Filter by minimum library score out of 100 (requires authentication)"
+// @Param scoreMax query int false "Filter by maximum library score out of 100 (requires authentication)"
+// @Param collectionId query string false "Only show anime in one of the user's collections (requires authentication)"
 // @Success 200 {object} models.AnimeWithLibraryListResponse "Anime catalog with optional library information"
 // @Failure 400 {object} models.ErrorResponse "Invalid request parameters"
 // @Failure 401 {object} models.ErrorResponse "Authentication required for library features"
@@ -72,16 +75,26 @@ func (h *Handler) catalog(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		userID = &user.ID
-	} else if input.Status != nil {
+	} else if input.FiltersLibrary() {
 		user := middleware.GetUser(r)
 		if user == nil {
-			h.jsonError(w, http.StatusUnauthorized, "authentication required for status filtering")
+			h.jsonError(w, http.StatusUnauthorized, "authentication required for library filtering")
 			return
 		}
 		userID = &user.ID
 	}
 
-	resp, err := h.services.Anime.GetAnimeCatalog(r.Context(), input, userID)
+	var collectionUserID *string
+	if input.CollectionID != nil {
+		user := middleware.GetUser(r)
+		if user == nil {
+			h.jsonError(w, http.StatusUnauthorized, "authentication required for collection filtering")
+			return
+		}
+		collectionUserID = &user.ID
+	}
+
+	resp, err := h.services.Anime.GetAnimeCatalog(r.Context(), input, userID, collectionUserID)
 	if err != nil {
 		log.Error("failed to fetch anime catalog", "err", err)
 		h.jsonError(w, http.StatusInternalServerError, "failed to fetch anime catalog")
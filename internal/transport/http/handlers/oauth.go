@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/service/auth/oauth"
 	"github.com/coeeter/aniways/internal/transport/http/middleware"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -13,6 +16,7 @@ func (h *Handler) OauthRoutes() {
 	h.r.With(middleware.RequireUser).Route("/auth/oauth", func(r chi.Router) {
 		r.Get("/{provider}", h.beginAuthHandler)
 		r.Get("/{provider}/callback", h.callbackHandler)
+		r.Post("/{provider}/password", h.passwordHandler)
 	})
 }
 
@@ -25,6 +29,7 @@ func (h *Handler) OauthRoutes() {
 // @Param provider path models.OAuthProvider true "OAuth provider"
 // @Param redirect query string false "Redirect URL after authentication"
 // @Success 302
+// @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /auth/oauth/{provider} [get]
@@ -39,6 +44,10 @@ func (h *Handler) beginAuthHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	url, err := provider.AuthURL(r.Context(), state)
+	if errors.Is(err, oauth.ErrUnsupportedOperation) {
+		h.jsonError(w, http.StatusBadRequest, "provider connects with credentials")
+		return
+	}
 	if err != nil {
 		log.Error("unable to create oauth url", "provider", provider.Name(), "err", err)
 		h.jsonError(w, http.StatusInternalServerError, "failed to create auth url")
@@ -105,3 +114,44 @@ func (h *Handler) callbackHandler(w http.ResponseWriter, r *http.Request) {
 
 	http.Redirect(w, r, redirect.Value, http.StatusFound)
 }
+
+// @Summary Connect OAuth provider with credentials
+// @Description Connect a provider that signs in with the user's account credentials instead of a redirect, such as Kitsu
+// @Tags OAuth
+// @Accept json
+// @Produce json
+// @Security cookieAuth
+// @Param provider path models.OAuthProvider true "OAuth provider"
+// @Param credentials body models.OAuthPasswordRequest true "Provider credentials"
+// @Success 200
+// @Failure 400 {object} models.ValidationErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /auth/oauth/{provider}/password [post]
+func (h *Handler) passwordHandler(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+	user := middleware.GetUser(r)
+
+	provider, ok := h.deps.Providers[chi.URLParam(r, "provider")].(oauth.PasswordProvider)
+	if !ok {
+		h.jsonError(w, http.StatusNotFound, "provider not found")
+		return
+	}
+
+	var req models.OAuthPasswordRequest
+	if !h.parseAndValidate(w, r, &req) {
+		return
+	}
+
+	err := provider.ExchangePassword(r.Context(), user.ID, req.Username, req.Password)
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusOK)
+	case errors.Is(err, oauth.ErrInvalidCredentials):
+		h.jsonError(w, http.StatusUnauthorized, "Invalid credentials")
+	default:
+		log.Error("unable to exchange password", "provider", provider.Name(), "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to exchange password")
+	}
+}
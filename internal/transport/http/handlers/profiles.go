@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/coeeter/aniways/internal/service/follows"
+	"github.com/coeeter/aniways/internal/service/profiles"
+	"github.com/coeeter/aniways/internal/transport/http/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *Handler) ProfileRoutes() {
+	h.r.Route("/profiles/{username}", func(r chi.Router) {
+		r.Get("/", h.getProfile)
+		r.Get("/library", h.getProfileLibrary)
+		r.Get("/collections", h.getProfileCollections)
+		r.Get("/following", h.getProfileFollowing)
+		r.Get("/followers", h.getProfileFollowers)
+		r.Get("/compatibility/{otherUsername}", h.getProfileCompatibility)
+	})
+}
+
+// @Summary Get public profile
+// @Description Get a user's public profile. Sections the user hid are null, activity is also hidden while the user is in incognito mode
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Param username path string true "Username"
+// @Success 200 {object} models.ProfileResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /profiles/{username} [get]
+func (h *Handler) getProfile(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	username, err := h.pathParam(r, "username")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.Profiles.GetProfile(r.Context(), username)
+	switch err {
+	case nil:
+		h.jsonOK(w, resp)
+	case profiles.ErrProfileNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to get profile", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get profile")
+	}
+}
+
+// @Summary Get public profile library
+// @Description Get the library of a user with a public profile by status, notes are left out
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Param username path string true "Username"
+// @Param status query string true "Library status"
+// @Param page query int false "Page number"
+// @Param itemsPerPage query int false "Number of items per page"
+// @Success 200 {object} models.LibraryListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /profiles/{username}/library [get]
+func (h *Handler) getProfileLibrary(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	username, err := h.pathParam(r, "username")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, size, err := h.parsePagination(r, 1, 30)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		h.jsonError(w, http.StatusBadRequest, "status is required")
+		return
+	}
+
+	resp, err := h.services.Profiles.GetProfileLibrary(r.Context(), profiles.GetProfileLibraryParams{
+		Username:     username,
+		Status:       status,
+		Page:         page,
+		ItemsPerPage: size,
+	})
+	switch err {
+	case nil:
+		h.jsonOK(w, resp)
+	case profiles.ErrInvalidStatus:
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case profiles.ErrSectionHidden:
+		h.jsonError(w, http.StatusForbidden, err.Error())
+	case profiles.ErrProfileNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to get profile library", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get profile library")
+	}
+}
+
+// @Summary Get public profile collections
+// @Description Get the public collections of a user with a public profile, most recently changed first. Unlisted collections are left out
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Param username path string true "Username"
+// @Param page query int false "Page number"
+// @Param itemsPerPage query int false "Number of items per page"
+// @Success 200 {object} models.CollectionListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /profiles/{username}/collections [get]
+func (h *Handler) getProfileCollections(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	username, err := h.pathParam(r, "username")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, size, err := h.parsePagination(r, 1, 30)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.Profiles.GetProfileCollections(r.Context(), profiles.GetProfileCollectionsParams{
+		Username:     username,
+		Page:         page,
+		ItemsPerPage: size,
+	})
+	switch err {
+	case nil:
+		h.jsonOK(w, resp)
+	case profiles.ErrProfileNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to get profile collections", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get profile collections")
+	}
+}
+
+// @Summary Get public profile following
+// @Description Get the users a user with a public profile follows, only users with a public profile are listed
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Param username path string true "Username"
+// @Param page query int false "Page number"
+// @Param itemsPerPage query int false "Number of items per page"
+// @Success 200 {object} models.FollowListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /profiles/{username}/following [get]
+func (h *Handler) getProfileFollowing(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	username, err := h.pathParam(r, "username")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, size, err := h.parsePagination(r, 1, 30)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.Follows.GetProfileFollowing(r.Context(), follows.GetProfileFollowsParams{
+		Username:     username,
+		Page:         page,
+		ItemsPerPage: size,
+	})
+	switch err {
+	case nil:
+		h.jsonOK(w, resp)
+	case follows.ErrUserNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to get profile following", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get profile following")
+	}
+}
+
+// @Summary Get public profile followers
+// @Description Get the followers of a user with a public profile, only users with a public profile are listed
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Param username path string true "Username"
+// @Param page query int false "Page number"
+// @Param itemsPerPage query int false "Number of items per page"
+// @Success 200 {object} models.FollowListResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /profiles/{username}/followers [get]
+func (h *Handler) getProfileFollowers(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	username, err := h.pathParam(r, "username")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, size, err := h.parsePagination(r, 1, 30)
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.services.Follows.GetProfileFollowers(r.Context(), follows.GetProfileFollowsParams{
+		Username:     username,
+		Page:         page,
+		ItemsPerPage: size,
+	})
+	switch err {
+	case nil:
+		h.jsonOK(w, resp)
+	case follows.ErrUserNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to get profile followers", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get profile followers")
+	}
+}
+
+// @Summary Get library compatibility
+// @Description Compare the libraries of two users with public libraries, matching variations of the same show. The current user can always compare their own library
+// @Tags Profiles
+// @Accept json
+// @Produce json
+// @Param username path string true "Username"
+// @Param otherUsername path string true "Username to compare with"
+// @Success 200 {object} models.CompatibilityResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /profiles/{username}/compatibility/{otherUsername} [get]
+func (h *Handler) getProfileCompatibility(w http.ResponseWriter, r *http.Request) {
+	log := h.logger(r)
+
+	username, err := h.pathParam(r, "username")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	otherUsername, err := h.pathParam(r, "otherUsername")
+	if err != nil {
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	params := profiles.GetCompatibilityParams{
+		Username:      username,
+		OtherUsername: otherUsername,
+	}
+	if user := middleware.GetUser(r); user != nil {
+		params.ViewerID = user.ID
+	}
+
+	resp, err := h.services.Profiles.GetCompatibility(r.Context(), params)
+	switch err {
+	case nil:
+		h.jsonOK(w, resp)
+	case profiles.ErrSameUser:
+		h.jsonError(w, http.StatusBadRequest, err.Error())
+	case profiles.ErrSectionHidden:
+		h.jsonError(w, http.StatusForbidden, err.Error())
+	case profiles.ErrProfileNotFound:
+		h.jsonError(w, http.StatusNotFound, err.Error())
+	default:
+		log.Error("failed to get library compatibility", "err", err)
+		h.jsonError(w, http.StatusInternalServerError, "failed to get library compatibility")
+	}
+}
@@ -64,12 +64,21 @@ func (h *Handler) saveSettings(w http.ResponseWriter, r *http.Request) {
 	}
 
 	settings, err := h.services.Settings.SaveSettings(r.Context(), settings.SaveSettingsParams{
-		UserID:            user.ID,
-		AutoNextEpisode:   req.AutoNextEpisode,
-		AutoPlayEpisode:   req.AutoPlayEpisode,
-		AutoResumeEpisode: req.AutoResumeEpisode,
-		IncognitoMode:     req.IncognitoMode,
-		ThemeID:           req.ThemeId,
+		UserID:               user.ID,
+		AutoNextEpisode:      req.AutoNextEpisode,
+		AutoPlayEpisode:      req.AutoPlayEpisode,
+		AutoResumeEpisode:    req.AutoResumeEpisode,
+		IncognitoMode:        req.IncognitoMode,
+		ThemeID:              req.ThemeId,
+		AutoStartLibrary:     req.AutoStartLibrary,
+		AutoCompleteLibrary:  req.AutoCompleteLibrary,
+		AutoPauseLibrary:     req.AutoPauseLibrary,
+		AutoPauseAfterDays:   req.AutoPauseAfterDays,
+		PublicProfile:        req.PublicProfile,
+		ProfileShowLibrary:   req.ProfileShowLibrary,
+		ProfileShowStats:     req.ProfileShowStats,
+		ProfileShowFavorites: req.ProfileShowFavorites,
+		ProfileShowActivity:  req.ProfileShowActivity,
 	})
 	if err != nil {
 		log.Error("failed to save settings", "err", err)
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/coeeter/aniways/internal/utils"
 )
@@ -28,3 +29,12 @@ func RequireAdmin(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// AdminActor names whoever made an admin request for audit trails. The admin
+// key is shared, so callers identify themselves with the X-Admin-User header.
+func AdminActor(r *http.Request) string {
+	if actor := strings.TrimSpace(r.Header.Get("X-Admin-User")); actor != "" {
+		return actor
+	}
+	return "admin"
+}
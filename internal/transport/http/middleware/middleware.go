@@ -10,6 +10,7 @@ import (
 
 	"github.com/cloudinary/cloudinary-go/v2"
 	"github.com/coeeter/aniways/internal/config"
+	"github.com/coeeter/aniways/internal/infra/cache"
 	"github.com/coeeter/aniways/internal/models"
 	"github.com/coeeter/aniways/internal/repository"
 	"github.com/coeeter/aniways/internal/service/users"
@@ -52,13 +53,30 @@ func UseMiddlewares(c MiddlewareConfig) {
 	)
 }
 
-func rateLimiter(env *config.Env) func(http.Handler) http.Handler {
-	redisHost := strings.Split(env.RedisAddr, ":")[0]
-	redisPort, err := strconv.Atoi(strings.Split(env.RedisAddr, ":")[1])
+// limitCounter shares rate limit counters through Redis. With the in-memory
+// cache backend httprate's default in-process counter is used instead.
+func limitCounter(env *config.Env, prefix string) httprate.Option {
+	if env.CacheBackend == cache.BackendMemory {
+		return httprate.WithNoop()
+	}
+
+	redisHost, port, _ := strings.Cut(env.RedisAddr, ":")
+	redisPort, err := strconv.Atoi(port)
 	if err != nil {
 		redisPort = 6379
 	}
 
+	return httprateredis.WithRedisLimitCounter(
+		&httprateredis.Config{
+			Host:      redisHost,
+			Port:      uint16(redisPort),
+			Password:  env.RedisPassword,
+			PrefixKey: prefix,
+		},
+	)
+}
+
+func rateLimiter(env *config.Env) func(http.Handler) http.Handler {
 	// General rate limiters for non-auth endpoints
 	nosessionLimiter := httprate.Limit(
 		60, 5*time.Minute,
@@ -69,14 +87,7 @@ func rateLimiter(env *config.Env) func(http.Handler) http.Handler {
 			}
 			return fmt.Sprintf("ip:%s", ip), nil
 		}),
-		httprateredis.WithRedisLimitCounter(
-			&httprateredis.Config{
-				Host:      redisHost,
-				Port:      uint16(redisPort),
-				Password:  env.RedisPassword,
-				PrefixKey: "aniways_rl_ip_nosession:",
-			},
-		),
+		limitCounter(env, "aniways_rl_ip_nosession:"),
 	)
 
 	sessionLimiter := httprate.Limit(
@@ -89,14 +100,7 @@ func rateLimiter(env *config.Env) func(http.Handler) http.Handler {
 			session, _ := r.Cookie("aniways_session")
 			return fmt.Sprintf("ip:%s:session:%s", ip, session), nil
 		}),
-		httprateredis.WithRedisLimitCounter(
-			&httprateredis.Config{
-				Host:      redisHost,
-				Port:      uint16(redisPort),
-				Password:  env.RedisPassword,
-				PrefixKey: "aniways_rl_ip_session:",
-			},
-		),
+		limitCounter(env, "aniways_rl_ip_session:"),
 	)
 
 	// Stricter rate limiter for login endpoint (security-critical)
@@ -109,14 +113,7 @@ func rateLimiter(env *config.Env) func(http.Handler) http.Handler {
 			}
 			return fmt.Sprintf("ip:%s", ip), nil
 		}),
-		httprateredis.WithRedisLimitCounter(
-			&httprateredis.Config{
-				Host:      redisHost,
-				Port:      uint16(redisPort),
-				Password:  env.RedisPassword,
-				PrefixKey: "aniways_rl_login:",
-			},
-		),
+		limitCounter(env, "aniways_rl_login:"),
 	)
 
 	return func(next http.Handler) http.Handler {
@@ -17,7 +17,7 @@ func DailyTask(
 	log *slog.Logger,
 ) {
 	log.Info("Running daily task")
-	if err := refreshAccessTokens(ctx, repo, providers, log); err != nil {
+	if err := refreshAccessTokens(ctx, repo, NewTokens(repo, providers, log), log); err != nil {
 		log.Error("Error in daily task", "err", err)
 	} else {
 		log.Info("Daily task completed successfully")
@@ -27,10 +27,10 @@ func DailyTask(
 func refreshAccessTokens(
 	ctx context.Context,
 	repo *repository.Queries,
-	providers map[string]oauth.Provider,
+	tokens *Tokens,
 	log *slog.Logger,
 ) error {
-	tokens, err := repo.GetTokensNearToExpiry(ctx)
+	expiring, err := repo.GetTokensNearToExpiry(ctx)
 	if errors.Is(err, pgx.ErrNoRows) {
 		log.Info("No tokens near to expiry")
 		return nil
@@ -39,19 +39,20 @@ func refreshAccessTokens(
 		return err
 	}
 
-	malProvider := providers[oauth.MALProviderName.String()]
-
-	for _, token := range tokens {
+	for _, token := range expiring {
 		if token.Provider == repository.ProviderAnilist {
 			continue
 		}
 
-		err := malProvider.RefreshToken(ctx, token.UserID, token.RefreshToken)
+		_, err := tokens.refresh(ctx, token, false, func(current repository.OauthToken) bool {
+			return expiresWithin(current, dailyRefreshWindow)
+		})
+		if errors.Is(err, ErrReauthRequired) {
+			continue
+		}
 		if err != nil {
 			return err
 		}
-
-		log.Info("Token refreshed", "user_id", token.UserID, "provider", token.Provider)
 	}
 
 	return nil
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/service/auth/oauth"
+	"github.com/coeeter/aniways/internal/worker/lease"
+)
+
+const (
+	// refreshWindow is how close to expiry a token is refreshed before use.
+	refreshWindow = time.Hour
+	// dailyRefreshWindow matches GetTokensNearToExpiry.
+	dailyRefreshWindow = 10 * 24 * time.Hour
+
+	refreshLockTTL = 30 * time.Second
+)
+
+// ErrReauthRequired is returned when the provider no longer accepts the
+// connection and the user has to connect it again.
+var ErrReauthRequired = errors.New("provider connection needs to be re-authorized")
+
+// Tokens hands out provider access tokens to sync and import jobs and
+// refreshes them just in time. Refreshes are serialized per user and provider
+// through a lease, so jobs in any replica never spend a refresh token twice.
+type Tokens struct {
+	repo      *repository.Queries
+	providers map[string]oauth.Provider
+	log       *slog.Logger
+}
+
+func NewTokens(repo *repository.Queries, providers map[string]oauth.Provider, log *slog.Logger) *Tokens {
+	return &Tokens{
+		repo:      repo,
+		providers: providers,
+		log:       log,
+	}
+}
+
+// Get returns the user's token for provider, refreshed first when it expires
+// within the hour. pgx.ErrNoRows means the provider is not connected.
+func (t *Tokens) Get(ctx context.Context, userID string, provider repository.Provider) (repository.OauthToken, error) {
+	token, err := t.repo.GetToken(ctx, repository.GetTokenParams{
+		UserID:   userID,
+		Provider: provider,
+	})
+	if err != nil {
+		return token, err
+	}
+	if token.NeedsReauth {
+		return token, ErrReauthRequired
+	}
+	if !expiresWithin(token, refreshWindow) {
+		return token, nil
+	}
+	return t.refresh(ctx, token, false, func(current repository.OauthToken) bool {
+		return expiresWithin(current, refreshWindow)
+	})
+}
+
+// Refresh replaces a token the provider rejected. When another job already
+// replaced it, the newer token is returned without refreshing again.
+func (t *Tokens) Refresh(ctx context.Context, rejected repository.OauthToken) (repository.OauthToken, error) {
+	return t.refresh(ctx, rejected, true, func(current repository.OauthToken) bool {
+		return current.Token == rejected.Token
+	})
+}
+
+// refresh takes the user's refresh lease, re-reads the token and refreshes it
+// when stale still holds, since a job holding the lease before us may have
+// refreshed it already.
+func (t *Tokens) refresh(
+	ctx context.Context,
+	token repository.OauthToken,
+	rejected bool,
+	stale func(current repository.OauthToken) bool,
+) (repository.OauthToken, error) {
+	name := fmt.Sprintf("oauth-refresh:%s:%s", token.UserID, token.Provider)
+	release, err := lease.Lock(ctx, t.repo, name, refreshLockTTL)
+	if err != nil {
+		return token, err
+	}
+	defer release()
+
+	current, err := t.repo.GetToken(ctx, repository.GetTokenParams{
+		UserID:   token.UserID,
+		Provider: token.Provider,
+	})
+	if err != nil {
+		return token, err
+	}
+	if current.NeedsReauth {
+		return current, ErrReauthRequired
+	}
+	if !stale(current) {
+		return current, nil
+	}
+
+	provider, ok := t.providers[string(current.Provider)]
+	if !ok {
+		return current, fmt.Errorf("unsupported provider: %s", current.Provider)
+	}
+
+	log := t.log.With("user_id", current.UserID, "provider", current.Provider)
+
+	err = provider.RefreshToken(ctx, current.UserID, current.RefreshToken)
+	switch {
+	case err == nil:
+	case errors.Is(err, oauth.ErrUnsupportedOperation):
+		// anilist tokens cannot be refreshed, use them until they stop working
+		if !rejected && !expiresWithin(current, 0) {
+			return current, nil
+		}
+		return current, t.markNeedsReauth(ctx, current, log)
+	case errors.Is(err, oauth.ErrRefreshRevoked):
+		return current, t.markNeedsReauth(ctx, current, log)
+	default:
+		return current, fmt.Errorf("refresh %s token: %w", current.Provider, err)
+	}
+
+	log.Info("Token refreshed")
+
+	return t.repo.GetToken(ctx, repository.GetTokenParams{
+		UserID:   current.UserID,
+		Provider: current.Provider,
+	})
+}
+
+func (t *Tokens) markNeedsReauth(ctx context.Context, token repository.OauthToken, log *slog.Logger) error {
+	err := t.repo.MarkOauthTokenNeedsReauth(ctx, repository.MarkOauthTokenNeedsReauthParams{
+		UserID:   token.UserID,
+		Provider: token.Provider,
+	})
+	if err != nil {
+		return fmt.Errorf("mark token needs reauth: %w", err)
+	}
+	log.Warn("Token can no longer be refreshed, connection needs re-auth")
+	return ErrReauthRequired
+}
+
+func expiresWithin(token repository.OauthToken, d time.Duration) bool {
+	return token.ExpiresAt.Valid && time.Until(token.ExpiresAt.Time) <= d
+}
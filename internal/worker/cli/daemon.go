@@ -16,15 +16,7 @@ var daemonCmd = &cobra.Command{
 		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
 		defer cancel()
 
-		mgr := worker.NewManager(
-			deps.Db,
-			deps.Repo,
-			deps.Scraper,
-			deps.MAL,
-			deps.Anilist,
-			deps.Cache,
-			deps.Log.With("component", "worker"),
-		)
+		mgr := worker.NewManager(deps, deps.Log.With("component", "worker"))
 
 		if err := mgr.Bootstrap(ctx); err != nil {
 			deps.Log.Error("Error in bootstrapping:", "err", err)
@@ -32,7 +24,16 @@ var daemonCmd = &cobra.Command{
 		}
 
 		deps.Log.Info("Starting worker daemon...")
-		mgr.StartBackground(ctx, deps.Providers)
+		if err := mgr.StartBackground(ctx); err != nil {
+			deps.Log.Error("Error starting background jobs", "err", err)
+			os.Exit(1)
+		}
+
+		go func() {
+			if err := mgr.ServeControl(ctx, ":"+deps.Env.WorkerControlPort); err != nil {
+				deps.Log.Error("Worker control server stopped", "err", err)
+			}
+		}()
 
 		<-ctx.Done()
 		deps.Log.Info("Worker daemon stopped")
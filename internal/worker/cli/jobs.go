@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/coeeter/aniways/internal/worker"
+	"github.com/spf13/cobra"
+)
+
+var jobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "List and run worker jobs",
+}
+
+var listJobsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered jobs and their effective schedules",
+	// listing only reads the catalog and env, no dependencies needed
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSCHEDULE\tDESCRIPTION\tOPTIONS")
+		for _, job := range worker.Jobs() {
+			spec, enabled, err := job.EffectiveSchedule()
+			if err != nil {
+				return err
+			}
+			if !enabled {
+				spec = "manual"
+			}
+			options := make([]string, 0, len(job.Options))
+			for name, usage := range job.Options {
+				options = append(options, name+": "+usage)
+			}
+			sort.Strings(options)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", job.Name, spec, job.Description, strings.Join(options, "; "))
+		}
+		return w.Flush()
+	},
+}
+
+var runJobCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "Run a job now",
+	Args:  cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		names := make([]string, 0, len(worker.Jobs()))
+		for _, job := range worker.Jobs() {
+			names = append(names, job.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		job, ok := worker.FindJob(name)
+		if !ok {
+			return fmt.Errorf("unknown job %q, see `worker jobs list`", name)
+		}
+
+		names, err := cmd.Flags().GetStringSlice("option")
+		if err != nil {
+			return err
+		}
+		opts, err := job.ParseOptions(names)
+		if err != nil {
+			return err
+		}
+
+		mgr := worker.NewManager(deps, deps.Log.With("command", "jobs-run"))
+		ran, err := mgr.RunNow(cmd.Context(), name, opts)
+		if err != nil {
+			return err
+		}
+		if !ran {
+			return fmt.Errorf("job %q is already running", name)
+		}
+		return nil
+	},
+}
+
+func init() {
+	jobsCmd.AddCommand(listJobsCmd)
+	runJobCmd.Flags().StringSliceP("option", "o", nil, "Job options to switch on, see `worker jobs list`")
+	jobsCmd.AddCommand(runJobCmd)
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/coeeter/aniways/internal/worker/mapping"
+	"github.com/spf13/cobra"
+)
+
+var mappingCmd = &cobra.Command{
+	Use:   "mapping",
+	Short: "External ID mapping operations",
+}
+
+var ingestOfflineDbCmd = &cobra.Command{
+	Use:   "ingest <anime-offline-database.json>",
+	Short: "Load the manami anime-offline-database into the mapping table",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log := deps.Log.With("command", "mapping-ingest")
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("open offline database: %w", err)
+		}
+		defer f.Close()
+
+		count, err := mapping.IngestOfflineDatabase(cmd.Context(), deps.Db, deps.Repo, f, log)
+		if err != nil {
+			return err
+		}
+
+		log.Info("Offline database ingested", "count", count)
+		return nil
+	},
+}
+
+func init() {
+	mappingCmd.AddCommand(ingestOfflineDbCmd)
+}
@@ -17,7 +17,7 @@ func Execute() {
 		PersistentPreRunE: initDepsOnce,
 	}
 
-	rootCmd.AddCommand(daemonCmd, authCmd, scrapeCmd, libraryCmd)
+	rootCmd.AddCommand(daemonCmd, jobsCmd, mappingCmd)
 
 	if len(os.Args) == 1 {
 		rootCmd.SetArgs([]string{"daemon"})
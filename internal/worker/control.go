@@ -0,0 +1,291 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/transport/http/middleware"
+	"github.com/coeeter/aniways/internal/worker/queue"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type controlStatus struct {
+	Holder string        `json:"holder"`
+	Leader bool          `json:"leader"`
+	Queue  *queue.Status `json:"queue"`
+	Jobs   []jobStatus   `json:"jobs"`
+}
+
+type jobStatus struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Schedule    string     `json:"schedule"`
+	Paused      bool       `json:"paused"`
+	NextRunAt   *time.Time `json:"nextRunAt"`
+	LastRun     *jobRun    `json:"lastRun"`
+}
+
+type jobRun struct {
+	ID         string     `json:"id"`
+	Trigger    string     `json:"trigger"`
+	Status     string     `json:"status"`
+	Holder     string     `json:"holder"`
+	ErrorCount int32      `json:"errorCount"`
+	WarnCount  int32      `json:"warnCount"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt"`
+	DurationMs *int64     `json:"durationMs"`
+	LogSummary *string    `json:"logSummary,omitempty"`
+}
+
+func jobRunFromRepository(r repository.JobRun, withLog bool) *jobRun {
+	run := &jobRun{
+		ID:         r.ID,
+		Trigger:    string(r.Trigger),
+		Status:     string(r.Status),
+		Holder:     r.Holder,
+		ErrorCount: r.ErrorCount,
+		WarnCount:  r.WarnCount,
+		StartedAt:  r.StartedAt.Time,
+	}
+	if r.FinishedAt.Valid {
+		run.FinishedAt = &r.FinishedAt.Time
+	}
+	if r.DurationMs.Valid {
+		run.DurationMs = &r.DurationMs.Int64
+	}
+	if withLog && r.LogSummary.Valid {
+		run.LogSummary = &r.LogSummary.String
+	}
+	return run
+}
+
+// ServeControl exposes job status and controls over HTTP until ctx is
+// cancelled. Requests need the admin key, like the API's admin routes.
+func (m *Manager) ServeControl(ctx context.Context, addr string) error {
+	r := chi.NewRouter()
+	r.Use(middleware.RequireAdmin)
+
+	r.Get("/status", m.handleStatus)
+	r.Get("/jobs", m.handleListJobs)
+	r.Get("/jobs/{name}/runs", m.handleListRuns)
+	r.Get("/jobs/{name}/runs/latest", m.handleLatestRun)
+	r.Post("/jobs/{name}/run", func(w http.ResponseWriter, r *http.Request) {
+		m.handleTrigger(ctx, w, r)
+	})
+	r.Post("/jobs/{name}/pause", func(w http.ResponseWriter, r *http.Request) {
+		m.handleSetPaused(w, r, true)
+	})
+	r.Post("/jobs/{name}/resume", func(w http.ResponseWriter, r *http.Request) {
+		m.handleSetPaused(w, r, false)
+	})
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	m.log.Info("worker control server listening", "addr", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (m *Manager) handleStatus(w http.ResponseWriter, r *http.Request) {
+	jobs, err := m.jobStatuses(r.Context())
+	if err != nil {
+		m.log.Error("Failed to get job statuses", "err", err)
+		writeError(w, http.StatusInternalServerError, "Failed to get job statuses")
+		return
+	}
+
+	status := controlStatus{
+		Holder: m.holder,
+		Leader: m.elector.IsLeader(),
+		Jobs:   jobs,
+	}
+	if m.queue != nil {
+		qs := m.queue.Status()
+		status.Queue = &qs
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (m *Manager) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := m.jobStatuses(r.Context())
+	if err != nil {
+		m.log.Error("Failed to get job statuses", "err", err)
+		writeError(w, http.StatusInternalServerError, "Failed to get job statuses")
+		return
+	}
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+func (m *Manager) jobStatuses(ctx context.Context) ([]jobStatus, error) {
+	latest, err := m.repo.GetLatestJobRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastRuns := make(map[string]repository.JobRun, len(latest))
+	for _, run := range latest {
+		lastRuns[run.JobName] = run
+	}
+
+	schedules, err := m.repo.GetJobSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	paused := make(map[string]bool, len(schedules))
+	for _, s := range schedules {
+		paused[s.JobName] = s.Paused
+	}
+
+	statuses := make([]jobStatus, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		status := jobStatus{
+			Name:        job.spec.Name,
+			Description: job.spec.Description,
+			Schedule:    job.schedule,
+			Paused:      paused[job.spec.Name],
+		}
+		if job.entryID != 0 {
+			if next := m.cron.Entry(job.entryID).Next; !next.IsZero() {
+				status.NextRunAt = &next
+			}
+		}
+		if run, ok := lastRuns[job.spec.Name]; ok {
+			status.LastRun = jobRunFromRepository(run, false)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (m *Manager) handleListRuns(w http.ResponseWriter, r *http.Request) {
+	job := m.findJob(chi.URLParam(r, "name"))
+	if job == nil {
+		writeError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 100 {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and 100")
+			return
+		}
+		limit = n
+	}
+
+	runs, err := m.repo.ListJobRuns(r.Context(), repository.ListJobRunsParams{
+		JobName:    job.spec.Name,
+		LimitCount: int32(limit),
+	})
+	if err != nil {
+		m.log.Error("Failed to list job runs", "job", job.spec.Name, "err", err)
+		writeError(w, http.StatusInternalServerError, "Failed to list job runs")
+		return
+	}
+
+	resp := make([]*jobRun, 0, len(runs))
+	for _, run := range runs {
+		resp = append(resp, jobRunFromRepository(run, false))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (m *Manager) handleLatestRun(w http.ResponseWriter, r *http.Request) {
+	job := m.findJob(chi.URLParam(r, "name"))
+	if job == nil {
+		writeError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	run, err := m.repo.GetLatestJobRun(r.Context(), job.spec.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(w, http.StatusNotFound, "Job has not run yet")
+		return
+	}
+	if err != nil {
+		m.log.Error("Failed to get latest job run", "job", job.spec.Name, "err", err)
+		writeError(w, http.StatusInternalServerError, "Failed to get latest job run")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, jobRunFromRepository(run, true))
+}
+
+// handleTrigger starts a job right away on this replica, ignoring leadership
+// and pauses. The run is still skipped if another run holds the job's lease.
+// Options are passed as repeated query params, e.g. ?option=fresh.
+func (m *Manager) handleTrigger(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	job := m.findJob(chi.URLParam(r, "name"))
+	if job == nil {
+		writeError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	opts, err := job.spec.ParseOptions(r.URL.Query()["option"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	m.log.Info("job triggered manually", "job", job.spec.Name, "options", opts, "by", middleware.AdminActor(r))
+	go m.runJob(ctx, job, repository.JobRunTriggerManual, opts)
+
+	writeJSON(w, http.StatusAccepted, map[string]string{
+		"message": "Job triggered",
+	})
+}
+
+func (m *Manager) handleSetPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	job := m.findJob(chi.URLParam(r, "name"))
+	if job == nil {
+		writeError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	actor := middleware.AdminActor(r)
+	err := m.repo.SetJobPaused(r.Context(), repository.SetJobPausedParams{
+		JobName:   job.spec.Name,
+		Paused:    paused,
+		UpdatedBy: pgtype.Text{String: actor, Valid: true},
+	})
+	if err != nil {
+		m.log.Error("Failed to update job schedule", "job", job.spec.Name, "err", err)
+		writeError(w, http.StatusInternalServerError, "Failed to update job schedule")
+		return
+	}
+
+	m.log.Info("job schedule updated", "job", job.spec.Name, "paused", paused, "by", actor)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, models.ErrorResponse{Error: msg})
+}
@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/worker/lease"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/robfig/cron/v3"
+)
+
+type scheduledJob struct {
+	spec JobSpec
+	// schedule is the effective cron spec, empty when the job is not scheduled
+	schedule string
+	entryID  cron.EntryID
+
+	newRun sync.Once
+	run    RunFunc
+}
+
+// runFunc builds the job from its declared dependencies on first use.
+func (m *Manager) runFunc(job *scheduledJob) RunFunc {
+	job.newRun.Do(func() {
+		job.run = job.spec.New(m.deps, m.services.build(job.spec.Needs))
+	})
+	return job.run
+}
+
+func (m *Manager) findJob(name string) *scheduledJob {
+	for _, job := range m.jobs {
+		if job.spec.Name == name {
+			return job
+		}
+	}
+	return nil
+}
+
+// runJob runs a job under its lease and records the run, with its outcome and
+// the tail of its log output, in job_runs. It reports false when the job was
+// skipped because another run holds the lease.
+func (m *Manager) runJob(ctx context.Context, job *scheduledJob, trigger repository.JobRunTrigger, opts RunOptions) bool {
+	log := m.log.With("job", job.spec.Name)
+	fn := m.runFunc(job)
+
+	return lease.RunExclusive(ctx, m.repo, "job:"+job.spec.Name, log, func(ctx context.Context) {
+		run, err := m.repo.StartJobRun(ctx, repository.StartJobRunParams{
+			JobName: job.spec.Name,
+			Trigger: trigger,
+			Holder:  m.holder,
+		})
+		if err != nil {
+			log.Error("record job run failed", "err", err)
+			if err := fn(ctx, opts, log); err != nil {
+				log.Error("job failed", "err", err)
+			}
+			return
+		}
+
+		rec := &runRecorder{}
+		start := time.Now()
+		runLog := newRecordingLogger(log, rec)
+		if err := fn(ctx, opts, runLog); err != nil {
+			runLog.Error("job failed", "err", err)
+		}
+
+		errorCount, warnCount, summary := rec.summary()
+		status := repository.JobRunStatusSucceeded
+		if errorCount > 0 {
+			status = repository.JobRunStatusFailed
+		}
+		log.Info("job run finished", "status", status, "duration", time.Since(start), "errors", errorCount)
+
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		if err := m.repo.FinishJobRun(saveCtx, repository.FinishJobRunParams{
+			ID:         run.ID,
+			Status:     status,
+			ErrorCount: int32(errorCount),
+			WarnCount:  int32(warnCount),
+			LogSummary: pgtype.Text{String: summary, Valid: summary != ""},
+		}); err != nil {
+			log.Error("record job run result failed", "err", err)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package lease
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/coeeter/aniways/internal/repository"
+)
+
+const (
+	// LeaderLease is the lease every replica competes for, only its holder
+	// runs scheduled jobs.
+	LeaderLease = "scheduler-leader"
+
+	leaderTTL = 30 * time.Second
+	jobTTL    = 2 * time.Minute
+)
+
+// NewHolderID returns an identifier unique to this process.
+func NewHolderID() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%04x", host, os.Getpid(), rand.IntN(1<<16))
+}
+
+// Elector keeps the leader lease renewed while this replica holds it and
+// takes it over once the previous leader stops renewing.
+type Elector struct {
+	repo     *repository.Queries
+	holder   string
+	isLeader atomic.Bool
+	log      *slog.Logger
+}
+
+func NewElector(repo *repository.Queries, holder string, log *slog.Logger) *Elector {
+	return &Elector{
+		repo:   repo,
+		holder: holder,
+		log:    log,
+	}
+}
+
+func (e *Elector) IsLeader() bool {
+	return e.isLeader.Load()
+}
+
+// Run campaigns for the leader lease until ctx is cancelled, then releases it
+// so another replica can take over without waiting for it to expire.
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(leaderTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		e.campaign(ctx)
+
+		select {
+		case <-ctx.Done():
+			if e.isLeader.Swap(false) {
+				releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+				defer cancel()
+				if err := e.repo.ReleaseLease(releaseCtx, repository.ReleaseLeaseParams{
+					Name:   LeaderLease,
+					Holder: e.holder,
+				}); err != nil {
+					e.log.Warn("release leader lease failed", "err", err)
+				}
+				e.log.Info("stepped down as leader")
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) campaign(ctx context.Context) {
+	n, err := e.repo.AcquireLease(ctx, repository.AcquireLeaseParams{
+		Name:       LeaderLease,
+		Holder:     e.holder,
+		TtlSeconds: int32(leaderTTL.Seconds()),
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		// without a renewal the lease may lapse, so stop acting as leader
+		e.log.Error("leader lease renewal failed", "err", err)
+		if e.isLeader.Swap(false) {
+			e.log.Warn("lost leadership")
+		}
+		return
+	}
+
+	leader := n > 0
+	if was := e.isLeader.Swap(leader); was != leader {
+		if leader {
+			e.log.Info("became leader", "holder", e.holder)
+		} else {
+			e.log.Warn("lost leadership")
+		}
+	}
+}
+
+// RunExclusive runs fn while holding the named lease and reports whether it
+// ran. When another run (in this or another replica) holds the lease, fn is
+// skipped. The lease is renewed while fn runs and released afterwards.
+func RunExclusive(
+	ctx context.Context,
+	repo *repository.Queries,
+	name string,
+	log *slog.Logger,
+	fn func(ctx context.Context),
+) bool {
+	// a fresh holder per run so overlapping runs in one process also conflict
+	holder := NewHolderID()
+	ttl := int32(jobTTL.Seconds())
+
+	n, err := repo.AcquireLease(ctx, repository.AcquireLeaseParams{
+		Name:       name,
+		Holder:     holder,
+		TtlSeconds: ttl,
+	})
+	if err != nil {
+		log.Error("acquire job lease failed", "lease", name, "err", err)
+		return false
+	}
+	if n == 0 {
+		log.Info("previous run still in progress, skipping", "lease", name)
+		return false
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		ticker := time.NewTicker(jobTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				if _, err := repo.AcquireLease(runCtx, repository.AcquireLeaseParams{
+					Name:       name,
+					Holder:     holder,
+					TtlSeconds: ttl,
+				}); err != nil && runCtx.Err() == nil {
+					log.Warn("renew job lease failed", "lease", name, "err", err)
+				}
+			}
+		}
+	}()
+
+	fn(runCtx)
+
+	releaseCtx, releaseCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer releaseCancel()
+	if err := repo.ReleaseLease(releaseCtx, repository.ReleaseLeaseParams{
+		Name:   name,
+		Holder: holder,
+	}); err != nil {
+		log.Warn("release job lease failed", "lease", name, "err", err)
+	}
+	return true
+}
+
+// Lock waits until it holds the named lease for ttl and returns a function
+// that releases it. Unlike RunExclusive the caller never skips its work, it
+// only waits for the current holder to finish or for its lease to expire.
+func Lock(
+	ctx context.Context,
+	repo *repository.Queries,
+	name string,
+	ttl time.Duration,
+) (release func(), err error) {
+	holder := NewHolderID()
+	for {
+		n, err := repo.AcquireLease(ctx, repository.AcquireLeaseParams{
+			Name:       name,
+			Holder:     holder,
+			TtlSeconds: int32(ttl.Seconds()),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("acquire lease %s: %w", name, err)
+		}
+		if n > 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+
+	return func() {
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = repo.ReleaseLease(releaseCtx, repository.ReleaseLeaseParams{
+			Name:   name,
+			Holder: holder,
+		})
+	}, nil
+}
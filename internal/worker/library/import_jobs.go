@@ -2,121 +2,146 @@ package library
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/coeeter/aniways/internal/infra/client/anilist"
+	"github.com/coeeter/aniways/internal/infra/client/kitsu"
 	"github.com/coeeter/aniways/internal/infra/client/myanimelist"
+	"github.com/coeeter/aniways/internal/infra/client/shikimori"
 	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/worker/auth"
+	"github.com/coeeter/aniways/internal/worker/queue"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type ImportJobPayload struct {
-	ID       string `json:"id"`
-	UserID   string `json:"user_id"`
-	Provider string `json:"provider"`
-	Status   string `json:"status"`
+	ID string `json:"id"`
 }
 
-func StartLibraryImportJobListener(
+func handleLibraryImportJob(
 	ctx context.Context,
-	db *pgxpool.Pool,
 	repo *repository.Queries,
 	malClient *myanimelist.Client,
 	aniClient *anilist.Client,
+	kitsuClient *kitsu.Client,
+	shikiClient *shikimori.Client,
+	tokens *auth.Tokens,
 	log *slog.Logger,
+	job queue.Job,
+	payload ImportJobPayload,
 ) error {
-	conn, err := db.Acquire(ctx)
-	if err != nil {
-		return err
+	importJob, err := repo.GetLibraryImportJob(ctx, payload.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Warn("library import job no longer exists", "id", payload.ID)
+		return nil
 	}
-	defer conn.Release()
-
-	_, err = conn.Exec(ctx, "LISTEN library_import_jobs")
 	if err != nil {
-		return err
+		return fmt.Errorf("get library import job: %w", err)
 	}
-
-	log.Info("library import listener started")
-
-	for {
-		notification, err := conn.Conn().WaitForNotification(ctx)
-		if err != nil {
-			if ctx.Err() != nil {
-				return nil
-			}
-			log.Error("WaitForNotification error", "err", err)
-			continue
-		}
-
-		var payload ImportJobPayload
-		if err := json.Unmarshal([]byte(notification.Payload), &payload); err != nil {
-			log.Error("invalid library import job payload", "err", err)
-			continue
-		}
-
-		go HandleLibraryImportJob(ctx, repo, malClient, aniClient, log, payload)
+	if importJob.Status == repository.LibraryImportStatusCompleted || importJob.Status == repository.LibraryImportStatusFailed {
+		return nil
 	}
-}
 
-func HandleLibraryImportJob(
-	ctx context.Context,
-	repo *repository.Queries,
-	malClient *myanimelist.Client,
-	aniClient *anilist.Client,
-	log *slog.Logger,
-	payload ImportJobPayload,
-) {
 	log.Info("processing library import job",
-		"id", payload.ID,
-		"user_id", payload.UserID,
-		"status", payload.Status,
-		"provider", payload.Provider,
+		"id", importJob.ID,
+		"user_id", importJob.UserID,
+		"status", importJob.Status,
+		"provider", importJob.Provider,
 	)
 
-	token, err := repo.GetToken(ctx, repository.GetTokenParams{
-		UserID:   payload.UserID,
-		Provider: repository.Provider(payload.Provider),
-	})
+	token, err := tokens.Get(ctx, importJob.UserID, importJob.Provider)
 	if err != nil {
-		log.Error("failed to get token", "err", err)
-		return
+		finishImportJob(ctx, repo, importJob.ID, repository.LibraryImportStatusFailed, err, log)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return queue.Permanent(fmt.Errorf("no token for provider %s", importJob.Provider))
+		}
+		if errors.Is(err, auth.ErrReauthRequired) {
+			return queue.Permanent(err)
+		}
+		return fmt.Errorf("get token: %w", err)
 	}
 
 	err = repo.UpdateLibraryImportJob(ctx, repository.UpdateLibraryImportJobParams{
-		ID:     payload.ID,
+		ID:     importJob.ID,
 		Status: repository.LibraryImportStatusInProgress,
 	})
 	if err != nil {
-		log.Error("failed to update library import job", "err", err)
-		return
+		return fmt.Errorf("update library import job: %w", err)
 	}
 
-	finalStatus := repository.LibraryImportStatusCompleted
-	switch payload.Provider {
-	case string(repository.ProviderAnilist):
-		err = importFromAnilist(ctx, repo, aniClient, token.Token, payload, log)
-	case string(repository.ProviderMyanimelist):
-		err = importFromMal(ctx, repo, malClient, token.Token, payload, log)
-	default:
-		log.Warn("unsupported provider", "provider", payload.Provider)
-		finalStatus = repository.LibraryImportStatusFailed
+	runImport := func(accessToken string) error {
+		im, err := newImporter(ctx, repo, importJob, log)
+		if err != nil {
+			return err
+		}
+
+		switch importJob.Provider {
+		case repository.ProviderAnilist:
+			err = importFromAnilist(ctx, im, aniClient, accessToken)
+		case repository.ProviderMyanimelist:
+			err = importFromMal(ctx, im, malClient, accessToken)
+		case repository.ProviderKitsu:
+			err = importFromKitsu(ctx, im, kitsuClient, accessToken)
+		case repository.ProviderShikimori:
+			err = importFromShikimori(ctx, im, shikiClient, accessToken)
+		default:
+			return queue.Permanent(fmt.Errorf("unsupported provider: %s", importJob.Provider))
+		}
+		if err != nil {
+			return err
+		}
+		return im.finish(ctx)
 	}
 
+	err = runImport(token.Token)
+	if isUnauthorized(err) {
+		// entries imported before the token was rejected are updated in place
+		token, err = tokens.Refresh(ctx, token)
+		if err == nil {
+			err = runImport(token.Token)
+		}
+	}
+	if errors.Is(err, auth.ErrReauthRequired) {
+		err = queue.Permanent(err)
+	}
+
+	if err == nil {
+		finishImportJob(ctx, repo, importJob.ID, repository.LibraryImportStatusCompleted, nil, log)
+		return nil
+	}
+
+	if job.LastAttempt() || queue.IsPermanent(err) {
+		finishImportJob(ctx, repo, importJob.ID, repository.LibraryImportStatusFailed, err, log)
+	} else {
+		// keep the job in progress, the queue retries it after a backoff
+		finishImportJob(ctx, repo, importJob.ID, repository.LibraryImportStatusInProgress, err, log)
+	}
+	return err
+}
+
+func finishImportJob(
+	ctx context.Context,
+	repo *repository.Queries,
+	id string,
+	status repository.LibraryImportStatus,
+	cause error,
+	log *slog.Logger,
+) {
 	errMsg := pgtype.Text{}
-	if err != nil {
-		finalStatus = repository.LibraryImportStatusFailed
+	if cause != nil {
 		errMsg = pgtype.Text{
-			String: err.Error(),
+			String: cause.Error(),
 			Valid:  true,
 		}
 	}
 
-	err = repo.UpdateLibraryImportJob(ctx, repository.UpdateLibraryImportJobParams{
-		ID:           payload.ID,
-		Status:       finalStatus,
+	err := repo.UpdateLibraryImportJob(ctx, repository.UpdateLibraryImportJobParams{
+		ID:           id,
+		Status:       status,
 		ErrorMessage: errMsg,
 	})
 	if err != nil {
@@ -126,11 +151,9 @@ func HandleLibraryImportJob(
 
 func importFromMal(
 	ctx context.Context,
-	repo *repository.Queries,
+	im *importer,
 	malClient *myanimelist.Client,
 	token string,
-	payload ImportJobPayload,
-	log *slog.Logger,
 ) error {
 	page := 1
 	itemsPerPage := 100
@@ -152,71 +175,35 @@ func importFromMal(
 		page++
 
 		for _, item := range list.Data {
-			malID := pgtype.Int4{
-				Int32: int32(item.Node.MalID),
-				Valid: item.Node.MalID != 0,
-			}
-			anime, err := repo.GetAnimeByMalId(ctx, malID)
-			if err != nil {
-				log.Error("failed to get anime by mal id", "mal_id", malID, "err", err)
-				continue
-			}
-
-			var inLibraryAlready bool
-			var animeID string
-			for _, a := range anime {
-				inLibraryAlready, err = repo.IsAnimeInLibrary(ctx, repository.IsAnimeInLibraryParams{
-					UserID:  payload.UserID,
-					AnimeID: a.ID,
-				})
-				if inLibraryAlready {
-					animeID = a.ID
-				}
-			}
-
-			if err != nil {
-				log.Error("failed to check if anime is in library", "anime_id", animeID, "err", err)
-				continue
-			}
-
-			if animeID == "" {
-				animeID = anime[0].ID
-			}
-
 			status := myanimelist.MalListStatus(item.ListStatus.Status)
-			watchedEpisodes := int32(item.ListStatus.EpisodesWatched)
-			updatedAt, err := time.Parse(time.RFC3339, item.ListStatus.UpdatedAt)
-			if err != nil {
-				updatedAt = time.Now()
+			// left zero when the date is missing or malformed, like an undated entry
+			var updatedAt time.Time
+			if t, err := time.Parse(time.RFC3339, item.ListStatus.UpdatedAt); err == nil {
+				updatedAt = t
 			}
 
-			if !inLibraryAlready {
-				err = repo.InsertLibrary(ctx, repository.InsertLibraryParams{
-					UserID:          payload.UserID,
-					AnimeID:         animeID,
-					Status:          repository.LibraryStatus(status.ToRepository()),
-					WatchedEpisodes: watchedEpisodes,
-					UpdatedAt:       updatedAt,
-				})
-				if err != nil {
-					log.Error("failed to insert library entry", "anime_id", animeID, "err", err)
-				}
-				continue
-			}
+			titles := []string{item.Node.Title, item.Node.AlternativeTitles.English, item.Node.AlternativeTitles.Japanese}
+			titles = append(titles, item.Node.AlternativeTitles.Synonyms...)
 
-			err = repo.UpdateLibrary(ctx, repository.UpdateLibraryParams{
-				UserID:          payload.UserID,
-				AnimeID:         animeID,
+			err = im.add(ctx, importItem{
+				ExternalID:      fmt.Sprintf("myanimelist:%d", item.Node.MalID),
+				MalID:           item.Node.MalID,
+				Titles:          titles,
+				SeasonYear:      item.Node.StartSeason.Year,
 				Status:          repository.LibraryStatus(status.ToRepository()),
-				WatchedEpisodes: watchedEpisodes,
-				UpdatedAt: pgtype.Timestamp{
-					Time:  updatedAt,
-					Valid: true,
+				WatchedEpisodes: int32(item.ListStatus.EpisodesWatched),
+				UpdatedAt:       updatedAt,
+				Details: &importDetails{
+					// MAL scores out of 10
+					Score:       importScore(item.ListStatus.Score * 10),
+					StartedAt:   malDate(item.ListStatus.StartDate),
+					CompletedAt: malDate(item.ListStatus.FinishDate),
+					Rewatches:   int32(item.ListStatus.NumTimesRewatched),
+					Notes:       item.ListStatus.Comments,
 				},
 			})
 			if err != nil {
-				log.Error("failed to update library entry", "anime_id", animeID, "err", err)
-				continue
+				return err
 			}
 		}
 	}
@@ -226,11 +213,9 @@ func importFromMal(
 
 func importFromAnilist(
 	ctx context.Context,
-	repo *repository.Queries,
+	im *importer,
 	aniClient *anilist.Client,
 	token string,
-	payload ImportJobPayload,
-	log *slog.Logger,
 ) error {
 	page := 1
 	itemsPerPage := 100
@@ -252,65 +237,180 @@ func importFromAnilist(
 		page++
 
 		for _, item := range list.Page.MediaList {
-			malID := pgtype.Int4{
-				Int32: int32(item.Media.GetIdMal()),
-				Valid: item.Media.GetIdMal() != 0,
+			media := item.GetMedia()
+			title := media.GetTitle()
+
+			titles := []string{title.GetRomaji(), title.GetEnglish(), title.GetNative()}
+			titles = append(titles, media.GetSynonyms()...)
+
+			var updatedAt time.Time
+			if item.GetUpdatedAt() > 0 {
+				updatedAt = time.Unix(int64(item.GetUpdatedAt()), 0)
 			}
-			anime, err := repo.GetAnimeByMalId(ctx, malID)
+
+			err = im.add(ctx, importItem{
+				ExternalID:      fmt.Sprintf("anilist:%d", media.GetId()),
+				MalID:           media.GetIdMal(),
+				AnilistID:       media.GetId(),
+				Titles:          titles,
+				SeasonYear:      media.GetSeasonYear(),
+				Status:          repository.LibraryStatus(aniClient.ConvertToRepoStatus(item.GetStatus())),
+				WatchedEpisodes: int32(item.GetProgress()),
+				UpdatedAt:       updatedAt,
+				Details: &importDetails{
+					Score:       importScore(int(item.GetScore())),
+					StartedAt:   fuzzyDate(item.StartedAt.GetYear(), item.StartedAt.GetMonth(), item.StartedAt.GetDay()),
+					CompletedAt: fuzzyDate(item.CompletedAt.GetYear(), item.CompletedAt.GetMonth(), item.CompletedAt.GetDay()),
+					Rewatches:   int32(item.GetRepeat()),
+					Notes:       item.GetNotes(),
+				},
+			})
 			if err != nil {
-				log.Error("failed to get anime by mal id", "mal_id", malID, "err", err)
-				continue
+				return err
 			}
+		}
+	}
+
+	return nil
+}
+
+func importFromKitsu(
+	ctx context.Context,
+	im *importer,
+	kitsuClient *kitsu.Client,
+	token string,
+) error {
+	kitsuUserID, err := kitsuClient.GetSelfID(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	page := 1
+	itemsPerPage := 100
+
+	for {
+		list, err := kitsuClient.GetLibraryEntries(ctx, kitsu.GetLibraryEntriesParams{
+			Token:        token,
+			UserID:       kitsuUserID,
+			Page:         page,
+			ItemsPerPage: itemsPerPage,
+		})
+		if err != nil {
+			return err
+		}
+
+		page++
 
-			var inLibraryAlready bool
-			var animeID string
-			for _, a := range anime {
-				inLibraryAlready, err = repo.IsAnimeInLibrary(ctx, repository.IsAnimeInLibraryParams{
-					UserID:  payload.UserID,
-					AnimeID: a.ID,
-				})
-				if inLibraryAlready {
-					animeID = a.ID
-				}
+		for _, item := range list.Entries {
+			var updatedAt time.Time
+			if t, err := time.Parse(time.RFC3339, item.UpdatedAt); err == nil {
+				updatedAt = t
 			}
 
+			err = im.add(ctx, importItem{
+				ExternalID:      "kitsu:" + item.AnimeID,
+				MalID:           int(malIDForKitsuEntry(ctx, im.repo, item)),
+				Titles:          item.Titles,
+				SeasonYear:      item.Year,
+				Status:          repository.LibraryStatus(item.Status.ToRepository()),
+				WatchedEpisodes: int32(item.Progress),
+				UpdatedAt:       updatedAt,
+			})
 			if err != nil {
-				log.Error("failed to check if anime is in library", "anime_id", animeID, "err", err)
-				continue
+				return err
 			}
+		}
 
-			if animeID == "" {
-				animeID = anime[0].ID
-			}
+		if !list.HasNext {
+			break
+		}
+	}
+
+	return nil
+}
+
+func importFromShikimori(
+	ctx context.Context,
+	im *importer,
+	shikiClient *shikimori.Client,
+	token string,
+) error {
+	user, err := shikiClient.WhoAmI(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	page := 1
+	itemsPerPage := 100
+
+	for {
+		rates, err := shikiClient.GetUserRates(ctx, shikimori.GetUserRatesParams{
+			Token:        token,
+			UserID:       user.ID,
+			Page:         page,
+			ItemsPerPage: itemsPerPage,
+		})
+		if err != nil {
+			return err
+		}
 
-			status := aniClient.ConvertToRepoStatus(item.GetStatus())
-			watchedEpisodes := item.GetProgress()
-
-			if !inLibraryAlready {
-				err = repo.InsertLibrary(ctx, repository.InsertLibraryParams{
-					UserID:          payload.UserID,
-					AnimeID:         animeID,
-					Status:          repository.LibraryStatus(status),
-					WatchedEpisodes: int32(watchedEpisodes),
-				})
-				if err != nil {
-					log.Error("failed to insert library entry", "anime_id", animeID, "err", err)
-				}
-				continue
+		if len(rates) == 0 {
+			break
+		}
+
+		page++
+
+		for _, item := range rates {
+			var updatedAt time.Time
+			if t, err := time.Parse(time.RFC3339, item.UpdatedAt); err == nil {
+				updatedAt = t
 			}
 
-			err = repo.UpdateLibrary(ctx, repository.UpdateLibraryParams{
-				UserID:          payload.UserID,
-				AnimeID:         animeID,
-				Status:          repository.LibraryStatus(status),
-				WatchedEpisodes: int32(watchedEpisodes),
+			// shikimori anime IDs are MAL IDs and rates carry no titles
+			err = im.add(ctx, importItem{
+				ExternalID:      fmt.Sprintf("shikimori:%d", item.TargetID),
+				MalID:           item.TargetID,
+				Status:          repository.LibraryStatus(item.Status.ToRepository()),
+				WatchedEpisodes: int32(item.Episodes),
+				UpdatedAt:       updatedAt,
 			})
 			if err != nil {
-				log.Error("failed to update library entry", "anime_id", animeID, "err", err)
-				continue
+				return err
 			}
 		}
+
+		if len(rates) < itemsPerPage {
+			break
+		}
 	}
 
 	return nil
 }
+
+// importScore keeps scores out of 100, 0 means the entry has none.
+func importScore(score int) pgtype.Int2 {
+	if score <= 0 {
+		return pgtype.Int2{}
+	}
+	return pgtype.Int2{Int16: int16(min(score, 100)), Valid: true}
+}
+
+// malDate parses MAL's list dates, which may leave out the day or month.
+func malDate(s string) pgtype.Date {
+	for _, layout := range []string{time.DateOnly, "2006-01", "2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return pgtype.Date{Time: t, Valid: true}
+		}
+	}
+	return pgtype.Date{}
+}
+
+// fuzzyDate converts AniList's fuzzy dates, missing months and days fall on
+// the first.
+func fuzzyDate(year, month, day int) pgtype.Date {
+	if year == 0 {
+		return pgtype.Date{}
+	}
+	t := time.Date(year, time.Month(max(month, 1)), max(day, 1), 0, 0, 0, 0, time.UTC)
+	return pgtype.Date{Time: t, Valid: true}
+}
@@ -0,0 +1,70 @@
+package library
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/coeeter/aniways/internal/infra/client/anilist"
+	"github.com/coeeter/aniways/internal/infra/client/kitsu"
+	"github.com/coeeter/aniways/internal/infra/client/myanimelist"
+	"github.com/coeeter/aniways/internal/infra/client/shikimori"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/service/auth/oauth"
+	"github.com/coeeter/aniways/internal/worker/auth"
+	"github.com/coeeter/aniways/internal/worker/queue"
+)
+
+const (
+	SyncQueue   = "library_sync"
+	ImportQueue = "library_import"
+
+	// keep in line with enqueue_library_sync in the job queue migration
+	syncMaxAttempts = 8
+)
+
+// syncOptions also drive the backoff stored on external_library_sync rows.
+var syncOptions = queue.Options{
+	Concurrency: 5,
+	BaseBackoff: 30 * time.Second,
+	MaxBackoff:  time.Hour,
+}
+
+// RegisterJobs wires the library sync and import handlers into the job queue.
+func RegisterJobs(
+	q *queue.Queue,
+	repo *repository.Queries,
+	malClient *myanimelist.Client,
+	aniClient *anilist.Client,
+	kitsuClient *kitsu.Client,
+	shikiClient *shikimori.Client,
+	providers map[string]oauth.Provider,
+	log *slog.Logger,
+) {
+	tokens := auth.NewTokens(repo, providers, log.With("component", "oauth-tokens"))
+
+	syncLog := log.With("job", "library-sync")
+	q.Register(SyncQueue, syncOptions, func(ctx context.Context, job queue.Job) error {
+		var payload SyncJobPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return queue.Permanent(fmt.Errorf("invalid library sync payload: %w", err))
+		}
+		return handleLibrarySync(ctx, repo, malClient, aniClient, kitsuClient, shikiClient, tokens, syncLog, payload)
+	})
+
+	importLog := log.With("job", "library-import")
+	q.Register(ImportQueue, queue.Options{
+		Concurrency: 2,
+		Lease:       2 * time.Minute,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  30 * time.Minute,
+	}, func(ctx context.Context, job queue.Job) error {
+		var payload ImportJobPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return queue.Permanent(fmt.Errorf("invalid library import payload: %w", err))
+		}
+		return handleLibraryImportJob(ctx, repo, malClient, aniClient, kitsuClient, shikiClient, tokens, importLog, job, payload)
+	})
+}
@@ -0,0 +1,38 @@
+package library
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/coeeter/aniways/internal/infra/client/kitsu"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// kitsuAnimeID maps a MAL ID to its Kitsu anime ID, preferring the offline
+// mapping dataset over a call to Kitsu's mappings API.
+func kitsuAnimeID(ctx context.Context, repo *repository.Queries, kitsuClient *kitsu.Client, malID int32) (string, error) {
+	row, err := repo.GetOfflineMappingByMalId(ctx, pgtype.Int4{Int32: malID, Valid: true})
+	if err == nil && row.KitsuID.Valid {
+		return strconv.Itoa(int(row.KitsuID.Int32)), nil
+	}
+	return kitsuClient.GetKitsuID(ctx, int(malID))
+}
+
+// malIDForKitsuEntry returns the MAL ID of a Kitsu library entry, falling back
+// to the offline mapping dataset when Kitsu has no MAL mapping for the anime.
+func malIDForKitsuEntry(ctx context.Context, repo *repository.Queries, entry kitsu.LibraryEntry) int32 {
+	if entry.MalID != 0 {
+		return int32(entry.MalID)
+	}
+
+	kitsuID, err := strconv.Atoi(entry.AnimeID)
+	if err != nil {
+		return 0
+	}
+	row, err := repo.GetOfflineMappingByKitsuId(ctx, pgtype.Int4{Int32: int32(kitsuID), Valid: true})
+	if err != nil || !row.MalID.Valid {
+		return 0
+	}
+	return row.MalID.Int32
+}
@@ -0,0 +1,472 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// titleMatchThreshold is the trigram similarity a fuzzy title match needs.
+	titleMatchThreshold = 0.6
+	// titleMatchMargin is how far the best fuzzy match has to lead the next
+	// anime before it is picked over reporting the entry as ambiguous.
+	titleMatchMargin = 0.15
+)
+
+// importItem is an entry of an external list in the shape the matcher reads,
+// every importer converts its provider's entries into it.
+type importItem struct {
+	// ExternalID identifies the entry on the provider in the import report.
+	ExternalID      string
+	MalID           int
+	AnilistID       int
+	Titles          []string
+	SeasonYear      int
+	Status          repository.LibraryStatus
+	WatchedEpisodes int32
+	// UpdatedAt is left zero when the provider does not report it.
+	UpdatedAt time.Time
+	// Details is nil when the provider does not track them, the local
+	// values are kept then.
+	Details *importDetails
+}
+
+// importDetails are the library fields beyond status and progress.
+type importDetails struct {
+	Score       pgtype.Int2
+	StartedAt   pgtype.Date
+	CompletedAt pgtype.Date
+	Rewatches   int32
+	Notes       string
+}
+
+// entry is the library entry the item becomes, local is nil for new entries.
+func (i importItem) entry(local *repository.Library) repository.Library {
+	var entry repository.Library
+	if local != nil {
+		entry = *local
+	}
+	entry.Status = i.Status
+	entry.WatchedEpisodes = i.WatchedEpisodes
+	if i.Details != nil {
+		entry.Score = i.Details.Score
+		entry.StartedAt = i.Details.StartedAt
+		entry.CompletedAt = i.Details.CompletedAt
+		entry.Rewatches = i.Details.Rewatches
+		entry.Notes = i.Details.Notes
+	}
+	return entry
+}
+
+func (i importItem) title() string {
+	for _, t := range i.Titles {
+		if t != "" {
+			return t
+		}
+	}
+	return i.ExternalID
+}
+
+// importer matches external entries to anime, merges them into the user's
+// library with the job's strategy and records every entry it could not place
+// and every entry it changed in the job's report. Dry runs only record.
+type importer struct {
+	repo     *repository.Queries
+	userID   string
+	jobID    string
+	strategy repository.LibraryImportStrategy
+	dryRun   bool
+	log      *slog.Logger
+	matched  int32
+}
+
+// newImporter clears the report of earlier attempts of the job.
+func newImporter(ctx context.Context, repo *repository.Queries, job repository.LibraryImportJob, log *slog.Logger) (*importer, error) {
+	if err := repo.ResetLibraryImportReport(ctx, job.ID); err != nil {
+		return nil, fmt.Errorf("reset library import report: %w", err)
+	}
+	return &importer{
+		repo:     repo,
+		userID:   job.UserID,
+		jobID:    job.ID,
+		strategy: job.Strategy,
+		dryRun:   job.DryRun,
+		log:      log,
+	}, nil
+}
+
+// add places one entry in the library. Entries that cannot be placed are
+// reported and skipped, database failures, including a failed library write,
+// are returned.
+func (im *importer) add(ctx context.Context, item importItem) error {
+	m, err := im.match(ctx, item)
+	if err != nil {
+		return err
+	}
+
+	if m.issue != "" {
+		return im.report(ctx, item, m)
+	}
+
+	if !im.takeRemote(item, m.local) {
+		im.matched++
+		return nil
+	}
+	entry := item.entry(m.local)
+
+	change := repository.InsertLibraryImportChangeParams{
+		JobID:           im.jobID,
+		AnimeID:         m.animeID,
+		Status:          item.Status,
+		WatchedEpisodes: item.WatchedEpisodes,
+	}
+	if m.local != nil {
+		change.LocalStatus = repository.NullLibraryStatus{LibraryStatus: m.local.Status, Valid: true}
+		change.LocalWatchedEpisodes = pgtype.Int4{Int32: m.local.WatchedEpisodes, Valid: true}
+	}
+	if err := im.repo.InsertLibraryImportChange(ctx, change); err != nil {
+		return fmt.Errorf("insert library import change: %w", err)
+	}
+
+	if im.dryRun {
+		im.matched++
+		return nil
+	}
+
+	updatedAt := pgtype.Timestamp{Time: item.UpdatedAt, Valid: !item.UpdatedAt.IsZero()}
+
+	if m.local == nil {
+		var insertedAt any
+		if updatedAt.Valid {
+			insertedAt = updatedAt.Time
+		}
+		err = im.repo.InsertLibrary(ctx, repository.InsertLibraryParams{
+			UserID:          im.userID,
+			AnimeID:         m.animeID,
+			Status:          entry.Status,
+			WatchedEpisodes: entry.WatchedEpisodes,
+			Score:           entry.Score,
+			StartedAt:       entry.StartedAt,
+			CompletedAt:     entry.CompletedAt,
+			Rewatches:       entry.Rewatches,
+			Notes:           entry.Notes,
+			UpdatedAt:       insertedAt,
+		})
+	} else {
+		err = im.repo.UpdateLibrary(ctx, repository.UpdateLibraryParams{
+			UserID:          im.userID,
+			AnimeID:         m.animeID,
+			Status:          entry.Status,
+			WatchedEpisodes: entry.WatchedEpisodes,
+			Score:           entry.Score,
+			StartedAt:       entry.StartedAt,
+			CompletedAt:     entry.CompletedAt,
+			Rewatches:       entry.Rewatches,
+			Notes:           entry.Notes,
+			UpdatedAt:       updatedAt,
+		})
+	}
+	if err != nil {
+		// the change is already in the report, fail the job so the retry
+		// starts over with a fresh report
+		return fmt.Errorf("write library entry %s: %w", m.animeID, err)
+	}
+
+	im.matched++
+	return nil
+}
+
+// takeRemote reports whether the entry from the provider replaces the local
+// one under the job's strategy. New entries are always taken.
+func (im *importer) takeRemote(item importItem, local *repository.Library) bool {
+	if local == nil {
+		return true
+	}
+	if sameEntry(*local, item.entry(local)) {
+		return false
+	}
+
+	switch im.strategy {
+	case repository.LibraryImportStrategyLocal:
+		return false
+	case repository.LibraryImportStrategyNewest:
+		// entries the provider does not date never win over local ones
+		return !item.UpdatedAt.IsZero() && item.UpdatedAt.After(local.UpdatedAt.Time)
+	case repository.LibraryImportStrategyMaxProgress:
+		return item.WatchedEpisodes > local.WatchedEpisodes
+	default:
+		return true
+	}
+}
+
+func sameEntry(a, b repository.Library) bool {
+	sameDate := func(x, y pgtype.Date) bool {
+		return x.Valid == y.Valid && (!x.Valid || x.Time.Equal(y.Time))
+	}
+	return a.Status == b.Status &&
+		a.WatchedEpisodes == b.WatchedEpisodes &&
+		a.Score == b.Score &&
+		sameDate(a.StartedAt, b.StartedAt) &&
+		sameDate(a.CompletedAt, b.CompletedAt) &&
+		a.Rewatches == b.Rewatches &&
+		a.Notes == b.Notes
+}
+
+// finish stores how many entries were imported next to the report.
+func (im *importer) finish(ctx context.Context) error {
+	return im.repo.SetLibraryImportMatchedCount(ctx, repository.SetLibraryImportMatchedCountParams{
+		ID:           im.jobID,
+		MatchedCount: im.matched,
+	})
+}
+
+func (im *importer) report(ctx context.Context, item importItem, m match) error {
+	im.log.Info("library import entry not matched",
+		"external_id", item.ExternalID,
+		"title", item.title(),
+		"issue", m.issue,
+		"candidates", len(m.candidates),
+	)
+
+	candidates := m.candidates
+	if candidates == nil {
+		candidates = []string{}
+	}
+
+	return im.repo.InsertLibraryImportReportItem(ctx, repository.InsertLibraryImportReportItemParams{
+		JobID:        im.jobID,
+		Issue:        m.issue,
+		ExternalID:   item.ExternalID,
+		Title:        item.title(),
+		MalID:        pgtype.Int4{Int32: int32(item.MalID), Valid: item.MalID != 0},
+		AnilistID:    pgtype.Int4{Int32: int32(item.AnilistID), Valid: item.AnilistID != 0},
+		CandidateIds: candidates,
+	})
+}
+
+type match struct {
+	animeID string
+	// local is the user's library entry for the anime, nil when there is none.
+	local *repository.Library
+	// issue is set when no anime was picked, candidates then lists the anime
+	// an ambiguous entry could be.
+	issue      repository.LibraryImportIssue
+	candidates []string
+}
+
+// match tries the MAL ID, then the AniList ID, then the entry's titles
+// against our anime.
+func (im *importer) match(ctx context.Context, item importItem) (match, error) {
+	if item.MalID != 0 {
+		anime, err := im.repo.GetAnimeByMalId(ctx, pgtype.Int4{Int32: int32(item.MalID), Valid: true})
+		if err != nil {
+			return match{}, fmt.Errorf("get anime by mal id: %w", err)
+		}
+		if len(anime) > 0 {
+			return im.pickVariation(ctx, anime)
+		}
+	}
+
+	if item.AnilistID != 0 {
+		anime, err := im.repo.GetAnimeByAnilistId(ctx, pgtype.Int4{Int32: int32(item.AnilistID), Valid: true})
+		if err != nil {
+			return match{}, fmt.Errorf("get anime by anilist id: %w", err)
+		}
+		if len(anime) > 0 {
+			return im.pickVariation(ctx, anime)
+		}
+	}
+
+	return im.matchTitle(ctx, item)
+}
+
+// titleGroup gathers the variations of one show found by title search, they
+// share a MAL ID when it is known.
+type titleGroup struct {
+	anime []repository.Anime
+	score float32
+	exact bool
+	year  bool
+}
+
+func (im *importer) matchTitle(ctx context.Context, item importItem) (match, error) {
+	groups := map[string]*titleGroup{}
+
+	for _, title := range item.Titles {
+		normalized := normalizeTitle(title)
+		if normalized == "" {
+			continue
+		}
+
+		rows, err := im.repo.FindAnimeTitleCandidates(ctx, repository.FindAnimeTitleCandidatesParams{
+			Title:      title,
+			SeasonYear: int32(item.SeasonYear),
+		})
+		if err != nil {
+			return match{}, fmt.Errorf("find anime title candidates: %w", err)
+		}
+
+		for _, row := range rows {
+			key := row.Anime.ID
+			if row.Anime.MalID.Valid {
+				key = fmt.Sprintf("mal:%d", row.Anime.MalID.Int32)
+			}
+
+			g, ok := groups[key]
+			if !ok {
+				g = &titleGroup{}
+				groups[key] = g
+			}
+			if !containsAnime(g.anime, row.Anime.ID) {
+				g.anime = append(g.anime, row.Anime)
+			}
+			g.score = max(g.score, row.Score)
+			if normalizeTitle(row.Anime.Ename) == normalized || normalizeTitle(row.Anime.Jname) == normalized {
+				g.exact = true
+			}
+			if item.SeasonYear != 0 && int(row.Anime.SeasonYear) == item.SeasonYear {
+				g.year = true
+			}
+		}
+	}
+
+	if len(groups) == 0 {
+		return match{issue: repository.LibraryImportIssueUnmatched}, nil
+	}
+
+	ranked := make([]*titleGroup, 0, len(groups))
+	for _, g := range groups {
+		ranked = append(ranked, g)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.exact != b.exact {
+			return a.exact
+		}
+		if a.year != b.year {
+			return a.year
+		}
+		return a.score > b.score
+	})
+
+	best := ranked[0]
+	if !best.exact && best.score < titleMatchThreshold {
+		return match{issue: repository.LibraryImportIssueUnmatched}, nil
+	}
+
+	if len(ranked) > 1 {
+		next := ranked[1]
+		tied := best.exact == next.exact && best.year == next.year
+		if tied && (best.exact || best.score-next.score < titleMatchMargin) {
+			return match{
+				issue:      repository.LibraryImportIssueAmbiguous,
+				candidates: candidateIDs(ranked, best),
+			}, nil
+		}
+	}
+
+	anime := best.anime
+	if malID := anime[0].MalID; malID.Valid {
+		// search only returns available variations, pick from all of them
+		all, err := im.repo.GetAnimeByMalId(ctx, malID)
+		if err != nil {
+			return match{}, fmt.Errorf("get anime by mal id: %w", err)
+		}
+		if len(all) > 0 {
+			anime = all
+		}
+	}
+	return im.pickVariation(ctx, anime)
+}
+
+// pickVariation prefers the variation already in the user's library, then
+// an available one with the most episodes out. Sub or dub preference is not
+// weighed since variations carry no audio language, the import endpoint
+// documents this.
+func (im *importer) pickVariation(ctx context.Context, anime []repository.Anime) (match, error) {
+	for _, a := range anime {
+		row, err := im.repo.GetLibraryOfUserByAnimeID(ctx, repository.GetLibraryOfUserByAnimeIDParams{
+			UserID:  im.userID,
+			AnimeID: a.ID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return match{}, fmt.Errorf("get library entry: %w", err)
+		}
+		return match{animeID: a.ID, local: &row.Library}, nil
+	}
+
+	best := anime[0]
+	for _, a := range anime[1:] {
+		if betterVariation(a, best) {
+			best = a
+		}
+	}
+	return match{animeID: best.ID}, nil
+}
+
+func betterVariation(a, b repository.Anime) bool {
+	aAvailable, bAvailable := !a.UnavailableAt.Valid, !b.UnavailableAt.Valid
+	if aAvailable != bAvailable {
+		return aAvailable
+	}
+	return a.LastEpisode > b.LastEpisode
+}
+
+func containsAnime(anime []repository.Anime, id string) bool {
+	for _, a := range anime {
+		if a.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// candidateIDs lists the anime of every group as close as the best one.
+func candidateIDs(ranked []*titleGroup, best *titleGroup) []string {
+	var ids []string
+	for _, g := range ranked {
+		if g.exact != best.exact || g.year != best.year {
+			break
+		}
+		if !best.exact && best.score-g.score >= titleMatchMargin {
+			break
+		}
+		for _, a := range g.anime {
+			ids = append(ids, a.ID)
+		}
+	}
+	return ids
+}
+
+// normalizeTitle lowercases the title and drops punctuation so titles that
+// only differ in styling compare equal.
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteRune(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,440 @@
+package library
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/coeeter/aniways/internal/infra/client/anilist"
+	"github.com/coeeter/aniways/internal/infra/client/kitsu"
+	"github.com/coeeter/aniways/internal/infra/client/myanimelist"
+	"github.com/coeeter/aniways/internal/infra/client/shikimori"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/worker/auth"
+	"github.com/coeeter/aniways/internal/worker/queue"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SyncJobPayload identifies the external_library_sync row a job pushes. The
+// row holds the desired remote state of the entry and is read when the job
+// runs, so changes made while the job waited are pushed together.
+type SyncJobPayload struct {
+	UserID   string `json:"user_id"`
+	AnimeID  string `json:"anime_id"`
+	Provider string `json:"provider"`
+}
+
+// SyncData is the desired state of the entry. The fields after the progress
+// are nil on syncs queued before they were tracked and left alone then.
+type SyncData struct {
+	Status          *string `json:"status"`
+	WatchedEpisodes *int32  `json:"watched_episodes"`
+	Score           *int32  `json:"score"`
+	StartedAt       *string `json:"started_at"`
+	CompletedAt     *string `json:"completed_at"`
+	Rewatches       *int32  `json:"rewatches"`
+	Notes           *string `json:"notes"`
+}
+
+// RetryFailedLibrarySyncs re-enqueues failed and pending syncs whose jobs were
+// dead-lettered or lost. Failed syncs wait for their next attempt time and
+// dead syncs are left for the user to retry.
+func RetryFailedLibrarySyncs(
+	ctx context.Context,
+	repo *repository.Queries,
+	log *slog.Logger,
+) {
+	entries, err := repo.GetFailedPendingLibrarySyncs(ctx)
+	if err != nil {
+		log.Error("Failed to fetch failed/pending library syncs", "err", err)
+		return
+	}
+
+	for _, entry := range entries {
+		payload := SyncJobPayload{
+			UserID:   entry.UserID,
+			AnimeID:  entry.AnimeID,
+			Provider: string(entry.Provider),
+		}
+		if err := queue.Enqueue(ctx, repo, SyncQueue, syncDedupeKey(payload), payload, syncMaxAttempts); err != nil {
+			log.Error("Failed to enqueue library sync", "user_id", entry.UserID, "anime_id", entry.AnimeID, "err", err)
+		}
+	}
+	log.Info("Re-enqueued library syncs", "count", len(entries))
+}
+
+// syncDedupeKey matches the key built by the enqueue_library_sync trigger.
+func syncDedupeKey(p SyncJobPayload) string {
+	return p.UserID + ":" + p.AnimeID + ":" + p.Provider
+}
+
+func handleLibrarySync(
+	ctx context.Context,
+	repo *repository.Queries,
+	malClient *myanimelist.Client,
+	aniClient *anilist.Client,
+	kitsuClient *kitsu.Client,
+	shikiClient *shikimori.Client,
+	tokens *auth.Tokens,
+	log *slog.Logger,
+	payload SyncJobPayload,
+) error {
+	entry, err := repo.GetLibrarySync(ctx, repository.GetLibrarySyncParams{
+		UserID:   payload.UserID,
+		AnimeID:  payload.AnimeID,
+		Provider: repository.Provider(payload.Provider),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// the entry was removed since the job was queued
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get library sync: %w", err)
+	}
+	switch entry.Status {
+	case repository.LibrarySyncStatusSuccess, repository.LibrarySyncStatusSkipped, repository.LibrarySyncStatusDead:
+		return nil
+	}
+
+	log.Info("Processing library sync",
+		"user_id", entry.UserID,
+		"anime_id", entry.AnimeID,
+		"provider", entry.Provider,
+		"action", entry.Action,
+		"revision", entry.Revision,
+	)
+
+	setStatus := func(status repository.LibrarySyncStatus) {
+		n, err := repo.UpdateLibrarySyncStatus(ctx, repository.UpdateLibrarySyncStatusParams{
+			Status:   status,
+			UserID:   entry.UserID,
+			AnimeID:  entry.AnimeID,
+			Provider: entry.Provider,
+			Revision: entry.Revision,
+		})
+		if err != nil {
+			log.Error("Failed to update library sync status", "err", err)
+		} else if n == 0 {
+			log.Info("Library sync superseded by a newer change")
+		}
+	}
+	fail := func(err error, permanent bool) error {
+		return failSync(ctx, repo, log, entry, err, permanent)
+	}
+
+	var syncData SyncData
+	if err := json.Unmarshal(entry.Payload, &syncData); err != nil {
+		return fail(fmt.Errorf("parse sync payload: %w", err), true)
+	}
+
+	status := ""
+	if syncData.Status != nil {
+		status = *syncData.Status
+	}
+	episodes := 0
+	if syncData.WatchedEpisodes != nil {
+		episodes = int(*syncData.WatchedEpisodes)
+	}
+
+	switch entry.Provider {
+	case repository.ProviderMyanimelist, repository.ProviderAnilist, repository.ProviderKitsu, repository.ProviderShikimori:
+	default:
+		log.Warn("Unsupported provider", "provider", entry.Provider)
+		setStatus(repository.LibrarySyncStatusSkipped)
+		return nil
+	}
+
+	anime, err := repo.GetAnimeById(ctx, entry.AnimeID)
+	if err != nil {
+		return fmt.Errorf("get anime: %w", err)
+	}
+	if !anime.MalID.Valid || anime.MalID.Int32 <= 0 {
+		// every provider is addressed by MAL ID, retrying cannot help
+		return fail(errors.New("anime has no MAL ID"), true)
+	}
+
+	token, err := tokens.Get(ctx, entry.UserID, entry.Provider)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// the user has not connected this provider
+		setStatus(repository.LibrarySyncStatusSkipped)
+		return nil
+	}
+	if errors.Is(err, auth.ErrReauthRequired) {
+		// retried by the user once they connect the provider again
+		return fail(err, true)
+	}
+	if err != nil {
+		return fmt.Errorf("get token: %w", err)
+	}
+
+	push := func(accessToken string) error {
+		pushCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+		defer cancel()
+
+		switch entry.Provider {
+		case repository.ProviderAnilist:
+			return handleAniProvider(pushCtx, aniClient, anime, accessToken, string(entry.Action), status, episodes, syncData)
+		case repository.ProviderKitsu:
+			return handleKitsuProvider(pushCtx, repo, kitsuClient, anime, accessToken, string(entry.Action), status, episodes, syncData)
+		case repository.ProviderShikimori:
+			return handleShikimoriProvider(pushCtx, shikiClient, anime, accessToken, string(entry.Action), status, episodes, syncData)
+		default:
+			return handleMalProvider(pushCtx, malClient, anime, accessToken, string(entry.Action), status, episodes, syncData)
+		}
+	}
+
+	err = push(token.Token)
+	if isUnauthorized(err) {
+		token, err = tokens.Refresh(ctx, token)
+		if err == nil {
+			err = push(token.Token)
+		}
+	}
+
+	if err != nil {
+		permanent := errors.Is(err, myanimelist.ErrNotFound) ||
+			errors.Is(err, kitsu.ErrNotFound) ||
+			errors.Is(err, shikimori.ErrNotFound) ||
+			errors.Is(err, auth.ErrReauthRequired)
+		return fail(fmt.Errorf("sync to %s: %w", entry.Provider, err), permanent)
+	}
+
+	setStatus(repository.LibrarySyncStatusSuccess)
+	return nil
+}
+
+// failSync records a failed attempt on the sync row, which owns the retry
+// schedule, and tells the queue when to try again. Once attempts run out or
+// the error is permanent the row is dead and the job stops.
+func failSync(
+	ctx context.Context,
+	repo *repository.Queries,
+	log *slog.Logger,
+	entry repository.ExternalLibrarySync,
+	err error,
+	permanent bool,
+) error {
+	delay := queue.Backoff(syncOptions, entry.Attempts+1)
+	row, recErr := repo.RecordLibrarySyncFailure(ctx, repository.RecordLibrarySyncFailureParams{
+		LastError:      pgtype.Text{String: err.Error(), Valid: true},
+		Permanent:      permanent,
+		MaxAttempts:    syncMaxAttempts,
+		BackoffSeconds: int32(delay.Seconds()),
+		UserID:         entry.UserID,
+		AnimeID:        entry.AnimeID,
+		Provider:       entry.Provider,
+		Revision:       entry.Revision,
+	})
+	if errors.Is(recErr, pgx.ErrNoRows) {
+		// the entry changed meanwhile, the job queued for it pushes the new state
+		log.Info("Library sync superseded by a newer change", "err", err)
+		return nil
+	}
+	if recErr != nil {
+		log.Error("Failed to record library sync failure", "err", recErr)
+		return err
+	}
+
+	if row.Status == repository.LibrarySyncStatusDead {
+		log.Warn("Library sync dead", "attempts", row.Attempts, "permanent", permanent, "err", err)
+		return queue.Permanent(err)
+	}
+	return queue.RetryAfter(err, delay)
+}
+
+// isUnauthorized reports whether the provider rejected the access token, in
+// which case it is refreshed and the request tried once more.
+func isUnauthorized(err error) bool {
+	return errors.Is(err, myanimelist.ErrUnauthorized) ||
+		errors.Is(err, kitsu.ErrUnauthorized) ||
+		errors.Is(err, shikimori.ErrUnauthorized) ||
+		anilist.IsUnauthorized(err)
+}
+
+func handleMalProvider(
+	ctx context.Context,
+	malClient *myanimelist.Client,
+	anime repository.Anime,
+	token string,
+	action string,
+	status string,
+	episodes int,
+	details SyncData,
+) error {
+	switch action {
+	case string(repository.LibraryActionsAddEntry):
+		params := myanimelist.UpdateAnimeListParams{
+			Token:           token,
+			AnimeID:         int(anime.MalID.Int32),
+			Status:          status,
+			WatchedEpisodes: episodes,
+			Rewatches:       intPtr(details.Rewatches),
+			Comments:        details.Notes,
+			StartDate:       nonEmpty(details.StartedAt),
+			FinishDate:      nonEmpty(details.CompletedAt),
+		}
+		if details.Score != nil {
+			// MAL scores out of 10, round to the nearest point keeping any score above 0
+			score := 0
+			if *details.Score > 0 {
+				score = max(1, int(*details.Score+5)/10)
+			}
+			params.Score = &score
+		}
+		return malClient.UpdateAnimeList(ctx, params)
+
+	case string(repository.LibraryActionsDeleteEntry):
+		return malClient.DeleteAnimeList(ctx, myanimelist.DeleteAnimeListParams{
+			Token:   token,
+			AnimeID: int(anime.MalID.Int32),
+		})
+	default:
+		return fmt.Errorf("unsupported action: %s", action)
+	}
+}
+
+func handleAniProvider(
+	ctx context.Context,
+	aniClient *anilist.Client,
+	anime repository.Anime,
+	token string,
+	action string,
+	status string,
+	episodes int,
+	details SyncData,
+) error {
+	switch action {
+	case string(repository.LibraryActionsAddEntry):
+		return aniClient.InsertAnimeToList(ctx, anilist.InsertAnimeToListParams{
+			Token:           token,
+			MalID:           int(anime.MalID.Int32),
+			Status:          status,
+			WatchedEpisodes: episodes,
+			Score:           intPtr(details.Score),
+			Rewatches:       intPtr(details.Rewatches),
+			Notes:           details.Notes,
+			StartedAt:       details.StartedAt,
+			CompletedAt:     details.CompletedAt,
+		})
+
+	case string(repository.LibraryActionsDeleteEntry):
+		return aniClient.DeleteAnimeList(ctx, anilist.DeleteAnimeListParams{
+			Token: token,
+			MalID: int(anime.MalID.Int32),
+		})
+	default:
+		return fmt.Errorf("unsupported action: %s", action)
+	}
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+// nonEmpty drops empty dates, providers do not reliably clear them.
+func nonEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+func handleKitsuProvider(
+	ctx context.Context,
+	repo *repository.Queries,
+	kitsuClient *kitsu.Client,
+	anime repository.Anime,
+	token string,
+	action string,
+	status string,
+	episodes int,
+	details SyncData,
+) error {
+	animeID, err := kitsuAnimeID(ctx, repo, kitsuClient, anime.MalID.Int32)
+	if errors.Is(err, kitsu.ErrNotFound) && action == string(repository.LibraryActionsDeleteEntry) {
+		// nothing can be in the library for an anime kitsu does not know
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case string(repository.LibraryActionsAddEntry):
+		params := kitsu.UpdateLibraryEntryParams{
+			Token:           token,
+			AnimeID:         animeID,
+			Status:          status,
+			WatchedEpisodes: episodes,
+			ReconsumeCount:  intPtr(details.Rewatches),
+			Notes:           details.Notes,
+			StartedAt:       nonEmpty(details.StartedAt),
+			FinishedAt:      nonEmpty(details.CompletedAt),
+		}
+		if details.Score != nil {
+			// kitsu rates from 2 to 20, round to the nearest point keeping any score above 0
+			rating := 0
+			if *details.Score > 0 {
+				rating = max(2, int(*details.Score+2)/5)
+			}
+			params.RatingTwenty = &rating
+		}
+		return kitsuClient.UpdateLibraryEntry(ctx, params)
+
+	case string(repository.LibraryActionsDeleteEntry):
+		return kitsuClient.DeleteLibraryEntry(ctx, kitsu.DeleteLibraryEntryParams{
+			Token:   token,
+			AnimeID: animeID,
+		})
+	default:
+		return fmt.Errorf("unsupported action: %s", action)
+	}
+}
+
+func handleShikimoriProvider(
+	ctx context.Context,
+	shikiClient *shikimori.Client,
+	anime repository.Anime,
+	token string,
+	action string,
+	status string,
+	episodes int,
+	details SyncData,
+) error {
+	switch action {
+	case string(repository.LibraryActionsAddEntry):
+		params := shikimori.UpdateUserRateParams{
+			Token:           token,
+			MalID:           int(anime.MalID.Int32),
+			Status:          status,
+			WatchedEpisodes: episodes,
+			Rewatches:       intPtr(details.Rewatches),
+			Text:            details.Notes,
+		}
+		if details.Score != nil {
+			// shikimori scores out of 10 like MAL
+			score := 0
+			if *details.Score > 0 {
+				score = max(1, int(*details.Score+5)/10)
+			}
+			params.Score = &score
+		}
+		return shikiClient.UpdateUserRate(ctx, params)
+
+	case string(repository.LibraryActionsDeleteEntry):
+		return shikiClient.DeleteUserRate(ctx, shikimori.DeleteUserRateParams{
+			Token: token,
+			MalID: int(anime.MalID.Int32),
+		})
+	default:
+		return fmt.Errorf("unsupported action: %s", action)
+	}
+}
@@ -2,52 +2,53 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
-	"github.com/coeeter/aniways/internal/infra/cache"
-	"github.com/coeeter/aniways/internal/infra/client/anilist"
-	"github.com/coeeter/aniways/internal/infra/client/hianime"
-	"github.com/coeeter/aniways/internal/infra/client/myanimelist"
+	"github.com/coeeter/aniways/internal/app"
 	"github.com/coeeter/aniways/internal/repository"
-	"github.com/coeeter/aniways/internal/service/auth/oauth"
-	"github.com/coeeter/aniways/internal/worker/auth"
+	"github.com/coeeter/aniways/internal/worker/lease"
 	"github.com/coeeter/aniways/internal/worker/library"
+	"github.com/coeeter/aniways/internal/worker/queue"
 	"github.com/coeeter/aniways/internal/worker/scraper"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5"
 	"github.com/robfig/cron/v3"
 )
 
 type Manager struct {
-	db        *pgxpool.Pool
-	repo      *repository.Queries
-	scraper   *hianime.HianimeScraper
-	malClient *myanimelist.Client
-	aniClient *anilist.Client
-	redis     *cache.RedisClient
-	log       *slog.Logger
+	deps     *app.Deps
+	repo     *repository.Queries
+	holder   string
+	elector  *lease.Elector
+	services *serviceBuilder
+	cron     *cron.Cron
+	jobs     []*scheduledJob
+	queue    *queue.Queue
+	log      *slog.Logger
 }
 
-func NewManager(
-	db *pgxpool.Pool,
-	repo *repository.Queries,
-	scraper *hianime.HianimeScraper,
-	malClient *myanimelist.Client,
-	aniClient *anilist.Client,
-	redis *cache.RedisClient,
-	log *slog.Logger,
-) *Manager {
-	return &Manager{
-		db:        db,
-		repo:      repo,
-		scraper:   scraper,
-		malClient: malClient,
-		aniClient: aniClient,
-		redis:     redis,
-		log:       log,
+func NewManager(deps *app.Deps, log *slog.Logger) *Manager {
+	holder := lease.NewHolderID()
+	m := &Manager{
+		deps:     deps,
+		repo:     deps.Repo,
+		holder:   holder,
+		elector:  lease.NewElector(deps.Repo, holder, log.With("component", "leader-election")),
+		services: newServiceBuilder(deps),
+		cron:     cron.New(),
+		log:      log,
 	}
+	for _, spec := range Jobs() {
+		m.jobs = append(m.jobs, &scheduledJob{spec: spec})
+	}
+	return m
 }
 
+// Bootstrap seeds an empty catalog before the daemon starts, blocking so the
+// API never serves an empty database for long. An unfinished seed run on a
+// seeded catalog is resumed by the full-seed job in the background instead,
+// a source that is still down must not keep the daemon from starting.
 func (m *Manager) Bootstrap(ctx context.Context) error {
 	count, err := m.repo.GetCountOfAnimes(ctx)
 	if err != nil {
@@ -58,72 +59,116 @@ func (m *Manager) Bootstrap(ctx context.Context) error {
 		log := m.log.With("job", "full-seed")
 		log.Info("no anime in DB — running initial scrape (blocking)")
 
-		if err := scraper.FullSeed(ctx, m.scraper, m.repo, log); err != nil {
-			return fmt.Errorf("full seed: %w", err)
+		// only one replica seeds, the others carry on with an empty catalog
+		var seedErr error
+		ran := lease.RunExclusive(ctx, m.repo, "job:full-seed", log, func(ctx context.Context) {
+			seedErr = scraper.FullSeed(ctx, m.deps.Scraper, m.repo, true, log)
+		})
+		if seedErr != nil {
+			return fmt.Errorf("full seed: %w", seedErr)
+		}
+		if ran {
+			log.Info("initial scrape complete")
 		}
-		log.Info("initial scrape complete")
-	} else {
-		m.log.Info("database already seeded; skipping initial scrape", "count", count)
+		return nil
 	}
-	return nil
-}
 
-func (m *Manager) StartBackground(ctx context.Context, providers map[string]oauth.Provider) {
-	c := cron.New()
-	_, err := c.AddFunc("@hourly", func() {
-		scraper.HourlyTask(ctx, m.scraper, m.repo, m.redis, m.log.With("job", "hourly-scrape"))
-	})
-	if err != nil {
-		m.log.Error("failed to add hourly task", "err", err)
-		return
+	m.log.Info("database already seeded; skipping initial scrape", "count", count)
+
+	unfinished, err := m.repo.GetLatestUnfinishedSeedRun(ctx, repository.SeedRunKindFullSeed)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		m.log.Error("get unfinished seed run failed", "err", err)
+	default:
+		job := m.findJob("full-seed")
+		m.log.Info("unfinished seed run found — resuming in the background",
+			"run_id", unfinished.ID,
+			"status", unfinished.Status,
+		)
+		go m.runJob(ctx, job, repository.JobRunTriggerSchedule, nil)
 	}
+	return nil
+}
 
-	_, err = c.AddFunc("@daily", func() {
-		auth.DailyTask(ctx, m.repo, providers, m.log.With("job", "daily-refresh-token"))
-	})
-	if err != nil {
-		m.log.Error("failed to add daily task", "err", err)
-		return
+func (m *Manager) StartBackground(ctx context.Context) error {
+	for _, job := range m.jobs {
+		if err := m.schedule(ctx, job); err != nil {
+			return fmt.Errorf("schedule %s: %w", job.spec.Name, err)
+		}
 	}
 
-	_, err = c.AddFunc("@every 6h", func() {
-		library.RetryFailedLibrarySyncs(ctx, m.repo, m.malClient, m.aniClient, m.log.With("job", "failed-library-sync-cron"))
-	})
+	go m.elector.Run(ctx)
 
-	m.log.Info("bootstrapping hourly + daily cron job")
-	c.Start()
+	m.log.Info("starting cron scheduler", "jobs", len(m.cron.Entries()))
+	m.cron.Start()
 
-	go func() {
-		err := library.StartLibrarySyncListener(
-			ctx,
-			m.db,
-			m.repo,
-			m.malClient,
-			m.aniClient,
-			m.log.With("job", "library-sync"),
-		)
-		if err != nil {
-			m.log.Error("library sync listener stopped", "err", err)
-		}
-	}()
+	m.queue = queue.New(m.deps.Db, m.repo, m.log.With("component", "job-queue"))
+	library.RegisterJobs(m.queue, m.repo, m.deps.MAL, m.deps.Anilist, m.deps.Kitsu, m.deps.Shiki, m.deps.Providers, m.log)
 
 	go func() {
-		err := library.StartLibraryImportJobListener(
-			ctx,
-			m.db,
-			m.repo,
-			m.malClient,
-			m.aniClient,
-			m.log.With("job", "library-import"),
-		)
-		if err != nil {
-			m.log.Error("library import listener stopped", "err", err)
+		if err := m.queue.Run(ctx); err != nil {
+			m.log.Error("job queue stopped", "err", err)
 		}
 	}()
 
 	go func() {
 		<-ctx.Done()
 		m.log.Info("Shutting down cron scheduler")
-		c.Stop()
+		m.cron.Stop()
 	}()
+	return nil
+}
+
+// schedule adds a job to cron using its effective schedule. Scheduled runs
+// only happen on the leader, unless paused, and never overlap with a previous
+// run of the same job on any replica.
+func (m *Manager) schedule(ctx context.Context, job *scheduledJob) error {
+	spec, enabled, err := job.spec.EffectiveSchedule()
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		m.log.Info("job not scheduled", "job", job.spec.Name)
+		return nil
+	}
+
+	id, err := m.cron.AddFunc(spec, func() {
+		log := m.log.With("job", job.spec.Name)
+		if !m.elector.IsLeader() {
+			log.Debug("not the leader, skipping")
+			return
+		}
+
+		paused, err := m.repo.IsJobPaused(ctx, job.spec.Name)
+		if err != nil {
+			log.Error("check paused failed", "err", err)
+		}
+		if paused {
+			log.Info("schedule paused, skipping")
+			return
+		}
+
+		m.runJob(ctx, job, repository.JobRunTriggerSchedule, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	job.schedule = spec
+	job.entryID = id
+	return nil
+}
+
+var ErrJobNotFound = errors.New("job not found")
+
+// RunNow runs a job by name in the foreground with the given options,
+// recorded as a manual run. It reports false when another run of the job was
+// already in progress.
+func (m *Manager) RunNow(ctx context.Context, name string, opts RunOptions) (bool, error) {
+	job := m.findJob(name)
+	if job == nil {
+		return false, ErrJobNotFound
+	}
+	return m.runJob(ctx, job, repository.JobRunTriggerManual, opts), nil
 }
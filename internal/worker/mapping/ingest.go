@@ -0,0 +1,186 @@
+package mapping
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const ingestBatchSize = 1000
+
+var sourcePatterns = map[string]*regexp.Regexp{
+	"mal":     regexp.MustCompile(`myanimelist\.net/anime/(\d+)`),
+	"anilist": regexp.MustCompile(`anilist\.co/anime/(\d+)`),
+	"kitsu":   regexp.MustCompile(`kitsu\.(?:io|app)/anime/(\d+)`),
+	"anidb":   regexp.MustCompile(`anidb\.net/anime/(\d+)`),
+}
+
+// offlineEntry is a single item of the manami anime-offline-database "data"
+// array. Fields we do not store are left out.
+type offlineEntry struct {
+	Sources     []string `json:"sources"`
+	Title       string   `json:"title"`
+	Type        string   `json:"type"`
+	Episodes    int      `json:"episodes"`
+	AnimeSeason struct {
+		Season string `json:"season"`
+		Year   int    `json:"year"`
+	} `json:"animeSeason"`
+	Synonyms []string `json:"synonyms"`
+}
+
+// IngestOfflineDatabase replaces the contents of anime_offline_mappings with
+// the entries of an anime-offline-database JSON file. The swap happens in one
+// transaction so readers never see a half-loaded table.
+func IngestOfflineDatabase(
+	ctx context.Context,
+	db *pgxpool.Pool,
+	repo *repository.Queries,
+	r io.Reader,
+	log *slog.Logger,
+) (int, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := repo.WithTx(tx)
+	if err := qtx.DeleteAllAnimeOfflineMappings(ctx); err != nil {
+		return 0, fmt.Errorf("clear offline mappings: %w", err)
+	}
+
+	dec := json.NewDecoder(r)
+	if err := seekDataArray(dec); err != nil {
+		return 0, err
+	}
+
+	total := 0
+	batch := make([]repository.InsertAnimeOfflineMappingsParams, 0, ingestBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if _, err := qtx.InsertAnimeOfflineMappings(ctx, batch); err != nil {
+			return fmt.Errorf("insert offline mappings: %w", err)
+		}
+		total += len(batch)
+		log.Info("ingested offline mappings", "count", total)
+		batch = batch[:0]
+		return nil
+	}
+
+	for dec.More() {
+		var entry offlineEntry
+		if err := dec.Decode(&entry); err != nil {
+			return 0, fmt.Errorf("decode entry %d: %w", total+len(batch), err)
+		}
+
+		params, ok := offlineEntryToParams(entry)
+		if !ok {
+			continue
+		}
+		batch = append(batch, params)
+
+		if len(batch) >= ingestBatchSize {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit offline mappings: %w", err)
+	}
+	return total, nil
+}
+
+// seekDataArray advances the decoder to the first element of the top level
+// "data" array.
+func seekDataArray(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected JSON object")
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		if key, ok := tok.(string); ok && key == "data" {
+			tok, err := dec.Token()
+			if err != nil {
+				return fmt.Errorf("invalid JSON: %w", err)
+			}
+			if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+				return fmt.Errorf("expected \"data\" to be an array")
+			}
+			return nil
+		}
+
+		// skip the value of any other key
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+	return fmt.Errorf("no \"data\" array found")
+}
+
+func offlineEntryToParams(entry offlineEntry) (repository.InsertAnimeOfflineMappingsParams, bool) {
+	ids := make(map[string]int32, len(sourcePatterns))
+	for _, src := range entry.Sources {
+		for name, re := range sourcePatterns {
+			if m := re.FindStringSubmatch(src); m != nil {
+				if id, err := strconv.Atoi(m[1]); err == nil {
+					ids[name] = int32(id)
+				}
+			}
+		}
+	}
+
+	// without a MAL or AniList source the entry cannot fill anything
+	if ids["mal"] == 0 && ids["anilist"] == 0 {
+		return repository.InsertAnimeOfflineMappingsParams{}, false
+	}
+
+	synonyms := entry.Synonyms
+	if synonyms == nil {
+		synonyms = []string{}
+	}
+
+	params := repository.InsertAnimeOfflineMappingsParams{
+		Title:      entry.Title,
+		Synonyms:   synonyms,
+		MediaType:  pgtype.Text{String: entry.Type, Valid: entry.Type != ""},
+		Episodes:   pgtype.Int4{Int32: int32(entry.Episodes), Valid: entry.Episodes > 0},
+		SeasonYear: pgtype.Int4{Int32: int32(entry.AnimeSeason.Year), Valid: entry.AnimeSeason.Year > 0},
+		MalID:      pgtype.Int4{Int32: ids["mal"], Valid: ids["mal"] > 0},
+		AnilistID:  pgtype.Int4{Int32: ids["anilist"], Valid: ids["anilist"] > 0},
+		KitsuID:    pgtype.Int4{Int32: ids["kitsu"], Valid: ids["kitsu"] > 0},
+		AnidbID:    pgtype.Int4{Int32: ids["anidb"], Valid: ids["anidb"] > 0},
+	}
+
+	switch season := repository.Season(strings.ToLower(entry.AnimeSeason.Season)); season {
+	case repository.SeasonWinter, repository.SeasonSpring, repository.SeasonSummer, repository.SeasonFall:
+		params.Season = repository.NullSeason{Season: season, Valid: true}
+	}
+
+	return params, true
+}
@@ -0,0 +1,206 @@
+package mapping
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/coeeter/aniways/internal/models"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/service/admin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// matches at or above this are pinned straight away
+	autoApplyConfidence float32 = 0.9
+	// matches between this and autoApplyConfidence go to the admin review queue
+	reviewConfidence float32 = 0.5
+	// the best fuzzy match must beat the runner-up by this much to auto-apply
+	ambiguityMargin float32 = 0.05
+
+	resolverActor = "anime-offline-database"
+)
+
+type match struct {
+	malID      int32
+	anilistID  int32
+	confidence float32
+	method     string
+	title      string
+}
+
+// ResolveMissingIDs fills missing MAL/AniList IDs from anime_offline_mappings.
+// Confident matches are written as mapping overrides so later scrapes keep
+// them, the rest are queued for admin review.
+func ResolveMissingIDs(
+	ctx context.Context,
+	repo *repository.Queries,
+	adminSvc *admin.AdminService,
+	log *slog.Logger,
+) error {
+	count, err := repo.GetAnimeOfflineMappingCount(ctx)
+	if err != nil {
+		return fmt.Errorf("count offline mappings: %w", err)
+	}
+	if count == 0 {
+		log.Info("no offline mappings ingested, skipping")
+		return nil
+	}
+
+	animes, err := repo.GetAnimesMissingExternalIds(ctx)
+	if err != nil {
+		return fmt.Errorf("get animes missing ids: %w", err)
+	}
+	log.Info("resolving missing ids", "animes", len(animes))
+
+	var applied, queued, unmatched int
+	for _, a := range animes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		child := log.With("anime_id", a.ID, "hi_id", a.HiAnimeID)
+
+		m, ok, err := findMatch(ctx, repo, a)
+		if err != nil {
+			child.Error("match failed", "err", err)
+			continue
+		}
+		if !ok {
+			unmatched++
+			continue
+		}
+
+		if m.confidence >= autoApplyConfidence {
+			if err := applyMatch(ctx, adminSvc, a, m); err != nil {
+				child.Error("apply match failed", "err", err)
+				continue
+			}
+			child.Info("applied mapping", "mal_id", m.malID, "anilist_id", m.anilistID, "method", m.method, "confidence", m.confidence)
+			applied++
+			continue
+		}
+
+		if err := repo.InsertAnimeMappingSuggestion(ctx, repository.InsertAnimeMappingSuggestionParams{
+			AnimeID:      a.ID,
+			MalID:        pgtype.Int4{Int32: m.malID, Valid: m.malID > 0},
+			AnilistID:    pgtype.Int4{Int32: m.anilistID, Valid: m.anilistID > 0},
+			Confidence:   m.confidence,
+			Method:       m.method,
+			MatchedTitle: m.title,
+		}); err != nil {
+			child.Error("queue suggestion failed", "err", err)
+			continue
+		}
+		queued++
+	}
+
+	log.Info("finished resolving missing ids", "applied", applied, "queued", queued, "unmatched", unmatched)
+	return nil
+}
+
+func findMatch(ctx context.Context, repo *repository.Queries, a repository.Anime) (match, bool, error) {
+	hasMal := a.MalID.Valid && a.MalID.Int32 > 0
+	hasAnilist := a.AnilistID.Valid && a.AnilistID.Int32 > 0
+
+	if hasMal {
+		row, err := repo.GetOfflineMappingByMalId(ctx, a.MalID)
+		if errors.Is(err, pgx.ErrNoRows) || (err == nil && !row.AnilistID.Valid) {
+			return match{}, false, nil
+		}
+		if err != nil {
+			return match{}, false, err
+		}
+		return match{malID: a.MalID.Int32, anilistID: row.AnilistID.Int32, confidence: 1, method: "mal_id", title: row.Title}, true, nil
+	}
+
+	if hasAnilist {
+		row, err := repo.GetOfflineMappingByAnilistId(ctx, a.AnilistID)
+		if err == nil {
+			return match{malID: row.MalID.Int32, anilistID: a.AnilistID.Int32, confidence: 1, method: "anilist_id", title: row.Title}, true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return match{}, false, err
+		}
+	}
+
+	candidates, err := repo.FindOfflineMappingCandidates(ctx, repository.FindOfflineMappingCandidatesParams{
+		Ename: a.Ename,
+		Jname: a.Jname,
+	})
+	if err != nil {
+		return match{}, false, err
+	}
+	if len(candidates) == 0 {
+		return match{}, false, nil
+	}
+
+	type ranked struct {
+		row   repository.FindOfflineMappingCandidatesRow
+		score float32
+	}
+	ranking := make([]ranked, len(candidates))
+	for i, c := range candidates {
+		ranking[i] = ranked{row: c, score: scoreCandidate(a, c)}
+	}
+	slices.SortFunc(ranking, func(x, y ranked) int { return cmp.Compare(y.score, x.score) })
+
+	best := ranking[0]
+	m := match{
+		malID:      best.row.MalID.Int32,
+		anilistID:  best.row.AnilistID.Int32,
+		confidence: best.score,
+		method:     "title_fuzzy",
+		title:      best.row.Title,
+	}
+	if hasAnilist {
+		// keep the scraped AniList ID, only the MAL ID was missing
+		m.anilistID = a.AnilistID.Int32
+	}
+
+	// two near-identical candidates (e.g. a sequel and its OVA) need a human
+	if len(ranking) > 1 && best.score-ranking[1].score < ambiguityMargin && m.confidence >= autoApplyConfidence {
+		m.confidence = autoApplyConfidence - 0.01
+	}
+	if m.confidence < reviewConfidence {
+		return match{}, false, nil
+	}
+	return m, true, nil
+}
+
+// scoreCandidate turns the trigram similarity into a confidence by rewarding
+// matching season data and penalising a different year.
+func scoreCandidate(a repository.Anime, c repository.FindOfflineMappingCandidatesRow) float32 {
+	score := c.Score
+	if a.SeasonYear > 0 && c.SeasonYear.Valid {
+		if a.SeasonYear == c.SeasonYear.Int32 {
+			score += 0.1
+		} else {
+			score -= 0.2
+		}
+	}
+	if a.Season != repository.SeasonUnknown && c.Season.Valid && a.Season == c.Season.Season {
+		score += 0.05
+	}
+	return min(max(score, 0), 1)
+}
+
+func applyMatch(ctx context.Context, adminSvc *admin.AdminService, a repository.Anime, m match) error {
+	req := models.AnimeMappingOverrideRequest{}
+	if m.malID > 0 {
+		req.MalID = &m.malID
+	}
+	if m.anilistID > 0 {
+		req.AnilistID = &m.anilistID
+	}
+	note := fmt.Sprintf("matched %q by %s (confidence %.2f)", m.title, m.method, m.confidence)
+	req.Note = &note
+
+	_, err := adminSvc.UpsertMappingOverride(ctx, a.HiAnimeID, resolverActor, req)
+	return err
+}
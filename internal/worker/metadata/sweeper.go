@@ -0,0 +1,120 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/coeeter/aniways/internal/infra/client/myanimelist"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/service/anime"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// sweepBatchSize caps a run to roughly 20 minutes at sweepRate
+	sweepBatchSize = 600
+	sweepAttempts  = 3
+	sweepRetryWait = 5 * time.Second
+	// failureBackoffHours is the first wait after an ID fails a whole run, it
+	// doubles on every further failure
+	failureBackoffHours = 6
+)
+
+// sweepRate is half the budget of the API's lazy refresher so both can hit
+// MAL at the same time.
+var sweepRate = rate.Every(2 * time.Second)
+
+// Sweep refreshes stale anime_metadata rows and backfills missing ones for
+// anime that have a MAL ID.
+func Sweep(
+	ctx context.Context,
+	repo *repository.Queries,
+	malClient *myanimelist.Client,
+	log *slog.Logger,
+) error {
+	now := time.Now()
+	candidates, err := repo.GetMetadataSweepCandidates(ctx, repository.GetMetadataSweepCandidatesParams{
+		StaleBefore:       pgtype.Timestamp{Time: now.Add(-anime.MetadataTTL), Valid: true},
+		AiringStaleBefore: pgtype.Timestamp{Time: now.Add(-anime.AiringMetadataTTL), Valid: true},
+		LimitCount:        sweepBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("get sweep candidates: %w", err)
+	}
+	log.Info("starting metadata sweep", "candidates", len(candidates))
+
+	limiter := rate.NewLimiter(sweepRate, 1)
+	var refreshed, backfilled, failed int
+
+	for _, c := range candidates {
+		err := refresh(ctx, repo, malClient, limiter, c.MalID)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			failed++
+			log.Warn("metadata refresh failed", "mal_id", c.MalID, "err", err)
+			if err := repo.RecordMetadataSweepFailure(ctx, repository.RecordMetadataSweepFailureParams{
+				MalID:        c.MalID,
+				LastError:    err.Error(),
+				BackoffHours: failureBackoffHours,
+			}); err != nil {
+				log.Error("record sweep failure failed", "mal_id", c.MalID, "err", err)
+			}
+			continue
+		}
+
+		if err := repo.ClearMetadataSweepFailure(ctx, c.MalID); err != nil {
+			log.Error("clear sweep failure failed", "mal_id", c.MalID, "err", err)
+		}
+		if c.Missing {
+			backfilled++
+		} else {
+			refreshed++
+		}
+	}
+
+	log.Info("metadata sweep finished", "refreshed", refreshed, "backfilled", backfilled, "failed", failed)
+	return nil
+}
+
+func refresh(
+	ctx context.Context,
+	repo *repository.Queries,
+	malClient *myanimelist.Client,
+	limiter *rate.Limiter,
+	malID int32,
+) error {
+	var lastErr error
+	for attempt := range sweepAttempts {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(sweepRetryWait * time.Duration(attempt)):
+			}
+		}
+
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		reqCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		dto, err := malClient.GetAnimeMetadata(reqCtx, int(malID))
+		cancel()
+		if errors.Is(err, myanimelist.ErrNotFound) {
+			return err
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		return repo.UpsertAnimeMetadata(ctx, dto.ToUpsertParams())
+	}
+	return lastErr
+}
@@ -0,0 +1,518 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/coeeter/aniways/internal/worker/lease"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// notifyChannel is the channel the jobs insert trigger notifies with the
+// queue name. It is only a hint, every queue is polled as well.
+const notifyChannel = "job_queue"
+
+const (
+	reapInterval     = time.Minute
+	pruneInterval    = time.Hour
+	retentionDays    = 7
+	listenRetryDelay = 5 * time.Second
+)
+
+// Job is a claimed job handed to a HandlerFunc.
+type Job struct {
+	ID          string
+	Queue       string
+	Payload     json.RawMessage
+	Attempt     int32
+	MaxAttempts int32
+}
+
+// LastAttempt reports whether a failure now dead-letters the job.
+func (j Job) LastAttempt() bool {
+	return j.Attempt >= j.MaxAttempts
+}
+
+type HandlerFunc func(ctx context.Context, job Job) error
+
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying, the job is dead-lettered
+// straight away.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var perm permanentError
+	return errors.As(err, &perm)
+}
+
+type retryAfterError struct {
+	err   error
+	after time.Duration
+}
+
+func (e retryAfterError) Error() string { return e.err.Error() }
+func (e retryAfterError) Unwrap() error { return e.err }
+
+// RetryAfter retries the job after d instead of the queue's own backoff, for
+// handlers that keep their own retry schedule.
+func RetryAfter(err error, d time.Duration) error {
+	return retryAfterError{err: err, after: d}
+}
+
+func retryDelay(opts Options, attempt int32, err error) time.Duration {
+	var ra retryAfterError
+	if errors.As(err, &ra) {
+		return ra.after
+	}
+	return Backoff(opts, attempt)
+}
+
+type Options struct {
+	// Concurrency is the number of jobs of this queue processed at once.
+	Concurrency int
+	// Lease is how long a claim is valid without a heartbeat. Jobs whose
+	// lease runs out (e.g. the worker crashed) are picked up again.
+	Lease        time.Duration
+	PollInterval time.Duration
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+var DefaultOptions = Options{
+	Concurrency:  5,
+	Lease:        time.Minute,
+	PollInterval: 30 * time.Second,
+	BaseBackoff:  30 * time.Second,
+	MaxBackoff:   time.Hour,
+}
+
+type registration struct {
+	name    string
+	opts    Options
+	handler HandlerFunc
+	wake    chan struct{}
+
+	inFlight   atomic.Int32
+	lastPollAt atomic.Int64
+}
+
+func (r *registration) signal() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+type Queue struct {
+	db       *pgxpool.Pool
+	repo     *repository.Queries
+	workerID string
+	queues   map[string]*registration
+	log      *slog.Logger
+
+	listening    atomic.Bool
+	lastNotifyAt atomic.Int64
+}
+
+type Status struct {
+	WorkerID           string        `json:"workerId"`
+	Listening          bool          `json:"listening"`
+	LastNotificationAt *time.Time    `json:"lastNotificationAt"`
+	Queues             []QueueStatus `json:"queues"`
+}
+
+type QueueStatus struct {
+	Name        string     `json:"name"`
+	Concurrency int        `json:"concurrency"`
+	InFlight    int        `json:"inFlight"`
+	LastPollAt  *time.Time `json:"lastPollAt"`
+}
+
+func New(db *pgxpool.Pool, repo *repository.Queries, log *slog.Logger) *Queue {
+	return &Queue{
+		db:       db,
+		repo:     repo,
+		workerID: lease.NewHolderID(),
+		queues:   make(map[string]*registration),
+		log:      log,
+	}
+}
+
+// Register adds a handler for a queue. Zero option fields fall back to
+// DefaultOptions. It must be called before Run.
+func (q *Queue) Register(name string, opts Options, handler HandlerFunc) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultOptions.Concurrency
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = DefaultOptions.Lease
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultOptions.PollInterval
+	}
+	if opts.BaseBackoff <= 0 {
+		opts.BaseBackoff = DefaultOptions.BaseBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultOptions.MaxBackoff
+	}
+
+	q.queues[name] = &registration{
+		name:    name,
+		opts:    opts,
+		handler: handler,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Enqueue adds a job. Jobs with the same non-empty dedupeKey collapse into
+// one while they are still waiting to run.
+func Enqueue(
+	ctx context.Context,
+	repo *repository.Queries,
+	queue, dedupeKey string,
+	payload any,
+	maxAttempts int32,
+) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal job payload: %w", err)
+	}
+
+	return repo.EnqueueJob(ctx, repository.EnqueueJobParams{
+		Queue:       queue,
+		DedupeKey:   pgtype.Text{String: dedupeKey, Valid: dedupeKey != ""},
+		Payload:     data,
+		MaxAttempts: maxAttempts,
+	})
+}
+
+// Status reports whether the notification listener is connected and how busy
+// each queue is in this process.
+func (q *Queue) Status() Status {
+	status := Status{
+		WorkerID:           q.workerID,
+		Listening:          q.listening.Load(),
+		LastNotificationAt: unixNanoTime(q.lastNotifyAt.Load()),
+	}
+	for _, r := range q.queues {
+		status.Queues = append(status.Queues, QueueStatus{
+			Name:        r.name,
+			Concurrency: r.opts.Concurrency,
+			InFlight:    int(r.inFlight.Load()),
+			LastPollAt:  unixNanoTime(r.lastPollAt.Load()),
+		})
+	}
+	slices.SortFunc(status.Queues, func(a, b QueueStatus) int { return strings.Compare(a.Name, b.Name) })
+	return status
+}
+
+func unixNanoTime(n int64) *time.Time {
+	if n == 0 {
+		return nil
+	}
+	t := time.Unix(0, n)
+	return &t
+}
+
+// Run processes all registered queues until ctx is cancelled, then waits for
+// in-flight jobs to finish.
+func (q *Queue) Run(ctx context.Context) error {
+	q.log.Info("job queue started", "worker_id", q.workerID, "queues", len(q.queues))
+
+	var wg sync.WaitGroup
+	for _, r := range q.queues {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.dispatch(ctx, r)
+		}()
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		q.listen(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		q.maintain(ctx)
+	}()
+
+	wg.Wait()
+	q.log.Info("job queue stopped")
+	return nil
+}
+
+func (q *Queue) dispatch(ctx context.Context, r *registration) {
+	log := q.log.With("queue", r.name)
+	sem := make(chan struct{}, r.opts.Concurrency)
+
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if free := cap(sem) - len(sem); free > 0 && ctx.Err() == nil {
+			jobs, err := q.repo.ClaimJobs(ctx, repository.ClaimJobsParams{
+				WorkerID:     pgtype.Text{String: q.workerID, Valid: true},
+				LeaseSeconds: int32(r.opts.Lease.Seconds()),
+				Queue:        r.name,
+				LimitCount:   int32(free),
+			})
+			if err != nil && ctx.Err() == nil {
+				log.Error("claim jobs failed", "err", err)
+			} else {
+				r.lastPollAt.Store(time.Now().UnixNano())
+			}
+
+			for _, j := range jobs {
+				sem <- struct{}{}
+				r.inFlight.Add(1)
+				go func() {
+					defer func() {
+						r.inFlight.Add(-1)
+						<-sem
+						r.signal()
+					}()
+					q.work(ctx, r, j, log)
+				}()
+			}
+
+			if len(jobs) == free {
+				// the queue may have more ready work
+				continue
+			}
+		}
+
+		due := q.nextDue(ctx, r, log)
+		select {
+		case <-ctx.Done():
+			due.Stop()
+			for range cap(sem) {
+				sem <- struct{}{}
+			}
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		case <-due.C:
+		}
+		due.Stop()
+	}
+}
+
+// nextDue fires when the earliest delayed job of the queue becomes ready. The
+// insert notification of a delayed job arrives before it can be claimed, so
+// without it the job would wait for the next poll.
+func (q *Queue) nextDue(ctx context.Context, r *registration, log *slog.Logger) *time.Timer {
+	runAt, err := q.repo.GetNextJobRunAt(ctx, r.name)
+	if err != nil || !runAt.Valid {
+		if err != nil && ctx.Err() == nil {
+			log.Warn("get next job run time failed", "err", err)
+		}
+		// never fires, the poll ticker still runs
+		t := time.NewTimer(time.Hour)
+		t.Stop()
+		return t
+	}
+	// the floor keeps a clock running ahead of the database from spinning
+	return time.NewTimer(max(time.Until(runAt.Time), 100*time.Millisecond))
+}
+
+func (q *Queue) work(ctx context.Context, r *registration, j repository.Job, log *slog.Logger) {
+	log = log.With("job_id", j.ID, "attempt", j.Attempts)
+	worker := pgtype.Text{String: q.workerID, Valid: true}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go q.heartbeat(jobCtx, cancel, r, j.ID, log)
+
+	err := runHandler(jobCtx, r.handler, Job{
+		ID:          j.ID,
+		Queue:       j.Queue,
+		Payload:     j.Payload,
+		Attempt:     j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+	})
+
+	// the job context may already be gone, the outcome still has to be saved
+	saveCtx, saveCancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer saveCancel()
+
+	if err == nil {
+		if err := q.repo.CompleteJob(saveCtx, repository.CompleteJobParams{ID: j.ID, WorkerID: worker}); err != nil {
+			log.Error("complete job failed", "err", err)
+		}
+		return
+	}
+
+	if ctx.Err() != nil {
+		// shutting down, hand the job back without burning an attempt
+		if err := q.repo.ReleaseJob(saveCtx, repository.ReleaseJobParams{ID: j.ID, WorkerID: worker}); err != nil {
+			log.Error("release job failed", "err", err)
+		}
+		return
+	}
+
+	status, failErr := q.repo.FailJob(saveCtx, repository.FailJobParams{
+		Permanent:      IsPermanent(err),
+		BackoffSeconds: int32(retryDelay(r.opts, j.Attempts, err).Seconds()),
+		LastError:      pgtype.Text{String: err.Error(), Valid: true},
+		ID:             j.ID,
+		WorkerID:       worker,
+	})
+	if failErr != nil {
+		log.Error("fail job failed", "err", failErr, "job_err", err)
+		return
+	}
+
+	if status == repository.JobStatusDead {
+		log.Error("job dead-lettered", "err", err)
+	} else {
+		log.Warn("job failed", "err", err, "status", status)
+	}
+}
+
+func runHandler(ctx context.Context, handler HandlerFunc, job Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// heartbeat extends the lease while the job runs and cancels it when the
+// lease was lost to another worker.
+func (q *Queue) heartbeat(ctx context.Context, cancel context.CancelFunc, r *registration, id string, log *slog.Logger) {
+	ticker := time.NewTicker(r.opts.Lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := q.repo.HeartbeatJob(ctx, repository.HeartbeatJobParams{
+				LeaseSeconds: int32(r.opts.Lease.Seconds()),
+				ID:           id,
+				WorkerID:     pgtype.Text{String: q.workerID, Valid: true},
+			})
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Warn("heartbeat failed", "err", err)
+				}
+				continue
+			}
+			if n == 0 {
+				log.Warn("job lease lost, cancelling")
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// Backoff doubles the delay for every attempt, capped at MaxBackoff, with up
+// to 20% jitter so failures of the same kind do not retry in lockstep.
+func Backoff(opts Options, attempt int32) time.Duration {
+	d := opts.BaseBackoff
+	for i := int32(1); i < attempt && d < opts.MaxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, opts.MaxBackoff)
+	return d + time.Duration(rand.Int64N(int64(d)/5+1))
+}
+
+func (q *Queue) listen(ctx context.Context) {
+	for {
+		err := q.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		q.log.Warn("job notification listener stopped, retrying", "err", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+func (q *Queue) listenOnce(ctx context.Context) error {
+	conn, err := q.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+	q.listening.Store(true)
+	defer q.listening.Store(false)
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		q.lastNotifyAt.Store(time.Now().UnixNano())
+		if r, ok := q.queues[notification.Payload]; ok {
+			r.signal()
+		}
+	}
+}
+
+func (q *Queue) maintain(ctx context.Context) {
+	reap := time.NewTicker(reapInterval)
+	defer reap.Stop()
+	prune := time.NewTicker(pruneInterval)
+	defer prune.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reap.C:
+			n, err := q.repo.RequeueExpiredJobs(ctx)
+			if err != nil {
+				q.log.Error("requeue expired jobs failed", "err", err)
+				continue
+			}
+			if n > 0 {
+				q.log.Warn("requeued jobs with expired leases", "count", n)
+				for _, r := range q.queues {
+					r.signal()
+				}
+			}
+		case <-prune.C:
+			n, err := q.repo.DeleteFinishedJobs(ctx, retentionDays)
+			if err != nil {
+				q.log.Error("prune finished jobs failed", "err", err)
+				continue
+			}
+			if n > 0 {
+				q.log.Info("pruned finished jobs", "count", n)
+			}
+		}
+	}
+}
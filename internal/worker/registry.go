@@ -0,0 +1,288 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/coeeter/aniways/internal/app"
+	"github.com/coeeter/aniways/internal/service/admin"
+	"github.com/coeeter/aniways/internal/service/anime"
+	libraryservice "github.com/coeeter/aniways/internal/service/library"
+	"github.com/coeeter/aniways/internal/worker/auth"
+	"github.com/coeeter/aniways/internal/worker/library"
+	"github.com/coeeter/aniways/internal/worker/mapping"
+	"github.com/coeeter/aniways/internal/worker/metadata"
+	"github.com/coeeter/aniways/internal/worker/scraper"
+	"github.com/coeeter/aniways/internal/worker/warmup"
+)
+
+// RunFunc runs a job once. opts holds the options set for a manual run, they
+// are all off for scheduled runs.
+type RunFunc func(ctx context.Context, opts RunOptions, log *slog.Logger) error
+
+// RunOptions are the switches a run was started with, by option name.
+type RunOptions map[string]bool
+
+// JobSpec declares a worker job once: the daemon schedules it, the control
+// API and `worker jobs` CLI run it by name.
+type JobSpec struct {
+	Name        string
+	Description string
+	// Schedule is the default cron spec, empty for jobs that only run on
+	// demand. WORKER_JOB_<NAME>_SCHEDULE overrides it.
+	Schedule string
+	// Needs lists the services the job uses on top of the clients in
+	// app.Deps. Only the services some job needs are ever built.
+	Needs []Dependency
+	// Options are the switches a manual run accepts, with their usage.
+	Options map[string]string
+	// New picks what the job uses out of the dependencies, the first time
+	// the job runs in the process.
+	New func(d *app.Deps, s Services) RunFunc
+}
+
+// Dependency is a service a job can declare in Needs.
+type Dependency int
+
+const (
+	NeedsAnimeService Dependency = iota
+	NeedsLibraryService
+	NeedsAdminService
+)
+
+// Services holds the services built for a job, those it did not declare in
+// Needs are nil.
+type Services struct {
+	Anime   *anime.AnimeService
+	Library *libraryservice.LibraryService
+	Admin   *admin.AdminService
+}
+
+var catalog = []JobSpec{
+	{
+		Name:        "hourly-scrape",
+		Description: "Scrape the latest recently updated anime",
+		Schedule:    "@hourly",
+		New: func(d *app.Deps, _ Services) RunFunc {
+			hianimeScraper, repo, c := d.Scraper, d.Repo, d.Cache
+			return func(ctx context.Context, _ RunOptions, log *slog.Logger) error {
+				scraper.HourlyTask(ctx, hianimeScraper, repo, c, log)
+				return nil
+			}
+		},
+	},
+	{
+		Name:        "reconcile-availability",
+		Description: "Tombstone anime removed from HiAnime",
+		Schedule:    "@daily",
+		New: func(d *app.Deps, _ Services) RunFunc {
+			hianimeScraper, repo, c := d.Scraper, d.Repo, d.Cache
+			return func(ctx context.Context, _ RunOptions, log *slog.Logger) error {
+				scraper.ReconcileTask(ctx, hianimeScraper, repo, c, log)
+				return nil
+			}
+		},
+	},
+	{
+		Name:        "resolve-missing-ids",
+		Description: "Fill missing MAL/AniList IDs from the offline mapping table",
+		Schedule:    "@daily",
+		Needs:       []Dependency{NeedsAdminService},
+		New: func(d *app.Deps, s Services) RunFunc {
+			repo := d.Repo
+			return func(ctx context.Context, _ RunOptions, log *slog.Logger) error {
+				return mapping.ResolveMissingIDs(ctx, repo, s.Admin, log)
+			}
+		},
+	},
+	{
+		Name:        "refresh-tokens",
+		Description: "Refresh expiring OAuth tokens",
+		Schedule:    "@daily",
+		New: func(d *app.Deps, _ Services) RunFunc {
+			repo, providers := d.Repo, d.Providers
+			return func(ctx context.Context, _ RunOptions, log *slog.Logger) error {
+				auth.DailyTask(ctx, repo, providers, log)
+				return nil
+			}
+		},
+	},
+	{
+		Name:        "retry-library-syncs",
+		Description: "Re-enqueue failed library syncs",
+		Schedule:    "@every 6h",
+		New: func(d *app.Deps, _ Services) RunFunc {
+			repo := d.Repo
+			return func(ctx context.Context, _ RunOptions, log *slog.Logger) error {
+				library.RetryFailedLibrarySyncs(ctx, repo, log)
+				return nil
+			}
+		},
+	},
+	{
+		Name:        "pause-inactive-library",
+		Description: "Pause watching library entries left untouched past each user's auto-pause period",
+		Schedule:    "@daily",
+		Needs:       []Dependency{NeedsLibraryService},
+		New: func(_ *app.Deps, s Services) RunFunc {
+			return func(ctx context.Context, _ RunOptions, log *slog.Logger) error {
+				paused, err := s.Library.PauseInactiveEntries(ctx)
+				if err != nil {
+					return err
+				}
+				log.Info("paused inactive library entries", "count", paused)
+				return nil
+			}
+		},
+	},
+	{
+		Name:        "metadata-sweep",
+		Description: "Refresh stale MAL metadata and backfill missing rows",
+		Schedule:    "@hourly",
+		New: func(d *app.Deps, _ Services) RunFunc {
+			repo, mal := d.Repo, d.MAL
+			return func(ctx context.Context, _ RunOptions, log *slog.Logger) error {
+				return metadata.Sweep(ctx, repo, mal, log)
+			}
+		},
+	},
+	{
+		Name:        "warm-listing-caches",
+		Description: "Refresh trending, popular, seasonal and genre caches before they expire",
+		Schedule:    "@hourly",
+		Needs:       []Dependency{NeedsAnimeService},
+		New: func(_ *app.Deps, s Services) RunFunc {
+			return func(ctx context.Context, _ RunOptions, log *slog.Logger) error {
+				return warmup.WarmListings(ctx, s.Anime, log)
+			}
+		},
+	},
+	{
+		Name:        "scrape-all-recently-updated",
+		Description: "Scrape every recently updated page",
+		New: func(d *app.Deps, _ Services) RunFunc {
+			hianimeScraper, repo := d.Scraper, d.Repo
+			return func(ctx context.Context, _ RunOptions, log *slog.Logger) error {
+				return scraper.ScrapeAllRecentlyUpdated(ctx, hianimeScraper, repo, log)
+			}
+		},
+	},
+	{
+		Name:        "full-seed",
+		Description: "Seed the whole catalog, resuming an unfinished run",
+		Options: map[string]string{
+			"fresh": "Start a new seed run instead of resuming an unfinished one",
+		},
+		New: func(d *app.Deps, _ Services) RunFunc {
+			hianimeScraper, repo := d.Scraper, d.Repo
+			return func(ctx context.Context, opts RunOptions, log *slog.Logger) error {
+				return scraper.FullSeed(ctx, hianimeScraper, repo, !opts["fresh"], log)
+			}
+		},
+	},
+}
+
+// serviceBuilder builds the services jobs declare, each at most once per
+// process. The metadata refresher the anime and library services share
+// starts workers that live as long as the process does.
+type serviceBuilder struct {
+	deps      *app.Deps
+	mu        sync.Mutex
+	refresher *anime.MetadataRefresher
+	services  Services
+}
+
+func newServiceBuilder(d *app.Deps) *serviceBuilder {
+	return &serviceBuilder{deps: d}
+}
+
+func (b *serviceBuilder) build(needs []Dependency) Services {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	d := b.deps
+	var s Services
+	for _, need := range needs {
+		switch need {
+		case NeedsAnimeService:
+			if b.services.Anime == nil {
+				b.services.Anime = anime.NewAnimeService(d.Repo, b.metadataRefresher(), d.MAL, d.Jikan, d.Anilist, d.Shiki, d.Cache)
+			}
+			s.Anime = b.services.Anime
+		case NeedsLibraryService:
+			if b.services.Library == nil {
+				b.services.Library = libraryservice.NewLibraryService(d.Repo, b.metadataRefresher(), d.Cache)
+			}
+			s.Library = b.services.Library
+		case NeedsAdminService:
+			if b.services.Admin == nil {
+				b.services.Admin = admin.NewAdminService(d.Repo, d.Scraper, d.Cache)
+			}
+			s.Admin = b.services.Admin
+		}
+	}
+	return s
+}
+
+func (b *serviceBuilder) metadataRefresher() *anime.MetadataRefresher {
+	if b.refresher == nil {
+		b.refresher = anime.NewRefresher(b.deps.Repo, b.deps.MAL)
+	}
+	return b.refresher
+}
+
+// Jobs returns the job catalog in declaration order.
+func Jobs() []JobSpec {
+	return catalog
+}
+
+func FindJob(name string) (JobSpec, bool) {
+	for _, job := range catalog {
+		if job.Name == name {
+			return job, true
+		}
+	}
+	return JobSpec{}, false
+}
+
+// ParseOptions turns option names into RunOptions, rejecting the ones the
+// job does not declare.
+func (j JobSpec) ParseOptions(names []string) (RunOptions, error) {
+	opts := RunOptions{}
+	for _, name := range names {
+		if _, ok := j.Options[name]; !ok {
+			return nil, fmt.Errorf("job %q has no option %q", j.Name, name)
+		}
+		opts[name] = true
+	}
+	return opts, nil
+}
+
+// EffectiveSchedule applies the env overrides for a job. A job is scheduled
+// only when it is enabled and has a non-empty spec.
+//
+//	WORKER_JOB_HOURLY_SCRAPE_SCHEDULE="@every 30m"
+//	WORKER_JOB_HOURLY_SCRAPE_ENABLED=false
+func (j JobSpec) EffectiveSchedule() (spec string, enabled bool, err error) {
+	prefix := "WORKER_JOB_" + strings.ToUpper(strings.ReplaceAll(j.Name, "-", "_"))
+
+	spec = j.Schedule
+	if v, ok := os.LookupEnv(prefix + "_SCHEDULE"); ok {
+		spec = strings.TrimSpace(v)
+	}
+
+	enabled = true
+	if v, ok := os.LookupEnv(prefix + "_ENABLED"); ok {
+		enabled, err = strconv.ParseBool(v)
+		if err != nil {
+			return "", false, fmt.Errorf("%s_ENABLED: %w", prefix, err)
+		}
+	}
+
+	return spec, enabled && spec != "", nil
+}
@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// maxSummaryLines bounds how many log lines of a run are kept in job_runs.
+const maxSummaryLines = 50
+
+// runRecorder collects the log output of a single job run so the outcome and
+// a short summary can be stored without changing the jobs themselves.
+type runRecorder struct {
+	mu     sync.Mutex
+	errors int
+	warns  int
+	lines  []string
+}
+
+func (r *runRecorder) add(rec slog.Record, attrs []slog.Attr) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case rec.Level >= slog.LevelError:
+		r.errors++
+	case rec.Level >= slog.LevelWarn:
+		r.warns++
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %s", rec.Time.Format("15:04:05"), rec.Level, rec.Message)
+	write := func(a slog.Attr) bool {
+		fmt.Fprintf(&b, " %s=%v", a.Key, a.Value)
+		return true
+	}
+	for _, a := range attrs {
+		write(a)
+	}
+	rec.Attrs(write)
+
+	r.lines = append(r.lines, b.String())
+	if len(r.lines) > maxSummaryLines {
+		r.lines = r.lines[len(r.lines)-maxSummaryLines:]
+	}
+}
+
+func (r *runRecorder) summary() (errors, warns int, text string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.errors, r.warns, strings.Join(r.lines, "\n")
+}
+
+// recordingHandler tees info and above records into a runRecorder. Attributes
+// added by the job are kept, the ones inherited from the parent logger are
+// left out of the summary.
+type recordingHandler struct {
+	inner slog.Handler
+	rec   *runRecorder
+	attrs []slog.Attr
+}
+
+func newRecordingLogger(parent *slog.Logger, rec *runRecorder) *slog.Logger {
+	return slog.New(&recordingHandler{inner: parent.Handler(), rec: rec})
+}
+
+func (h *recordingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= slog.LevelInfo || h.inner.Enabled(ctx, level)
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= slog.LevelInfo {
+		h.rec.add(r, h.attrs)
+	}
+	if !h.inner.Enabled(ctx, r.Level) {
+		return nil
+	}
+	return h.inner.Handle(ctx, r)
+}
+
+func (h *recordingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &recordingHandler{
+		inner: h.inner.WithAttrs(attrs),
+		rec:   h.rec,
+		attrs: append(append([]slog.Attr{}, h.attrs...), attrs...),
+	}
+}
+
+func (h *recordingHandler) WithGroup(name string) slog.Handler {
+	return &recordingHandler{inner: h.inner.WithGroup(name), rec: h.rec, attrs: h.attrs}
+}
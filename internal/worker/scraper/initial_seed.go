@@ -2,15 +2,14 @@ package scraper
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
-	"os"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/coeeter/aniways/internal/infra/client/hianime"
+	"github.com/coeeter/aniways/internal/mappers"
 	"github.com/coeeter/aniways/internal/repository"
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -23,98 +22,195 @@ const (
 	updateSpacing  = 20 * time.Millisecond
 )
 
+// FullSeed crawls the whole AZ list and then replays every Recently-Updated
+// page in reverse. Progress is checkpointed per page in seed_runs, so when
+// resume is set an unfinished run continues from where it stopped.
 func FullSeed(
 	ctx context.Context,
 	scraper *hianime.HianimeScraper,
 	repo *repository.Queries,
+	resume bool,
 	log *slog.Logger,
 ) error {
-	log.Info("Starting AZ‐list seed")
-	if err := scrapeAllAZ(ctx, scraper, repo, log); err != nil {
+	run, err := startSeedRun(ctx, repo, repository.SeedRunKindFullSeed, repository.SeedRunPhaseAzList, resume, log)
+	if err != nil {
 		return err
 	}
 
-	log.Info("Starting reverse Recently‐Updated seed")
-	if err := ScrapeAllRecentlyUpdated(ctx, scraper, repo, log); err != nil {
+	if run.Phase == repository.SeedRunPhaseAzList {
+		log.Info("Starting AZ‐list seed", "run_id", run.ID)
+		if err := scrapeAllAZ(ctx, scraper, run, log); err != nil {
+			run.fail(err)
+			return err
+		}
+		if err := run.checkpoint(ctx, repository.SeedRunPhaseRecentlyUpdated, 0, 0); err != nil {
+			run.fail(err)
+			return err
+		}
+	}
+
+	log.Info("Starting reverse Recently‐Updated seed", "run_id", run.ID)
+	if err := scrapeAllRecentlyUpdated(ctx, scraper, run, log); err != nil {
+		run.fail(err)
 		return err
 	}
 
-	log.Info("Full seed complete")
+	if err := run.complete(ctx); err != nil {
+		return fmt.Errorf("complete seed run: %w", err)
+	}
+	log.Info("Full seed complete", "run_id", run.ID)
 	return nil
 }
 
 func scrapeAllAZ(
 	ctx context.Context,
 	scraper *hianime.HianimeScraper,
-	repo *repository.Queries,
+	run *seedRun,
 	log *slog.Logger,
 ) error {
-	sem := make(chan struct{}, maxConcurrency)
-	for page := 1; ; page++ {
-		log.Info("AZ page", "page", page)
-		listing, err := scraper.GetAZList(ctx, page)
+	total := int(run.TotalPages)
+	if total == 0 {
+		first, err := retryFetchPage(ctx, scraper.GetAZList, 1)
 		if err != nil {
-			return fmt.Errorf("AZ page %d: %w", page, err)
+			return fmt.Errorf("AZ first page: %w", err)
 		}
+		total = first.PageInfo.TotalPages
+		if err := run.checkpoint(ctx, repository.SeedRunPhaseAzList, 1, total); err != nil {
+			return err
+		}
+	}
 
-		var (
-			wg       sync.WaitGroup
-			mu       sync.Mutex
-			toInsert = make([]repository.InsertMultipleAnimesParams, 0, len(listing.Items))
-		)
-		for _, a := range listing.Items {
-			wg.Add(1)
-			go func(item hianime.ScrapedAnimeInfoDto) {
-				defer wg.Done()
-
-				select {
-				case sem <- struct{}{}:
-				case <-ctx.Done():
-					return
-				}
-				defer func() { <-sem }()
+	sem := make(chan struct{}, maxConcurrency)
+	process := func(ctx context.Context, page int) error {
+		return scrapeAZPage(ctx, scraper, run, sem, page, log)
+	}
 
-				child := log.With("hi_id", a.HiAnimeID)
+	consecutiveFailures := 0
+	for page := int(run.NextPage); page <= total; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-				info, err := retryFetchDetail(ctx, scraper, item.HiAnimeID)
-				if err != nil {
-					child.Warn("detail fetch failed", "err", err)
-					return
-				}
+		log.Info("AZ page", "page", page, "of", total)
+		if err := process(ctx, page); err != nil {
+			consecutiveFailures++
+			run.quarantinePage(ctx, page, err)
+			if consecutiveFailures >= maxConsecutivePageFailures {
+				return fmt.Errorf("AZ page %d: %d consecutive page failures: %w", page, consecutiveFailures, err)
+			}
+		} else {
+			consecutiveFailures = 0
+		}
 
-				p := repository.InsertMultipleAnimesParams{
-					Ename:       item.EName,
-					Jname:       item.JName,
-					ImageUrl:    item.PosterURL,
-					Genre:       info.Genre,
-					HiAnimeID:   item.HiAnimeID,
-					MalID:       pgtype.Int4{Int32: int32(info.MalID), Valid: info.MalID > 0},
-					AnilistID:   pgtype.Int4{Int32: int32(info.AnilistID), Valid: info.AnilistID > 0},
-					LastEpisode: int32(item.LastEpisode),
-					Season:      repository.Season(strings.ToLower(info.Season)),
-					SeasonYear:  int32(info.SeasonYear),
-				}
+		if err := run.checkpoint(ctx, repository.SeedRunPhaseAzList, page+1, total); err != nil {
+			return err
+		}
+		time.Sleep(pageDelay)
+	}
+
+	if err := run.retryQuarantined(ctx, process); err != nil {
+		return err
+	}
+
+	log.Info("A–Z scraper finished", "last_page", total)
+	return nil
+}
+
+func scrapeAZPage(
+	ctx context.Context,
+	scraper *hianime.HianimeScraper,
+	run *seedRun,
+	sem chan struct{},
+	page int,
+	log *slog.Logger,
+) error {
+	listing, err := retryFetchPage(ctx, scraper.GetAZList, page)
+	if err != nil {
+		return fmt.Errorf("AZ page %d: %w", page, err)
+	}
+
+	// A resumed or retried page may already be partly in the database, and
+	// InsertMultipleAnimes is a COPY that fails on any duplicate.
+	hiIDs := make([]string, len(listing.Items))
+	for i, a := range listing.Items {
+		hiIDs[i] = a.HiAnimeID
+	}
+	existingRows, err := run.repo.GetAnimesByHiAnimeIds(ctx, hiIDs)
+	if err != nil {
+		return fmt.Errorf("query existing AZ page %d: %w", page, err)
+	}
+	existing := make(map[string]struct{}, len(existingRows))
+	for _, row := range existingRows {
+		existing[row.HiAnimeID] = struct{}{}
+	}
+	overrides, err := loadMappingOverrides(ctx, run.repo, hiIDs)
+	if err != nil {
+		return fmt.Errorf("AZ page %d: %w", page, err)
+	}
 
-				mu.Lock()
-				toInsert = append(toInsert, p)
-				mu.Unlock()
-			}(a)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		toInsert = make([]repository.InsertMultipleAnimesParams, 0, len(listing.Items))
+	)
+	for _, a := range listing.Items {
+		if _, ok := existing[a.HiAnimeID]; ok {
+			continue
 		}
-		wg.Wait()
 
-		if len(toInsert) > 0 {
-			if _, err := repo.InsertMultipleAnimes(ctx, toInsert); err != nil {
-				return fmt.Errorf("insert AZ page %d: %w", page, err)
+		wg.Add(1)
+		go func(item hianime.ScrapedAnimeInfoDto) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
 			}
-			log.Info("inserted A–Z page", "page", page, "count", len(toInsert))
-		}
+			defer func() { <-sem }()
 
-		if !listing.PageInfo.HasNextPage {
-			log.Info("A–Z scraper finished", "last_page", page)
-			return nil
+			child := log.With("hi_id", item.HiAnimeID)
+
+			info, err := retryFetchDetail(ctx, scraper, item.HiAnimeID)
+			if err != nil {
+				child.Warn("detail fetch failed", "err", err)
+				run.recordError(ctx, item.HiAnimeID, err)
+				return
+			}
+
+			// names come from the listing, everything else from the detail page
+			info.EName, info.JName = item.EName, item.JName
+			if o, ok := overrides[item.HiAnimeID]; ok {
+				mappers.ApplyMappingOverride(&info, o)
+			}
+
+			p := repository.InsertMultipleAnimesParams{
+				Ename:       info.EName,
+				Jname:       info.JName,
+				ImageUrl:    item.PosterURL,
+				Genre:       info.Genre,
+				HiAnimeID:   item.HiAnimeID,
+				MalID:       pgtype.Int4{Int32: int32(info.MalID), Valid: info.MalID > 0},
+				AnilistID:   pgtype.Int4{Int32: int32(info.AnilistID), Valid: info.AnilistID > 0},
+				LastEpisode: int32(item.LastEpisode),
+				Season:      repository.Season(strings.ToLower(info.Season)),
+				SeasonYear:  int32(info.SeasonYear),
+			}
+
+			mu.Lock()
+			toInsert = append(toInsert, p)
+			mu.Unlock()
+		}(a)
+	}
+	wg.Wait()
+
+	if len(toInsert) > 0 {
+		if _, err := run.repo.InsertMultipleAnimes(ctx, toInsert); err != nil {
+			return fmt.Errorf("insert AZ page %d: %w", page, err)
 		}
-		time.Sleep(pageDelay)
+		log.Info("inserted A–Z page", "page", page, "count", len(toInsert))
 	}
+	return nil
 }
 
 func retryFetchDetail(
@@ -134,154 +230,90 @@ func retryFetchDetail(
 	return hianime.ScrapedAnimeInfoDto{}, lastErr
 }
 
+// ScrapeAllRecentlyUpdated replays every Recently-Updated page in reverse as a
+// standalone seed run. Anime that fail are recorded on the run for reprocessing
+// through the admin bulk job.
 func ScrapeAllRecentlyUpdated(
 	ctx context.Context,
 	scraper *hianime.HianimeScraper,
 	repo *repository.Queries,
 	log *slog.Logger,
 ) error {
-	first, err := scraper.GetRecentlyUpdatedAnime(ctx, 1)
+	run, err := startSeedRun(ctx, repo, repository.SeedRunKindRecentlyUpdated, repository.SeedRunPhaseRecentlyUpdated, false, log)
 	if err != nil {
-		return fmt.Errorf("RU first page: %w", err)
+		return err
 	}
-	total := first.PageInfo.TotalPages
-	log.Info("will scrape RU pages in reverse order", "pages", total)
-
-	sem := make(chan struct{}, maxConcurrency)
-	start := time.Now()
 
-	erroredAnimes := struct {
-		sync.Mutex
-		animes []struct {
-			HiAnimeID string
-			Error     error
-		}
-	}{}
+	if err := scrapeAllRecentlyUpdated(ctx, scraper, run, log); err != nil {
+		run.fail(err)
+		return err
+	}
 
-	for page := total; page >= 1; page-- {
-		listing, err := scraper.GetRecentlyUpdatedAnime(ctx, page)
-		if err != nil {
-			return fmt.Errorf("RU page %d: %w", page, err)
-		}
-		log.Info("scraping RU page", "page", page, "of", total, "items", len(listing.Items))
+	if err := run.complete(ctx); err != nil {
+		return fmt.Errorf("complete seed run: %w", err)
+	}
+	return nil
+}
 
-		hiIDs := make([]string, len(listing.Items))
-		for i, a := range listing.Items {
-			hiIDs[i] = a.HiAnimeID
-		}
-		existingRows, err := repo.GetAnimesByHiAnimeIds(ctx, hiIDs)
+func scrapeAllRecentlyUpdated(
+	ctx context.Context,
+	scraper *hianime.HianimeScraper,
+	run *seedRun,
+	log *slog.Logger,
+) error {
+	total := int(run.TotalPages)
+	if total == 0 {
+		first, err := retryFetchPage(ctx, scraper.GetRecentlyUpdatedAnime, 1)
 		if err != nil {
-			return fmt.Errorf("query existing RU page %d: %w", page, err)
+			return fmt.Errorf("RU first page: %w", err)
 		}
-		existingMap := make(map[string]repository.Anime, len(existingRows))
-		for _, row := range existingRows {
-			existingMap[row.HiAnimeID] = row
+		total = first.PageInfo.TotalPages
+		if err := run.checkpoint(ctx, repository.SeedRunPhaseRecentlyUpdated, total, total); err != nil {
+			return err
 		}
+	}
+	log.Info("will scrape RU pages in reverse order", "pages", total, "from", run.NextPage)
 
-		var wg sync.WaitGroup
-		for idx := len(listing.Items) - 1; idx >= 0; idx-- {
-			scraped := listing.Items[idx]
-			wg.Add(1)
-			go func(scraped hianime.ScrapedAnimeInfoDto, globalIdx int) {
-				defer wg.Done()
-
-				select {
-				case sem <- struct{}{}:
-				case <-ctx.Done():
-					return
-				}
-				defer func() { <-sem }()
-
-				child := log.With("hi_id", scraped.HiAnimeID)
-
-				info, err := retryFetchDetail(ctx, scraper, scraped.HiAnimeID)
-				if err != nil {
-					child.Warn("detail fetch failed", "err", err)
-					erroredAnimes.Lock()
-					erroredAnimes.animes = append(erroredAnimes.animes, struct {
-						HiAnimeID string
-						Error     error
-					}{HiAnimeID: scraped.HiAnimeID, Error: err})
-					erroredAnimes.Unlock()
-					return
-				}
-
-				// If mal_id is missing but anilist_id exists, try to find related anime and copy mal_id
-				if info.MalID == 0 && info.AnilistID > 0 {
-					relatedAnimes, err := repo.GetAnimeByAnilistId(ctx, pgtype.Int4{Int32: int32(info.AnilistID), Valid: true})
-					if err == nil {
-						// Find anime with same anilist_id that has a mal_id
-						for _, related := range relatedAnimes {
-							if related.MalID.Valid && related.MalID.Int32 > 0 {
-								info.MalID = int(related.MalID.Int32)
-								child.Info("copied mal_id from related anime", "mal_id", info.MalID, "anilist_id", info.AnilistID, "from_hi_id", related.HiAnimeID)
-								break
-							}
-						}
-					}
-				}
+	sem := make(chan struct{}, maxConcurrency)
+	start := time.Now()
+	process := func(ctx context.Context, page int) error {
+		return scrapeRecentlyUpdatedPage(ctx, scraper, run, sem, page, total, start, log)
+	}
 
-				updatedAt := start.Add(time.Duration(globalIdx) * updateSpacing)
-
-				if existing, ok := existingMap[scraped.HiAnimeID]; ok {
-					if err := repo.UpdateAnime(ctx, repository.UpdateAnimeParams{
-						ID:          existing.ID,
-						Ename:       info.EName,
-						Jname:       info.JName,
-						ImageUrl:    info.PosterURL,
-						Genre:       info.Genre,
-						HiAnimeID:   info.HiAnimeID,
-						MalID:       pgtype.Int4{Int32: int32(info.MalID), Valid: info.MalID > 0},
-						AnilistID:   pgtype.Int4{Int32: int32(info.AnilistID), Valid: info.AnilistID > 0},
-						LastEpisode: int32(scraped.LastEpisode),
-						UpdatedAt:   pgtype.Timestamp{Time: updatedAt, Valid: true},
-						Season:      repository.Season(strings.ToLower(info.Season)),
-						SeasonYear:  int32(info.SeasonYear),
-					}); err != nil {
-						child.Error("update failed", "err", err)
-					}
-				} else {
-					if err := repo.InsertAnime(ctx, repository.InsertAnimeParams{
-						Ename:       info.EName,
-						Jname:       info.JName,
-						ImageUrl:    info.PosterURL,
-						Genre:       info.Genre,
-						HiAnimeID:   info.HiAnimeID,
-						MalID:       pgtype.Int4{Int32: int32(info.MalID), Valid: info.MalID > 0},
-						AnilistID:   pgtype.Int4{Int32: int32(info.AnilistID), Valid: info.AnilistID > 0},
-						LastEpisode: int32(scraped.LastEpisode),
-						CreatedAt:   pgtype.Timestamp{Time: updatedAt, Valid: true},
-						UpdatedAt:   pgtype.Timestamp{Time: updatedAt, Valid: true},
-						Season:      repository.Season(strings.ToLower(info.Season)),
-						SeasonYear:  int32(info.SeasonYear),
-					}); err != nil {
-						child.Error("insert failed", "err", err, "hi_anime_id", info.HiAnimeID, "mal_id", info.MalID)
-					}
-				}
+	consecutiveFailures := 0
+	for page := int(run.NextPage); page >= 1; page-- {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-			}(scraped, (total-page)*len(listing.Items)+(len(listing.Items)-1-idx))
+		if err := process(ctx, page); err != nil {
+			consecutiveFailures++
+			run.quarantinePage(ctx, page, err)
+			if consecutiveFailures >= maxConsecutivePageFailures {
+				return fmt.Errorf("RU page %d: %d consecutive page failures: %w", page, consecutiveFailures, err)
+			}
+		} else {
+			consecutiveFailures = 0
 		}
 
-		wg.Wait()
-		log.Info("finished RU page", "page", page)
+		if err := run.checkpoint(ctx, repository.SeedRunPhaseRecentlyUpdated, page-1, total); err != nil {
+			return err
+		}
 		time.Sleep(pageDelay)
 	}
 
-	if len(erroredAnimes.animes) > 0 {
-		log.Warn("some RU animes failed to scrape",
-			"count", len(erroredAnimes.animes),
+	if err := run.retryQuarantined(ctx, process); err != nil {
+		return err
+	}
+
+	errored, err := run.repo.GetSeedRunErroredHiAnimeIds(ctx, run.ID)
+	if err != nil {
+		log.Error("failed to count errored animes", "err", err)
+	} else if len(errored) > 0 {
+		log.Warn("some animes failed to scrape; reprocess them from the admin seed runs page",
+			"count", len(errored),
+			"run_id", run.ID,
 		)
-		json, err := json.MarshalIndent(erroredAnimes.animes, "", "  ")
-		if err != nil {
-			log.Error("failed to marshal errored animes", "err", err)
-			return nil
-		}
-		err = os.WriteFile("errored_ru_animes.json", json, os.ModePerm)
-		if err != nil {
-			log.Error("failed to write errored animes to file", "err", err)
-			return nil
-		}
-		log.Info("wrote errored animes to errored_ru_animes.json")
 	}
 
 	log.Info("finished RU scrape",
@@ -290,3 +322,126 @@ func ScrapeAllRecentlyUpdated(
 	)
 	return nil
 }
+
+func scrapeRecentlyUpdatedPage(
+	ctx context.Context,
+	scraper *hianime.HianimeScraper,
+	run *seedRun,
+	sem chan struct{},
+	page, total int,
+	start time.Time,
+	log *slog.Logger,
+) error {
+	listing, err := retryFetchPage(ctx, scraper.GetRecentlyUpdatedAnime, page)
+	if err != nil {
+		return fmt.Errorf("RU page %d: %w", page, err)
+	}
+	log.Info("scraping RU page", "page", page, "of", total, "items", len(listing.Items))
+
+	hiIDs := make([]string, len(listing.Items))
+	for i, a := range listing.Items {
+		hiIDs[i] = a.HiAnimeID
+	}
+	existingRows, err := run.repo.GetAnimesByHiAnimeIds(ctx, hiIDs)
+	if err != nil {
+		return fmt.Errorf("query existing RU page %d: %w", page, err)
+	}
+	existingMap := make(map[string]repository.Anime, len(existingRows))
+	for _, row := range existingRows {
+		existingMap[row.HiAnimeID] = row
+	}
+	overrides, err := loadMappingOverrides(ctx, run.repo, hiIDs)
+	if err != nil {
+		return fmt.Errorf("RU page %d: %w", page, err)
+	}
+
+	repo := run.repo
+	var wg sync.WaitGroup
+	for idx := len(listing.Items) - 1; idx >= 0; idx-- {
+		scraped := listing.Items[idx]
+		wg.Add(1)
+		go func(scraped hianime.ScrapedAnimeInfoDto, globalIdx int) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			child := log.With("hi_id", scraped.HiAnimeID)
+
+			info, err := retryFetchDetail(ctx, scraper, scraped.HiAnimeID)
+			if err != nil {
+				child.Warn("detail fetch failed", "err", err)
+				run.recordError(ctx, scraped.HiAnimeID, err)
+				return
+			}
+
+			if o, ok := overrides[scraped.HiAnimeID]; ok {
+				mappers.ApplyMappingOverride(&info, o)
+			}
+
+			// If mal_id is missing but anilist_id exists, try to find related anime and copy mal_id
+			if info.MalID == 0 && info.AnilistID > 0 {
+				relatedAnimes, err := repo.GetAnimeByAnilistId(ctx, pgtype.Int4{Int32: int32(info.AnilistID), Valid: true})
+				if err == nil {
+					// Find anime with same anilist_id that has a mal_id
+					for _, related := range relatedAnimes {
+						if related.MalID.Valid && related.MalID.Int32 > 0 {
+							info.MalID = int(related.MalID.Int32)
+							child.Info("copied mal_id from related anime", "mal_id", info.MalID, "anilist_id", info.AnilistID, "from_hi_id", related.HiAnimeID)
+							break
+						}
+					}
+				}
+			}
+
+			updatedAt := start.Add(time.Duration(globalIdx) * updateSpacing)
+
+			if existing, ok := existingMap[scraped.HiAnimeID]; ok {
+				if err := repo.UpdateAnime(ctx, repository.UpdateAnimeParams{
+					ID:          existing.ID,
+					Ename:       info.EName,
+					Jname:       info.JName,
+					ImageUrl:    info.PosterURL,
+					Genre:       info.Genre,
+					HiAnimeID:   info.HiAnimeID,
+					MalID:       pgtype.Int4{Int32: int32(info.MalID), Valid: info.MalID > 0},
+					AnilistID:   pgtype.Int4{Int32: int32(info.AnilistID), Valid: info.AnilistID > 0},
+					LastEpisode: int32(scraped.LastEpisode),
+					UpdatedAt:   pgtype.Timestamp{Time: updatedAt, Valid: true},
+					Season:      repository.Season(strings.ToLower(info.Season)),
+					SeasonYear:  int32(info.SeasonYear),
+				}); err != nil {
+					child.Error("update failed", "err", err)
+					run.recordError(ctx, scraped.HiAnimeID, err)
+				}
+			} else {
+				if err := repo.InsertAnime(ctx, repository.InsertAnimeParams{
+					Ename:       info.EName,
+					Jname:       info.JName,
+					ImageUrl:    info.PosterURL,
+					Genre:       info.Genre,
+					HiAnimeID:   info.HiAnimeID,
+					MalID:       pgtype.Int4{Int32: int32(info.MalID), Valid: info.MalID > 0},
+					AnilistID:   pgtype.Int4{Int32: int32(info.AnilistID), Valid: info.AnilistID > 0},
+					LastEpisode: int32(scraped.LastEpisode),
+					CreatedAt:   pgtype.Timestamp{Time: updatedAt, Valid: true},
+					UpdatedAt:   pgtype.Timestamp{Time: updatedAt, Valid: true},
+					Season:      repository.Season(strings.ToLower(info.Season)),
+					SeasonYear:  int32(info.SeasonYear),
+				}); err != nil {
+					child.Error("insert failed", "err", err, "hi_anime_id", info.HiAnimeID, "mal_id", info.MalID)
+					run.recordError(ctx, scraped.HiAnimeID, err)
+				}
+			}
+
+		}(scraped, (total-page)*len(listing.Items)+(len(listing.Items)-1-idx))
+	}
+
+	wg.Wait()
+	log.Info("finished RU page", "page", page)
+	return nil
+}
@@ -0,0 +1,27 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coeeter/aniways/internal/repository"
+)
+
+// loadMappingOverrides fetches the admin pinned mappings for a page of anime
+// so they can be applied on top of the scraped details.
+func loadMappingOverrides(
+	ctx context.Context,
+	repo *repository.Queries,
+	hiIDs []string,
+) (map[string]repository.AnimeMappingOverride, error) {
+	rows, err := repo.GetAnimeMappingOverridesByHiAnimeIds(ctx, hiIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get mapping overrides: %w", err)
+	}
+
+	overrides := make(map[string]repository.AnimeMappingOverride, len(rows))
+	for _, row := range rows {
+		overrides[row.HiAnimeID] = row
+	}
+	return overrides, nil
+}
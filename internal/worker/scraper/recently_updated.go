@@ -10,6 +10,7 @@ import (
 
 	"github.com/coeeter/aniways/internal/infra/cache"
 	"github.com/coeeter/aniways/internal/infra/client/hianime"
+	"github.com/coeeter/aniways/internal/mappers"
 	"github.com/coeeter/aniways/internal/repository"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -20,11 +21,11 @@ func HourlyTask(
 	ctx context.Context,
 	scraper *hianime.HianimeScraper,
 	repo *repository.Queries,
-	redis *cache.RedisClient,
+	c cache.Cache,
 	log *slog.Logger,
 ) {
 	log.Info("Running hourly task")
-	if err := scrapeRecentlyUpdated(ctx, scraper, repo, redis, log); err != nil {
+	if err := scrapeRecentlyUpdated(ctx, scraper, repo, c, log); err != nil {
 		log.Error("Error in hourly task", "err", err)
 	} else {
 		log.Info("Hourly task completed successfully")
@@ -35,7 +36,7 @@ func scrapeRecentlyUpdated(
 	ctx context.Context,
 	scraper *hianime.HianimeScraper,
 	repo *repository.Queries,
-	redis *cache.RedisClient,
+	c cache.Cache,
 	log *slog.Logger,
 ) error {
 	listing, err := scraper.GetRecentlyUpdatedAnime(ctx, 1)
@@ -45,24 +46,33 @@ func scrapeRecentlyUpdated(
 	items := listing.Items
 	now := time.Now()
 
-	g, ctx := errgroup.WithContext(ctx)
+	hiIDs := make([]string, len(items))
+	for i, a := range items {
+		hiIDs[i] = a.HiAnimeID
+	}
+	overrides, err := loadMappingOverrides(ctx, repo, hiIDs)
+	if err != nil {
+		return err
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
 	g.SetLimit(maxConcurrency)
 
-	var success, skipped, failed int32
+	var success, skipped, failed, inserted int32
 
 	for i, scraped := range items {
 		offset := (len(items) - 1) - i
 
 		g.Go(func() error {
 			select {
-			case <-ctx.Done():
+			case <-gctx.Done():
 				return nil
 			default:
 			}
 
 			child := log.With("hi_id", scraped.HiAnimeID)
 
-			dbAnime, err := repo.GetAnimeByHiAnimeId(ctx, scraped.HiAnimeID)
+			dbAnime, err := repo.GetAnimeByHiAnimeId(gctx, scraped.HiAnimeID)
 			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 				child.Error("db lookup failed", "err", err)
 				atomic.AddInt32(&failed, 1)
@@ -76,16 +86,23 @@ func scrapeRecentlyUpdated(
 				return nil
 			}
 
-			info, err := retryFetchDetail(ctx, scraper, scraped.HiAnimeID)
+			info, err := retryFetchDetail(gctx, scraper, scraped.HiAnimeID)
 			if err != nil {
 				child.Warn("detail fetch failed", "err", err)
 				atomic.AddInt32(&failed, 1)
 				return nil
 			}
 
+			if hasExisting {
+				info.EName, info.JName = dbAnime.Ename, dbAnime.Jname
+			}
+			if o, ok := overrides[scraped.HiAnimeID]; ok {
+				mappers.ApplyMappingOverride(&info, o)
+			}
+
 			// If mal_id is missing but anilist_id exists, try to find related anime and copy mal_id
 			if info.MalID == 0 && info.AnilistID > 0 {
-				relatedAnimes, err := repo.GetAnimeByAnilistId(ctx, pgtype.Int4{Int32: int32(info.AnilistID), Valid: true})
+				relatedAnimes, err := repo.GetAnimeByAnilistId(gctx, pgtype.Int4{Int32: int32(info.AnilistID), Valid: true})
 				if err == nil {
 					// Find anime with same anilist_id that has a mal_id
 					for _, related := range relatedAnimes {
@@ -103,8 +120,8 @@ func scrapeRecentlyUpdated(
 			if hasExisting {
 				params := repository.UpdateAnimeParams{
 					ID:          dbAnime.ID,
-					Ename:       dbAnime.Ename,
-					Jname:       dbAnime.Jname,
+					Ename:       info.EName,
+					Jname:       info.JName,
 					ImageUrl:    scraped.PosterURL,
 					Genre:       info.Genre,
 					HiAnimeID:   scraped.HiAnimeID,
@@ -115,13 +132,13 @@ func scrapeRecentlyUpdated(
 					Season:      repository.Season(strings.ToLower(info.Season)),
 					SeasonYear:  int32(info.SeasonYear),
 				}
-				if err := repo.UpdateAnime(ctx, params); err != nil {
+				if err := repo.UpdateAnime(gctx, params); err != nil {
 					child.Error("update failed", "err", err)
 					atomic.AddInt32(&failed, 1)
 					return nil
 				}
-				if err := redis.Del(ctx, "anime_episodes:"+dbAnime.ID); err != nil {
-					child.Warn("cache delete failed", "err", err)
+				if _, err := c.InvalidateTag(gctx, cache.AnimeTag(dbAnime.ID)); err != nil {
+					child.Warn("cache invalidate failed", "err", err)
 				}
 			} else {
 				params := repository.InsertAnimeParams{
@@ -138,11 +155,12 @@ func scrapeRecentlyUpdated(
 					Season:      repository.Season(strings.ToLower(info.Season)),
 					SeasonYear:  int32(info.SeasonYear),
 				}
-				if err := repo.InsertAnime(ctx, params); err != nil {
+				if err := repo.InsertAnime(gctx, params); err != nil {
 					child.Error("insert failed", "err", err, "hi_anime_id", scraped.HiAnimeID, "mal_id", info.MalID)
 					atomic.AddInt32(&failed, 1)
 					return nil
 				}
+				atomic.AddInt32(&inserted, 1)
 			}
 
 			atomic.AddInt32(&success, 1)
@@ -152,9 +170,17 @@ func scrapeRecentlyUpdated(
 
 	_ = g.Wait()
 
+	// listings only show anime we have, new ones may fill their gaps
+	if inserted > 0 {
+		if _, err := c.InvalidateTag(ctx, cache.TagListings); err != nil {
+			log.Warn("listing cache invalidate failed", "err", err)
+		}
+	}
+
 	log.Info("recently-updated page processed",
 		"items", len(items),
 		"success", success,
+		"inserted", inserted,
 		"skipped", skipped,
 		"failed", failed,
 	)
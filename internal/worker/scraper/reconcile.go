@@ -0,0 +1,220 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/coeeter/aniways/internal/infra/cache"
+	"github.com/coeeter/aniways/internal/infra/client/hianime"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/sync/errgroup"
+)
+
+// tombstoneConfirmations is how many reconciliation runs in a row must fail to
+// find an anime before it is marked unavailable.
+const tombstoneConfirmations = 3
+
+// ReconcileTask walks the whole AZ list and tombstones anime that HiAnime no
+// longer serves. Library entries pointing at a tombstoned anime are moved to a
+// surviving variation with the same MAL ID when one exists.
+func ReconcileTask(
+	ctx context.Context,
+	scraper *hianime.HianimeScraper,
+	repo *repository.Queries,
+	c cache.Cache,
+	log *slog.Logger,
+) {
+	log.Info("Running reconciliation task")
+	if err := reconcileAvailability(ctx, scraper, repo, c, log); err != nil {
+		log.Error("Error in reconciliation task", "err", err)
+	} else {
+		log.Info("Reconciliation task completed successfully")
+	}
+}
+
+func reconcileAvailability(
+	ctx context.Context,
+	scraper *hianime.HianimeScraper,
+	repo *repository.Queries,
+	c cache.Cache,
+	log *slog.Logger,
+) error {
+	seen, err := collectAZHiAnimeIDs(ctx, scraper, log)
+	if err != nil {
+		// an incomplete walk would make every anime on the missing pages look
+		// deleted, so nothing is marked unless the whole list was read
+		return fmt.Errorf("walk AZ list: %w", err)
+	}
+
+	seenIDs := make([]string, 0, len(seen))
+	for id := range seen {
+		seenIDs = append(seenIDs, id)
+	}
+	restored, err := repo.ResetAnimeMissingChecks(ctx, seenIDs)
+	if err != nil {
+		return fmt.Errorf("reset missing checks: %w", err)
+	}
+
+	available, err := repo.GetAvailableAnimeHiAnimeIds(ctx)
+	if err != nil {
+		return fmt.Errorf("get available anime: %w", err)
+	}
+
+	var missing []repository.GetAvailableAnimeHiAnimeIdsRow
+	for _, a := range available {
+		if _, ok := seen[a.HiAnimeID]; !ok {
+			missing = append(missing, a)
+		}
+	}
+	log.Info("AZ list walked", "seen", len(seen), "known", len(available), "missing", len(missing), "restored", restored)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrency)
+
+	for _, a := range missing {
+		g.Go(func() error {
+			child := log.With("hi_id", a.HiAnimeID, "anime_id", a.ID)
+
+			gone, err := confirmRemoved(gctx, scraper, a.HiAnimeID)
+			if err != nil {
+				child.Warn("could not confirm removal", "err", err)
+				return nil
+			}
+			if !gone {
+				// still served, just not listed in the AZ list
+				if _, err := repo.ResetAnimeMissingChecks(gctx, []string{a.HiAnimeID}); err != nil {
+					child.Error("reset missing checks failed", "err", err)
+				}
+				return nil
+			}
+
+			checks, err := repo.IncrementAnimeMissingChecks(gctx, a.ID)
+			if err != nil {
+				child.Error("increment missing checks failed", "err", err)
+				return nil
+			}
+			if checks < tombstoneConfirmations {
+				child.Info("anime missing upstream", "checks", checks)
+				return nil
+			}
+
+			if err := tombstoneAnime(gctx, repo, c, a, child); err != nil {
+				child.Error("tombstone failed", "err", err)
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+func collectAZHiAnimeIDs(
+	ctx context.Context,
+	scraper *hianime.HianimeScraper,
+	log *slog.Logger,
+) (map[string]struct{}, error) {
+	seen := make(map[string]struct{})
+	for page := 1; ; page++ {
+		listing, err := retryFetchPage(ctx, scraper.GetAZList, page)
+		if err != nil {
+			return nil, fmt.Errorf("AZ page %d: %w", page, err)
+		}
+		for _, a := range listing.Items {
+			seen[a.HiAnimeID] = struct{}{}
+		}
+
+		if !listing.PageInfo.HasNextPage {
+			log.Info("AZ list walk finished", "last_page", page)
+			return seen, nil
+		}
+		time.Sleep(pageDelay)
+	}
+}
+
+// confirmRemoved reports whether the detail page for hiID is gone. Errors
+// other than a 404 are returned so a flaky request never counts as a miss.
+func confirmRemoved(ctx context.Context, scraper *hianime.HianimeScraper, hiID string) (bool, error) {
+	var lastErr error
+	for range retryCount {
+		_, err := scraper.GetAnimeInfoByHiAnimeID(ctx, hiID)
+		if err == nil {
+			return false, nil
+		}
+		if errors.Is(err, hianime.ErrNotFound) {
+			return true, nil
+		}
+		lastErr = err
+		time.Sleep(retryDelay)
+	}
+	return false, lastErr
+}
+
+func tombstoneAnime(
+	ctx context.Context,
+	repo *repository.Queries,
+	c cache.Cache,
+	a repository.GetAvailableAnimeHiAnimeIdsRow,
+	log *slog.Logger,
+) error {
+	if err := repo.MarkAnimeUnavailable(ctx, a.ID); err != nil {
+		return fmt.Errorf("mark unavailable: %w", err)
+	}
+	log.Warn("anime tombstoned")
+
+	if _, err := c.InvalidateTag(ctx, cache.AnimeTag(a.ID)); err != nil {
+		log.Warn("cache invalidate failed", "err", err)
+	}
+
+	if !a.MalID.Valid || a.MalID.Int32 == 0 {
+		return nil
+	}
+
+	survivor, err := repo.GetAvailableAnimeVariation(ctx, repository.GetAvailableAnimeVariationParams{
+		MalID: a.MalID,
+		ID:    a.ID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Info("no surviving variation, library entries left in place")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get surviving variation: %w", err)
+	}
+
+	relinked, err := repo.RelinkLibraryEntries(ctx, repository.RelinkLibraryEntriesParams{
+		FromAnimeID: a.ID,
+		ToAnimeID:   survivor.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("relink library entries: %w", err)
+	}
+	log.Info("relinked library entries", "to_anime_id", survivor.ID, "count", relinked)
+
+	relinked, err = repo.RelinkCollectionItems(ctx, repository.RelinkCollectionItemsParams{
+		FromAnimeID: a.ID,
+		ToAnimeID:   survivor.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("relink collection items: %w", err)
+	}
+	log.Info("relinked collection items", "to_anime_id", survivor.ID, "count", relinked)
+
+	relinked, err = repo.RelinkLibraryActivities(ctx, repository.RelinkLibraryActivitiesParams{
+		FromAnimeID: a.ID,
+		ToAnimeID:   survivor.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("relink library activities: %w", err)
+	}
+	log.Info("relinked library activities", "to_anime_id", survivor.ID, "count", relinked)
+
+	// the survivor's relations now stand in for the tombstoned variation
+	if _, err := c.InvalidateTag(ctx, cache.AnimeTag(survivor.ID)); err != nil {
+		log.Warn("cache invalidate failed", "anime_id", survivor.ID, "err", err)
+	}
+	return nil
+}
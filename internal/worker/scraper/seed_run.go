@@ -0,0 +1,188 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/coeeter/aniways/internal/infra/client/hianime"
+	"github.com/coeeter/aniways/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxConsecutivePageFailures stops a run when the source looks down instead
+// of quarantining every remaining page.
+const maxConsecutivePageFailures = 5
+
+type pageFetcher func(ctx context.Context, page int) (hianime.Pagination[hianime.ScrapedAnimeInfoDto], error)
+
+// seedRun wraps the seed_runs row so the scrapers can checkpoint pages,
+// quarantine failed pages and record errored anime as they go.
+type seedRun struct {
+	repository.SeedRun
+	repo *repository.Queries
+	log  *slog.Logger
+}
+
+func startSeedRun(
+	ctx context.Context,
+	repo *repository.Queries,
+	kind repository.SeedRunKind,
+	phase repository.SeedRunPhase,
+	resume bool,
+	log *slog.Logger,
+) (*seedRun, error) {
+	if resume {
+		existing, err := repo.GetLatestUnfinishedSeedRun(ctx, kind)
+		switch {
+		case err == nil:
+			log.Info("resuming seed run",
+				"run_id", existing.ID,
+				"phase", existing.Phase,
+				"next_page", existing.NextPage,
+				"total_pages", existing.TotalPages,
+			)
+			if err := repo.UpdateSeedRunStatus(ctx, repository.UpdateSeedRunStatusParams{
+				ID:     existing.ID,
+				Status: repository.SeedRunStatusRunning,
+			}); err != nil {
+				return nil, fmt.Errorf("mark seed run running: %w", err)
+			}
+			existing.Status = repository.SeedRunStatusRunning
+			return &seedRun{SeedRun: existing, repo: repo, log: log.With("run_id", existing.ID)}, nil
+		case !errors.Is(err, pgx.ErrNoRows):
+			return nil, fmt.Errorf("get unfinished seed run: %w", err)
+		}
+		log.Info("no unfinished seed run found, starting a new one")
+	}
+
+	run, err := repo.CreateSeedRun(ctx, repository.CreateSeedRunParams{
+		Kind:  kind,
+		Phase: phase,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create seed run: %w", err)
+	}
+	return &seedRun{SeedRun: run, repo: repo, log: log.With("run_id", run.ID)}, nil
+}
+
+func (r *seedRun) checkpoint(ctx context.Context, phase repository.SeedRunPhase, nextPage, totalPages int) error {
+	if err := r.repo.UpdateSeedRunCheckpoint(ctx, repository.UpdateSeedRunCheckpointParams{
+		ID:         r.ID,
+		Phase:      phase,
+		NextPage:   int32(nextPage),
+		TotalPages: int32(totalPages),
+	}); err != nil {
+		return fmt.Errorf("checkpoint seed run: %w", err)
+	}
+	r.Phase = phase
+	r.NextPage = int32(nextPage)
+	r.TotalPages = int32(totalPages)
+	return nil
+}
+
+func (r *seedRun) quarantinePage(ctx context.Context, page int, cause error) {
+	r.log.Warn("quarantining page", "phase", r.Phase, "page", page, "err", cause)
+	if err := r.repo.QuarantineSeedRunPage(ctx, repository.QuarantineSeedRunPageParams{
+		SeedRunID:    r.ID,
+		Phase:        r.Phase,
+		Page:         int32(page),
+		ErrorMessage: cause.Error(),
+	}); err != nil {
+		r.log.Error("failed to quarantine page", "page", page, "err", err)
+	}
+}
+
+// retryQuarantined gives every quarantined page of the current phase one more
+// attempt, releasing the ones that now succeed.
+func (r *seedRun) retryQuarantined(ctx context.Context, process func(ctx context.Context, page int) error) error {
+	pages, err := r.repo.GetQuarantinedSeedRunPages(ctx, repository.GetQuarantinedSeedRunPagesParams{
+		SeedRunID: r.ID,
+		Phase:     r.Phase,
+	})
+	if err != nil {
+		return fmt.Errorf("get quarantined pages: %w", err)
+	}
+	if len(pages) == 0 {
+		return nil
+	}
+
+	r.log.Info("retrying quarantined pages", "phase", r.Phase, "count", len(pages))
+	for _, q := range pages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := process(ctx, int(q.Page)); err != nil {
+			r.quarantinePage(ctx, int(q.Page), err)
+			continue
+		}
+
+		if err := r.repo.DeleteQuarantinedSeedRunPage(ctx, repository.DeleteQuarantinedSeedRunPageParams{
+			SeedRunID: r.ID,
+			Phase:     r.Phase,
+			Page:      q.Page,
+		}); err != nil {
+			r.log.Error("failed to release quarantined page", "page", q.Page, "err", err)
+		}
+		time.Sleep(pageDelay)
+	}
+	return nil
+}
+
+func (r *seedRun) recordError(ctx context.Context, hiAnimeID string, cause error) {
+	if err := r.repo.UpsertSeedRunError(ctx, repository.UpsertSeedRunErrorParams{
+		SeedRunID:    r.ID,
+		HiAnimeID:    hiAnimeID,
+		Phase:        r.Phase,
+		ErrorMessage: cause.Error(),
+	}); err != nil {
+		r.log.Error("failed to record errored anime", "hi_id", hiAnimeID, "err", err)
+	}
+}
+
+func (r *seedRun) complete(ctx context.Context) error {
+	return r.repo.UpdateSeedRunStatus(ctx, repository.UpdateSeedRunStatusParams{
+		ID:     r.ID,
+		Status: repository.SeedRunStatusCompleted,
+	})
+}
+
+// fail marks the run as failed so that the next full-seed job run, or the
+// daemon on its next start, picks it up from the last checkpoint. A fresh
+// context is used as the run context may be the reason we are failing.
+func (r *seedRun) fail(cause error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := r.repo.UpdateSeedRunStatus(ctx, repository.UpdateSeedRunStatusParams{
+		ID:           r.ID,
+		Status:       repository.SeedRunStatusFailed,
+		ErrorMessage: pgtype.Text{String: cause.Error(), Valid: true},
+	}); err != nil {
+		r.log.Error("failed to mark seed run as failed", "err", err)
+	}
+}
+
+func retryFetchPage(
+	ctx context.Context,
+	fetch pageFetcher,
+	page int,
+) (hianime.Pagination[hianime.ScrapedAnimeInfoDto], error) {
+	var lastErr error
+	for range retryCount {
+		listing, err := fetch(ctx, page)
+		if err == nil {
+			return listing, nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+		time.Sleep(retryDelay)
+	}
+	return hianime.Pagination[hianime.ScrapedAnimeInfoDto]{}, lastErr
+}
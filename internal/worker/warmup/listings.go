@@ -0,0 +1,60 @@
+package warmup
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/coeeter/aniways/internal/infra/cache"
+	"github.com/coeeter/aniways/internal/service/anime"
+)
+
+// refreshWindow is how close to expiry a listing must be before the warmer
+// refetches it. It is wider than the job's hourly schedule so a key is always
+// refreshed at least one run before it goes stale.
+const refreshWindow = 2 * time.Hour
+
+// WarmListings refreshes the cached home page listings that are about to
+// expire, so no request has to wait on AniList for them.
+func WarmListings(ctx context.Context, svc *anime.AnimeService, log *slog.Logger) error {
+	ctx = cache.WithRefreshAhead(ctx, refreshWindow)
+
+	listings := []struct {
+		name string
+		warm func(context.Context) error
+	}{
+		{"trending", func(ctx context.Context) error {
+			_, err := svc.GetTrendingAnimes(ctx)
+			return err
+		}},
+		{"popular", func(ctx context.Context) error {
+			_, err := svc.GetPopularAnimes(ctx)
+			return err
+		}},
+		{"seasonal", func(ctx context.Context) error {
+			_, err := svc.GetSeasonalAnimes(ctx)
+			return err
+		}},
+		{"genre-previews", func(ctx context.Context) error {
+			_, err := svc.GetGenrePreviews(ctx)
+			return err
+		}},
+	}
+
+	failed := 0
+	for _, l := range listings {
+		start := time.Now()
+		if err := l.warm(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failed++
+			log.Error("warm listing failed", "listing", l.name, "err", err)
+			continue
+		}
+		log.Info("listing warm", "listing", l.name, "took", time.Since(start))
+	}
+
+	log.Info("cache warming finished", "listings", len(listings), "failed", failed)
+	return nil
+}
@@ -0,0 +1,64 @@
+-- name: InsertLibraryActivity :exec
+-- Nothing is recorded while the user is in incognito mode.
+INSERT INTO library_activities(user_id, anime_id, type, watched_episodes, score)
+SELECT
+  sqlc.arg(user_id)::varchar,
+  sqlc.arg(anime_id)::varchar,
+  sqlc.arg(type)::library_activity_type,
+  sqlc.arg(watched_episodes)::integer,
+  sqlc.narg(score)::smallint
+WHERE
+  NOT EXISTS (
+    SELECT
+      1
+    FROM
+      settings
+    WHERE
+      settings.user_id = sqlc.arg(user_id)
+      AND settings.incognito_mode);
+
+-- name: GetFeed :many
+-- The feed is built on read from the activities of the followed users who
+-- are not muted and show their activity on a public profile.
+SELECT
+  sqlc.embed(library_activities),
+  sqlc.embed(users),
+  sqlc.embed(animes)
+FROM
+  follows
+  INNER JOIN library_activities ON library_activities.user_id = follows.followee_id
+  INNER JOIN users ON users.id = library_activities.user_id
+  INNER JOIN animes ON animes.id = library_activities.anime_id
+  LEFT JOIN settings ON settings.user_id = library_activities.user_id
+WHERE
+  follows.follower_id = sqlc.arg(user_id)
+  AND NOT follows.muted
+  AND coalesce(settings.public_profile, FALSE)
+  AND coalesce(settings.profile_show_activity, TRUE)
+  AND NOT coalesce(settings.incognito_mode, FALSE)
+ORDER BY
+  library_activities.created_at DESC,
+  library_activities.id
+LIMIT $1 OFFSET $2;
+
+-- name: GetFeedCount :one
+SELECT
+  COUNT(*)
+FROM
+  follows
+  INNER JOIN library_activities ON library_activities.user_id = follows.followee_id
+  LEFT JOIN settings ON settings.user_id = library_activities.user_id
+WHERE
+  follows.follower_id = sqlc.arg(user_id)
+  AND NOT follows.muted
+  AND coalesce(settings.public_profile, FALSE)
+  AND coalesce(settings.profile_show_activity, TRUE)
+  AND NOT coalesce(settings.incognito_mode, FALSE);
+
+-- name: RelinkLibraryActivities :execrows
+UPDATE
+  library_activities
+SET
+  anime_id = sqlc.arg(to_anime_id)
+WHERE
+  anime_id = sqlc.arg(from_anime_id);
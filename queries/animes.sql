@@ -18,9 +18,9 @@ SELECT
     *
 FROM
     animes
-WHERE
-    animes.mal_id IS NOT NULL
-    OR animes.mal_id != 0
+WHERE (animes.mal_id IS NOT NULL
+    OR animes.mal_id != 0)
+AND animes.unavailable_at IS NULL
 ORDER BY
     updated_at DESC
 LIMIT $1 OFFSET $2;
@@ -30,9 +30,9 @@ SELECT
     COUNT(*)
 FROM
     animes
-WHERE
-    animes.mal_id IS NOT NULL
-    OR animes.mal_id != 0;
+WHERE (animes.mal_id IS NOT NULL
+    OR animes.mal_id != 0)
+AND animes.unavailable_at IS NULL;
 
 -- name: GetAnimeByGenre :many
 SELECT
@@ -41,6 +41,7 @@ FROM
     animes
 WHERE
     genre ILIKE '%' || sqlc.arg (genre) || '%'
+    AND unavailable_at IS NULL
 ORDER BY
     updated_at DESC
 LIMIT $1 OFFSET $2;
@@ -51,16 +52,17 @@ SELECT
 FROM
     animes
 WHERE
-    genre ILIKE '%' || sqlc.arg (genre) || '%';
+    genre ILIKE '%' || sqlc.arg (genre) || '%'
+    AND unavailable_at IS NULL;
 
 -- name: GetRandomAnime :one
 SELECT
     *
 FROM
     animes
-WHERE
-    animes.mal_id IS NOT NULL
-    OR animes.mal_id != 0
+WHERE (animes.mal_id IS NOT NULL
+    OR animes.mal_id != 0)
+AND animes.unavailable_at IS NULL
 ORDER BY
     RANDOM()
 LIMIT 1;
@@ -74,6 +76,7 @@ WHERE
     genre ILIKE '%' || sqlc.arg (genre) || '%'
     AND (animes.mal_id IS NOT NULL
         OR animes.mal_id != 0)
+    AND animes.unavailable_at IS NULL
 ORDER BY
     RANDOM()
 LIMIT 1;
@@ -104,6 +107,25 @@ WHERE
 ORDER BY
     created_at ASC;
 
+-- name: FindAnimeTitleCandidates :many
+-- Anime whose english or japanese title resembles the title, closest first.
+-- A season year of 0 matches any year, otherwise a year either side is
+-- allowed since providers disagree on releases around new year.
+SELECT
+    sqlc.embed(animes),
+    GREATEST(similarity(animes.ename, sqlc.arg (title)::text), similarity(animes.jname, sqlc.arg (title)::text))::real AS score
+FROM
+    animes
+WHERE (animes.ename % sqlc.arg (title)::text
+    OR animes.jname % sqlc.arg (title)::text)
+AND (sqlc.arg (season_year)::int = 0
+    OR animes.season_year = 0
+    OR abs(animes.season_year - sqlc.arg (season_year)::int) <= 1)
+AND animes.unavailable_at IS NULL
+ORDER BY
+    score DESC
+LIMIT 10;
+
 -- name: GetAnimeByHiAnimeId :one
 SELECT
     *
@@ -127,6 +149,7 @@ FROM
     animes
 WHERE
     mal_id = ANY (sqlc.arg (mal_ids)::int[])
+    AND unavailable_at IS NULL
 ORDER BY
     updated_at DESC;
 
@@ -155,6 +178,7 @@ AND (sqlc.arg (genre) = ''
     OR sqlc.arg (genre) IS NULL
     OR genre ILIKE '%' || sqlc.arg (genre) || '%')
 AND animes.mal_id IS NOT NULL
+AND animes.unavailable_at IS NULL
 ORDER BY
     query_rank DESC
 LIMIT $1 OFFSET $2;
@@ -172,7 +196,8 @@ WHERE (sqlc.arg (query) = ''
 AND (sqlc.arg (genre) = ''
     OR sqlc.arg (genre) IS NULL
     OR genre ILIKE '%' || sqlc.arg (genre) || '%')
-AND animes.mal_id IS NOT NULL;
+AND animes.mal_id IS NOT NULL
+AND animes.unavailable_at IS NULL;
 
 -- name: InsertAnime :exec
 INSERT INTO animes (ename, jname, image_url, genre, hi_anime_id, mal_id, anilist_id, last_episode, created_at, updated_at, season, season_year)
@@ -271,6 +296,7 @@ FROM
 WHERE
     season = @season::season
     AND season_year = @season_year::int
+    AND unavailable_at IS NULL
 ORDER BY
     updated_at DESC
 LIMIT $1 OFFSET $2;
@@ -282,7 +308,8 @@ FROM
     animes
 WHERE
     season = @season::season
-    AND season_year = @season_year::int;
+    AND season_year = @season_year::int
+    AND unavailable_at IS NULL;
 
 -- name: GetAnimeByYear :many
 SELECT
@@ -291,6 +318,7 @@ FROM
     animes
 WHERE
     season_year = @season_year::int
+    AND unavailable_at IS NULL
 ORDER BY
     updated_at DESC
 LIMIT $1 OFFSET $2;
@@ -301,7 +329,8 @@ SELECT
 FROM
     animes
 WHERE
-    season_year = @season_year::int;
+    season_year = @season_year::int
+    AND unavailable_at IS NULL;
 
 -- name: GetAnimeBySeason :many
 SELECT
@@ -310,6 +339,7 @@ FROM
     animes
 WHERE
     season = @season::season
+    AND unavailable_at IS NULL
 ORDER BY
     season_year DESC
 LIMIT $1 OFFSET $2;
@@ -320,7 +350,8 @@ SELECT
 FROM
     animes
 WHERE
-    season = @season::season;
+    season = @season::season
+    AND unavailable_at IS NULL;
 
 -- name: GetAnimeCatalog :many
 WITH p AS (
@@ -341,7 +372,16 @@ WITH p AS (
         END AS g,
         sqlc.narg (genres_mode)::text AS gm,
         sqlc.narg (sort_by)::text AS sb,
-        sqlc.narg (sort_order)::text AS so
+        sqlc.narg (sort_order)::text AS so,
+        -- the collection, only when it belongs to collection_user_id
+        (
+            SELECT
+                c.id
+            FROM
+                collections c
+            WHERE
+                c.id = sqlc.narg (collection_id)::varchar
+                AND c.user_id = sqlc.narg (collection_user_id)::varchar) AS cid
 )
 SELECT
     a.*,
@@ -352,6 +392,10 @@ SELECT
     l.watched_episodes AS library_watched_episodes,
     l.created_at AS library_created_at,
     l.updated_at AS library_updated_at,
+    l.score AS library_score,
+    l.started_at AS library_started_at,
+    l.completed_at AS library_completed_at,
+    l.rewatches AS library_rewatches,
     CASE WHEN p.q IS NOT NULL THEN
         ts_rank(a.search_vector, plainto_tsquery('english', p.q))
     ELSE
@@ -364,10 +408,17 @@ FROM
             AND a.id = l.anime_id
             AND l.user_id = sqlc.narg (user_id)::varchar)
     CROSS JOIN p
+    -- Only matches items when a collection is selected
+    LEFT JOIN collection_items ci ON (ci.collection_id = p.cid
+            AND ci.anime_id = a.id)
 WHERE
     -- only MAL-linked rows
     (a.mal_id IS NOT NULL
         AND a.mal_id <> 0)
+    -- hide tombstoned anime from the catalog, library and collection modes keep them
+    AND (a.unavailable_at IS NULL
+        OR sqlc.narg (user_id)::varchar IS NOT NULL
+        OR sqlc.narg (collection_id)::varchar IS NOT NULL)
     -- search (skip when q is null)
     AND (p.q IS NULL
         OR a.ename % p.q
@@ -398,6 +449,14 @@ WHERE
         -- Library status filtering
         AND (sqlc.narg (library_status)::library_status IS NULL
             OR l.status = sqlc.narg (library_status)::library_status)
+        -- Library score range (skip each bound when null)
+        AND (sqlc.narg (score_min)::int IS NULL
+            OR l.score >= sqlc.narg (score_min)::int)
+        AND (sqlc.narg (score_max)::int IS NULL
+            OR l.score <= sqlc.narg (score_max)::int)
+        -- Collection membership (when collection_id provided, only show its items)
+        AND (sqlc.narg (collection_id)::varchar IS NULL
+            OR ci.anime_id IS NOT NULL)
     ORDER BY
         -- relevance
         CASE WHEN p.sb = 'relevance'
@@ -466,6 +525,42 @@ WHERE
             AND p.so = 'desc' THEN
             l.updated_at
         END DESC NULLS LAST,
+        -- library score
+        CASE WHEN p.sb = 'library_score'
+            AND p.so = 'asc' THEN
+            l.score
+        END ASC NULLS LAST,
+        CASE WHEN p.sb = 'library_score'
+            AND p.so = 'desc' THEN
+            l.score
+        END DESC NULLS LAST,
+        -- library started_at
+        CASE WHEN p.sb = 'library_started_at'
+            AND p.so = 'asc' THEN
+            l.started_at
+        END ASC NULLS LAST,
+        CASE WHEN p.sb = 'library_started_at'
+            AND p.so = 'desc' THEN
+            l.started_at
+        END DESC NULLS LAST,
+        -- library completed_at
+        CASE WHEN p.sb = 'library_completed_at'
+            AND p.so = 'asc' THEN
+            l.completed_at
+        END ASC NULLS LAST,
+        CASE WHEN p.sb = 'library_completed_at'
+            AND p.so = 'desc' THEN
+            l.completed_at
+        END DESC NULLS LAST,
+        -- manual collection order (only when a collection is selected)
+        CASE WHEN p.sb = 'collection_position'
+            AND p.so = 'asc' THEN
+            ci.position
+        END ASC NULLS LAST,
+        CASE WHEN p.sb = 'collection_position'
+            AND p.so = 'desc' THEN
+            ci.position
+        END DESC NULLS LAST,
         -- legacy updated_at (maps to anime_updated_at for backward compatibility)
         CASE WHEN p.sb = 'updated_at'
             AND p.so = 'asc' THEN
@@ -495,7 +590,16 @@ WITH p AS (
                 WHERE
                     trim(g) <> '')
         END AS g,
-        sqlc.narg (genres_mode)::text AS gm
+        sqlc.narg (genres_mode)::text AS gm,
+        -- the collection, only when it belongs to collection_user_id
+        (
+            SELECT
+                c.id
+            FROM
+                collections c
+            WHERE
+                c.id = sqlc.narg (collection_id)::varchar
+                AND c.user_id = sqlc.narg (collection_user_id)::varchar) AS cid
 )
 SELECT
     COUNT(*)
@@ -506,10 +610,17 @@ FROM
             AND a.id = l.anime_id
             AND l.user_id = sqlc.narg (user_id)::varchar)
     CROSS JOIN p
+    -- Only matches items when a collection is selected
+    LEFT JOIN collection_items ci ON (ci.collection_id = p.cid
+            AND ci.anime_id = a.id)
 WHERE
     -- only MAL-linked rows
     (a.mal_id IS NOT NULL
         AND a.mal_id <> 0)
+    -- hide tombstoned anime from the catalog, library and collection modes keep them
+    AND (a.unavailable_at IS NULL
+        OR sqlc.narg (user_id)::varchar IS NOT NULL
+        OR sqlc.narg (collection_id)::varchar IS NOT NULL)
     -- search (skip when q is null)
     AND (p.q IS NULL
         OR a.ename % p.q
@@ -539,7 +650,15 @@ WHERE
             OR l.user_id IS NOT NULL) -- library mode (must be in library)
         -- Library status filtering
         AND (sqlc.narg (library_status)::library_status IS NULL
-            OR l.status = sqlc.narg (library_status)::library_status);
+            OR l.status = sqlc.narg (library_status)::library_status)
+        -- Library score range (skip each bound when null)
+        AND (sqlc.narg (score_min)::int IS NULL
+            OR l.score >= sqlc.narg (score_min)::int)
+        AND (sqlc.narg (score_max)::int IS NULL
+            OR l.score <= sqlc.narg (score_max)::int)
+        -- Collection membership (when collection_id provided, only show its items)
+        AND (sqlc.narg (collection_id)::varchar IS NULL
+            OR ci.anime_id IS NOT NULL);
 
 -- name: GetGenrePreviews :many
 WITH g AS (
@@ -547,6 +666,8 @@ WITH g AS (
         unnest(a.genres_arr) AS genre
     FROM
         animes a
+    WHERE
+        a.unavailable_at IS NULL
 )
 SELECT
     g.genre::text AS name,
@@ -555,7 +676,8 @@ SELECT
                 a2.image_url::text
             FROM animes a2
             WHERE
-                a2.genres_arr @> ARRAY[g.genre]::text[] ORDER BY a2.season_year DESC, a2.updated_at DESC, a2.id DESC LIMIT 6), ARRAY[]::text[]) AS previews
+                a2.genres_arr @> ARRAY[g.genre]::text[]
+                AND a2.unavailable_at IS NULL ORDER BY a2.season_year DESC, a2.updated_at DESC, a2.id DESC LIMIT 6), ARRAY[]::text[]) AS previews
 FROM
     g
 ORDER BY
@@ -575,6 +697,60 @@ WHERE
         WHERE
             a.id = sqlc.arg (id))
     AND animes.id != sqlc.arg (id)
+    AND animes.unavailable_at IS NULL
 ORDER BY
     animes.created_at DESC;
 
+-- name: GetAvailableAnimeHiAnimeIds :many
+SELECT
+    id,
+    hi_anime_id,
+    mal_id,
+    missing_checks
+FROM
+    animes
+WHERE
+    unavailable_at IS NULL;
+
+-- name: ResetAnimeMissingChecks :execrows
+UPDATE
+    animes
+SET
+    missing_checks = 0,
+    unavailable_at = NULL
+WHERE
+    hi_anime_id = ANY (sqlc.arg (hi_anime_ids)::text[])
+    AND (missing_checks > 0
+        OR unavailable_at IS NOT NULL);
+
+-- name: IncrementAnimeMissingChecks :one
+UPDATE
+    animes
+SET
+    missing_checks = missing_checks + 1
+WHERE
+    id = sqlc.arg (id)
+RETURNING
+    missing_checks;
+
+-- name: MarkAnimeUnavailable :exec
+UPDATE
+    animes
+SET
+    unavailable_at = NOW()
+WHERE
+    id = sqlc.arg (id);
+
+-- name: GetAvailableAnimeVariation :one
+SELECT
+    *
+FROM
+    animes
+WHERE
+    mal_id = sqlc.arg (mal_id)
+    AND id != sqlc.arg (id)
+    AND unavailable_at IS NULL
+ORDER BY
+    last_episode DESC,
+    updated_at DESC
+LIMIT 1;
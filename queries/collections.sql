@@ -0,0 +1,197 @@
+-- name: CreateCollection :one
+INSERT INTO collections(user_id, name, description, visibility)
+  VALUES (sqlc.arg(user_id), sqlc.arg(name), sqlc.arg(description), sqlc.arg(visibility))
+RETURNING
+  id;
+
+-- name: GetCollectionsOfUser :many
+SELECT
+  sqlc.embed(collections),
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      collection_items
+    WHERE
+      collection_items.collection_id = collections.id) AS item_count
+FROM
+  collections
+WHERE
+  collections.user_id = sqlc.arg(user_id)
+ORDER BY
+  collections.updated_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetCollectionsOfUserCount :one
+SELECT
+  COUNT(*)
+FROM
+  collections
+WHERE
+  user_id = sqlc.arg(user_id);
+
+-- name: GetPublicCollectionsOfUser :many
+SELECT
+  sqlc.embed(collections),
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      collection_items
+    WHERE
+      collection_items.collection_id = collections.id) AS item_count
+FROM
+  collections
+WHERE
+  collections.user_id = sqlc.arg(user_id)
+  AND collections.visibility = 'public'
+ORDER BY
+  collections.updated_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetPublicCollectionsOfUserCount :one
+SELECT
+  COUNT(*)
+FROM
+  collections
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND visibility = 'public';
+
+-- name: GetCollectionOfUser :one
+SELECT
+  sqlc.embed(collections),
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      collection_items
+    WHERE
+      collection_items.collection_id = collections.id) AS item_count
+FROM
+  collections
+WHERE
+  collections.id = sqlc.arg(id)
+  AND collections.user_id = sqlc.arg(user_id);
+
+-- name: GetSharedCollectionBySlug :one
+-- Private collections are never shared, even with a valid slug.
+SELECT
+  sqlc.embed(collections),
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      collection_items
+    WHERE
+      collection_items.collection_id = collections.id) AS item_count
+FROM
+  collections
+WHERE
+  collections.slug = sqlc.arg(slug)
+  AND collections.visibility <> 'private';
+
+-- name: UpdateCollection :execrows
+UPDATE
+  collections
+SET
+  name = sqlc.arg(name),
+  description = sqlc.arg(description),
+  visibility = sqlc.arg(visibility),
+  updated_at = NOW()
+WHERE
+  id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id);
+
+-- name: TouchCollection :exec
+UPDATE
+  collections
+SET
+  updated_at = NOW()
+WHERE
+  id = sqlc.arg(id);
+
+-- name: DeleteCollection :execrows
+DELETE FROM collections
+WHERE id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id);
+
+-- name: GetCollectionItems :many
+SELECT
+  sqlc.embed(collection_items),
+  sqlc.embed(animes)
+FROM
+  collection_items
+  INNER JOIN animes ON animes.id = collection_items.anime_id
+WHERE
+  collection_items.collection_id = sqlc.arg(collection_id)
+ORDER BY
+  collection_items.position ASC,
+  collection_items.created_at ASC
+LIMIT $1 OFFSET $2;
+
+-- name: GetCollectionAnimeIDs :many
+SELECT
+  anime_id
+FROM
+  collection_items
+WHERE
+  collection_id = sqlc.arg(collection_id);
+
+-- name: AddCollectionItem :execrows
+-- New items go to the end of the collection, adding an anime twice is a no-op.
+INSERT INTO collection_items(collection_id, anime_id, position, note)
+SELECT
+  sqlc.arg(collection_id)::varchar,
+  sqlc.arg(anime_id)::varchar,
+  coalesce(max(position), 0) + 1,
+  sqlc.arg(note)::text
+FROM
+  collection_items
+WHERE
+  collection_id = sqlc.arg(collection_id)::varchar
+ON CONFLICT (collection_id, anime_id)
+  DO NOTHING;
+
+-- name: UpdateCollectionItemNote :execrows
+UPDATE
+  collection_items
+SET
+  note = sqlc.arg(note)
+WHERE
+  collection_id = sqlc.arg(collection_id)
+  AND anime_id = sqlc.arg(anime_id);
+
+-- name: DeleteCollectionItem :execrows
+DELETE FROM collection_items
+WHERE collection_id = sqlc.arg(collection_id)
+  AND anime_id = sqlc.arg(anime_id);
+
+-- name: ReorderCollectionItems :exec
+-- anime_ids lists every item of the collection in its new order.
+UPDATE
+  collection_items
+SET
+  position = o.position::integer
+FROM
+  unnest(sqlc.arg(anime_ids)::varchar[])
+  WITH ORDINALITY AS o(anime_id, position)
+WHERE
+  collection_items.collection_id = sqlc.arg(collection_id)
+  AND collection_items.anime_id = o.anime_id;
+
+-- name: RelinkCollectionItems :execrows
+UPDATE
+  collection_items ci
+SET
+  anime_id = sqlc.arg(to_anime_id)
+WHERE
+  ci.anime_id = sqlc.arg(from_anime_id)
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      collection_items ci2
+    WHERE
+      ci2.collection_id = ci.collection_id
+      AND ci2.anime_id = sqlc.arg(to_anime_id));
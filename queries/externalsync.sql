@@ -1,23 +1,36 @@
 -- name: UpsertLibrarySync :exec
 INSERT INTO external_library_sync(user_id, anime_id, provider, action, payload)
   VALUES (sqlc.arg(user_id), sqlc.arg(anime_id), sqlc.arg(provider), sqlc.arg(action), sqlc.arg(payload))
-ON CONFLICT (user_id, anime_id, provider, action)
+ON CONFLICT (user_id, anime_id, provider)
   DO UPDATE SET
+    action = EXCLUDED.action,
     payload = EXCLUDED.payload,
     status = 'pending',
+    revision = external_library_sync.revision + 1,
+    attempts = 0,
+    last_error = NULL,
+    next_attempt_at = NULL,
     updated_at = NOW();
 
--- name: UpdateLibrarySyncStatus :exec
+-- name: UpdateLibrarySyncStatus :execrows
+-- Only applies to the revision the sync job read, a newer state stays
+-- pending for the job queued after it.
 UPDATE
   external_library_sync
 SET
   status = sqlc.arg(status),
+  last_error = CASE WHEN sqlc.arg(status) = 'success' THEN
+    NULL
+  ELSE
+    last_error
+  END,
+  next_attempt_at = NULL,
   updated_at = NOW()
 WHERE
   user_id = sqlc.arg(user_id)
   AND anime_id = sqlc.arg(anime_id)
   AND provider = sqlc.arg(provider)
-  AND action = sqlc.arg(action);
+  AND revision = sqlc.arg(revision);
 
 -- name: GetFailedPendingLibrarySyncs :many
 SELECT
@@ -26,6 +39,8 @@ FROM
   external_library_sync
 WHERE
   status IN ('failed', 'pending')
+  AND (next_attempt_at IS NULL
+    OR next_attempt_at <= NOW())
 ORDER BY
   updated_at ASC;
 
@@ -60,8 +75,7 @@ WHERE
 DELETE FROM external_library_sync
 WHERE user_id = sqlc.arg(user_id)
   AND anime_id = sqlc.arg(anime_id)
-  AND provider = sqlc.arg(provider)
-  AND action = sqlc.arg(action);
+  AND provider = sqlc.arg(provider);
 
 -- name: GetPendingLibrarySyncsForAllUsers :many
 SELECT
@@ -73,3 +87,74 @@ WHERE
 ORDER BY
   updated_at ASC;
 
+
+-- name: GetLibrarySync :one
+SELECT
+  *
+FROM
+  external_library_sync
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND anime_id = sqlc.arg(anime_id)
+  AND provider = sqlc.arg(provider);
+
+-- name: RecordLibrarySyncFailure :one
+-- Schedules the next attempt after the backoff, or marks the sync dead once
+-- attempts run out or the error is permanent. No row is returned when the
+-- entry changed since the job read it.
+UPDATE
+  external_library_sync
+SET
+  attempts = attempts + 1,
+  last_error = sqlc.arg(last_error),
+  status = CASE WHEN sqlc.arg(permanent)::boolean
+    OR attempts + 1 >= sqlc.arg(max_attempts)::int THEN
+    'dead'::library_sync_status
+  ELSE
+    'failed'::library_sync_status
+  END,
+  next_attempt_at = CASE WHEN sqlc.arg(permanent)::boolean
+    OR attempts + 1 >= sqlc.arg(max_attempts)::int THEN
+    NULL
+  ELSE
+    NOW() + sqlc.arg(backoff_seconds)::int * INTERVAL '1 second'
+  END,
+  updated_at = NOW()
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND anime_id = sqlc.arg(anime_id)
+  AND provider = sqlc.arg(provider)
+  AND revision = sqlc.arg(revision)
+RETURNING
+  status,
+  attempts;
+
+-- name: ListLibrarySyncFailures :many
+SELECT
+  sqlc.embed(external_library_sync),
+  sqlc.embed(animes)
+FROM
+  external_library_sync
+  JOIN animes ON animes.id = external_library_sync.anime_id
+WHERE
+  external_library_sync.user_id = sqlc.arg(user_id)
+  AND external_library_sync.status IN ('failed', 'dead')
+ORDER BY
+  external_library_sync.updated_at DESC;
+
+-- name: RetryLibrarySyncs :execrows
+-- Puts failed and dead syncs back to pending, which enqueues them again. An
+-- empty anime_id retries every failed sync of the user.
+UPDATE
+  external_library_sync
+SET
+  status = 'pending',
+  attempts = 0,
+  last_error = NULL,
+  next_attempt_at = NULL,
+  updated_at = NOW()
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND (sqlc.arg(anime_id)::text = ''
+    OR anime_id = sqlc.arg(anime_id))
+  AND status IN ('failed', 'dead');
@@ -0,0 +1,75 @@
+-- name: CreateFollow :execrows
+INSERT INTO follows(follower_id, followee_id)
+  VALUES (sqlc.arg(follower_id), sqlc.arg(followee_id))
+ON CONFLICT
+  DO NOTHING;
+
+-- name: DeleteFollow :execrows
+DELETE FROM follows
+WHERE follower_id = sqlc.arg(follower_id)
+  AND followee_id = sqlc.arg(followee_id);
+
+-- name: SetFollowMuted :execrows
+UPDATE
+  follows
+SET
+  muted = sqlc.arg(muted)
+WHERE
+  follower_id = sqlc.arg(follower_id)
+  AND followee_id = sqlc.arg(followee_id);
+
+-- name: GetFollowing :many
+-- public_only leaves out users without a public profile, for lists shown to
+-- other users.
+SELECT
+  sqlc.embed(follows),
+  sqlc.embed(users)
+FROM
+  follows
+  INNER JOIN users ON users.id = follows.followee_id
+  LEFT JOIN settings ON settings.user_id = follows.followee_id
+WHERE
+  follows.follower_id = sqlc.arg(user_id)
+  AND (NOT sqlc.arg(public_only)::boolean
+    OR coalesce(settings.public_profile, FALSE))
+ORDER BY
+  follows.created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetFollowingCount :one
+SELECT
+  COUNT(*)
+FROM
+  follows
+  LEFT JOIN settings ON settings.user_id = follows.followee_id
+WHERE
+  follows.follower_id = sqlc.arg(user_id)
+  AND (NOT sqlc.arg(public_only)::boolean
+    OR coalesce(settings.public_profile, FALSE));
+
+-- name: GetFollowers :many
+SELECT
+  sqlc.embed(follows),
+  sqlc.embed(users)
+FROM
+  follows
+  INNER JOIN users ON users.id = follows.follower_id
+  LEFT JOIN settings ON settings.user_id = follows.follower_id
+WHERE
+  follows.followee_id = sqlc.arg(user_id)
+  AND (NOT sqlc.arg(public_only)::boolean
+    OR coalesce(settings.public_profile, FALSE))
+ORDER BY
+  follows.created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetFollowersCount :one
+SELECT
+  COUNT(*)
+FROM
+  follows
+  LEFT JOIN settings ON settings.user_id = follows.follower_id
+WHERE
+  follows.followee_id = sqlc.arg(user_id)
+  AND (NOT sqlc.arg(public_only)::boolean
+    OR coalesce(settings.public_profile, FALSE));
@@ -1,6 +1,6 @@
 -- name: CreateLibraryImportJob :one
-INSERT INTO library_import_jobs(user_id, provider)
-  VALUES (sqlc.arg(user_id), sqlc.arg(provider))
+INSERT INTO library_import_jobs(user_id, provider, strategy, dry_run)
+  VALUES (sqlc.arg(user_id), sqlc.arg(provider), sqlc.arg(strategy), sqlc.arg(dry_run))
 RETURNING
   id;
 
@@ -12,6 +12,15 @@ FROM
 WHERE
   id = sqlc.arg(id);
 
+-- name: GetLibraryImportJobOfUser :one
+SELECT
+  *
+FROM
+  library_import_jobs
+WHERE
+  id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id);
+
 -- name: GetLibraryImportJobByUserId :many
 SELECT
   *
@@ -37,3 +46,56 @@ SET
 WHERE
   id = sqlc.arg(id);
 
+
+-- name: ResetLibraryImportReport :exec
+-- Clears what a previous attempt of the job recorded before it runs again.
+WITH deleted_items AS (
+  DELETE FROM library_import_report_items
+  WHERE job_id = sqlc.arg(job_id)),
+deleted_changes AS (
+  DELETE FROM library_import_changes
+  WHERE job_id = sqlc.arg(job_id))
+UPDATE
+  library_import_jobs
+SET
+  matched_count = 0
+WHERE
+  library_import_jobs.id = sqlc.arg(job_id);
+
+-- name: InsertLibraryImportReportItem :exec
+INSERT INTO library_import_report_items(job_id, issue, external_id, title, mal_id, anilist_id, candidate_ids)
+  VALUES (sqlc.arg(job_id), sqlc.arg(issue), sqlc.arg(external_id), sqlc.arg(title), sqlc.arg(mal_id), sqlc.arg(anilist_id), sqlc.arg(candidate_ids)::text[]);
+
+-- name: SetLibraryImportMatchedCount :exec
+UPDATE
+  library_import_jobs
+SET
+  matched_count = sqlc.arg(matched_count)
+WHERE
+  id = sqlc.arg(id);
+
+-- name: GetLibraryImportReportItems :many
+SELECT
+  *
+FROM
+  library_import_report_items
+WHERE
+  job_id = sqlc.arg(job_id)
+ORDER BY
+  id ASC;
+
+-- name: InsertLibraryImportChange :exec
+INSERT INTO library_import_changes(job_id, anime_id, local_status, local_watched_episodes, status, watched_episodes)
+  VALUES (sqlc.arg(job_id), sqlc.arg(anime_id), sqlc.narg(local_status), sqlc.narg(local_watched_episodes), sqlc.arg(status), sqlc.arg(watched_episodes));
+
+-- name: GetLibraryImportChanges :many
+SELECT
+  sqlc.embed(library_import_changes),
+  sqlc.embed(animes)
+FROM
+  library_import_changes
+  INNER JOIN animes ON animes.id = library_import_changes.anime_id
+WHERE
+  library_import_changes.job_id = sqlc.arg(job_id)
+ORDER BY
+  library_import_changes.id ASC;
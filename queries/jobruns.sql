@@ -0,0 +1,74 @@
+-- name: StartJobRun :one
+INSERT INTO job_runs(job_name, trigger, holder)
+  VALUES (sqlc.arg(job_name), sqlc.arg(trigger), sqlc.arg(holder))
+RETURNING
+  *;
+
+-- name: FinishJobRun :exec
+UPDATE
+  job_runs
+SET
+  status = sqlc.arg(status),
+  error_count = sqlc.arg(error_count),
+  warn_count = sqlc.arg(warn_count),
+  log_summary = sqlc.arg(log_summary),
+  finished_at = NOW(),
+  duration_ms = (EXTRACT(EPOCH FROM NOW() - started_at) * 1000)::bigint
+WHERE
+  id = sqlc.arg(id);
+
+-- name: GetLatestJobRuns :many
+SELECT DISTINCT ON (job_name)
+  *
+FROM
+  job_runs
+ORDER BY
+  job_name,
+  started_at DESC;
+
+-- name: GetLatestJobRun :one
+SELECT
+  *
+FROM
+  job_runs
+WHERE
+  job_name = sqlc.arg(job_name)
+ORDER BY
+  started_at DESC
+LIMIT 1;
+
+-- name: ListJobRuns :many
+SELECT
+  *
+FROM
+  job_runs
+WHERE
+  job_name = sqlc.arg(job_name)
+ORDER BY
+  started_at DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetJobSchedules :many
+SELECT
+  *
+FROM
+  job_schedules;
+
+-- name: SetJobPaused :exec
+INSERT INTO job_schedules(job_name, paused, updated_by)
+  VALUES (sqlc.arg(job_name), sqlc.arg(paused), sqlc.arg(updated_by))
+ON CONFLICT (job_name)
+  DO UPDATE SET
+    paused = EXCLUDED.paused,
+    updated_by = EXCLUDED.updated_by;
+
+-- name: IsJobPaused :one
+SELECT
+  EXISTS (
+    SELECT
+      1
+    FROM
+      job_schedules
+    WHERE
+      job_name = sqlc.arg(job_name)
+      AND paused);
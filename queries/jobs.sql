@@ -0,0 +1,167 @@
+-- name: EnqueueJob :exec
+SELECT
+  enqueue_job(sqlc.arg(queue), sqlc.narg(dedupe_key), sqlc.arg(payload), sqlc.arg(max_attempts));
+
+-- name: ClaimJobs :many
+UPDATE
+  jobs
+SET
+  status = 'running',
+  attempts = attempts + 1,
+  locked_by = sqlc.arg(worker_id),
+  locked_until = NOW() + sqlc.arg(lease_seconds)::int * INTERVAL '1 second'
+WHERE
+  id IN (
+    SELECT
+      j.id
+    FROM
+      jobs j
+    WHERE
+      j.queue = sqlc.arg(queue)
+      AND j.status = 'queued'
+      AND j.run_at <= NOW()
+      -- jobs sharing a key run one at a time, in order
+      AND NOT EXISTS (
+        SELECT
+          1
+        FROM
+          jobs r
+        WHERE
+          r.queue = j.queue
+          AND r.dedupe_key = j.dedupe_key
+          AND r.status = 'running')
+    ORDER BY
+      j.run_at ASC
+    LIMIT sqlc.arg(limit_count)
+    FOR UPDATE
+      SKIP LOCKED)
+RETURNING
+  *;
+
+-- name: GetNextJobRunAt :one
+SELECT
+  MIN(run_at)::timestamptz AS run_at
+FROM
+  jobs
+WHERE
+  queue = sqlc.arg(queue)
+  AND status = 'queued'
+  AND run_at > NOW();
+
+-- name: HeartbeatJob :execrows
+UPDATE
+  jobs
+SET
+  locked_until = NOW() + sqlc.arg(lease_seconds)::int * INTERVAL '1 second'
+WHERE
+  id = sqlc.arg(id)
+  AND locked_by = sqlc.arg(worker_id)
+  AND status = 'running';
+
+-- name: CompleteJob :exec
+UPDATE
+  jobs
+SET
+  status = 'completed',
+  locked_by = NULL,
+  locked_until = NULL,
+  completed_at = NOW()
+WHERE
+  id = sqlc.arg(id)
+  AND locked_by = sqlc.arg(worker_id);
+
+-- name: FailJob :one
+-- Requeues the job after the backoff, or dead-letters it once attempts run
+-- out. A job that already has a queued successor is superseded instead.
+UPDATE
+  jobs
+SET
+  status = CASE WHEN sqlc.arg(permanent)::boolean
+    OR jobs.attempts >= jobs.max_attempts THEN
+    'dead'::job_status
+  WHEN EXISTS (
+    SELECT
+      1
+    FROM
+      jobs n
+    WHERE
+      n.queue = jobs.queue
+      AND n.dedupe_key = jobs.dedupe_key
+      AND n.status = 'queued') THEN
+    'completed'::job_status
+  ELSE
+    'queued'::job_status
+  END,
+  run_at = NOW() + sqlc.arg(backoff_seconds)::int * INTERVAL '1 second',
+  last_error = sqlc.arg(last_error),
+  locked_by = NULL,
+  locked_until = NULL,
+  completed_at = CASE WHEN sqlc.arg(permanent)::boolean
+    OR jobs.attempts >= jobs.max_attempts THEN
+    NOW()
+  ELSE
+    NULL
+  END
+WHERE
+  jobs.id = sqlc.arg(id)
+  AND jobs.locked_by = sqlc.arg(worker_id)
+RETURNING
+  status;
+
+-- name: ReleaseJob :exec
+-- Hands a job back without counting the attempt, used on shutdown.
+UPDATE
+  jobs
+SET
+  status = 'queued',
+  attempts = GREATEST(attempts - 1, 0),
+  locked_by = NULL,
+  locked_until = NULL
+WHERE
+  jobs.id = sqlc.arg(id)
+  AND jobs.locked_by = sqlc.arg(worker_id)
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      jobs n
+    WHERE
+      n.queue = jobs.queue
+      AND n.dedupe_key = jobs.dedupe_key
+      AND n.status = 'queued');
+
+-- name: RequeueExpiredJobs :execrows
+UPDATE
+  jobs
+SET
+  status = CASE WHEN jobs.attempts >= jobs.max_attempts THEN
+    'dead'::job_status
+  WHEN EXISTS (
+    SELECT
+      1
+    FROM
+      jobs n
+    WHERE
+      n.queue = jobs.queue
+      AND n.dedupe_key = jobs.dedupe_key
+      AND n.status = 'queued') THEN
+    'completed'::job_status
+  ELSE
+    'queued'::job_status
+  END,
+  last_error = 'lease expired',
+  locked_by = NULL,
+  locked_until = NULL,
+  completed_at = CASE WHEN jobs.attempts >= jobs.max_attempts THEN
+    NOW()
+  ELSE
+    NULL
+  END
+WHERE
+  jobs.status = 'running'
+  AND jobs.locked_until < NOW();
+
+-- name: DeleteFinishedJobs :execrows
+DELETE FROM jobs
+WHERE status IN ('completed', 'dead')
+  AND updated_at < NOW() - sqlc.arg(retention_days)::int * INTERVAL '1 day';
@@ -0,0 +1,31 @@
+-- name: AcquireLease :execrows
+-- Takes the lease when it is free or expired, or extends it for its holder.
+INSERT INTO worker_leases(name, holder, expires_at)
+  VALUES (sqlc.arg(name), sqlc.arg(holder), NOW() + sqlc.arg(ttl_seconds)::int * INTERVAL '1 second')
+ON CONFLICT (name)
+  DO UPDATE SET
+    holder = EXCLUDED.holder,
+    expires_at = EXCLUDED.expires_at,
+    acquired_at = CASE WHEN worker_leases.holder = EXCLUDED.holder THEN
+      worker_leases.acquired_at
+    ELSE
+      NOW()
+    END
+  WHERE
+    worker_leases.holder = EXCLUDED.holder
+    OR worker_leases.expires_at < NOW();
+
+-- name: ReleaseLease :exec
+DELETE FROM worker_leases
+WHERE name = sqlc.arg(name)
+  AND holder = sqlc.arg(holder);
+
+-- name: ListLeases :many
+SELECT
+  *
+FROM
+  worker_leases
+WHERE
+  expires_at >= NOW()
+ORDER BY
+  name ASC;
@@ -52,8 +52,8 @@ WHERE
   AND library.anime_id = sqlc.arg(anime_id);
 
 -- name: InsertLibrary :exec
-INSERT INTO library(user_id, anime_id, status, watched_episodes, updated_at)
-  VALUES (sqlc.arg(user_id), sqlc.arg(anime_id), sqlc.arg(status), sqlc.arg(watched_episodes), coalesce(sqlc.arg(updated_at), NOW()));
+INSERT INTO library(user_id, anime_id, status, watched_episodes, score, started_at, completed_at, rewatches, notes, updated_at)
+  VALUES (sqlc.arg(user_id), sqlc.arg(anime_id), sqlc.arg(status), sqlc.arg(watched_episodes), sqlc.narg(score), sqlc.narg(started_at), sqlc.narg(completed_at), sqlc.arg(rewatches), sqlc.arg(notes), coalesce(sqlc.arg(updated_at), NOW()));
 
 -- name: UpdateLibrary :exec
 UPDATE
@@ -61,6 +61,11 @@ UPDATE
 SET
   status = sqlc.arg(status),
   watched_episodes = sqlc.arg(watched_episodes),
+  score = sqlc.narg(score),
+  started_at = sqlc.narg(started_at),
+  completed_at = sqlc.narg(completed_at),
+  rewatches = sqlc.arg(rewatches),
+  notes = sqlc.arg(notes),
   updated_at = coalesce(sqlc.arg(updated_at), NOW())
 WHERE
   user_id = sqlc.arg(user_id)
@@ -127,3 +132,50 @@ WHERE
 DELETE FROM library
 WHERE user_id = sqlc.arg(user_id);
 
+-- name: RelinkLibraryEntries :execrows
+-- updated_at is kept, the user did not change the entry.
+UPDATE
+  library l
+SET
+  anime_id = sqlc.arg(to_anime_id)
+WHERE
+  l.anime_id = sqlc.arg(from_anime_id)
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      library l2
+    WHERE
+      l2.user_id = l.user_id
+      AND l2.anime_id = sqlc.arg(to_anime_id));
+
+-- name: GetLibraryTransitionRules :one
+-- Users without a settings row get the column defaults. total_episodes is
+-- only known to be final once the series finished airing.
+SELECT
+  coalesce(settings.auto_start_library, TRUE)::boolean AS auto_start,
+  coalesce(settings.auto_complete_library, TRUE)::boolean AS auto_complete,
+  coalesce(anime_metadata.total_episodes, 0)::integer AS total_episodes,
+  coalesce(anime_metadata.airing_status = 'finished_airing', FALSE)::boolean AS finished_airing
+FROM
+  animes
+  LEFT JOIN anime_metadata ON anime_metadata.mal_id = animes.mal_id
+  LEFT JOIN settings ON settings.user_id = sqlc.arg(user_id)
+WHERE
+  animes.id = sqlc.arg(anime_id);
+
+-- name: PauseInactiveLibraryEntries :many
+UPDATE
+  library
+SET
+  status = 'paused',
+  updated_at = NOW()
+FROM
+  settings
+WHERE
+  settings.user_id = library.user_id
+  AND settings.auto_pause_library
+  AND library.status = 'watching'
+  AND library.updated_at < NOW() - make_interval(days => settings.auto_pause_after_days)
+RETURNING
+  library.*;
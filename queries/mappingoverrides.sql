@@ -0,0 +1,58 @@
+-- name: GetAnimeMappingOverride :one
+SELECT
+  *
+FROM
+  anime_mapping_overrides
+WHERE
+  hi_anime_id = sqlc.arg(hi_anime_id);
+
+-- name: GetAnimeMappingOverridesByHiAnimeIds :many
+SELECT
+  *
+FROM
+  anime_mapping_overrides
+WHERE
+  hi_anime_id = ANY (sqlc.arg(hi_anime_ids)::text[]);
+
+-- name: ListAnimeMappingOverrides :many
+SELECT
+  *
+FROM
+  anime_mapping_overrides
+ORDER BY
+  updated_at DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: UpsertAnimeMappingOverride :one
+INSERT INTO anime_mapping_overrides(hi_anime_id, mal_id, anilist_id, season, season_year, ename, jname, note, updated_by)
+  VALUES (sqlc.arg(hi_anime_id), sqlc.narg(mal_id), sqlc.narg(anilist_id), sqlc.narg(season), sqlc.narg(season_year), sqlc.narg(ename), sqlc.narg(jname), sqlc.narg(note), sqlc.arg(updated_by))
+ON CONFLICT (hi_anime_id)
+  DO UPDATE SET
+    mal_id = EXCLUDED.mal_id,
+    anilist_id = EXCLUDED.anilist_id,
+    season = EXCLUDED.season,
+    season_year = EXCLUDED.season_year,
+    ename = EXCLUDED.ename,
+    jname = EXCLUDED.jname,
+    note = EXCLUDED.note,
+    updated_by = EXCLUDED.updated_by
+  RETURNING
+    *;
+
+-- name: DeleteAnimeMappingOverride :execrows
+DELETE FROM anime_mapping_overrides
+WHERE hi_anime_id = sqlc.arg(hi_anime_id);
+
+-- name: InsertAnimeMappingOverrideAudit :exec
+INSERT INTO anime_mapping_override_audit(hi_anime_id, action, changed_by, previous, current)
+  VALUES (sqlc.arg(hi_anime_id), sqlc.arg(action), sqlc.arg(changed_by), sqlc.arg(previous), sqlc.arg(current));
+
+-- name: GetAnimeMappingOverrideAudit :many
+SELECT
+  *
+FROM
+  anime_mapping_override_audit
+WHERE
+  hi_anime_id = sqlc.arg(hi_anime_id)
+ORDER BY
+  created_at DESC;
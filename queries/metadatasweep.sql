@@ -0,0 +1,79 @@
+-- name: GetMetadataSweepCandidates :many
+-- MAL IDs whose metadata is missing or older than the TTL (airing titles use
+-- a shorter one), airing and library-referenced titles first, then by
+-- popularity. IDs that recently failed wait for their backoff.
+SELECT
+  a.mal_id::int AS mal_id,
+  (m.mal_id IS NULL)::boolean AS missing
+FROM
+  animes a
+  LEFT JOIN anime_metadata m ON m.mal_id = a.mal_id
+  LEFT JOIN anime_metadata_sweep_failures f ON f.mal_id = a.mal_id
+  LEFT JOIN (
+    SELECT
+      l.anime_id,
+      COUNT(*) AS library_count
+    FROM
+      library l
+    GROUP BY
+      l.anime_id) lc ON lc.anime_id = a.id
+WHERE
+  a.mal_id IS NOT NULL
+  AND a.mal_id > 0
+  AND a.unavailable_at IS NULL
+  AND (f.mal_id IS NULL
+    OR f.next_attempt_at <= NOW())
+  AND (m.mal_id IS NULL
+    OR m.updated_at < sqlc.arg(stale_before)::timestamp
+    OR (m.airing_status = 'currently_airing'
+      AND m.updated_at < sqlc.arg(airing_stale_before)::timestamp))
+GROUP BY
+  a.mal_id,
+  m.mal_id,
+  m.airing_status,
+  m.popularity
+ORDER BY
+  (m.airing_status = 'currently_airing') DESC NULLS LAST,
+  SUM(COALESCE(lc.library_count, 0)) DESC,
+  m.popularity ASC NULLS LAST
+LIMIT sqlc.arg(limit_count);
+
+-- name: RecordMetadataSweepFailure :exec
+INSERT INTO anime_metadata_sweep_failures(mal_id, last_error, next_attempt_at)
+  VALUES (sqlc.arg(mal_id), sqlc.arg(last_error), NOW() + sqlc.arg(backoff_hours)::int * INTERVAL '1 hour')
+ON CONFLICT (mal_id)
+  DO UPDATE SET
+    attempts = anime_metadata_sweep_failures.attempts + 1,
+    last_error = EXCLUDED.last_error,
+    next_attempt_at = NOW() + LEAST(sqlc.arg(backoff_hours)::int * POWER(2, anime_metadata_sweep_failures.attempts), 24 * 30) * INTERVAL '1 hour';
+
+-- name: ClearMetadataSweepFailure :exec
+DELETE FROM anime_metadata_sweep_failures
+WHERE mal_id = sqlc.arg(mal_id);
+
+-- name: GetMetadataCoverage :one
+SELECT
+  COUNT(*)::bigint AS total,
+  COUNT(m.mal_id)::bigint AS with_metadata,
+  COUNT(*) FILTER (WHERE m.mal_id IS NULL)::bigint AS missing,
+  COUNT(*) FILTER (WHERE m.updated_at >= sqlc.arg(stale_before)::timestamp)::bigint AS fresh,
+  COUNT(*) FILTER (WHERE m.updated_at < sqlc.arg(stale_before)::timestamp)::bigint AS stale,
+  COUNT(*) FILTER (WHERE m.airing_status = 'currently_airing')::bigint AS airing,
+  COUNT(*) FILTER (WHERE m.airing_status = 'currently_airing'
+    AND m.updated_at < sqlc.arg(airing_stale_before)::timestamp)::bigint AS airing_stale,
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      anime_metadata_sweep_failures)::bigint AS failing,
+  MIN(m.updated_at)::timestamp AS oldest_updated_at
+FROM (
+  SELECT DISTINCT
+    a.mal_id
+  FROM
+    animes a
+  WHERE
+    a.mal_id IS NOT NULL
+    AND a.mal_id > 0
+    AND a.unavailable_at IS NULL) ids
+  LEFT JOIN anime_metadata m ON m.mal_id = ids.mal_id;
@@ -22,7 +22,8 @@ FROM
   oauth_tokens
 WHERE
   expires_at <= NOW() + INTERVAL '10 days'
-  AND expires_at > NOW();
+  AND expires_at > NOW()
+  AND NOT needs_reauth;
 
 -- name: SaveOauthToken :exec
 INSERT INTO oauth_tokens(user_id, token, refresh_token, provider, expires_at)
@@ -34,7 +35,17 @@ UPDATE
 SET
   token = sqlc.arg(token),
   refresh_token = sqlc.arg(refresh_token),
-  expires_at = sqlc.arg(expires_at)
+  expires_at = sqlc.arg(expires_at),
+  needs_reauth = FALSE
+WHERE
+  user_id = sqlc.arg(user_id)
+  AND provider = sqlc.arg(provider);
+
+-- name: MarkOauthTokenNeedsReauth :exec
+UPDATE
+  oauth_tokens
+SET
+  needs_reauth = TRUE
 WHERE
   user_id = sqlc.arg(user_id)
   AND provider = sqlc.arg(provider);
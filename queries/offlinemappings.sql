@@ -0,0 +1,132 @@
+-- name: DeleteAllAnimeOfflineMappings :exec
+DELETE FROM anime_offline_mappings;
+
+-- name: InsertAnimeOfflineMappings :copyfrom
+INSERT INTO anime_offline_mappings(title, synonyms, media_type, episodes, season, season_year, mal_id, anilist_id, kitsu_id, anidb_id)
+  VALUES (sqlc.arg(title), sqlc.arg(synonyms), sqlc.arg(media_type), sqlc.arg(episodes), sqlc.arg(season), sqlc.arg(season_year), sqlc.arg(mal_id), sqlc.arg(anilist_id), sqlc.arg(kitsu_id), sqlc.arg(anidb_id));
+
+-- name: GetAnimeOfflineMappingCount :one
+SELECT
+  COUNT(*)
+FROM
+  anime_offline_mappings;
+
+-- name: GetOfflineMappingByAnilistId :one
+SELECT
+  *
+FROM
+  anime_offline_mappings
+WHERE
+  anilist_id = sqlc.arg(anilist_id)
+  AND mal_id IS NOT NULL
+LIMIT 1;
+
+-- name: GetOfflineMappingByKitsuId :one
+SELECT
+  *
+FROM
+  anime_offline_mappings
+WHERE
+  kitsu_id = sqlc.arg(kitsu_id)
+  AND mal_id IS NOT NULL
+LIMIT 1;
+
+-- name: GetOfflineMappingByMalId :one
+SELECT
+  *
+FROM
+  anime_offline_mappings
+WHERE
+  mal_id = sqlc.arg(mal_id)
+LIMIT 1;
+
+-- name: FindOfflineMappingCandidates :many
+SELECT
+  m.*,
+  GREATEST(similarity(m.title, sqlc.arg(ename)::text), similarity(m.title, sqlc.arg(jname)::text), COALESCE((
+      SELECT
+        MAX(GREATEST(similarity(s, sqlc.arg(ename)::text), similarity(s, sqlc.arg(jname)::text)))
+      FROM unnest(m.synonyms) AS s), 0))::real AS score
+FROM
+  anime_offline_mappings m
+WHERE
+  m.mal_id IS NOT NULL
+  AND (m.title % sqlc.arg(ename)::text
+    OR m.title % sqlc.arg(jname)::text)
+ORDER BY
+  score DESC
+LIMIT 5;
+
+-- name: GetAnimesMissingExternalIds :many
+SELECT
+  a.*
+FROM
+  animes a
+WHERE
+  a.unavailable_at IS NULL
+  AND (a.mal_id IS NULL
+    OR a.mal_id = 0
+    OR a.anilist_id IS NULL
+    OR a.anilist_id = 0)
+  -- admin pinned mappings always win
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      anime_mapping_overrides o
+    WHERE
+      o.hi_anime_id = a.hi_anime_id)
+  -- already queued or reviewed
+  AND NOT EXISTS (
+    SELECT
+      1
+    FROM
+      anime_mapping_suggestions s
+    WHERE
+      s.anime_id = a.id)
+ORDER BY
+  a.updated_at DESC;
+
+-- name: InsertAnimeMappingSuggestion :exec
+INSERT INTO anime_mapping_suggestions(anime_id, mal_id, anilist_id, confidence, method, matched_title)
+  VALUES (sqlc.arg(anime_id), sqlc.arg(mal_id), sqlc.arg(anilist_id), sqlc.arg(confidence), sqlc.arg(method), sqlc.arg(matched_title))
+ON CONFLICT (anime_id)
+  DO NOTHING;
+
+-- name: ListAnimeMappingSuggestions :many
+SELECT
+  sqlc.embed(s),
+  a.hi_anime_id,
+  a.ename,
+  a.jname,
+  a.season,
+  a.season_year
+FROM
+  anime_mapping_suggestions s
+  JOIN animes a ON a.id = s.anime_id
+WHERE
+  s.status = sqlc.arg(status)
+ORDER BY
+  s.confidence DESC,
+  s.created_at ASC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: GetAnimeMappingSuggestion :one
+SELECT
+  sqlc.embed(s),
+  a.hi_anime_id
+FROM
+  anime_mapping_suggestions s
+  JOIN animes a ON a.id = s.anime_id
+WHERE
+  s.id = sqlc.arg(id);
+
+-- name: UpdateAnimeMappingSuggestionStatus :exec
+UPDATE
+  anime_mapping_suggestions
+SET
+  status = sqlc.arg(status),
+  reviewed_by = sqlc.arg(reviewed_by),
+  reviewed_at = NOW()
+WHERE
+  id = sqlc.arg(id);
@@ -0,0 +1,108 @@
+-- name: GetProfileByUsername :one
+-- Users without a settings row get the column defaults, so no public profile.
+SELECT
+  sqlc.embed(users),
+  coalesce(settings.public_profile, FALSE)::boolean AS public_profile,
+  coalesce(settings.profile_show_library, TRUE)::boolean AS show_library,
+  coalesce(settings.profile_show_stats, TRUE)::boolean AS show_stats,
+  coalesce(settings.profile_show_favorites, TRUE)::boolean AS show_favorites,
+  coalesce(settings.profile_show_activity, TRUE)::boolean AS show_activity,
+  coalesce(settings.incognito_mode, FALSE)::boolean AS incognito_mode
+FROM
+  users
+  LEFT JOIN settings ON settings.user_id = users.id
+WHERE
+  users.username = sqlc.arg(username);
+
+-- name: GetProfileStats :one
+SELECT
+  COUNT(*) FILTER (WHERE status = 'watching') AS watching,
+  COUNT(*) FILTER (WHERE status = 'planning') AS planning,
+  COUNT(*) FILTER (WHERE status = 'completed') AS completed,
+  COUNT(*) FILTER (WHERE status = 'dropped') AS dropped,
+  COUNT(*) FILTER (WHERE status = 'paused') AS paused,
+  coalesce(SUM(watched_episodes), 0)::bigint AS episodes_watched,
+  -- 0 when no entry is scored, scores start at 1
+  coalesce(AVG(score), 0)::float8 AS mean_score
+FROM
+  library
+WHERE
+  user_id = sqlc.arg(user_id);
+
+-- name: GetProfileFavorites :many
+-- Favorites are the user's highest scored entries.
+SELECT
+  sqlc.embed(library),
+  sqlc.embed(animes)
+FROM
+  library
+  INNER JOIN animes ON animes.id = library.anime_id
+WHERE
+  library.user_id = sqlc.arg(user_id)
+  AND library.score IS NOT NULL
+ORDER BY
+  library.score DESC,
+  library.updated_at DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetProfileActivity :many
+-- Read from the recorded activities rather than the library, changes made in
+-- incognito mode are never recorded and so never show up later.
+SELECT
+  sqlc.embed(library_activities),
+  sqlc.embed(animes)
+FROM
+  library_activities
+  INNER JOIN animes ON animes.id = library_activities.anime_id
+WHERE
+  library_activities.user_id = sqlc.arg(user_id)
+ORDER BY
+  library_activities.created_at DESC,
+  library_activities.id
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetSharedLibraryEntries :many
+-- Entries are matched on MAL ID so different variations of a show count as
+-- the same title, anime without one only match themselves. A user with
+-- several variations of a show is represented by the latest changed one.
+WITH user_library AS (
+  SELECT DISTINCT ON (title_key)
+    coalesce(nullif(animes.mal_id, 0)::text, animes.id) AS title_key,
+    library.anime_id,
+    library.status,
+    library.score
+  FROM
+    library
+    INNER JOIN animes ON animes.id = library.anime_id
+  WHERE
+    library.user_id = sqlc.arg(user_id)
+  ORDER BY
+    title_key,
+    library.updated_at DESC
+),
+other_library AS (
+  SELECT DISTINCT ON (title_key)
+    coalesce(nullif(animes.mal_id, 0)::text, animes.id) AS title_key,
+    library.status,
+    library.score
+  FROM
+    library
+    INNER JOIN animes ON animes.id = library.anime_id
+  WHERE
+    library.user_id = sqlc.arg(other_user_id)
+  ORDER BY
+    title_key,
+    library.updated_at DESC
+)
+SELECT
+  sqlc.embed(animes),
+  user_library.status AS user_status,
+  user_library.score AS user_score,
+  other_library.status AS other_status,
+  other_library.score AS other_score
+FROM
+  user_library
+  INNER JOIN other_library ON other_library.title_key = user_library.title_key
+  INNER JOIN animes ON animes.id = user_library.anime_id
+ORDER BY
+  animes.ename;
@@ -0,0 +1,116 @@
+-- name: CreateSeedRun :one
+INSERT INTO seed_runs(kind, phase)
+  VALUES (sqlc.arg(kind), sqlc.arg(phase))
+RETURNING
+  *;
+
+-- name: GetSeedRun :one
+SELECT
+  *
+FROM
+  seed_runs
+WHERE
+  id = sqlc.arg(id);
+
+-- name: GetLatestUnfinishedSeedRun :one
+SELECT
+  *
+FROM
+  seed_runs
+WHERE
+  kind = sqlc.arg(kind)
+  AND status <> 'completed'
+ORDER BY
+  created_at DESC
+LIMIT 1;
+
+-- name: ListSeedRuns :many
+SELECT
+  sqlc.embed(seed_runs),
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      seed_run_errors e
+    WHERE
+      e.seed_run_id = seed_runs.id) AS errored_count,
+  (
+    SELECT
+      COUNT(*)
+    FROM
+      seed_run_quarantined_pages q
+    WHERE
+      q.seed_run_id = seed_runs.id) AS quarantined_count
+FROM
+  seed_runs
+ORDER BY
+  created_at DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: UpdateSeedRunCheckpoint :exec
+UPDATE
+  seed_runs
+SET
+  phase = sqlc.arg(phase),
+  next_page = sqlc.arg(next_page),
+  total_pages = sqlc.arg(total_pages)
+WHERE
+  id = sqlc.arg(id);
+
+-- name: UpdateSeedRunStatus :exec
+UPDATE
+  seed_runs
+SET
+  status = sqlc.arg(status)::seed_run_status,
+  error_message = sqlc.arg(error_message),
+  completed_at = CASE WHEN sqlc.arg(status)::seed_run_status = 'completed' THEN
+    NOW()
+  ELSE
+    NULL
+  END
+WHERE
+  id = sqlc.arg(id);
+
+-- name: QuarantineSeedRunPage :exec
+INSERT INTO seed_run_quarantined_pages(seed_run_id, phase, page, error_message)
+  VALUES (sqlc.arg(seed_run_id), sqlc.arg(phase), sqlc.arg(page), sqlc.arg(error_message))
+ON CONFLICT (seed_run_id, phase, page)
+  DO UPDATE SET
+    attempts = seed_run_quarantined_pages.attempts + 1,
+    error_message = EXCLUDED.error_message,
+    updated_at = NOW();
+
+-- name: GetQuarantinedSeedRunPages :many
+SELECT
+  *
+FROM
+  seed_run_quarantined_pages
+WHERE
+  seed_run_id = sqlc.arg(seed_run_id)
+  AND phase = sqlc.arg(phase)
+ORDER BY
+  page ASC;
+
+-- name: DeleteQuarantinedSeedRunPage :exec
+DELETE FROM seed_run_quarantined_pages
+WHERE seed_run_id = sqlc.arg(seed_run_id)
+  AND phase = sqlc.arg(phase)
+  AND page = sqlc.arg(page);
+
+-- name: UpsertSeedRunError :exec
+INSERT INTO seed_run_errors(seed_run_id, hi_anime_id, phase, error_message)
+  VALUES (sqlc.arg(seed_run_id), sqlc.arg(hi_anime_id), sqlc.arg(phase), sqlc.arg(error_message))
+ON CONFLICT (seed_run_id, hi_anime_id)
+  DO UPDATE SET
+    phase = EXCLUDED.phase,
+    error_message = EXCLUDED.error_message;
+
+-- name: GetSeedRunErroredHiAnimeIds :many
+SELECT
+  hi_anime_id
+FROM
+  seed_run_errors
+WHERE
+  seed_run_id = sqlc.arg(seed_run_id)
+ORDER BY
+  created_at ASC;
@@ -10,15 +10,24 @@ WHERE
 
 -- name: SaveSettings :one
 WITH upserted AS (
-INSERT INTO settings(user_id, auto_next_episode, auto_play_episode, auto_resume_episode, incognito_mode, theme_id)
-    VALUES (sqlc.arg(user_id), sqlc.arg(auto_next_episode), sqlc.arg(auto_play_episode), sqlc.arg(auto_resume_episode), sqlc.arg(incognito_mode), sqlc.arg(theme_id))
+INSERT INTO settings(user_id, auto_next_episode, auto_play_episode, auto_resume_episode, incognito_mode, theme_id, auto_start_library, auto_complete_library, auto_pause_library, auto_pause_after_days, public_profile, profile_show_library, profile_show_stats, profile_show_favorites, profile_show_activity)
+    VALUES (sqlc.arg(user_id), sqlc.arg(auto_next_episode), sqlc.arg(auto_play_episode), sqlc.arg(auto_resume_episode), sqlc.arg(incognito_mode), sqlc.arg(theme_id), sqlc.arg(auto_start_library), sqlc.arg(auto_complete_library), sqlc.arg(auto_pause_library), sqlc.arg(auto_pause_after_days), sqlc.arg(public_profile), sqlc.arg(profile_show_library), sqlc.arg(profile_show_stats), sqlc.arg(profile_show_favorites), sqlc.arg(profile_show_activity))
   ON CONFLICT (user_id)
     DO UPDATE SET
       auto_next_episode = EXCLUDED.auto_next_episode,
       auto_play_episode = EXCLUDED.auto_play_episode,
       auto_resume_episode = EXCLUDED.auto_resume_episode,
       incognito_mode = EXCLUDED.incognito_mode,
-      theme_id = EXCLUDED.theme_id
+      theme_id = EXCLUDED.theme_id,
+      auto_start_library = EXCLUDED.auto_start_library,
+      auto_complete_library = EXCLUDED.auto_complete_library,
+      auto_pause_library = EXCLUDED.auto_pause_library,
+      auto_pause_after_days = EXCLUDED.auto_pause_after_days,
+      public_profile = EXCLUDED.public_profile,
+      profile_show_library = EXCLUDED.profile_show_library,
+      profile_show_stats = EXCLUDED.profile_show_stats,
+      profile_show_favorites = EXCLUDED.profile_show_favorites,
+      profile_show_activity = EXCLUDED.profile_show_activity
     RETURNING
       *
 )
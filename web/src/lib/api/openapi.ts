@@ -744,13 +744,23 @@ export interface paths {
 						| 'relevance'
 						| 'updated_at'
 						| 'anime_updated_at'
-						| 'library_updated_at';
+						| 'library_updated_at'
+						| 'library_score'
+						| 'library_started_at'
+						| 'library_completed_at'
+						| 'collection_position';
 					/** @description Sort order: 'asc' or 'desc' (default: 'desc') */
 					sortOrder?: 'asc' | 'desc';
 					/** @description Only show anime in user's library (requires authentication) */
 					inLibraryOnly?: boolean;
 					/** @description Filter by library status (requires authentication) */
 					status?: 'watching' | 'completed' | 'planning' | 'dropped' | 'paused';
+					/** @description Filter by minimum library score out of 100 (requires authentication) */
+					scoreMin?: number;
+					/** @description Filter by maximum library score out of 100 (requires authentication) */
+					scoreMax?: number;
+					/** @description Only show anime in one of the user's collections (requires authentication) */
+					collectionId?: string;
 				};
 				header?: never;
 				path?: never;
@@ -1723,7 +1733,7 @@ export interface paths {
 		};
 		/**
 		 * Get connected OAuth providers
-		 * @description Get connected OAuth providers
+		 * @description Get connected OAuth providers, needsReauth is set when the provider revoked the connection and it has to be connected again
 		 */
 		get: {
 			parameters: {
@@ -1740,7 +1750,7 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': string[];
+						'application/json': components['schemas']['models.OauthProviderResponse'][];
 					};
 				};
 				/** @description Internal Server Error */
@@ -2099,7 +2109,7 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	'/desktop/releases': {
+	'/collections': {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2107,12 +2117,17 @@ export interface paths {
 			cookie?: never;
 		};
 		/**
-		 * Get all desktop releases
-		 * @description Get all desktop releases grouped by version
+		 * Get user's collections
+		 * @description Get the collections of the current user, most recently changed first
 		 */
 		get: {
 			parameters: {
-				query?: never;
+				query?: {
+					/** @description Page number */
+					page?: number;
+					/** @description Number of items per page */
+					itemsPerPage?: number;
+				};
 				header?: never;
 				path?: never;
 				cookie?: never;
@@ -2125,7 +2140,16 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.DesktopReleaseResponse'][];
+						'application/json': components['schemas']['models.CollectionListResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
 					};
 				};
 				/** @description Internal Server Error */
@@ -2141,8 +2165,8 @@ export interface paths {
 		};
 		put?: never;
 		/**
-		 * Create desktop release
-		 * @description Create a new desktop release for a specific platform
+		 * Create collection
+		 * @description Create a collection for the current user
 		 */
 		post: {
 			parameters: {
@@ -2151,20 +2175,15 @@ export interface paths {
 				path?: never;
 				cookie?: never;
 			};
-			/** @description Release object */
-			requestBody: {
-				content: {
-					'application/json': components['schemas']['models.CreateDesktopReleaseRequest'];
-				};
-			};
+			requestBody: components['requestBodies']['models.CollectionRequest'];
 			responses: {
-				/** @description Created */
-				201: {
+				/** @description OK */
+				200: {
 					headers: {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.DesktopReleaseResponse'];
+						'application/json': components['schemas']['models.CollectionResponse'];
 					};
 				};
 				/** @description Bad Request */
@@ -2176,15 +2195,6 @@ export interface paths {
 						'application/json': components['schemas']['models.ValidationErrorResponse'];
 					};
 				};
-				/** @description Unauthorized */
-				401: {
-					headers: {
-						[name: string]: unknown;
-					};
-					content: {
-						'application/json': components['schemas']['models.ErrorResponse'];
-					};
-				};
 				/** @description Internal Server Error */
 				500: {
 					headers: {
@@ -2202,7 +2212,7 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	'/desktop/releases/{version}': {
+	'/collections/{collectionID}': {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2210,16 +2220,21 @@ export interface paths {
 			cookie?: never;
 		};
 		/**
-		 * Get desktop release by version
-		 * @description Get a specific desktop release version with all platform binaries
+		 * Get collection
+		 * @description Get one of the current user's collections with a page of its items in order
 		 */
 		get: {
 			parameters: {
-				query?: never;
+				query?: {
+					/** @description Page number */
+					page?: number;
+					/** @description Number of items per page */
+					itemsPerPage?: number;
+				};
 				header?: never;
 				path: {
-					/** @description Version string (e.g. 1.0.0) */
-					version: string;
+					/** @description Collection ID */
+					collectionID: string;
 				};
 				cookie?: never;
 			};
@@ -2231,7 +2246,7 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.DesktopVersionResponse'];
+						'application/json': components['schemas']['models.CollectionWithItemsResponse'];
 					};
 				};
 				/** @description Bad Request */
@@ -2263,30 +2278,30 @@ export interface paths {
 				};
 			};
 		};
-		put?: never;
-		post?: never;
 		/**
-		 * Delete desktop releases by version
-		 * @description Delete all desktop releases for a specific version
+		 * Update collection
+		 * @description Update the name, description and visibility of a collection
 		 */
-		delete: {
+		put: {
 			parameters: {
 				query?: never;
 				header?: never;
 				path: {
-					/** @description Version string (e.g. 1.0.0) */
-					version: string;
+					/** @description Collection ID */
+					collectionID: string;
 				};
 				cookie?: never;
 			};
-			requestBody?: never;
+			requestBody: components['requestBodies']['models.CollectionRequest'];
 			responses: {
-				/** @description No Content */
-				204: {
+				/** @description OK */
+				200: {
 					headers: {
 						[name: string]: unknown;
 					};
-					content?: never;
+					content: {
+						'application/json': components['schemas']['models.CollectionResponse'];
+					};
 				};
 				/** @description Bad Request */
 				400: {
@@ -2294,11 +2309,11 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.ErrorResponse'];
+						'application/json': components['schemas']['models.ValidationErrorResponse'];
 					};
 				};
-				/** @description Unauthorized */
-				401: {
+				/** @description Not Found */
+				404: {
 					headers: {
 						[name: string]: unknown;
 					};
@@ -2317,38 +2332,37 @@ export interface paths {
 				};
 			};
 		};
-		options?: never;
-		head?: never;
-		patch?: never;
-		trace?: never;
-	};
-	'/desktop/releases/latest': {
-		parameters: {
-			query?: never;
-			header?: never;
-			path?: never;
-			cookie?: never;
-		};
+		post?: never;
 		/**
-		 * Get latest desktop release
-		 * @description Get the latest desktop release with all platform binaries
+		 * Delete collection
+		 * @description Delete a collection and its items
 		 */
-		get: {
+		delete: {
 			parameters: {
 				query?: never;
 				header?: never;
-				path?: never;
+				path: {
+					/** @description Collection ID */
+					collectionID: string;
+				};
 				cookie?: never;
 			};
 			requestBody?: never;
 			responses: {
 				/** @description OK */
 				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content?: never;
+				};
+				/** @description Bad Request */
+				400: {
 					headers: {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.DesktopVersionResponse'];
+						'application/json': components['schemas']['models.ErrorResponse'];
 					};
 				};
 				/** @description Not Found */
@@ -2371,128 +2385,73 @@ export interface paths {
 				};
 			};
 		};
-		put?: never;
-		post?: never;
-		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	'/health': {
+	'/collections/{collectionID}/items': {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
+		get?: never;
+		put?: never;
 		/**
-		 * Simple health check
-		 * @description Check if the API service is running
+		 * Add anime to collection
+		 * @description Add an anime with an optional note to the end of a collection
 		 */
-		get: {
+		post: {
 			parameters: {
 				query?: never;
 				header?: never;
-				path?: never;
+				path: {
+					/** @description Collection ID */
+					collectionID: string;
+				};
 				cookie?: never;
 			};
-			requestBody?: never;
+			/** @description Collection item */
+			requestBody: {
+				content: {
+					'application/json': components['schemas']['models.CollectionItemRequest'];
+				};
+			};
 			responses: {
 				/** @description OK */
 				200: {
 					headers: {
 						[name: string]: unknown;
 					};
-					content: {
-						'application/json': components['schemas']['handlers.HealthCheckResponse'];
-					};
+					content?: never;
 				};
-			};
-		};
-		put?: never;
-		post?: never;
-		delete?: never;
-		options?: never;
-		head?: never;
-		patch?: never;
-		trace?: never;
-	};
-	'/health/z': {
-		parameters: {
-			query?: never;
-			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		/**
-		 * Comprehensive health check
-		 * @description Check the health of all services and external APIs
-		 */
-		get: {
-			parameters: {
-				query?: never;
-				header?: never;
-				path?: never;
-				cookie?: never;
-			};
-			requestBody?: never;
-			responses: {
-				/** @description OK */
-				200: {
+				/** @description Bad Request */
+				400: {
 					headers: {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['handlers.HealthResponse'];
+						'application/json': components['schemas']['models.ValidationErrorResponse'];
 					};
 				};
-				/** @description Service Unavailable */
-				503: {
+				/** @description Not Found */
+				404: {
 					headers: {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['handlers.HealthResponse'];
+						'application/json': components['schemas']['models.ErrorResponse'];
 					};
 				};
-			};
-		};
-		put?: never;
-		post?: never;
-		delete?: never;
-		options?: never;
-		head?: never;
-		patch?: never;
-		trace?: never;
-	};
-	'/home': {
-		parameters: {
-			query?: never;
-			header?: never;
-			path?: never;
-			cookie?: never;
-		};
-		/**
-		 * Get home page data
-		 * @description Get all home page data in a single response including trending, popular, recently updated, seasonal anime, and user library sections (if authenticated)
-		 */
-		get: {
-			parameters: {
-				query?: never;
-				header?: never;
-				path?: never;
-				cookie?: never;
-			};
-			requestBody?: never;
-			responses: {
-				/** @description OK */
-				200: {
+				/** @description Conflict */
+				409: {
 					headers: {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.HomeResponse'];
+						'application/json': components['schemas']['models.ErrorResponse'];
 					};
 				};
 				/** @description Internal Server Error */
@@ -2506,52 +2465,61 @@ export interface paths {
 				};
 			};
 		};
-		put?: never;
-		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	'/library': {
+	'/collections/{collectionID}/items/{animeID}': {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
+		get?: never;
 		/**
-		 * Get user's anime library
-		 * @description Get user's anime library
+		 * Update collection item
+		 * @description Update the note of an anime in a collection
 		 */
-		get: {
+		put: {
 			parameters: {
-				query: {
-					/** @description Library status filter */
-					status: 'planning' | 'watching' | 'completed' | 'dropped' | 'paused';
-					/** @description Page number */
-					page?: number;
-					/** @description Number of items per page */
-					itemsPerPage?: number;
-				};
+				query?: never;
 				header?: never;
-				path?: never;
+				path: {
+					/** @description Collection ID */
+					collectionID: string;
+					/** @description Anime ID */
+					animeID: string;
+				};
 				cookie?: never;
 			};
-			requestBody?: never;
+			/** @description Collection item note */
+			requestBody: {
+				content: {
+					'application/json': components['schemas']['models.CollectionItemNoteRequest'];
+				};
+			};
 			responses: {
 				/** @description OK */
 				200: {
 					headers: {
 						[name: string]: unknown;
 					};
-					content: {
-						'application/json': components['schemas']['models.LibraryListResponse'];
-					};
+					content?: never;
 				};
 				/** @description Bad Request */
 				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ValidationErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
 					headers: {
 						[name: string]: unknown;
 					};
@@ -2570,17 +2538,21 @@ export interface paths {
 				};
 			};
 		};
-		put?: never;
 		post?: never;
 		/**
-		 * Clear user's library
-		 * @description Clear user's library
+		 * Remove anime from collection
+		 * @description Remove an anime from a collection
 		 */
 		delete: {
 			parameters: {
 				query?: never;
 				header?: never;
-				path?: never;
+				path: {
+					/** @description Collection ID */
+					collectionID: string;
+					/** @description Anime ID */
+					animeID: string;
+				};
 				cookie?: never;
 			};
 			requestBody?: never;
@@ -2592,7 +2564,25 @@ export interface paths {
 					};
 					content?: never;
 				};
-				/** @description Internal Server Error */
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
 				500: {
 					headers: {
 						[name: string]: unknown;
@@ -2608,24 +2598,101 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	'/library/{animeID}': {
+	'/collections/{collectionID}/items/order': {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
+		get?: never;
 		/**
-		 * Get anime status in library
-		 * @description Get anime status in library
+		 * Reorder collection
+		 * @description Set the manual order of a collection, listing every anime in it once
 		 */
-		get: {
+		put: {
 			parameters: {
 				query?: never;
 				header?: never;
 				path: {
-					/** @description Anime ID */
-					animeID: string;
+					/** @description Collection ID */
+					collectionID: string;
+				};
+				cookie?: never;
+			};
+			/** @description New order */
+			requestBody: {
+				content: {
+					'application/json': components['schemas']['models.CollectionOrderRequest'];
+				};
+			};
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content?: never;
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ValidationErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/collections/shared/{slug}': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get shared collection
+		 * @description Get an unlisted or public collection by its slug with a page of its items in order. Public collections are also listed on the owner's public profile
+		 */
+		get: {
+			parameters: {
+				query?: {
+					/** @description Page number */
+					page?: number;
+					/** @description Number of items per page */
+					itemsPerPage?: number;
+				};
+				header?: never;
+				path: {
+					/** @description Collection slug */
+					slug: string;
 				};
 				cookie?: never;
 			};
@@ -2637,7 +2704,7 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.LibraryResponse'];
+						'application/json': components['schemas']['models.CollectionWithItemsResponse'];
 					};
 				};
 				/** @description Bad Request */
@@ -2669,21 +2736,33 @@ export interface paths {
 				};
 			};
 		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/desktop/releases': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
 		/**
-		 * Update anime in library
-		 * @description Update anime in library
+		 * Get all desktop releases
+		 * @description Get all desktop releases grouped by version
 		 */
-		put: {
+		get: {
 			parameters: {
 				query?: never;
 				header?: never;
-				path: {
-					/** @description Anime ID */
-					animeID: string;
-				};
+				path?: never;
 				cookie?: never;
 			};
-			requestBody: components['requestBodies']['models.LibraryRequest'];
+			requestBody?: never;
 			responses: {
 				/** @description OK */
 				200: {
@@ -2691,7 +2770,46 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.LibraryResponse'];
+						'application/json': components['schemas']['models.DesktopReleaseResponse'][];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		/**
+		 * Create desktop release
+		 * @description Create a new desktop release for a specific platform
+		 */
+		post: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			/** @description Release object */
+			requestBody: {
+				content: {
+					'application/json': components['schemas']['models.CreateDesktopReleaseRequest'];
+				};
+			};
+			responses: {
+				/** @description Created */
+				201: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.DesktopReleaseResponse'];
 					};
 				};
 				/** @description Bad Request */
@@ -2703,6 +2821,15 @@ export interface paths {
 						'application/json': components['schemas']['models.ValidationErrorResponse'];
 					};
 				};
+				/** @description Unauthorized */
+				401: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
 				/** @description Internal Server Error */
 				500: {
 					headers: {
@@ -2714,21 +2841,34 @@ export interface paths {
 				};
 			};
 		};
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/desktop/releases/{version}': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
 		/**
-		 * Add anime to library
-		 * @description Add anime to library
+		 * Get desktop release by version
+		 * @description Get a specific desktop release version with all platform binaries
 		 */
-		post: {
+		get: {
 			parameters: {
 				query?: never;
 				header?: never;
 				path: {
-					/** @description Anime ID */
-					animeID: string;
+					/** @description Version string (e.g. 1.0.0) */
+					version: string;
 				};
 				cookie?: never;
 			};
-			requestBody: components['requestBodies']['models.LibraryRequest'];
+			requestBody?: never;
 			responses: {
 				/** @description OK */
 				200: {
@@ -2736,7 +2876,7 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.LibraryResponse'];
+						'application/json': components['schemas']['models.DesktopVersionResponse'];
 					};
 				};
 				/** @description Bad Request */
@@ -2745,7 +2885,16 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.ValidationErrorResponse'];
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
 					};
 				};
 				/** @description Internal Server Error */
@@ -2759,24 +2908,26 @@ export interface paths {
 				};
 			};
 		};
+		put?: never;
+		post?: never;
 		/**
-		 * Remove anime from library
-		 * @description Remove anime from library
+		 * Delete desktop releases by version
+		 * @description Delete all desktop releases for a specific version
 		 */
 		delete: {
 			parameters: {
 				query?: never;
 				header?: never;
 				path: {
-					/** @description Anime ID */
-					animeID: string;
+					/** @description Version string (e.g. 1.0.0) */
+					version: string;
 				};
 				cookie?: never;
 			};
 			requestBody?: never;
 			responses: {
-				/** @description OK */
-				200: {
+				/** @description No Content */
+				204: {
 					headers: {
 						[name: string]: unknown;
 					};
@@ -2791,6 +2942,15 @@ export interface paths {
 						'application/json': components['schemas']['models.ErrorResponse'];
 					};
 				};
+				/** @description Unauthorized */
+				401: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
 				/** @description Internal Server Error */
 				500: {
 					headers: {
@@ -2807,27 +2967,1270 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	'/library/{animeID}/switch/{variationID}': {
+	'/desktop/releases/latest': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get latest desktop release
+		 * @description Get the latest desktop release with all platform binaries
+		 */
+		get: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.DesktopVersionResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/feed': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get activity feed
+		 * @description Get the library activity of followed users, newest first. Muted users and users who hide their activity are left out
+		 */
+		get: {
+			parameters: {
+				query?: {
+					/** @description Page number */
+					page?: number;
+					/** @description Number of items per page */
+					itemsPerPage?: number;
+				};
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.FeedResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/follows/{username}': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		/**
+		 * Follow user
+		 * @description Follow a user with a public profile
+		 */
+		post: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path: {
+					/** @description Username */
+					username: string;
+				};
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content?: never;
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Conflict */
+				409: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		/**
+		 * Unfollow user
+		 * @description Stop following a user
+		 */
+		delete: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path: {
+					/** @description Username */
+					username: string;
+				};
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content?: never;
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/follows/{username}/mute': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		/**
+		 * Mute followed user
+		 * @description Mute or unmute a followed user, muted users stay followed but are left out of the feed
+		 */
+		put: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path: {
+					/** @description Username */
+					username: string;
+				};
+				cookie?: never;
+			};
+			/** @description Mute object */
+			requestBody: {
+				content: {
+					'application/json': components['schemas']['models.FollowMuteRequest'];
+				};
+			};
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content?: never;
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ValidationErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/follows/followers': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get followers
+		 * @description Get the users following the current user, most recent first
+		 */
+		get: {
+			parameters: {
+				query?: {
+					/** @description Page number */
+					page?: number;
+					/** @description Number of items per page */
+					itemsPerPage?: number;
+				};
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.FollowListResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/follows/following': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get followed users
+		 * @description Get the users the current user follows with whether each is muted, most recently followed first
+		 */
+		get: {
+			parameters: {
+				query?: {
+					/** @description Page number */
+					page?: number;
+					/** @description Number of items per page */
+					itemsPerPage?: number;
+				};
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.FollowListResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/health': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Simple health check
+		 * @description Check if the API service is running
+		 */
+		get: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['handlers.HealthCheckResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/health/z': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Comprehensive health check
+		 * @description Check the health of all services and external APIs
+		 */
+		get: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['handlers.HealthResponse'];
+					};
+				};
+				/** @description Service Unavailable */
+				503: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['handlers.HealthResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/home': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get home page data
+		 * @description Get all home page data in a single response including trending, popular, recently updated, seasonal anime, and user library sections (if authenticated)
+		 */
+		get: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.HomeResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/library': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get user's anime library
+		 * @description Get user's anime library
+		 */
+		get: {
+			parameters: {
+				query: {
+					/** @description Library status filter */
+					status: 'planning' | 'watching' | 'completed' | 'dropped' | 'paused';
+					/** @description Page number */
+					page?: number;
+					/** @description Number of items per page */
+					itemsPerPage?: number;
+				};
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.LibraryListResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		/**
+		 * Clear user's library
+		 * @description Clear user's library
+		 */
+		delete: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content?: never;
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/library/{animeID}': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get anime status in library
+		 * @description Get anime status in library
+		 */
+		get: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path: {
+					/** @description Anime ID */
+					animeID: string;
+				};
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.LibraryResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		/**
+		 * Update anime in library
+		 * @description Update anime in library
+		 */
+		put: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path: {
+					/** @description Anime ID */
+					animeID: string;
+				};
+				cookie?: never;
+			};
+			requestBody: components['requestBodies']['models.LibraryRequest'];
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.LibraryResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ValidationErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		/**
+		 * Add anime to library
+		 * @description Add anime to library
+		 */
+		post: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path: {
+					/** @description Anime ID */
+					animeID: string;
+				};
+				cookie?: never;
+			};
+			requestBody: components['requestBodies']['models.LibraryRequest'];
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.LibraryResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ValidationErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		/**
+		 * Remove anime from library
+		 * @description Remove anime from library
+		 */
+		delete: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path: {
+					/** @description Anime ID */
+					animeID: string;
+				};
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content?: never;
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/library/{animeID}/switch/{variationID}': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		/**
+		 * Switch library entry to different variation
+		 * @description Switch user's library entry from current anime to a variation (same MAL ID)
+		 */
+		put: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path: {
+					/** @description Current Anime ID */
+					animeID: string;
+					/** @description Target Variation Anime ID */
+					variationID: string;
+				};
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.LibraryResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/library/continue-watching': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get continue watching list
+		 * @description Get continue watching list
+		 */
+		get: {
+			parameters: {
+				query?: {
+					/** @description Page number */
+					page?: number;
+					/** @description Number of items per page */
+					itemsPerPage?: number;
+				};
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.LibraryListResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/library/import': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		get?: never;
+		put?: never;
+		/**
+		 * Import library from external provider
+		 * @description Import library from external provider. Entries are matched by MAL ID, then AniList ID, then title and season year. Of several variations of a show the one already in the library is picked, otherwise the available one with the most episodes. Sub or dub preference is not applied, the catalogue does not record the audio language of a variation
+		 */
+		post: {
+			parameters: {
+				query: {
+					/** @description External provider to import from */
+					provider: string;
+					/** @description Which side wins for entries in both libraries (default: 'remote') */
+					strategy?: 'remote' | 'local' | 'newest' | 'max_progress';
+					/** @description Only report what the import would change */
+					dryRun?: boolean;
+				};
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ImportJobResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/library/import/{id}': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get library import status
+		 * @description Get the status and report of one of the current user's library imports
+		 */
+		get: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path: {
+					/** @description Import job ID */
+					id: string;
+				};
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.LibraryImportJobResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/library/planning': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get plan to watch list
+		 * @description Get plan to watch list
+		 */
+		get: {
+			parameters: {
+				query?: {
+					/** @description Page number */
+					page?: number;
+					/** @description Number of items per page */
+					itemsPerPage?: number;
+				};
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.LibraryListResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/library/stats': {
+		parameters: {
+			query?: never;
+			header?: never;
+			path?: never;
+			cookie?: never;
+		};
+		/**
+		 * Get library statistics
+		 * @description Get library statistics including watching, planning, and completed counts
+		 */
+		get: {
+			parameters: {
+				query?: never;
+				header?: never;
+				path?: never;
+				cookie?: never;
+			};
+			requestBody?: never;
+			responses: {
+				/** @description OK */
+				200: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.LibraryStatsResponse'];
+					};
+				};
+				/** @description Internal Server Error */
+				500: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+			};
+		};
+		put?: never;
+		post?: never;
+		delete?: never;
+		options?: never;
+		head?: never;
+		patch?: never;
+		trace?: never;
+	};
+	'/profiles/{username}': {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
 		/**
-		 * Switch library entry to different variation
-		 * @description Switch user's library entry from current anime to a variation (same MAL ID)
+		 * Get public profile
+		 * @description Get a user's public profile. Sections the user hid are null, activity is also hidden while the user is in incognito mode
 		 */
-		put: {
+		get: {
 			parameters: {
 				query?: never;
 				header?: never;
 				path: {
-					/** @description Current Anime ID */
-					animeID: string;
-					/** @description Target Variation Anime ID */
-					variationID: string;
+					/** @description Username */
+					username: string;
 				};
 				cookie?: never;
 			};
@@ -2839,7 +4242,7 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.LibraryResponse'];
+						'application/json': components['schemas']['models.ProfileResponse'];
 					};
 				};
 				/** @description Bad Request */
@@ -2871,6 +4274,7 @@ export interface paths {
 				};
 			};
 		};
+		put?: never;
 		post?: never;
 		delete?: never;
 		options?: never;
@@ -2878,7 +4282,7 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	'/library/continue-watching': {
+	'/profiles/{username}/collections': {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -2886,8 +4290,8 @@ export interface paths {
 			cookie?: never;
 		};
 		/**
-		 * Get continue watching list
-		 * @description Get continue watching list
+		 * Get public profile collections
+		 * @description Get the public collections of a user with a public profile, most recently changed first. Unlisted collections are left out
 		 */
 		get: {
 			parameters: {
@@ -2898,7 +4302,10 @@ export interface paths {
 					itemsPerPage?: number;
 				};
 				header?: never;
-				path?: never;
+				path: {
+					/** @description Username */
+					username: string;
+				};
 				cookie?: never;
 			};
 			requestBody?: never;
@@ -2909,7 +4316,7 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.LibraryListResponse'];
+						'application/json': components['schemas']['models.CollectionListResponse'];
 					};
 				};
 				/** @description Bad Request */
@@ -2921,6 +4328,15 @@ export interface paths {
 						'application/json': components['schemas']['models.ErrorResponse'];
 					};
 				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
 				/** @description Internal Server Error */
 				500: {
 					headers: {
@@ -2940,27 +4356,27 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	'/library/import': {
+	'/profiles/{username}/compatibility/{otherUsername}': {
 		parameters: {
 			query?: never;
 			header?: never;
 			path?: never;
 			cookie?: never;
 		};
-		get?: never;
-		put?: never;
 		/**
-		 * Import library from external provider
-		 * @description Import library from external provider
+		 * Get library compatibility
+		 * @description Compare the libraries of two users with public libraries, matching variations of the same show. The current user can always compare their own library
 		 */
-		post: {
+		get: {
 			parameters: {
-				query: {
-					/** @description External provider to import from */
-					provider: string;
-				};
+				query?: never;
 				header?: never;
-				path?: never;
+				path: {
+					/** @description Username */
+					username: string;
+					/** @description Username to compare with */
+					otherUsername: string;
+				};
 				cookie?: never;
 			};
 			requestBody?: never;
@@ -2971,7 +4387,7 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.ImportJobResponse'];
+						'application/json': components['schemas']['models.CompatibilityResponse'];
 					};
 				};
 				/** @description Bad Request */
@@ -2983,6 +4399,24 @@ export interface paths {
 						'application/json': components['schemas']['models.ErrorResponse'];
 					};
 				};
+				/** @description Forbidden */
+				403: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
 				/** @description Internal Server Error */
 				500: {
 					headers: {
@@ -2994,13 +4428,15 @@ export interface paths {
 				};
 			};
 		};
+		put?: never;
+		post?: never;
 		delete?: never;
 		options?: never;
 		head?: never;
 		patch?: never;
 		trace?: never;
 	};
-	'/library/import/{id}': {
+	'/profiles/{username}/followers': {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -3008,16 +4444,21 @@ export interface paths {
 			cookie?: never;
 		};
 		/**
-		 * Get library import status
-		 * @description Get library import status
+		 * Get public profile followers
+		 * @description Get the followers of a user with a public profile, only users with a public profile are listed
 		 */
 		get: {
 			parameters: {
-				query?: never;
+				query?: {
+					/** @description Page number */
+					page?: number;
+					/** @description Number of items per page */
+					itemsPerPage?: number;
+				};
 				header?: never;
 				path: {
-					/** @description Import job ID */
-					id: string;
+					/** @description Username */
+					username: string;
 				};
 				cookie?: never;
 			};
@@ -3029,7 +4470,7 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.LibraryImportJobResponse'];
+						'application/json': components['schemas']['models.FollowListResponse'];
 					};
 				};
 				/** @description Bad Request */
@@ -3069,7 +4510,7 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	'/library/planning': {
+	'/profiles/{username}/following': {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -3077,8 +4518,8 @@ export interface paths {
 			cookie?: never;
 		};
 		/**
-		 * Get plan to watch list
-		 * @description Get plan to watch list
+		 * Get public profile following
+		 * @description Get the users a user with a public profile follows, only users with a public profile are listed
 		 */
 		get: {
 			parameters: {
@@ -3089,7 +4530,10 @@ export interface paths {
 					itemsPerPage?: number;
 				};
 				header?: never;
-				path?: never;
+				path: {
+					/** @description Username */
+					username: string;
+				};
 				cookie?: never;
 			};
 			requestBody?: never;
@@ -3100,7 +4544,7 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.LibraryListResponse'];
+						'application/json': components['schemas']['models.FollowListResponse'];
 					};
 				};
 				/** @description Bad Request */
@@ -3112,6 +4556,15 @@ export interface paths {
 						'application/json': components['schemas']['models.ErrorResponse'];
 					};
 				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
 				/** @description Internal Server Error */
 				500: {
 					headers: {
@@ -3131,7 +4584,7 @@ export interface paths {
 		patch?: never;
 		trace?: never;
 	};
-	'/library/stats': {
+	'/profiles/{username}/library': {
 		parameters: {
 			query?: never;
 			header?: never;
@@ -3139,14 +4592,24 @@ export interface paths {
 			cookie?: never;
 		};
 		/**
-		 * Get library statistics
-		 * @description Get library statistics including watching, planning, and completed counts
+		 * Get public profile library
+		 * @description Get the library of a user with a public profile by status, notes are left out
 		 */
 		get: {
 			parameters: {
-				query?: never;
+				query: {
+					/** @description Library status */
+					status: 'planning' | 'watching' | 'completed' | 'dropped' | 'paused';
+					/** @description Page number */
+					page?: number;
+					/** @description Number of items per page */
+					itemsPerPage?: number;
+				};
 				header?: never;
-				path?: never;
+				path: {
+					/** @description Username */
+					username: string;
+				};
 				cookie?: never;
 			};
 			requestBody?: never;
@@ -3157,7 +4620,34 @@ export interface paths {
 						[name: string]: unknown;
 					};
 					content: {
-						'application/json': components['schemas']['models.LibraryStatsResponse'];
+						'application/json': components['schemas']['models.LibraryListResponse'];
+					};
+				};
+				/** @description Bad Request */
+				400: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Forbidden */
+				403: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
+					};
+				};
+				/** @description Not Found */
+				404: {
+					headers: {
+						[name: string]: unknown;
+					};
+					content: {
+						'application/json': components['schemas']['models.ErrorResponse'];
 					};
 				};
 				/** @description Internal Server Error */
@@ -3687,6 +5177,19 @@ export interface components {
 			/** @example 1.0.0 */
 			version?: string;
 		};
+		'models.ActivityResponse': {
+			anime: components['schemas']['models.AnimeResponse'];
+			/** @example 2023-01-01T00:00:00Z */
+			createdAt: string;
+			/** @example V1StGXR8Z5jdHi6B */
+			id: string;
+			/** @example 85 */
+			score?: number;
+			type: components['schemas']['models.LibraryActivityType'];
+			user: components['schemas']['models.PublicUserResponse'];
+			/** @example 12 */
+			watchedEpisodes: number;
+		};
 		'models.AnimeFullResponse': {
 			anime: components['schemas']['models.AnimeWithMetadataResponse'];
 			banner?: components['schemas']['models.BannerResponse'];
@@ -3873,6 +5376,90 @@ export interface components {
 			language: string;
 			person: components['schemas']['models.CharacterVoicePersonResponse'];
 		};
+		'models.CollectionItemNoteRequest': {
+			/** @example Start with the OVA */
+			note?: string;
+		};
+		'models.CollectionItemRequest': {
+			/** @example V1StGXR8Z5jdHi6B */
+			animeId: string;
+			/** @example Start with the OVA */
+			note?: string;
+		};
+		'models.CollectionItemResponse': {
+			anime: components['schemas']['models.AnimeResponse'];
+			/** @example V1StGXR8Z5jdHi6B */
+			animeId: string;
+			/** @example 2023-01-01T00:00:00Z */
+			createdAt: string;
+			/** @example Start with the OVA */
+			note: string;
+			/** @example 1 */
+			position: number;
+		};
+		'models.CollectionListResponse': {
+			items: components['schemas']['models.CollectionResponse'][];
+			pageInfo: components['schemas']['models.PageInfo'];
+		};
+		'models.CollectionOrderRequest': {
+			/** @example [
+			 *       "V1StGXR8Z5jdHi6B"
+			 *     ] */
+			animeIds: string[];
+		};
+		'models.CollectionRequest': {
+			/** @example Slice of life to watch with a blanket */
+			description?: string;
+			/** @example Comfy winter watches */
+			name: string;
+			/** @example private */
+			visibility: components['schemas']['models.CollectionVisibility'];
+		};
+		'models.CollectionResponse': {
+			/** @example 2023-01-01T00:00:00Z */
+			createdAt: string;
+			/** @example Slice of life to watch with a blanket */
+			description: string;
+			/** @example V1StGXR8Z5jdHi6B */
+			id: string;
+			/** @example 12 */
+			itemCount: number;
+			/** @example Comfy winter watches */
+			name: string;
+			/** @example x8Fk2LmQ0pZr */
+			slug: string;
+			/** @example 2023-01-01T00:00:00Z */
+			updatedAt: string;
+			/** @example V1StGXR8Z5jdHi6B */
+			userId: string;
+			/** @example private */
+			visibility: components['schemas']['models.CollectionVisibility'];
+		};
+		/** @enum {string} */
+		'models.CollectionVisibility': 'private' | 'unlisted' | 'public';
+		'models.CollectionWithItemsResponse': {
+			collection: components['schemas']['models.CollectionResponse'];
+			items: components['schemas']['models.CollectionItemResponse'][];
+			pageInfo: components['schemas']['models.PageInfo'];
+		};
+		/** @enum {string} */
+		'models.CompatibilityBasis': 'scores' | 'statuses';
+		'models.CompatibilityResponse': {
+			basis: components['schemas']['models.CompatibilityBasis'];
+			/** @example 86 */
+			compatibility: number;
+			otherCompletedUserPlanning: components['schemas']['models.AnimeResponse'][];
+			otherUser: components['schemas']['models.PublicUserResponse'];
+			/** @example 0.71 */
+			scoreCorrelation?: number;
+			/** @example 42 */
+			sharedTitles: number;
+			/** @example 0.64 */
+			statusAgreement: number;
+			statusOverlap: components['schemas']['models.StatusCountsResponse'];
+			user: components['schemas']['models.PublicUserResponse'];
+			userCompletedOtherPlanning: components['schemas']['models.AnimeResponse'][];
+		};
 		'models.CreateDesktopReleaseRequest': {
 			downloadUrl: string;
 			fileName: string;
@@ -3944,6 +5531,25 @@ export interface components {
 			/** @example Invalid request */
 			error: string;
 		};
+		'models.FeedResponse': {
+			items: components['schemas']['models.ActivityResponse'][];
+			pageInfo: components['schemas']['models.PageInfo'];
+		};
+		'models.FollowListResponse': {
+			items: components['schemas']['models.FollowResponse'][];
+			pageInfo: components['schemas']['models.PageInfo'];
+		};
+		'models.FollowMuteRequest': {
+			/** @example true */
+			muted?: boolean;
+		};
+		'models.FollowResponse': {
+			/** @example 2023-01-01T00:00:00Z */
+			followedAt: string;
+			/** @example false */
+			muted?: boolean;
+			user: components['schemas']['models.PublicUserResponse'];
+		};
 		'models.ForgetPasswordRequest': {
 			/** @example user@example.com */
 			email: string;
@@ -3965,25 +5571,87 @@ export interface components {
 			/** @example V1StGXR8Z5jdHi6BmyT23 */
 			id: string;
 		};
+		/** @enum {string} */
+		'models.LibraryActivityType': 'added' | 'progressed' | 'completed' | 'scored';
+		'models.LibraryImportChangeResponse': {
+			anime: components['schemas']['models.AnimeResponse'];
+			/** @example V1StGXR8Z5jdHi6B */
+			animeId: string;
+			/** @example watching */
+			localStatus?: components['schemas']['models.LibraryStatus'];
+			/** @example 12 */
+			localWatchedEpisodes?: number;
+			/** @example completed */
+			status: components['schemas']['models.LibraryStatus'];
+			/** @example 24 */
+			watchedEpisodes: number;
+		};
 		'models.LibraryImportJobResponse': {
 			/** @example 2023-01-01T00:00:00Z */
 			completedAt: string;
 			/** @example 2023-01-01T00:00:00Z */
 			createdAt: string;
+			/** @example false */
+			dryRun: boolean;
 			/** @example V1StGXR8Z5jdHi6B */
 			id: string;
+			report: components['schemas']['models.LibraryImportReportResponse'];
 			/** @example pending */
 			status: components['schemas']['models.LibraryImportStatus'];
+			/** @example remote */
+			strategy: components['schemas']['models.LibraryImportStrategy'];
 			/** @example 2023-01-01T00:00:00Z */
 			updatedAt: string;
 			/** @example V1StGXR8Z5jdHi6B */
 			userId: string;
 		};
+		'models.LibraryImportReportItemResponse': {
+			/** @example 21 */
+			anilistId?: number;
+			candidateIds: string[];
+			/** @example anilist:21 */
+			externalId: string;
+			/**
+			 * @example ambiguous
+			 * @enum {string}
+			 */
+			issue: 'unmatched' | 'ambiguous';
+			/** @example 21 */
+			malId?: number;
+			/** @example One Piece */
+			title: string;
+		};
+		'models.LibraryImportReportResponse': {
+			/** @example 40 */
+			added: number;
+			/** @example 1 */
+			ambiguous: number;
+			changes: components['schemas']['models.LibraryImportChangeResponse'][];
+			items: components['schemas']['models.LibraryImportReportItemResponse'][];
+			/** @example 120 */
+			matched: number;
+			/** @example 2 */
+			progressRegressed: number;
+			/** @example 12 */
+			statusChanged: number;
+			/** @example 3 */
+			unmatched: number;
+		};
 		/** @enum {string} */
 		'models.LibraryImportStatus': 'pending' | 'in_progress' | 'completed' | 'failed';
+		/** @enum {string} */
+		'models.LibraryImportStrategy': 'remote' | 'local' | 'newest' | 'max_progress';
 		'models.LibraryInfo': {
+			/** @example 2024-03-20 */
+			completedAt?: string;
 			/** @example V1StGXR8Z5jdHi6B */
 			id: string;
+			/** @example 1 */
+			rewatches: number;
+			/** @example 85 */
+			score?: number;
+			/** @example 2024-01-31 */
+			startedAt?: string;
 			/** @example watching */
 			status: components['schemas']['models.LibraryStatus'];
 			/** @example 2023-01-01T00:00:00Z */
@@ -3996,6 +5664,16 @@ export interface components {
 			pageInfo: components['schemas']['models.PageInfo'];
 		};
 		'models.LibraryRequest': {
+			/** @example 2024-03-20 */
+			completedAt?: string;
+			/** @example Rewatch with friends */
+			notes?: string;
+			/** @example 1 */
+			rewatches?: number;
+			/** @example 85 */
+			score?: number;
+			/** @example 2024-01-31 */
+			startedAt?: string;
 			/** @example watching */
 			status: components['schemas']['models.LibraryStatus'];
 			/** @example 12 */
@@ -4005,10 +5683,20 @@ export interface components {
 			anime: components['schemas']['models.AnimeResponse'];
 			/** @example V1StGXR8Z5jdHi6B */
 			animeId: string;
+			/** @example 2024-03-20 */
+			completedAt?: string;
 			/** @example 2023-01-01T00:00:00Z */
 			createdAt: string;
 			/** @example V1StGXR8Z5jdHi6B */
 			id: string;
+			/** @example Rewatch with friends */
+			notes: string;
+			/** @example 1 */
+			rewatches: number;
+			/** @example 85 */
+			score?: number;
+			/** @example 2024-01-31 */
+			startedAt?: string;
 			/** @example watching */
 			status: components['schemas']['models.LibraryStatus'];
 			/** @example 2023-01-01T00:00:00Z */
@@ -4075,6 +5763,54 @@ export interface components {
 			/** @example Shion Wakayama */
 			name: string;
 		};
+		'models.ProfileResponse': {
+			activity?: components['schemas']['models.ActivityResponse'][];
+			/** @example 2023-01-01T00:00:00Z */
+			createdAt: string;
+			favorites?: components['schemas']['models.LibraryResponse'][];
+			/** @example V1StGXR8Z5jdHi6B */
+			id: string;
+			/** @example https://res.cloudinary.com/example/avatar.jpg */
+			profilePicture?: string;
+			sections: components['schemas']['models.ProfileSectionsResponse'];
+			stats?: components['schemas']['models.ProfileStatsResponse'];
+			/** @example johndoe */
+			username: string;
+		};
+		'models.ProfileSectionsResponse': {
+			/** @example true */
+			activity: boolean;
+			/** @example true */
+			favorites: boolean;
+			/** @example true */
+			library: boolean;
+			/** @example true */
+			stats: boolean;
+		};
+		'models.ProfileStatsResponse': {
+			/** @example 85 */
+			completed: number;
+			/** @example 3 */
+			dropped: number;
+			/** @example 1240 */
+			episodesWatched: number;
+			/** @example 78.5 */
+			meanScore: number;
+			/** @example 2 */
+			paused: number;
+			/** @example 20 */
+			planning: number;
+			/** @example 4 */
+			watching: number;
+		};
+		'models.PublicUserResponse': {
+			/** @example V1StGXR8Z5jdHi6B */
+			id: string;
+			/** @example https://res.cloudinary.com/example/avatar.jpg */
+			profilePicture?: string;
+			/** @example johndoe */
+			username: string;
+		};
 		'models.RelationsResponse': {
 			related: components['schemas']['models.AnimeResponse'][];
 			watchOrder: components['schemas']['models.AnimeResponse'][];
@@ -4105,30 +5841,78 @@ export interface components {
 			start: number;
 		};
 		'models.SettingsRequest': {
+			/** @example true */
+			autoCompleteLibrary?: boolean;
 			/** @example true */
 			autoNextEpisode?: boolean;
+			/** @example 30 */
+			autoPauseAfterDays?: number;
+			/** @example false */
+			autoPauseLibrary?: boolean;
 			/** @example false */
 			autoPlayEpisode?: boolean;
 			/** @example true */
 			autoResumeEpisode?: boolean;
+			/** @example true */
+			autoStartLibrary?: boolean;
 			/** @example false */
 			incognitoMode?: boolean;
+			/** @example true */
+			profileShowActivity?: boolean;
+			/** @example true */
+			profileShowFavorites?: boolean;
+			/** @example true */
+			profileShowLibrary?: boolean;
+			/** @example true */
+			profileShowStats?: boolean;
+			/** @example false */
+			publicProfile?: boolean;
 			/** @example 1 */
 			themeId?: number;
 		};
 		'models.SettingsResponse': {
+			/** @example true */
+			autoCompleteLibrary: boolean;
 			/** @example true */
 			autoNextEpisode: boolean;
+			/** @example 30 */
+			autoPauseAfterDays: number;
+			/** @example false */
+			autoPauseLibrary: boolean;
 			/** @example false */
 			autoPlayEpisode: boolean;
 			/** @example true */
 			autoResumeEpisode: boolean;
+			/** @example true */
+			autoStartLibrary: boolean;
 			/** @example false */
 			incognitoMode: boolean;
+			/** @example true */
+			profileShowActivity: boolean;
+			/** @example true */
+			profileShowFavorites: boolean;
+			/** @example true */
+			profileShowLibrary: boolean;
+			/** @example true */
+			profileShowStats: boolean;
+			/** @example false */
+			publicProfile: boolean;
 			theme: components['schemas']['models.Theme'];
 			/** @example V1StGXR8Z5jdHi6B */
 			userId: string;
 		};
+		'models.StatusCountsResponse': {
+			/** @example 20 */
+			completed: number;
+			/** @example 1 */
+			dropped: number;
+			/** @example 0 */
+			paused: number;
+			/** @example 5 */
+			planning: number;
+			/** @example 2 */
+			watching: number;
+		};
 		'models.StreamingDataResponse': {
 			intro: components['schemas']['models.SegmentResponse'];
 			outro: components['schemas']['models.SegmentResponse'];
@@ -4181,6 +5965,12 @@ export interface components {
 			/** @example johndoe */
 			username: string;
 		};
+		'models.OauthProviderResponse': {
+			/** @example false */
+			needsReauth: boolean;
+			/** @example myanimelist */
+			provider: string;
+		};
 		'models.UserResponse': {
 			/** @example 2023-01-01T00:00:00Z */
 			createdAt: string;
@@ -4209,6 +5999,12 @@ export interface components {
 	responses: never;
 	parameters: never;
 	requestBodies: {
+		/** @description Collection object */
+		'models.CollectionRequest': {
+			content: {
+				'application/json': components['schemas']['models.CollectionRequest'];
+			};
+		};
 		/** @description Library object */
 		'models.LibraryRequest': {
 			content: {
@@ -121,6 +121,15 @@ export class AppState {
 			autoPlayEpisode: true,
 			incognitoMode: false,
 			autoResumeEpisode: true,
+			autoStartLibrary: true,
+			autoCompleteLibrary: true,
+			autoPauseLibrary: false,
+			autoPauseAfterDays: 30,
+			publicProfile: false,
+			profileShowLibrary: true,
+			profileShowStats: true,
+			profileShowFavorites: true,
+			profileShowActivity: true,
 			theme: {
 				id: 1,
 				name: 'Default',